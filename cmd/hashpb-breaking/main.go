@@ -0,0 +1,52 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command hashpb-breaking compares two descriptor sets and reports schema changes that alter the digests of existing data.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/breaking"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <previous.binpb> <current.binpb>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	failOnRename := flag.Bool("fail-on-rename", false, "Fail if fields are renamed (renames do not change digests but invalidate ignore sets)")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	previous, err := descset.LoadFiles(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	current, err := descset.LoadFiles(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, c := range breaking.Compare(previous, current) {
+		fmt.Println(c)
+		if c.Breaking() || *failOnRename {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command hashpb-conformance checks test vectors produced with the gen_vectors plugin parameter against the hashpb package.
+// It doesn't link any generated code: use conformance.Main to build a runner that checks your generated code as well.
+package main
+
+import (
+	"os"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/conformance"
+)
+
+func main() {
+	os.Exit(conformance.Main(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,81 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command hashpb-manifest records the digests of sample instances of the message types in a descriptor set and reports
+// the message types whose digests changed since the manifest was last updated.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/manifest"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+)
+
+func main() {
+	descriptors := flag.String("descriptors", "", "Path to a serialized FileDescriptorSet containing the message types")
+	manifestPath := flag.String("manifest", "hashpb-manifest.json", "Path to the manifest file")
+	update := flag.Bool("update", false, "Overwrite the manifest with the current digests")
+	flag.Parse()
+
+	if *descriptors == "" {
+		fmt.Fprintln(os.Stderr, "-descriptors is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*descriptors, *manifestPath, *update); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(descriptors, manifestPath string, update bool) error {
+	files, err := descset.LoadFiles(descriptors)
+	if err != nil {
+		return err
+	}
+
+	current, err := manifest.Build(files)
+	if err != nil {
+		return err
+	}
+
+	previous, err := manifest.Load(manifestPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		update = true
+	}
+
+	if update {
+		if err := current.Write(manifestPath); err != nil {
+			return err
+		}
+
+		fmt.Printf("Wrote manifest with %d message types to %s\n", len(current.Entries), manifestPath)
+		return nil
+	}
+
+	diff := manifest.Compare(previous, current)
+	for _, t := range diff.Changed {
+		fmt.Printf("changed: %s\n", t)
+	}
+	for _, t := range diff.Added {
+		fmt.Printf("added:   %s\n", t)
+	}
+	for _, t := range diff.Removed {
+		fmt.Printf("removed: %s\n", t)
+	}
+
+	if len(diff.Changed) > 0 {
+		return fmt.Errorf("digests of %d message types changed (run with -update to accept the changes)", len(diff.Changed))
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpbreg"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func runBench(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb bench [flags] <file>...")
+		fmt.Fprintln(fs.Output(), "Reports the throughput and allocations of hashing the sample messages using reflection and, if the")
+		fmt.Fprintln(fs.Output(), "generated code of the message type is linked into the binary and registered with registry=true, using generated code.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	iterations := fs.Int("n", 10000, "Number of times each sample message is hashed")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := mf.validate(fs); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 || *iterations < 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]proto.Message, fs.NArg())
+	size := 0
+	for i, path := range fs.Args() {
+		if msgs[i], err = l.read(path, mf.format); err != nil {
+			return err
+		}
+		size += proto.Size(msgs[i])
+	}
+
+	// reflection is measured on the generated Go types if they are available, as that is what hashpb would hash.
+	generatedMsgs, fn, reason := generated(mf.msgType, msgs)
+	if fn != nil {
+		msgs = generatedMsgs
+	}
+
+	hasher := hashFns[mf.hashName]()
+	opts := mf.hashOptions()
+	reflection, err := measure(*iterations, msgs, func(msg proto.Message) error {
+		hasher.Reset()
+		return hashpb.Hash(hasher, msg, opts...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash using reflection: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "\tns/msg\tMB/s\tallocs/msg\tB/msg\t")
+	reflection.print(tw, "reflection", size)
+
+	if fn == nil {
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "generated code not benchmarked: %s\n", reason)
+		return nil
+	}
+
+	ignore := mf.ignoreSet()
+	gen, err := measure(*iterations, msgs, func(msg proto.Message) error {
+		hasher.Reset()
+		if !fn(msg, hasher, ignore) {
+			return fmt.Errorf("the registered hash function does not support %T", msg)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash using generated code: %w", err)
+	}
+
+	gen.print(tw, "generated", size)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "speedup of generated code: %.1fx\n", float64(reflection.elapsed)/float64(gen.elapsed))
+	return nil
+}
+
+// benchResult holds the totals of hashing every sample message a number of times.
+type benchResult struct {
+	elapsed    time.Duration
+	iterations int
+	ops        int
+	allocs     uint64
+	bytes      uint64
+}
+
+func measure(iterations int, msgs []proto.Message, fn func(proto.Message) error) (benchResult, error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		for _, msg := range msgs {
+			if err := fn(msg); err != nil {
+				return benchResult{}, err
+			}
+		}
+	}
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return benchResult{
+		elapsed:    elapsed,
+		iterations: iterations,
+		ops:        iterations * len(msgs),
+		allocs:     after.Mallocs - before.Mallocs,
+		bytes:      after.TotalAlloc - before.TotalAlloc,
+	}, nil
+}
+
+// print writes a row of the results table. size is the total serialized size of the sample messages.
+func (r benchResult) print(w io.Writer, name string, size int) {
+	ops := uint64(r.ops)
+	mbps := float64(size) * float64(r.iterations) / r.elapsed.Seconds() / 1e6
+	fmt.Fprintf(w, "%s\t%d\t%.2f\t%d\t%d\t\n", name, r.elapsed.Nanoseconds()/int64(r.ops), mbps, r.allocs/ops, r.bytes/ops)
+}
+
+// generated returns the sample messages converted to the generated Go type of the message type along with its
+// registered hash function, or the reason why generated code can't be benchmarked.
+func generated(msgType string, msgs []proto.Message) ([]proto.Message, hashpbreg.HashFunc, string) {
+	fn, ok := hashpbreg.Lookup(msgType)
+	if !ok {
+		return nil, nil, fmt.Sprintf("no hash function is registered for %s", msgType)
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(msgType))
+	if err != nil {
+		return nil, nil, fmt.Sprintf("the Go type of %s is not linked into the binary", msgType)
+	}
+
+	converted := make([]proto.Message, len(msgs))
+	for i, msg := range msgs {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, nil, fmt.Sprintf("failed to marshal sample message: %v", err)
+		}
+
+		converted[i] = mt.New().Interface()
+		if err := proto.Unmarshal(data, converted[i]); err != nil {
+			return nil, nil, fmt.Sprintf("failed to convert sample message to the generated type: %v", err)
+		}
+	}
+
+	return converted, fn, ""
+}
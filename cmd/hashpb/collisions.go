@@ -0,0 +1,165 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/collision"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+const objectHashConfig = "objecthash"
+
+func runCollisions(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("collisions", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb collisions [flags] [file]")
+		fmt.Fprintln(fs.Output(), "Reads varint length-delimited binary messages from file (default: stdin) and reports digest collisions and near misses for each hash function.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	hashFlag := fs.Lookup("hash")
+	hashFlag.Usage = "Comma-separated list of hash functions: " + strings.Join(append(hashNames(), objectHashConfig), ", ")
+	hashFlag.DefValue = "fnv64a,xxhash,sha256"
+	mf.hashName = hashFlag.DefValue
+
+	mutations := fs.Int("mutations", 0, "Number of mutations of each record to add to the corpus")
+	seed := fs.Int64("seed", 1, "Seed used to generate mutations")
+	volume := fs.Uint64("volume", 0, "Number of messages to project the collision probability to (default: the number of distinct messages)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if mf.descriptors == "" || mf.msgType == "" {
+		fmt.Fprintln(fs.Output(), "-descriptors and -type are required")
+		fs.Usage()
+		return errUsage
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	var configs []collision.Config
+	for _, name := range strings.Split(mf.hashName, ",") {
+		opts := []hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}
+		if name != objectHashConfig {
+			hashFn, ok := hashFns[name]
+			if !ok {
+				fmt.Fprintf(fs.Output(), "Unknown hash function %q\n", name)
+				fs.Usage()
+				return errUsage
+			}
+			opts = []hashpb.Option{hashpb.WithHash(hashFn)}
+		}
+
+		if mf.ignore != "" {
+			opts = append(opts, hashpb.WithIgnore(strings.Split(mf.ignore, ",")...))
+		}
+		configs = append(configs, collision.Config{Name: name, Options: opts})
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	path := "-"
+	if fs.NArg() == 1 {
+		path = fs.Arg(0)
+	}
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	var corpus []proto.Message
+	r := bufio.NewReader(in)
+	uo := protodelim.UnmarshalOptions{UnmarshalOptions: proto.UnmarshalOptions{Resolver: l.types}}
+	for n := 0; ; n++ {
+		msg := l.newMessage()
+		if err := uo.UnmarshalFrom(r, msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("failed to read record %d from %s: %w", n, path, err)
+		}
+
+		corpus = append(corpus, msg)
+	}
+
+	records := len(corpus)
+	if *mutations > 0 {
+		for i := 0; i < records; i++ {
+			corpus = append(corpus, collision.Mutations(corpus[i], *mutations, *seed+int64(i))...)
+		}
+	}
+
+	reports, err := collision.Analyze(corpus, configs...)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "HASH\tBITS\tMESSAGES\tDISTINCT\tCOLLISIONS\tMAX PREFIX\tEXPECTED PREFIX\tPROBABILITY")
+	for _, rep := range reports {
+		p := rep.Probability
+		if *volume > 0 {
+			p = collision.Probability(*volume, rep.Bits)
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%.1f\t%.3g\n", rep.Name, rep.Bits, rep.Messages, rep.Distinct, len(rep.Collisions), rep.MaxCommonPrefix, rep.ExpectedCommonPrefix, p)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, rep := range reports {
+		for _, c := range rep.Collisions {
+			fmt.Fprintf(stdout, "%s collision %s: %s\n", rep.Name, hex.EncodeToString(c.Digest), describeRecords(c.Indexes, records, *mutations))
+		}
+	}
+
+	return nil
+}
+
+// describeRecords names the messages at the given corpus indexes, which start with the records read from the input
+// followed by the given number of mutations of each record.
+func describeRecords(indexes []int, records, mutations int) string {
+	names := make([]string, len(indexes))
+	for i, idx := range indexes {
+		if idx < records {
+			names[i] = fmt.Sprintf("record %d", idx)
+		} else {
+			names[i] = fmt.Sprintf("mutation of record %d", (idx-records)/mutations)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,186 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"flag"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var hashFns = map[string]func() hash.Hash{
+	"fnv64a": func() hash.Hash { return fnv.New64a() },
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+	"xxhash": func() hash.Hash { return xxhash.New() },
+}
+
+const (
+	formatBinary = "binary"
+	formatJSON   = "json"
+	formatText   = "text"
+)
+
+// msgFlags are the flags shared by commands that read messages.
+type msgFlags struct {
+	descriptors string
+	msgType     string
+	format      string
+	hashName    string
+	ignore      string
+}
+
+func (mf *msgFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&mf.descriptors, "descriptors", "", "Path to a serialized FileDescriptorSet containing the message type (required)")
+	fs.StringVar(&mf.msgType, "type", "", "Fully-qualified name of the message type (required)")
+	fs.StringVar(&mf.format, "format", "", "Input format: binary, json or text (default: detected from the file extension)")
+	fs.StringVar(&mf.hashName, "hash", "xxhash", "Hash function: "+strings.Join(hashNames(), ", "))
+	fs.StringVar(&mf.ignore, "ignore", "", "Comma-separated list of fully-qualified field names to ignore")
+}
+
+func (mf *msgFlags) validate(fs *flag.FlagSet) error {
+	if mf.descriptors == "" || mf.msgType == "" {
+		fmt.Fprintln(fs.Output(), "-descriptors and -type are required")
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, ok := hashFns[mf.hashName]; !ok {
+		fmt.Fprintf(fs.Output(), "Unknown hash function %q\n", mf.hashName)
+		fs.Usage()
+		return errUsage
+	}
+
+	return nil
+}
+
+func (mf *msgFlags) hashOptions() []hashpb.Option {
+	opts := []hashpb.Option{hashpb.WithHash(hashFns[mf.hashName])}
+	if mf.ignore != "" {
+		opts = append(opts, hashpb.WithIgnore(strings.Split(mf.ignore, ",")...))
+	}
+
+	return opts
+}
+
+// ignoreSet returns the fields to ignore as an ignore set for generated code.
+func (mf *msgFlags) ignoreSet() map[string]struct{} {
+	if mf.ignore == "" {
+		return nil
+	}
+
+	ignore := make(map[string]struct{})
+	for _, name := range strings.Split(mf.ignore, ",") {
+		ignore[name] = struct{}{}
+	}
+
+	return ignore
+}
+
+// loader reads messages of a single type.
+type loader struct {
+	md    protoreflect.MessageDescriptor
+	types *dynamicpb.Types
+}
+
+func (mf *msgFlags) loader() (*loader, error) {
+	files, err := descset.LoadFiles(mf.descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(mf.msgType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find message type %s: %w", mf.msgType, err)
+	}
+
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message type", mf.msgType)
+	}
+
+	return &loader{md: md, types: dynamicpb.NewTypes(files)}, nil
+}
+
+func (l *loader) newMessage() *dynamicpb.Message {
+	return dynamicpb.NewMessage(l.md)
+}
+
+// read reads a message from path ("-" for stdin) in the given format, or the format detected from the file extension.
+func (l *loader) read(path, format string) (proto.Message, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if format == "" {
+		format = detectFormat(path)
+	}
+
+	msg := l.newMessage()
+	switch format {
+	case formatBinary:
+		err = proto.UnmarshalOptions{Resolver: l.types}.Unmarshal(data, msg)
+	case formatJSON:
+		err = protojson.UnmarshalOptions{Resolver: l.types}.Unmarshal(data, msg)
+	case formatText:
+		err = prototext.UnmarshalOptions{Resolver: l.types}.Unmarshal(data, msg)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	return msg, nil
+}
+
+func detectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".txtpb", ".textproto", ".txt", ".pbtxt":
+		return formatText
+	default:
+		return formatBinary
+	}
+}
+
+func hashNames() []string {
+	names := make([]string, 0, len(hashFns))
+	for name := range hashFns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,71 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+// errDiffer is returned by diff when the messages differ. The differences have already been printed.
+var errDiffer = errors.New("messages differ")
+
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb diff [flags] <a> <b>")
+		fmt.Fprintln(fs.Output(), "Prints the paths of the fields whose digests differ between the two messages.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := mf.validate(fs); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	a, err := l.read(fs.Arg(0), mf.format)
+	if err != nil {
+		return err
+	}
+
+	b, err := l.read(fs.Arg(1), mf.format)
+	if err != nil {
+		return err
+	}
+
+	paths, err := hashpb.Diff(a, b, mf.hashOptions()...)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		fmt.Fprintln(stdout, p)
+	}
+
+	if len(paths) > 0 {
+		return errDiffer
+	}
+
+	return nil
+}
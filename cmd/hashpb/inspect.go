@@ -0,0 +1,216 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const inspectRowSize = 16
+
+func runInspect(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb inspect [flags] <file>")
+		fmt.Fprintln(fs.Output(), "Prints an annotated hexdump of the canonical bytes written to the hash function for each field.")
+		fmt.Fprintln(fs.Output(), "Fields of nested messages are listed separately. Use - to read from stdin.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := mf.validate(fs); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	msg, err := l.read(fs.Arg(0), mf.format)
+	if err != nil {
+		return err
+	}
+
+	ins := &inspector{out: stdout, ignore: mf.ignoreSet(), opts: mf.hashOptions()}
+
+	if err := ins.message("", msg.ProtoReflect()); err != nil {
+		return err
+	}
+
+	return ins.summary(msg)
+}
+
+// inspector prints the canonical bytes of each field of a message in traversal order.
+type inspector struct {
+	out    io.Writer
+	ignore map[string]struct{}
+	opts   []hashpb.Option
+	stream bytes.Buffer
+}
+
+func (ins *inspector) message(path string, m protoreflect.Message) error {
+	for _, fd := range traversalOrder(m.Descriptor()) {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if hashpb.Ignored(ins.ignore, string(od.FullName())) {
+				continue
+			}
+
+			if fd = m.WhichOneof(od); fd == nil {
+				continue
+			}
+		}
+
+		if err := ins.field(path, m, fd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ins *inspector) field(path string, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if path != "" {
+		path += "."
+	}
+	path += string(fd.Name())
+
+	// set message fields are hashed as the fields of the message, so they are broken down into their fields.
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && m.Has(fd) && !ins.ignored(fd) {
+		return ins.message(path, m.Get(fd).Message())
+	}
+
+	rec := &recorder{}
+	if err := hashpb.HashField(rec, m, fd, ins.opts...); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	if rec.Len() == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(ins.out, "%s (%s, %d bytes)\n", path, fieldEncoding(fd), rec.Len())
+	data := rec.Bytes()
+	for i := 0; i < len(data); i += inspectRowSize {
+		row := data[i:]
+		if len(row) > inspectRowSize {
+			row = row[:inspectRowSize]
+		}
+
+		hexRow := hex.EncodeToString(row)
+		groups := make([]string, len(row))
+		for j := range row {
+			groups[j] = hexRow[2*j : 2*j+2]
+		}
+
+		fmt.Fprintf(ins.out, "  %08x  %s\n", ins.stream.Len()+i, strings.Join(groups, " "))
+	}
+
+	ins.stream.Write(data)
+	return nil
+}
+
+func (ins *inspector) ignored(fd protoreflect.FieldDescriptor) bool {
+	return hashpb.Ignored(ins.ignore, string(fd.FullName()), hashpb.StableIgnoreKey(fd))
+}
+
+// summary prints the length and digest of the canonical stream after checking that it matches the stream hashed by the
+// hashpb package.
+func (ins *inspector) summary(msg proto.Message) error {
+	rec := &recorder{}
+	if err := hashpb.Hash(rec, msg, ins.opts...); err != nil {
+		return fmt.Errorf("failed to hash message: %w", err)
+	}
+
+	if !bytes.Equal(rec.Bytes(), ins.stream.Bytes()) {
+		return fmt.Errorf("the fields add up to %d bytes but the canonical stream has %d bytes", ins.stream.Len(), rec.Len())
+	}
+
+	digest, err := hashpb.Sum(nil, msg, ins.opts...)
+	if err != nil {
+		return fmt.Errorf("failed to hash message: %w", err)
+	}
+
+	fmt.Fprintf(ins.out, "total %d bytes, digest %s\n", rec.Len(), hex.EncodeToString(digest))
+	return nil
+}
+
+// traversalOrder returns the fields of the message in traversal order. Each oneof is represented by its first member,
+// at whose position the member that is set is hashed.
+func traversalOrder(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, 0, md.Fields().Len())
+	seen := make(map[protoreflect.FullName]struct{})
+	all := make([]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := range all {
+		all[i] = md.Fields().Get(i)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Number() < all[j].Number() })
+
+	for _, fd := range all {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if _, ok := seen[od.FullName()]; ok {
+				continue
+			}
+			seen[od.FullName()] = struct{}{}
+		}
+
+		fields = append(fields, fd)
+	}
+
+	return fields
+}
+
+func fieldEncoding(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return "values in key order, each " + hashpb.Encoding(fd.MapValue().Kind())
+	case fd.IsList() && hashpb.Unordered(fd):
+		return "sorted SHA-256 digests of the elements, each varint length followed by the bytes"
+	case fd.IsList() && hashpb.SampleEvery(fd) > 0:
+		return fmt.Sprintf("varint length followed by every %d-th element, each %s", hashpb.SampleEvery(fd), hashpb.Encoding(fd.Kind()))
+	case fd.IsList():
+		return "elements in order, each " + hashpb.Encoding(fd.Kind())
+	default:
+		return hashpb.Encoding(fd.Kind())
+	}
+}
+
+// recorder is a hash.Hash that records the bytes written to it.
+type recorder struct {
+	bytes.Buffer
+}
+
+func (r *recorder) Sum(b []byte) []byte {
+	return append(b, r.Bytes()...)
+}
+
+func (r *recorder) Size() int {
+	return r.Len()
+}
+
+func (r *recorder) BlockSize() int {
+	return 1
+}
@@ -0,0 +1,225 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// errFindings is returned by lint when it reports findings. The findings have already been printed.
+var errFindings = errors.New("lint findings")
+
+const anyFullName = "google.protobuf.Any"
+
+func runLint(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb lint [flags]")
+		fmt.Fprintln(fs.Output(), "Reports the fields and messages whose digests may differ between producers of the same content, such as")
+		fmt.Fprintln(fs.Output(), "google.protobuf.Any values, floating-point values, extensions and messages prone to carrying unknown fields.")
+		fmt.Fprintln(fs.Output(), "Only the fields reachable from the given message types (default: all message types) that are not ignored are checked.")
+		fs.PrintDefaults()
+	}
+
+	descriptors := fs.String("descriptors", "", "Path to a serialized FileDescriptorSet containing the message types (required)")
+	types := fs.String("type", "", "Comma-separated list of fully-qualified names of the message types to check")
+	ignore := fs.String("ignore", "", "Comma-separated list of fully-qualified field names to ignore")
+	decimalFloats := fs.Bool("decimal-floats", false, "Floating-point values are hashed using hashpb.WithDecimalFloats")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *descriptors == "" {
+		fmt.Fprintln(fs.Output(), "-descriptors is required")
+		fs.Usage()
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	files, err := descset.LoadFiles(*descriptors)
+	if err != nil {
+		return err
+	}
+
+	roots, err := lintRoots(files, *types)
+	if err != nil {
+		return err
+	}
+
+	l := &linter{decimalFloats: *decimalFloats, msgs: make(map[protoreflect.FullName]protoreflect.MessageDescriptor)}
+	if *ignore != "" {
+		l.ignore = make(map[string]struct{})
+		for _, name := range strings.Split(*ignore, ",") {
+			l.ignore[name] = struct{}{}
+		}
+	}
+
+	for _, md := range roots {
+		l.collect(md)
+	}
+
+	findings := l.findings()
+	for _, f := range findings {
+		fmt.Fprintln(stdout, f)
+	}
+
+	if len(findings) > 0 {
+		return errFindings
+	}
+
+	return nil
+}
+
+func lintRoots(files *protoregistry.Files, types string) ([]protoreflect.MessageDescriptor, error) {
+	var roots []protoreflect.MessageDescriptor
+	if types == "" {
+		files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+			roots = appendMessages(roots, fd.Messages())
+			return true
+		})
+		return roots, nil
+	}
+
+	for _, name := range strings.Split(types, ",") {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find message type %s: %w", name, err)
+		}
+
+		md, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a message type", name)
+		}
+
+		roots = append(roots, md)
+	}
+
+	return roots, nil
+}
+
+func appendMessages(msgs []protoreflect.MessageDescriptor, mds protoreflect.MessageDescriptors) []protoreflect.MessageDescriptor {
+	for i := 0; i < mds.Len(); i++ {
+		msgs = append(msgs, mds.Get(i))
+		msgs = appendMessages(msgs, mds.Get(i).Messages())
+	}
+
+	return msgs
+}
+
+// linter checks the messages reachable from a set of message types through fields that are not ignored.
+type linter struct {
+	ignore        map[string]struct{}
+	decimalFloats bool
+	msgs          map[protoreflect.FullName]protoreflect.MessageDescriptor
+}
+
+func (l *linter) collect(md protoreflect.MessageDescriptor) {
+	if md.IsMapEntry() {
+		if vmd := md.Fields().ByNumber(2).Message(); vmd != nil {
+			l.collect(vmd)
+		}
+		return
+	}
+
+	if _, ok := l.msgs[md.FullName()]; ok {
+		return
+	}
+
+	l.msgs[md.FullName()] = md
+
+	// google.protobuf.Any is hashed as its type URL and value bytes, so the packed message is never traversed.
+	if md.FullName() == anyFullName {
+		return
+	}
+
+	for _, fd := range l.fields(md) {
+		if fmd := fd.Message(); fmd != nil {
+			l.collect(fmd)
+		}
+	}
+}
+
+// fields returns the fields of the message that are hashed.
+func (l *linter) fields(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, 0, md.Fields().Len())
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && hashpb.Ignored(l.ignore, string(od.FullName())) {
+			continue
+		}
+
+		if hashpb.Ignored(l.ignore, string(fd.FullName()), hashpb.StableIgnoreKey(fd)) {
+			continue
+		}
+
+		fields = append(fields, fd)
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number() < fields[j].Number() })
+	return fields
+}
+
+// findings returns the findings for the collected messages, sorted by message name and field number.
+func (l *linter) findings() []string {
+	names := make([]string, 0, len(l.msgs))
+	for name := range l.msgs {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var findings []string
+	for _, name := range names {
+		md := l.msgs[protoreflect.FullName(name)]
+		if md.ExtensionRanges().Len() > 0 {
+			findings = append(findings, name+": extensions are not hashed, so values set in extensions don't change the digest")
+		}
+
+		if md.ReservedRanges().Len() > 0 || md.ReservedNames().Len() > 0 {
+			findings = append(findings, name+": has removed fields that producers using an older schema may still send; "+
+				"unknown fields are not hashed")
+		}
+
+		if md.FullName() == anyFullName {
+			continue
+		}
+
+		for _, fd := range l.fields(md) {
+			if msg := l.check(fd); msg != "" {
+				findings = append(findings, string(fd.FullName())+": "+msg)
+			}
+		}
+	}
+
+	return findings
+}
+
+func (l *linter) check(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+
+	switch {
+	case fd.Message() != nil && fd.Message().FullName() == anyFullName:
+		return "google.protobuf.Any values are hashed as serialized bytes, which are not deterministic across producers"
+	case (fd.Kind() == protoreflect.FloatKind || fd.Kind() == protoreflect.DoubleKind) && !l.decimalFloats:
+		return "floating-point values are hashed as IEEE 754 bits; use hashpb.WithDecimalFloats to hash them in decimal form"
+	default:
+		return ""
+	}
+}
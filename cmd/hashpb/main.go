@@ -0,0 +1,82 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command hashpb computes and inspects hashpb digests of serialized messages.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	run   func(args []string, stdout io.Writer) error
+	usage string
+}
+
+var commands = map[string]command{
+	"bench":      {run: runBench, usage: "Compare the throughput of hashing using reflection and generated code"},
+	"collisions": {run: runCollisions, usage: "Report digest collisions and near misses in a stream of messages"},
+	"diff":       {run: runDiff, usage: "Print the field paths that differ between two messages"},
+	"inspect":    {run: runInspect, usage: "Print an annotated hexdump of the canonical bytes of each field"},
+	"lint":       {run: runLint, usage: "Report fields whose digests may differ between producers"},
+	"stream":     {run: runStream, usage: "Print the digest of each record in a length-delimited stream"},
+	"sum":        {run: runSum, usage: "Print the digest of a message"},
+	"verify-gen": {run: runVerifyGen, usage: "Check that generated code is up to date"},
+}
+
+// errUsage is returned by commands when the arguments are invalid. The usage has already been printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	if err := cmd.run(args[1:], stdout); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+
+		if errors.Is(err, errDiffer) || errors.Is(err, errFindings) {
+			return 1
+		}
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: hashpb <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const msgType = "cerbos.hashpb.test.NestedTestAllTypes"
+
+func TestSum(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	msg := mkMsg()
+
+	inputs := map[string][]byte{
+		"msg.binpb":  mustMarshal(t, proto.Marshal, msg),
+		"msg.json":   mustMarshal(t, protojson.Marshal, msg),
+		"msg.txtpb":  mustMarshal(t, prototext.Marshal, msg),
+		"msg.binary": mustMarshal(t, proto.Marshal, msg),
+	}
+
+	for name, data := range inputs {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	ignore := "cerbos.hashpb.test.TestAllTypes.single_string"
+	for _, tc := range []struct {
+		ignore map[string]struct{}
+		args   []string
+	}{
+		{args: []string{}},
+		{args: []string{"-ignore", ignore}, ignore: map[string]struct{}{ignore: {}}},
+	} {
+		digest := xxhash.New()
+		msg.HashPB(digest, tc.ignore)
+		want := fmt.Sprintf("%016x", digest.Sum64())
+
+		for name := range inputs {
+			args := append([]string{"sum", "-descriptors", descriptors, "-type", msgType}, tc.args...)
+			stdout := runOK(t, append(args, filepath.Join(dir, name))...)
+			if have := strings.Fields(stdout)[0]; have != want {
+				t.Errorf("Digest mismatch for %s %v: want=%s have=%s", name, tc.args, want, have)
+			}
+		}
+	}
+
+	if code := run([]string{"sum", "-type", msgType, filepath.Join(dir, "msg.binpb")}, &bytes.Buffer{}, &bytes.Buffer{}); code != 2 {
+		t.Errorf("Expected usage error without descriptors: exit code %d", code)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	a := mkMsg()
+	b := mkMsg()
+	b.Child.Payload.SingleString = "wibble"
+	b.Payload.MapInt64NestedType[-2].Bb = 3
+
+	pathA := filepath.Join(dir, "a.binpb")
+	pathB := filepath.Join(dir, "b.binpb")
+	for path, msg := range map[string]proto.Message{pathA: a, pathB: b} {
+		if err := os.WriteFile(path, mustMarshal(t, proto.Marshal, msg), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	if have := runOK(t, "diff", "-descriptors", descriptors, "-type", msgType, pathA, pathA); have != "" {
+		t.Errorf("Expected no differences: %q", have)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"diff", "-descriptors", descriptors, "-type", msgType, pathA, pathB}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 for differing messages: have %d: %s", code, stderr.String())
+	}
+
+	want := "child.payload.single_string\npayload.map_int64_nested_type[-2].bb\n"
+	if have := stdout.String(); have != want {
+		t.Errorf("Unexpected differences: want=%q have=%q", want, have)
+	}
+
+	stdout.Reset()
+	ignore := "cerbos.hashpb.test.TestAllTypes.single_string"
+	if code := run([]string{"diff", "-descriptors", descriptors, "-type", msgType, "-ignore", ignore, pathA, pathB}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 for differing messages: have %d: %s", code, stderr.String())
+	}
+
+	if have, want := stdout.String(), "payload.map_int64_nested_type[-2].bb\n"; have != want {
+		t.Errorf("Unexpected differences with ignore: want=%q have=%q", want, have)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	msg := mkMsg()
+	path := filepath.Join(dir, "msg.binpb")
+	if err := os.WriteFile(path, mustMarshal(t, proto.Marshal, msg), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	digest := xxhash.New()
+	msg.HashPB(digest, nil)
+
+	stdout := runOK(t, "inspect", "-descriptors", descriptors, "-type", msgType, path)
+	for _, want := range []string{
+		"child.payload.single_int32 (varint of the value sign-extended to 64 bits, 10 bytes)\n  00000000  d6 ff ff ff ff ff ff ff ff 01\n",
+		"payload.single_nested_enum (varint of the value sign-extended to 64 bits, 1 bytes)\n",
+		"payload.map_int32_string (values in key order, each varint length followed by the UTF-8 bytes, 6 bytes)\n",
+		fmt.Sprintf(", digest %016x\n", digest.Sum64()),
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, stdout)
+		}
+	}
+
+	ignore := "cerbos.hashpb.test.TestAllTypes.single_int32"
+	stdout = runOK(t, "inspect", "-descriptors", descriptors, "-type", msgType, "-ignore", ignore, path)
+	if strings.Contains(stdout, "single_int32") {
+		t.Errorf("Expected ignored field to be left out:\n%s", stdout)
+	}
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lint", "-descriptors", descriptors, "-type", msgType}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 for findings: have %d: %s", code, stderr.String())
+	}
+
+	for _, want := range []string{
+		"cerbos.hashpb.test.TestAllTypes.single_any: google.protobuf.Any values",
+		"cerbos.hashpb.test.TestAllTypes.single_float: floating-point values",
+		"cerbos.hashpb.test.TestAllTypes.repeated_double: floating-point values",
+		"google.protobuf.Value.number_value: floating-point values",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected finding %q in %q", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"lint", "-descriptors", descriptors, "-type", msgType, "-decimal-floats"}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 for findings: have %d: %s", code, stderr.String())
+	}
+
+	if have, want := stdout.String(), "cerbos.hashpb.test.TestAllTypes.single_any: "; !strings.HasPrefix(have, want) || strings.Count(have, "\n") != 1 {
+		t.Errorf("Expected a single finding starting with %q, got %q", want, have)
+	}
+
+	ignore := "cerbos.hashpb.test.TestAllTypes.single_any"
+	if have := runOK(t, "lint", "-descriptors", descriptors, "-type", msgType, "-decimal-floats", "-ignore", ignore); have != "" {
+		t.Errorf("Expected no findings: %q", have)
+	}
+}
+
+func TestBench(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	path := filepath.Join(dir, "msg.binpb")
+	if err := os.WriteFile(path, mustMarshal(t, proto.Marshal, mkMsg()), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	stdout := runOK(t, "bench", "-descriptors", descriptors, "-type", msgType, "-n", "10", path, path)
+	for _, want := range []string{"ns/msg", "reflection", "generated", "speedup of generated code"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	msgs := []*pb.NestedTestAllTypes{mkMsg(), {}, {Payload: &pb.TestAllTypes{SingleString: "wibble"}}}
+
+	var buf bytes.Buffer
+	var wantDigests, wantTotals []string
+	rolling := xxhash.New()
+	for _, msg := range msgs {
+		if _, err := protodelim.MarshalTo(&buf, msg); err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+
+		digest := xxhash.New()
+		msg.HashPB(digest, nil)
+		wantDigests = append(wantDigests, fmt.Sprintf("%016x", digest.Sum64()))
+
+		_, _ = rolling.Write(digest.Sum(nil))
+		wantTotals = append(wantTotals, fmt.Sprintf("%016x", rolling.Sum64()))
+	}
+
+	path := filepath.Join(dir, "records.binpb")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("Failed to write records: %v", err)
+	}
+
+	stdout := runOK(t, "stream", "-descriptors", descriptors, "-type", msgType, path)
+	if have := strings.Fields(stdout); !reflect.DeepEqual(have, wantDigests) {
+		t.Errorf("Unexpected digests: want=%v have=%v", wantDigests, have)
+	}
+
+	stdout = runOK(t, "stream", "-descriptors", descriptors, "-type", msgType, "-total", path)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != len(msgs) {
+		t.Fatalf("Expected %d lines: %q", len(msgs), stdout)
+	}
+
+	for i, line := range lines {
+		if want := wantDigests[i] + "  " + wantTotals[i]; line != want {
+			t.Errorf("Unexpected output for record %d: want=%q have=%q", i, want, line)
+		}
+	}
+
+	if err := os.WriteFile(path, buf.Bytes()[:buf.Len()-1], 0o600); err != nil {
+		t.Fatalf("Failed to write records: %v", err)
+	}
+
+	if code := run([]string{"stream", "-descriptors", descriptors, "-type", msgType, path}, &bytes.Buffer{}, &bytes.Buffer{}); code != 1 {
+		t.Errorf("Expected failure for truncated stream: exit code %d", code)
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+
+	var buf bytes.Buffer
+	for _, msg := range []*pb.NestedTestAllTypes{mkMsg(), {}, mkMsg(), {Payload: &pb.TestAllTypes{SingleString: "wibble"}}} {
+		if _, err := protodelim.MarshalTo(&buf, msg); err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+	}
+
+	path := filepath.Join(dir, "records.binpb")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("Failed to write records: %v", err)
+	}
+
+	stdout := runOK(t, "collisions", "-descriptors", descriptors, "-type", msgType, "-hash", "xxhash,objecthash", "-mutations", "5", path)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and a line per hash function: %q", stdout)
+	}
+
+	for i, want := range [][]string{{"xxhash", "64", "24"}, {"objecthash", "256", "24"}} {
+		if have := strings.Fields(lines[i+1]); len(have) < 3 || !reflect.DeepEqual(have[:3], want) {
+			t.Errorf("Unexpected report: want prefix %v, have %q", want, lines[i+1])
+		}
+	}
+
+	if code := run([]string{"collisions", "-descriptors", descriptors, "-type", msgType, "-hash", "crc", path}, &bytes.Buffer{}, &bytes.Buffer{}); code != 2 {
+		t.Errorf("Expected usage error for unknown hash function: exit code %d", code)
+	}
+}
+
+func TestVerifyGen(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := writeDescriptors(t, dir)
+	opt := "paths=source_relative,registry=true,gen_conformance_tests=true,gen_tests=true,gen_vectors=true,gen_fuzz_tests=true,gen_map_order_tests=true,schema_fingerprint=true,gen_spec=true,objecthash=true,depth_limit=true,propagate_errors=true,sum64=true,append_hash=true,multi_hash=true,writer=true,no_ignore=true,shallow=true,filtered=true"
+	args := []string{"verify-gen", "-descriptors", descriptors, "-opt", opt}
+
+	runOK(t, append(args, "-dir", filepath.Join("..", ".."), "internal/pb/all_types.proto")...)
+
+	out := filepath.Join(dir, "internal", "pb")
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	for _, name := range []string{"all_types_hashpb.pb.go", "all_types_hashpb_bench_test.go", "removed_hashpb.pb.go", "all_types.pb.go"} {
+		if err := os.WriteFile(filepath.Join(out, name), []byte("package pb\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(append(args, "-dir", dir, "internal/pb/all_types.proto"), &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 for stale code: have %d: %s", code, stderr.String())
+	}
+
+	have := stdout.String()
+	for _, want := range []string{
+		"stale: " + filepath.Join(out, "all_types_hashpb.pb.go"),
+		"missing: " + filepath.Join(out, "hashpb_helpers.pb.go"),
+		"orphan: " + filepath.Join(out, "all_types_hashpb_bench_test.go"),
+		"orphan: " + filepath.Join(out, "removed_hashpb.pb.go"),
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected output to contain %q: %s", want, have)
+		}
+	}
+
+	if strings.Contains(have, "all_types.pb.go") {
+		t.Errorf("Expected files that are not generated by the plugin to be left alone: %s", have)
+	}
+}
+
+func mkMsg() *pb.NestedTestAllTypes {
+	return &pb.NestedTestAllTypes{
+		Child: &pb.NestedTestAllTypes{
+			Payload: &pb.TestAllTypes{SingleInt32: -42, SingleString: "wobble"},
+		},
+		Payload: &pb.TestAllTypes{
+			SingleInt64:         42,
+			SingleSint32:        -42,
+			SingleFloat:         42.42,
+			SingleString:        "wibble",
+			SingleBytes:         []byte("wibble"),
+			StandaloneEnum:      pb.TestAllTypes_BAZ,
+			SingleDuration:      durationpb.New(10 * time.Minute),
+			SingleTimestamp:     timestamppb.New(time.Unix(1642694886, 0)),
+			SingleStringWrapper: wrapperspb.String("wibble wobble"),
+			NestedType:          &pb.TestAllTypes_SingleNestedEnum{SingleNestedEnum: pb.TestAllTypes_BAR},
+			RepeatedSfixed64:    []int64{1, -2, 3},
+			MapInt32String:      map[int32]string{-1: "a", 2: "b", 3: "c"},
+			MapInt64NestedType:  map[int64]*pb.TestAllTypes_NestedMessage{1: {Bb: 1}, -2: {Bb: 2}},
+		},
+	}
+}
+
+func runOK(t *testing.T, args ...string) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("Command %v failed with exit code %d: %s", args, code, stderr.String())
+	}
+
+	return stdout.String()
+}
+
+func writeDescriptors(t *testing.T, dir string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "descriptors.binpb")
+	data := mustMarshal(t, proto.Marshal, descset.Build(pb.File_internal_pb_all_types_proto))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write descriptors: %v", err)
+	}
+
+	return path
+}
+
+func mustMarshal(t *testing.T, marshal func(proto.Message) ([]byte, error), msg proto.Message) []byte {
+	t.Helper()
+
+	data, err := marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	return data
+}
@@ -0,0 +1,99 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+func runStream(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("stream", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb stream [flags] [file]")
+		fmt.Fprintln(fs.Output(), "Reads varint length-delimited binary messages from file (default: stdin) and prints the digest of each record.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	total := fs.Bool("total", false, "Also print a rolling digest of all the records read so far")
+	maxSize := fs.Int64("max-size", 0, "Maximum size of a record in bytes (default: 4MiB, -1 for no limit)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := mf.validate(fs); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if mf.format != "" && mf.format != formatBinary {
+		return fmt.Errorf("stream only supports the %s format", formatBinary)
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	path := "-"
+	if fs.NArg() == 1 {
+		path = fs.Arg(0)
+	}
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	rolling := hashFns[mf.hashName]()
+	r := bufio.NewReader(in)
+	uo := protodelim.UnmarshalOptions{UnmarshalOptions: proto.UnmarshalOptions{Resolver: l.types}, MaxSize: *maxSize}
+	opts := mf.hashOptions()
+	for n := 0; ; n++ {
+		msg := l.newMessage()
+		if err := uo.UnmarshalFrom(r, msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to read record %d from %s: %w", n, path, err)
+		}
+
+		digest, err := hashpb.Sum(nil, msg, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to hash record %d from %s: %w", n, path, err)
+		}
+
+		if !*total {
+			fmt.Fprintln(stdout, hex.EncodeToString(digest))
+			continue
+		}
+
+		_, _ = rolling.Write(digest)
+		fmt.Fprintf(stdout, "%s  %s\n", hex.EncodeToString(digest), hex.EncodeToString(rolling.Sum(nil)))
+	}
+}
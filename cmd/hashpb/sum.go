@@ -0,0 +1,60 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+func runSum(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sum", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: hashpb sum [flags] <file>...")
+		fmt.Fprintln(fs.Output(), "Prints the digest of each file. Use - to read from stdin.")
+		fs.PrintDefaults()
+	}
+
+	var mf msgFlags
+	mf.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := mf.validate(fs); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	l, err := mf.loader()
+	if err != nil {
+		return err
+	}
+
+	opts := mf.hashOptions()
+	for _, path := range fs.Args() {
+		msg, err := l.read(path, mf.format)
+		if err != nil {
+			return err
+		}
+
+		digest, err := hashpb.Sum(nil, msg, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+
+		fmt.Fprintf(stdout, "%s  %s\n", hex.EncodeToString(digest), path)
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const versionLinePrefix = "// protoc-gen-go-hashpb "
+
+func runVerifyGen(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("verify-gen", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hashpb verify-gen [flags] [file.proto...]")
+		fmt.Fprintln(flags.Output(), "Regenerates code for the given files (default: all files in the descriptor set) and compares it with the files on disk.")
+		fmt.Fprintln(flags.Output(), "Generated files on disk that the plugin no longer produces for the given files are reported as orphans.")
+		flags.PrintDefaults()
+	}
+
+	descriptors := flags.String("descriptors", "", "Path to a serialized FileDescriptorSet containing the files (required)")
+	opt := flags.String("opt", "", "Comma-separated plugin parameters used to generate the code (e.g. paths=source_relative,registry=true)")
+	dir := flags.String("dir", ".", "Directory containing the generated code")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *descriptors == "" {
+		fmt.Fprintln(flags.Output(), "-descriptors is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	fds, err := descset.Load(*descriptors)
+	if err != nil {
+		return err
+	}
+
+	req, err := descset.Request(fds, flags.Args(), *opt)
+	if err != nil {
+		return err
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return errors.New(resp.GetError())
+	}
+
+	stale := 0
+	generated := make(map[string]struct{}, len(resp.File))
+	for _, f := range resp.File {
+		path := filepath.Join(*dir, filepath.FromSlash(f.GetName()))
+		generated[path] = struct{}{}
+		have, err := os.ReadFile(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			fmt.Fprintf(stdout, "missing: %s\n", path)
+			stale++
+			continue
+		}
+
+		if dropVersion(string(have)) != dropVersion(f.GetContent()) {
+			fmt.Fprintf(stdout, "stale: %s\n", path)
+			stale++
+		}
+	}
+
+	orphans, err := findOrphans(req, *dir, generated)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range orphans {
+		fmt.Fprintf(stdout, "orphan: %s\n", path)
+		stale++
+	}
+
+	if stale > 0 {
+		return fmt.Errorf("%d generated files are out of date", stale)
+	}
+
+	return nil
+}
+
+// findOrphans returns the files that look like plugin output in the directories of the given files but weren't
+// generated. Per-file outputs are orphans if they belong to one of the given files or to a file that is not in the
+// descriptor set anymore. Helper files are orphans if all the files of the directory were given, because helpers are
+// shared by the files of a package.
+func findOrphans(req *pluginpb.CodeGeneratorRequest, dir string, generated map[string]struct{}) ([]string, error) {
+	// Parameters of the generator don't affect the output file names, so only protogen needs to understand them.
+	p, err := protogen.Options{ParamFunc: func(string, string) error { return nil }}.New(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// prefixes maps the output path prefix of each file to whether it was given.
+	prefixes := make(map[string]bool, len(p.Files))
+	// complete records whether all the files with output in a directory were given.
+	complete := make(map[string]bool)
+	for _, f := range p.Files {
+		prefix := filepath.Join(dir, filepath.FromSlash(f.GeneratedFilenamePrefix))
+		prefixes[prefix] = f.Generate
+
+		outDir := filepath.Dir(prefix)
+		if c, ok := complete[outDir]; !ok || c {
+			complete[outDir] = f.Generate
+		}
+	}
+
+	var orphans []string
+	for outDir, all := range complete {
+		entries, err := os.ReadDir(outDir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", outDir, err)
+		}
+
+		for _, e := range entries {
+			path := filepath.Join(outDir, e.Name())
+			if _, ok := generated[path]; ok || e.IsDir() {
+				continue
+			}
+
+			name := e.Name()
+			if strings.HasPrefix(name, "hashpb_helpers") && strings.HasSuffix(name, ".pb.go") {
+				if all {
+					orphans = append(orphans, path)
+				}
+				continue
+			}
+
+			if !generatedName(name) {
+				continue
+			}
+
+			prefix := filepath.Join(outDir, name[:strings.LastIndex(name, "_hashpb")])
+			if given, ok := prefixes[prefix]; given || !ok {
+				orphans = append(orphans, path)
+			}
+		}
+	}
+
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// generatedName reports whether the file name has the form of a per-file output of the plugin, such as
+// x_hashpb.pb.go, x_hashpb_test.go or x_hashpb_vectors.json.
+func generatedName(name string) bool {
+	i := strings.LastIndex(name, "_hashpb")
+	if i <= 0 {
+		return false
+	}
+
+	rest := name[i+len("_hashpb"):]
+	switch {
+	case rest == ".pb.go":
+		return true
+	case strings.HasSuffix(rest, "_test.go"), strings.HasSuffix(rest, ".json"):
+		return strings.HasPrefix(rest, "_")
+	default:
+		return false
+	}
+}
+
+// dropVersion removes the line recording the plugin version so that code generated by different builds of the same
+// plugin compares equal.
+func dropVersion(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if !strings.HasPrefix(l, versionLinePrefix) {
+			kept = append(kept, l)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
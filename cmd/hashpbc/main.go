@@ -0,0 +1,54 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command hashpbc generates hashpb code from a serialized FileDescriptorSet without running protoc or buf.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <descriptors.binpb> [file.proto...]\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Generates code for the given files, or for all the files in the descriptor set if none are given.")
+		flag.PrintDefaults()
+	}
+	out := flag.String("out", ".", "Output directory")
+	opt := flag.String("opt", "", "Comma-separated plugin parameters (e.g. paths=source_relative,registry=true)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), flag.Args()[1:], *opt, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(descriptors string, filesToGenerate []string, opt, out string) error {
+	fds, err := descset.Load(descriptors)
+	if err != nil {
+		return err
+	}
+
+	req, err := descset.Request(fds, filesToGenerate, opt)
+	if err != nil {
+		return err
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		return err
+	}
+
+	return generator.WriteFiles(resp, out)
+}
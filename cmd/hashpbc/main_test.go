@@ -0,0 +1,66 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRun(t *testing.T) {
+	fds := descset.Build(pb.File_internal_pb_all_types_proto)
+	data, err := proto.Marshal(fds)
+	if err != nil {
+		t.Fatalf("Failed to marshal descriptor set: %v", err)
+	}
+
+	dir := t.TempDir()
+	descriptors := filepath.Join(dir, "descriptors.binpb")
+	if err := os.WriteFile(descriptors, data, 0o600); err != nil {
+		t.Fatalf("Failed to write descriptor set: %v", err)
+	}
+
+	out := filepath.Join(dir, "out")
+	if err := run(descriptors, []string{"internal/pb/all_types.proto"}, "paths=source_relative,registry=true,schema_fingerprint=true,objecthash=true,depth_limit=true,propagate_errors=true,sum64=true,append_hash=true,multi_hash=true,writer=true,no_ignore=true,shallow=true,filtered=true", out); err != nil {
+		t.Fatalf("Failed to run: %v", err)
+	}
+
+	for _, name := range []string{"all_types_hashpb.pb.go", "hashpb_helpers.pb.go"} {
+		want := readGenerated(t, filepath.Join("..", "..", "internal", "pb", name))
+		have := readGenerated(t, filepath.Join(out, "internal", "pb", name))
+		if want != have {
+			t.Errorf("Generated %s differs from the committed version", name)
+		}
+	}
+
+	if err := run(descriptors, []string{"missing.proto"}, "", out); err == nil {
+		t.Error("Expected error for file that is not in the descriptor set")
+	}
+}
+
+// readGenerated reads a generated file, dropping the line with the plugin version.
+func readGenerated(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if !strings.HasPrefix(l, "// protoc-gen-go-hashpb ") {
+			kept = append(kept, l)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
@@ -4,10 +4,10 @@ go 1.19
 
 require (
 	github.com/cespare/xxhash/v2 v2.1.2
+	github.com/google/go-cmp v0.5.6
+	golang.org/x/text v0.14.0
 	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/google/go-cmp v0.5.6 // indirect
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
-)
+require golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
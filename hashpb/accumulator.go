@@ -0,0 +1,120 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"hash"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotResumable is returned when checkpointing or resuming an Accumulator whose hash function does not implement
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+var ErrNotResumable = fmt.Errorf("%w: hash function does not support state serialization", ErrUnsupportedHash)
+
+const accumulatorStateVersion = 1
+
+// Accumulator computes a single digest over a sequence of messages, such as the records of a stream or of a bulk
+// re-hash job. Each message is hashed using the options and its digest is written, length-prefixed, to a running hasher
+// created using the hash function set using WithHash, so the result depends on the messages and their order.
+//
+// If the hash function implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, as xxhash and the hash
+// functions of the standard library do, the partially-computed digest can be checkpointed with MarshalBinary and
+// resumed with UnmarshalBinary in another process, so that long-running jobs don't have to start over after a
+// restart. An Accumulator is not safe for concurrent use.
+type Accumulator struct {
+	opts   *Options
+	hasher hash.Hash
+	count  uint64
+}
+
+// NewAccumulator creates an empty accumulator hashing messages with the given options.
+func NewAccumulator(opts ...Option) *Accumulator {
+	o := newOptions(opts)
+	return &Accumulator{opts: o, hasher: o.hashFn()}
+}
+
+// Add hashes the message and adds its digest to the accumulated digest.
+func (a *Accumulator) Add(msg proto.Message) error {
+	digest, err := sum(nil, msg, a.opts, hashMsg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.hasher.Write(protowire.AppendBytes(nil, digest)); err != nil {
+		return fmt.Errorf("failed to accumulate digest of %s: %w", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+
+	a.count++
+	return nil
+}
+
+// Count returns the number of messages added to the accumulator.
+func (a *Accumulator) Count() uint64 {
+	return a.count
+}
+
+// Sum appends the accumulated digest to dst. More messages can be added afterwards.
+func (a *Accumulator) Sum(dst []byte) []byte {
+	return a.hasher.Sum(dst)
+}
+
+// Reset discards the messages added to the accumulator.
+func (a *Accumulator) Reset() {
+	a.hasher.Reset()
+	a.count = 0
+}
+
+// MarshalBinary returns a checkpoint of the state of the accumulator. It returns ErrNotResumable if the hash function
+// does not implement encoding.BinaryMarshaler.
+func (a *Accumulator) MarshalBinary() ([]byte, error) {
+	m, ok := a.hasher.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, ErrNotResumable
+	}
+
+	state, err := m.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hasher state: %w", err)
+	}
+
+	b := protowire.AppendVarint(nil, accumulatorStateVersion)
+	b = protowire.AppendVarint(b, a.count)
+	return append(b, state...), nil
+}
+
+// UnmarshalBinary restores the state of the accumulator from a checkpoint created by MarshalBinary. The accumulator
+// must have been created with the same options, in particular the same hash function, as the one that was
+// checkpointed. It returns ErrNotResumable if the hash function does not implement encoding.BinaryUnmarshaler.
+func (a *Accumulator) UnmarshalBinary(data []byte) error {
+	u, ok := a.hasher.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return ErrNotResumable
+	}
+
+	version, n := protowire.ConsumeVarint(data)
+	if n < 0 {
+		return errors.New("invalid accumulator state")
+	}
+
+	if version != accumulatorStateVersion {
+		return fmt.Errorf("unsupported accumulator state version %d", version)
+	}
+
+	count, m := protowire.ConsumeVarint(data[n:])
+	if m < 0 {
+		return errors.New("invalid accumulator state")
+	}
+
+	if err := u.UnmarshalBinary(data[n+m:]); err != nil {
+		return fmt.Errorf("failed to unmarshal hasher state: %w", err)
+	}
+
+	a.count = count
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+)
+
+func TestAccumulator(t *testing.T) {
+	msgs := []*pb.TestAllTypes{{SingleInt32: 1}, {SingleString: "foo"}, {SingleBool: true}, {}}
+
+	accumulate := func(t *testing.T, acc *hashpb.Accumulator, msgs []*pb.TestAllTypes) {
+		t.Helper()
+		for _, m := range msgs {
+			if err := acc.Add(m); err != nil {
+				t.Fatalf("Failed to add: %v", err)
+			}
+		}
+	}
+
+	hashFns := map[string]func() hash.Hash{
+		"xxhash": func() hash.Hash { return xxhash.New() },
+		"sha256": sha256.New,
+	}
+
+	for name, hashFn := range hashFns {
+		t.Run(name, func(t *testing.T) {
+			full := hashpb.NewAccumulator(hashpb.WithHash(hashFn))
+			accumulate(t, full, msgs)
+			want := full.Sum(nil)
+
+			first := hashpb.NewAccumulator(hashpb.WithHash(hashFn))
+			accumulate(t, first, msgs[:2])
+			checkpoint, err := first.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Failed to checkpoint: %v", err)
+			}
+
+			resumed := hashpb.NewAccumulator(hashpb.WithHash(hashFn))
+			if err := resumed.UnmarshalBinary(checkpoint); err != nil {
+				t.Fatalf("Failed to resume: %v", err)
+			}
+			accumulate(t, resumed, msgs[2:])
+
+			if have := resumed.Sum(nil); !bytes.Equal(want, have) {
+				t.Errorf("Digest mismatch: want=%x have=%x", want, have)
+			}
+
+			if resumed.Count() != uint64(len(msgs)) {
+				t.Errorf("Count mismatch: want=%d have=%d", len(msgs), resumed.Count())
+			}
+
+			reordered := hashpb.NewAccumulator(hashpb.WithHash(hashFn))
+			accumulate(t, reordered, []*pb.TestAllTypes{msgs[1], msgs[0], msgs[2], msgs[3]})
+			if bytes.Equal(want, reordered.Sum(nil)) {
+				t.Error("Expected the digest to depend on the order of the messages")
+			}
+		})
+	}
+
+	t.Run("not_resumable", func(t *testing.T) {
+		acc := hashpb.NewAccumulator(hashpb.WithHash(func() hash.Hash { return hashpb.NewMultiHasher(xxhash.New(), xxhash.New()) }))
+		if _, err := acc.MarshalBinary(); !errors.Is(err, hashpb.ErrNotResumable) {
+			t.Errorf("Expected ErrNotResumable, got %v", err)
+		}
+
+		if err := acc.UnmarshalBinary(nil); !errors.Is(err, hashpb.ErrNotResumable) {
+			t.Errorf("Expected ErrNotResumable, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if err := hashpb.NewAccumulator().UnmarshalBinary([]byte{0xff}); err == nil {
+			t.Error("Expected error resuming from invalid state")
+		}
+	})
+}
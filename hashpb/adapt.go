@@ -0,0 +1,26 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// SumV1 is like Sum but accepts a legacy message generated by github.com/golang/protobuf or github.com/gogo/protobuf.
+// The message is converted to the new API internally and produces the same digest as its APIv2 counterpart.
+func SumV1(dst []byte, msg protoadapt.MessageV1, opts ...Option) ([]byte, error) {
+	return Sum(dst, protoadapt.MessageV2Of(msg), opts...)
+}
+
+// Sum64V1 is like Sum64 but accepts a legacy message generated by github.com/golang/protobuf or github.com/gogo/protobuf.
+func Sum64V1(msg protoadapt.MessageV1, opts ...Option) (uint64, error) {
+	return Sum64(protoadapt.MessageV2Of(msg), opts...)
+}
+
+// HashV1 is like Hash but accepts a legacy message generated by github.com/golang/protobuf or github.com/gogo/protobuf.
+func HashV1(hasher hash.Hash, msg protoadapt.MessageV1, opts ...Option) error {
+	return Hash(hasher, protoadapt.MessageV2Of(msg), opts...)
+}
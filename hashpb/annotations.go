@@ -0,0 +1,172 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldAnnotations caches the (hashpb.field) options of field descriptors, which are expensive to read.
+var fieldAnnotations sync.Map
+
+// annotations holds the (hashpb.field) options of a field that affect hashing.
+type annotations struct {
+	stableKey       string
+	caseInsensitive bool
+	trimSpace       bool
+	sampleEvery     int
+	sortBy          protoreflect.FieldDescriptor
+	unordered       bool
+}
+
+func annotationsOf(fd protoreflect.FieldDescriptor) annotations {
+	if a, ok := fieldAnnotations.Load(fd); ok {
+		return a.(annotations)
+	}
+
+	var a annotations
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts != nil {
+		fo := proto.GetExtension(opts, optionspb.E_Field).(*optionspb.FieldOptions)
+		if name := fo.GetStableName(); name != "" {
+			a.stableKey = string(fd.ContainingMessage().FullName()) + "." + name
+		}
+		a.caseInsensitive = fo.GetCaseInsensitive() && fd.Kind() == protoreflect.StringKind
+		a.trimSpace = fo.GetTrimSpace() && fd.Kind() == protoreflect.StringKind
+		if fd.IsList() && fo.GetSampleEvery() > 1 {
+			a.sampleEvery = int(fo.GetSampleEvery())
+		}
+		a.sortBy = sortKeyField(fd, fo.GetSortBy())
+		if fd.IsList() && fd.Message() != nil && fo.GetUnordered() {
+			// Unordered fields ignore the options that depend on the order of the elements.
+			a.unordered = true
+			a.sortBy = nil
+			a.sampleEvery = 0
+		}
+	}
+
+	fieldAnnotations.Store(fd, a)
+	return a
+}
+
+// stringValue returns the value hashed for the string s of the field, applying the normalization enabled by the
+// options and the annotations of the field.
+func stringValue(fd protoreflect.FieldDescriptor, s string, nfc bool) string {
+	s = normalizeString(s, nfc)
+
+	a := annotationsOf(fd)
+	if a.trimSpace {
+		s = strings.TrimSpace(s)
+	}
+
+	if a.caseInsensitive {
+		s = strings.ToLower(s)
+	}
+
+	return s
+}
+
+// SampleEvery returns the sampling interval of the repeated field set by its (hashpb.field).sample_every option, or
+// zero if every element of the field is hashed.
+func SampleEvery(fd protoreflect.FieldDescriptor) int {
+	return annotationsOf(fd).sampleEvery
+}
+
+// Unordered reports whether the elements of the repeated message field are hashed as an unordered collection, as set
+// by its (hashpb.field).unordered option.
+func Unordered(fd protoreflect.FieldDescriptor) bool {
+	return annotationsOf(fd).unordered
+}
+
+// SortBy returns the field of the element message by which the elements of the repeated message field are sorted
+// before hashing, as set by its (hashpb.field).sort_by option, or nil if the elements are hashed in the order they are
+// stored. Options that don't name a singular, non-floating point scalar field of the element message are ignored.
+func SortBy(fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	return annotationsOf(fd).sortBy
+}
+
+func sortKeyField(fd protoreflect.FieldDescriptor, name string) protoreflect.FieldDescriptor {
+	if name == "" || !fd.IsList() || fd.Message() == nil {
+		return nil
+	}
+
+	key := fd.Message().Fields().ByName(protoreflect.Name(name))
+	if key == nil || !isSortKey(key) {
+		return nil
+	}
+
+	return key
+}
+
+// isSortKey reports whether the field can be used as a sort key by the (hashpb.field).sort_by option.
+func isSortKey(fd protoreflect.FieldDescriptor) bool {
+	if fd.Cardinality() == protoreflect.Repeated || fd.Message() != nil {
+		return false
+	}
+
+	switch fd.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return false
+	default:
+		return true
+	}
+}
+
+// sortedList is a list whose elements are accessed in the order of their sort keys.
+type sortedList struct {
+	protoreflect.List
+	order []int
+}
+
+func (l sortedList) Get(i int) protoreflect.Value {
+	return l.List.Get(l.order[i])
+}
+
+// sortList returns the list ordered by the sort key of the field, or the list itself if the field has no sort key.
+func sortList(fd protoreflect.FieldDescriptor, list protoreflect.List) protoreflect.List {
+	key := SortBy(fd)
+	if key == nil || list.Len() < 2 {
+		return list
+	}
+
+	keys := make([]protoreflect.Value, list.Len())
+	order := make([]int, list.Len())
+	for i := range order {
+		keys[i] = list.Get(i).Message().Get(key)
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessValue(key.Kind(), keys[order[i]], keys[order[j]])
+	})
+
+	return sortedList{List: list, order: order}
+}
+
+func lessValue(kind protoreflect.Kind, a, b protoreflect.Value) bool {
+	switch kind {
+	case protoreflect.BoolKind:
+		return !a.Bool() && b.Bool()
+	case protoreflect.EnumKind:
+		return a.Enum() < b.Enum()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return a.Int() < b.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return a.Uint() < b.Uint()
+	case protoreflect.StringKind:
+		return a.String() < b.String()
+	case protoreflect.BytesKind:
+		return bytes.Compare(a.Bytes(), b.Bytes()) < 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestStringAnnotations(t *testing.T) {
+	testCases := []struct {
+		name      string
+		options   *optionspb.FieldOptions
+		want      [2]string
+		same      [2]string
+		different [2]string
+	}{
+		{
+			name:      "case_insensitive",
+			options:   &optionspb.FieldOptions{CaseInsensitive: true},
+			want:      [2]string{"alice@example.com", "ally"},
+			same:      [2]string{"Alice@Example.COM", "ALLY"},
+			different: [2]string{" alice@example.com", "ally"},
+		},
+		{
+			name:      "trim_space",
+			options:   &optionspb.FieldOptions{TrimSpace: true},
+			want:      [2]string{"Alice Smith", "ally"},
+			same:      [2]string{"  Alice Smith\n", "\tally "},
+			different: [2]string{"alice smith", "ally"},
+		},
+		{
+			name:      "both",
+			options:   &optionspb.FieldOptions{TrimSpace: true, CaseInsensitive: true},
+			want:      [2]string{"alice@example.com", "ally"},
+			same:      [2]string{" Alice@Example.COM ", "ALLY\n"},
+			different: [2]string{"alice@example.org", "ally"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := annotatedStringDescriptor(t, tc.options)
+			mkMsg := func(values [2]string, displayName string) *dynamicpb.Message {
+				msg := dynamicpb.NewMessage(md)
+				msg.Set(md.Fields().ByName("email"), protoreflect.ValueOfString(values[0]))
+				msg.Mutable(md.Fields().ByName("aliases")).List().Append(protoreflect.ValueOfString(values[1]))
+				msg.Set(md.Fields().ByName("display_name"), protoreflect.ValueOfString(displayName))
+				return msg
+			}
+
+			for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+				sum := func(m proto.Message) []byte {
+					t.Helper()
+
+					digest, err := hashpb.Sum(nil, m, hashpb.WithScheme(scheme))
+					if err != nil {
+						t.Fatalf("Failed to hash: %v", err)
+					}
+
+					return digest
+				}
+
+				want := sum(mkMsg(tc.want, "Alice"))
+				if have := sum(mkMsg(tc.same, "Alice")); !bytes.Equal(want, have) {
+					t.Errorf("Expected %q to hash like %q with scheme %v", tc.same, tc.want, scheme)
+				}
+
+				if have := sum(mkMsg(tc.different, "Alice")); bytes.Equal(want, have) {
+					t.Errorf("Expected %q to hash differently from %q with scheme %v", tc.different, tc.want, scheme)
+				}
+
+				if have := sum(mkMsg(tc.want, " ALICE ")); bytes.Equal(want, have) {
+					t.Errorf("Expected fields without annotations to be hashed as they are with scheme %v", scheme)
+				}
+			}
+		})
+	}
+}
+
+func TestSampledList(t *testing.T) {
+	md := annotatedStringDescriptor(t, &optionspb.FieldOptions{SampleEvery: 3})
+	if have := hashpb.SampleEvery(md.Fields().ByName("aliases")); have != 3 {
+		t.Fatalf("Expected repeated field to be sampled every 3 elements, got %d", have)
+	}
+
+	if have := hashpb.SampleEvery(md.Fields().ByName("email")); have != 0 {
+		t.Fatalf("Expected singular field not to be sampled, got %d", have)
+	}
+
+	mkMsg := func(aliases ...string) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(md)
+		list := msg.Mutable(md.Fields().ByName("aliases")).List()
+		for _, a := range aliases {
+			list.Append(protoreflect.ValueOfString(a))
+		}
+		return msg
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(m proto.Message) []byte {
+			t.Helper()
+
+			digest, err := hashpb.Sum(nil, m, hashpb.WithScheme(scheme))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		want := sum(mkMsg("a", "b", "c", "d", "e", "f", "g"))
+		if have := sum(mkMsg("a", "x", "y", "d", "z", "f", "g")); !bytes.Equal(want, have) {
+			t.Errorf("Expected elements between samples not to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg("a", "b", "c", "x", "e", "f", "g")); bytes.Equal(want, have) {
+			t.Errorf("Expected sampled elements to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg("a", "b", "c", "d", "e", "f")); bytes.Equal(want, have) {
+			t.Errorf("Expected the length to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg()); !bytes.Equal(sum(dynamicpb.NewMessage(md)), have) {
+			t.Errorf("Expected empty sampled list to hash like an unset list with scheme %v", scheme)
+		}
+	}
+}
+
+func TestSortedList(t *testing.T) {
+	md := messageListDescriptor(t, &optionspb.FieldOptions{SortBy: "id"})
+	items := md.Fields().ByName("items")
+	if have := hashpb.SortBy(items); have == nil || have.Name() != "id" {
+		t.Fatalf("Expected repeated field to be sorted by id, got %v", have)
+	}
+
+	mkMsg := func(elems ...[2]string) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(md)
+		list := msg.Mutable(items).List()
+		for _, e := range elems {
+			item := list.NewElement().Message()
+			item.Set(item.Descriptor().Fields().ByName("id"), protoreflect.ValueOfString(e[0]))
+			item.Set(item.Descriptor().Fields().ByName("value"), protoreflect.ValueOfString(e[1]))
+			list.Append(protoreflect.ValueOfMessage(item))
+		}
+		return msg
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(m proto.Message) []byte {
+			t.Helper()
+
+			digest, err := hashpb.Sum(nil, m, hashpb.WithScheme(scheme))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		want := sum(mkMsg([2]string{"a", "1"}, [2]string{"b", "2"}, [2]string{"c", "3"}))
+		if have := sum(mkMsg([2]string{"c", "3"}, [2]string{"a", "1"}, [2]string{"b", "2"})); !bytes.Equal(want, have) {
+			t.Errorf("Expected the order of the elements not to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg([2]string{"a", "1"}, [2]string{"b", "3"}, [2]string{"c", "2"})); bytes.Equal(want, have) {
+			t.Errorf("Expected the values of the elements to affect the digest with scheme %v", scheme)
+		}
+
+		// Elements with equal keys keep their relative order.
+		if have, other := sum(mkMsg([2]string{"a", "1"}, [2]string{"a", "2"})), sum(mkMsg([2]string{"a", "2"}, [2]string{"a", "1"})); bytes.Equal(have, other) {
+			t.Errorf("Expected the order of elements with equal keys to affect the digest with scheme %v", scheme)
+		}
+	}
+
+	unsorted := messageListDescriptor(t, &optionspb.FieldOptions{SortBy: "value_missing"})
+	if have := hashpb.SortBy(unsorted.Fields().ByName("items")); have != nil {
+		t.Errorf("Expected sort_by naming a missing field to be ignored, got %v", have)
+	}
+}
+
+func TestUnorderedList(t *testing.T) {
+	md := messageListDescriptor(t, &optionspb.FieldOptions{Unordered: true})
+	items := md.Fields().ByName("items")
+	if !hashpb.Unordered(items) {
+		t.Fatal("Expected repeated field to be unordered")
+	}
+
+	mkMsg := func(values ...string) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(md)
+		list := msg.Mutable(items).List()
+		for _, v := range values {
+			item := list.NewElement().Message()
+			item.Set(item.Descriptor().Fields().ByName("value"), protoreflect.ValueOfString(v))
+			list.Append(protoreflect.ValueOfMessage(item))
+		}
+		return msg
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(m proto.Message) []byte {
+			t.Helper()
+
+			digest, err := hashpb.Sum(nil, m, hashpb.WithScheme(scheme))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		want := sum(mkMsg("a", "b", "c"))
+		if have := sum(mkMsg("c", "a", "b")); !bytes.Equal(want, have) {
+			t.Errorf("Expected the order of the elements not to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg("a", "b", "d")); bytes.Equal(want, have) {
+			t.Errorf("Expected the values of the elements to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg("a", "b", "c", "a")); bytes.Equal(want, have) {
+			t.Errorf("Expected duplicate elements to affect the digest with scheme %v", scheme)
+		}
+
+		if have := sum(mkMsg()); !bytes.Equal(sum(dynamicpb.NewMessage(md)), have) {
+			t.Errorf("Expected empty unordered list to hash like an unset list with scheme %v", scheme)
+		}
+	}
+}
+
+// messageListDescriptor returns the descriptor of a message with a repeated message field annotated with the given
+// options. The elements have string id and value fields.
+func messageListDescriptor(t *testing.T, fo *optionspb.FieldOptions) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, fo)
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("sorted.proto"),
+		Package: proto.String("sorted"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Inventory"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     proto.String("items"),
+					JsonName: proto.String("items"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".sorted.Item"),
+					Options:  opts,
+				}},
+			},
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+					{Name: proto.String("value"), JsonName: proto.String("value"), Number: proto.Int32(2), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	return fd.Messages().ByName("Inventory")
+}
+
+// annotatedStringDescriptor returns the descriptor of a message with singular and repeated string fields annotated
+// with the given options, and a string field without annotations.
+func annotatedStringDescriptor(t *testing.T, fo *optionspb.FieldOptions) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, fo)
+
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Options:  opts,
+		}
+	}
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("annotated.proto"),
+		Package: proto.String("annotated"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("email", 1, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, opts),
+				field("aliases", 2, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, opts),
+				field("display_name", 3, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, nil),
+			},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	return fd.Messages().Get(0)
+}
@@ -0,0 +1,250 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records the exact content that services act on as structured audit records.
+//
+// A record identifies a message by its type, the digest of its content and the fingerprint of its schema, along with
+// the hashing scheme, version, hash function, ignored fields and normalizations used to compute the digest, so that
+// the digest can be recomputed and the content matched against stored copies long after the fact. Records are written to a Sink, such as JSONSink, which writes them as JSON lines.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Record describes a message that a service acted on.
+type Record struct {
+	// Timestamp is the time at which the record was created.
+	Timestamp time.Time `json:"timestamp"`
+	// MessageType is the fully-qualified name of the message type.
+	MessageType string `json:"messageType"`
+	// Scheme is the hashing scheme used to compute the digest: hashpb or objecthash.
+	Scheme string `json:"scheme"`
+	// SchemeVersion is the version of the hashpb scheme (see hashpb.GenVersion).
+	SchemeVersion int `json:"schemeVersion"`
+	// HashFunction is the name of the hash function used to compute the digest, such as xxhash or sha256. Hash functions
+	// that the package doesn't know are named after the Go type of their hasher.
+	HashFunction string `json:"hashFunction"`
+	// Ignored are the sorted names of the fields and oneofs set using hashpb.WithIgnore (see hashpb.Options.Ignored).
+	// Fields matched by hashpb.WithIgnoreMatcher are not listed.
+	Ignored []string `json:"ignored,omitempty"`
+	// Normalizations describe the options that change how values are hashed (see hashpb.Options.Normalizations).
+	Normalizations []string `json:"normalizations,omitempty"`
+	// SchemaFingerprint is the hex-encoded fingerprint of the schema of the message (see hashpb.SchemaFingerprint).
+	SchemaFingerprint string `json:"schemaFingerprint"`
+	// Digest is the digest of the message.
+	Digest hashpb.Digest `json:"digest"`
+	// Attributes describe the context in which the service acted on the message, such as the action or the actor.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+// SinkFunc is a function that implements Sink.
+type SinkFunc func(ctx context.Context, record Record) error
+
+func (f SinkFunc) Write(ctx context.Context, record Record) error {
+	return f(ctx, record)
+}
+
+// JSONSink is a Sink that writes each record as a line of JSON. It is safe for concurrent use.
+type JSONSink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewJSONSink creates a JSONSink that writes to w.
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+func (s *JSONSink) Write(_ context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	return nil
+}
+
+type options struct {
+	hashOpts []hashpb.Option
+	now      func() time.Time
+}
+
+type Option func(*options)
+
+// WithHashOptions sets the options used to hash messages.
+func WithHashOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.hashOpts = opts
+	}
+}
+
+// WithClock sets the function that returns the timestamps of the records. Defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// Emitter creates audit records and writes them to a sink. It is safe for concurrent use if the sink is.
+type Emitter struct {
+	sink           Sink
+	hashOpts       []hashpb.Option
+	scheme         string
+	hashFunction   string
+	ignored        []string
+	normalizations []string
+	now            func() time.Time
+	fingerprints   sync.Map
+}
+
+// New creates an Emitter that writes records to the sink.
+func New(sink Sink, opts ...Option) *Emitter {
+	o := &options{now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	hashOpts := hashpb.NewOptions(o.hashOpts...)
+	e := &Emitter{
+		sink:           sink,
+		hashOpts:       o.hashOpts,
+		scheme:         "hashpb",
+		hashFunction:   hashFunctionName(hashOpts.HashFunc()),
+		ignored:        hashOpts.Ignored(),
+		normalizations: hashOpts.Normalizations(),
+		now:            o.now,
+	}
+
+	if len(e.ignored) == 0 {
+		e.ignored = nil
+	}
+
+	if hashOpts.Scheme() == hashpb.SchemeObjectHash {
+		e.scheme = "objecthash"
+		e.hashFunction = "sha256"
+	}
+
+	return e
+}
+
+// Record creates the audit record of the message without writing it to the sink.
+func (e *Emitter) Record(msg proto.Message, attrs map[string]string) (Record, error) {
+	digest, err := hashpb.SumDigest(msg, e.hashOpts...)
+	if err != nil {
+		return Record{}, err
+	}
+
+	md := msg.ProtoReflect().Descriptor()
+	return Record{
+		Timestamp:         e.now(),
+		MessageType:       string(md.FullName()),
+		Scheme:            e.scheme,
+		SchemeVersion:     hashpb.GenVersion,
+		HashFunction:      e.hashFunction,
+		Ignored:           e.ignored,
+		Normalizations:    e.normalizations,
+		SchemaFingerprint: e.fingerprint(md),
+		Digest:            digest,
+		Attributes:        attrs,
+	}, nil
+}
+
+// Emit creates the audit record of the message and writes it to the sink. The attributes, which may be nil, are
+// recorded as they are.
+func (e *Emitter) Emit(ctx context.Context, msg proto.Message, attrs map[string]string) (Record, error) {
+	record, err := e.Record(msg, attrs)
+	if err != nil {
+		return Record{}, err
+	}
+
+	if err := e.sink.Write(ctx, record); err != nil {
+		return Record{}, err
+	}
+
+	return record, nil
+}
+
+// fingerprint returns the hex-encoded schema fingerprint of the message type, which is only computed once per type.
+func (e *Emitter) fingerprint(md protoreflect.MessageDescriptor) string {
+	if fp, ok := e.fingerprints.Load(md.FullName()); ok {
+		return fp.(string)
+	}
+
+	fp := fmt.Sprintf("%016x", hashpb.SchemaFingerprint(md))
+	e.fingerprints.Store(md.FullName(), fp)
+	return fp
+}
+
+// knownHashFunctions are the hash functions that records refer to by name.
+var knownHashFunctions = []struct {
+	name string
+	fn   func() hash.Hash
+}{
+	{name: "xxhash", fn: func() hash.Hash { return xxhash.New() }},
+	{name: "sha256", fn: sha256.New},
+	{name: "sha224", fn: sha256.New224},
+	{name: "sha512", fn: sha512.New},
+	{name: "sha384", fn: sha512.New384},
+	{name: "sha1", fn: sha1.New},
+	{name: "md5", fn: md5.New},
+	{name: "fnv32", fn: func() hash.Hash { return fnv.New32() }},
+	{name: "fnv32a", fn: func() hash.Hash { return fnv.New32a() }},
+	{name: "fnv64", fn: func() hash.Hash { return fnv.New64() }},
+	{name: "fnv64a", fn: func() hash.Hash { return fnv.New64a() }},
+	{name: "fnv128", fn: fnv.New128},
+	{name: "fnv128a", fn: fnv.New128a},
+}
+
+// hashFunctionName identifies the hash function by comparing its digest of a fixed input with the digests of the known
+// hash functions, because functions can't be compared. Unknown hash functions are named after the Go type of their
+// hasher.
+func hashFunctionName(fn func() hash.Hash) string {
+	probe := []byte("cerbos/protoc-gen-go-hashpb")
+	h := fn()
+	_, _ = h.Write(probe)
+	sum := h.Sum(nil)
+
+	for _, known := range knownHashFunctions {
+		kh := known.fn()
+		if kh.Size() != h.Size() {
+			continue
+		}
+
+		_, _ = kh.Write(probe)
+		if bytes.Equal(kh.Sum(nil), sum) {
+			return known.name
+		}
+	}
+
+	return fmt.Sprintf("%T", h)
+}
@@ -0,0 +1,135 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/audit"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEmit(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	msg := &pb.TestAllTypes{SingleString: "wibble"}
+
+	digest, err := hashpb.SumDigest(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	want := audit.Record{
+		Timestamp:         now,
+		MessageType:       "cerbos.hashpb.test.TestAllTypes",
+		Scheme:            "hashpb",
+		SchemeVersion:     hashpb.GenVersion,
+		HashFunction:      "xxhash",
+		SchemaFingerprint: fmt.Sprintf("%016x", hashpb.SchemaFingerprint(msg.ProtoReflect().Descriptor())),
+		Digest:            digest,
+		Attributes:        map[string]string{"action": "approve"},
+	}
+
+	var out bytes.Buffer
+	emitter := audit.New(audit.NewJSONSink(&out), audit.WithClock(func() time.Time { return now }))
+	have, err := emitter.Emit(context.Background(), msg, map[string]string{"action": "approve"})
+	if err != nil {
+		t.Fatalf("Failed to emit: %v", err)
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("Record mismatch (-want +have):\n%s", diff)
+	}
+
+	var written audit.Record
+	if err := json.Unmarshal(out.Bytes(), &written); err != nil {
+		t.Fatalf("Failed to unmarshal written record %q: %v", out.String(), err)
+	}
+
+	if diff := cmp.Diff(want, written); diff != "" {
+		t.Errorf("Written record mismatch (-want +have):\n%s", diff)
+	}
+
+	objectHash := audit.New(audit.NewJSONSink(&out), audit.WithHashOptions(hashpb.WithScheme(hashpb.SchemeObjectHash)))
+	record, err := objectHash.Record(msg, nil)
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	if record.Scheme != "objecthash" || record.HashFunction != "sha256" || record.Digest.Equal(digest) {
+		t.Errorf("Expected an objecthash record, got %+v", record)
+	}
+}
+
+func TestRecordHashOptions(t *testing.T) {
+	msg := &pb.TestAllTypes{SingleString: "wibble", SingleInt32: 42}
+	hashOpts := []hashpb.Option{
+		hashpb.WithHash(sha512.New384),
+		hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_int32", "cerbos.hashpb.test.TestAllTypes.single_bool"),
+		hashpb.WithNormalizeUnicode(),
+	}
+
+	record, err := audit.New(audit.NewJSONSink(io.Discard), audit.WithHashOptions(hashOpts...)).Record(msg, nil)
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	if record.HashFunction != "sha384" {
+		t.Errorf("Expected hash function sha384, got %q", record.HashFunction)
+	}
+
+	wantIgnored := []string{"cerbos.hashpb.test.TestAllTypes.single_bool", "cerbos.hashpb.test.TestAllTypes.single_int32"}
+	if diff := cmp.Diff(wantIgnored, record.Ignored); diff != "" {
+		t.Errorf("Ignored mismatch (-want +have):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(hashpb.NewOptions(hashOpts...).Normalizations(), record.Normalizations); diff != "" || len(record.Normalizations) == 0 {
+		t.Errorf("Normalizations mismatch (-want +have):\n%s", diff)
+	}
+
+	// The recorded options must be enough to recompute the digest.
+	recomputed, err := hashpb.SumDigest(msg, hashpb.WithHash(sha512.New384), hashpb.WithIgnore(record.Ignored...), hashpb.WithNormalizeUnicode())
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if !recomputed.Equal(record.Digest) {
+		t.Errorf("Recomputed digest %s doesn't match the recorded digest %s", recomputed, record.Digest)
+	}
+
+	keyed := func() hash.Hash { return hmac.New(sha256.New, []byte("key")) }
+	custom, err := audit.New(audit.NewJSONSink(io.Discard), audit.WithHashOptions(hashpb.WithHash(keyed))).Record(msg, nil)
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	if want := fmt.Sprintf("%T", keyed()); custom.HashFunction != want {
+		t.Errorf("Expected the hash function to be named after the hasher type, got %q", custom.HashFunction)
+	}
+}
+
+func TestEmitErrors(t *testing.T) {
+	errSink := errors.New("sink failed")
+	emitter := audit.New(audit.SinkFunc(func(context.Context, audit.Record) error { return errSink }))
+
+	if _, err := emitter.Emit(context.Background(), &pb.TestAllTypes{}, nil); !errors.Is(err, errSink) {
+		t.Errorf("Expected sink error, got %v", err)
+	}
+
+	if _, err := emitter.Emit(context.Background(), nil, nil); !errors.Is(err, hashpb.ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage, got %v", err)
+	}
+}
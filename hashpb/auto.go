@@ -0,0 +1,116 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Hashable is implemented by messages with HashPB methods generated by protoc-gen-go-hashpb.
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash.
+type Hashable interface {
+	HashPB(hasher hash.Hash, ignore map[string]struct{})
+}
+
+// ErrHashable is implemented by messages with HashPBErr methods generated by protoc-gen-go-hashpb using the
+// propagate_errors=true parameter. Unlike HashPB, the method returns the first error returned by the hasher.
+type ErrHashable interface {
+	HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error
+}
+
+// Sum64Hashable is implemented by messages with Sum64HashPB methods generated by protoc-gen-go-hashpb using the
+// sum64=true parameter. The method returns the 64-bit xxhash digest of the message, which is the same as the digest
+// produced by HashPB with an xxhash hasher.
+type Sum64Hashable interface {
+	Sum64HashPB(ignore map[string]struct{}) uint64
+}
+
+// AppendHashable is implemented by messages with AppendHashPB methods generated by protoc-gen-go-hashpb using the
+// append_hash=true parameter. The method appends the xxhash digest of the message to dst, which is the same as the
+// digest produced by Sum with the default options.
+type AppendHashable interface {
+	AppendHashPB(dst []byte, ignore map[string]struct{}) []byte
+}
+
+// WriterHashable is implemented by messages with WriteHashPB methods generated by protoc-gen-go-hashpb using the
+// writer=true parameter. The method writes the bytes that HashPB feeds to the hasher to w instead.
+type WriterHashable interface {
+	WriteHashPB(w io.Writer, ignore map[string]struct{}) error
+}
+
+// NoIgnoreHashable is implemented by messages with HashPBNoIgnore methods generated by protoc-gen-go-hashpb using the
+// no_ignore=true parameter. The method produces the same digest as HashPB with an empty ignore set, without checking
+// the ignore set for each field.
+type NoIgnoreHashable interface {
+	HashPBNoIgnore(hasher hash.Hash)
+}
+
+// SumAuto is like Sum but uses the generated HashPB method if the message has one, falling back to reflection otherwise.
+// The generated HashPBErr method is preferred over HashPB if both are available, and HashPBNoIgnore is preferred over
+// HashPB if no fields are ignored.
+func SumAuto(dst []byte, msg proto.Message, opts ...Option) ([]byte, error) {
+	return sum(dst, msg, newOptions(opts), hashAuto)
+}
+
+// Sum64Auto is like Sum64 but uses the generated HashPB method if the message has one, falling back to reflection otherwise.
+func Sum64Auto(msg proto.Message, opts ...Option) (uint64, error) {
+	return sum64(msg, newOptions(opts), hashAuto)
+}
+
+func hashAuto(hasher hash.Hash, msg proto.Message, o *Options) error {
+	if o.scheme == SchemeObjectHash {
+		return objectHashAuto(hasher, msg, o)
+	}
+
+	if o.shallow {
+		if h, ok := msg.(ShallowHashable); ok && !o.reflectionOnly() {
+			h.HashPBShallow(hasher, o.ignore)
+			return nil
+		}
+
+		return hashMsg(hasher, msg, o)
+	}
+
+	if o.reflectionOnly() {
+		return hashMsg(hasher, msg, o)
+	}
+
+	if o.maxDepth > 0 {
+		if h, ok := msg.(DepthLimitedHashable); ok {
+			return h.HashPBWithMaxDepth(hasher, o.ignore, o.maxDepth)
+		}
+
+		return hashMsg(hasher, msg, o)
+	}
+
+	if h, ok := msg.(ErrHashable); ok {
+		return h.HashPBErr(hasher, o.ignore)
+	}
+
+	if h, ok := msg.(NoIgnoreHashable); ok && len(o.ignore) == 0 {
+		h.HashPBNoIgnore(hasher)
+		return nil
+	}
+
+	if h, ok := msg.(Hashable); ok {
+		h.HashPB(hasher, o.ignore)
+		return nil
+	}
+
+	return hashMsg(hasher, msg, o)
+}
+
+// plainGenerated reports whether the generated HashPB methods produce the digests for the options, which is when
+// hashAuto reaches its last branches.
+func (o *Options) plainGenerated() bool {
+	return o.scheme == SchemeDefault && o.maxDepth == 0 && !o.shallow && !o.reflectionOnly()
+}
+
+// reflectionOnly reports whether the options change the digests in ways that the generated methods don't support.
+func (o *Options) reflectionOnly() bool {
+	return o.defaults || o.presence || o.required || o.decimal || o.nfc || len(o.canonicalizers) > 0 || o.wrappers || o.nullAsUnset || o.parallel > 0 || o.stats != nil || o.strictUTF8 || o.emptyAs == EmptyAsSet || o.fieldFilter != nil || (o.ignoreAs == IgnoreAsUnset && len(o.ignore) > 0)
+}
@@ -0,0 +1,113 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache stores computed digests keyed by entity ID and schema fingerprint, so that services can avoid
+// rehashing entities that haven't changed. Implementations backed by shared storage let horizontally-scaled services
+// reuse the digests computed by other instances.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotFound is returned by caches when there is no digest for the requested key.
+var ErrNotFound = errors.New("digest not found")
+
+// Key identifies a cached digest.
+type Key struct {
+	// EntityID identifies the hashed entity. It must change whenever the content of the entity changes, so it usually
+	// includes a revision number or a modification timestamp.
+	EntityID string
+	// SchemaFingerprint is the fingerprint of the schema the digest was computed with (see hashpb.SchemaFingerprint).
+	// Including it in the key makes digests computed under an older schema unreachable after a schema change.
+	SchemaFingerprint uint64
+}
+
+// String returns the key in the entityID@fingerprint form.
+func (k Key) String() string {
+	return fmt.Sprintf("%s@%016x", k.EntityID, k.SchemaFingerprint)
+}
+
+// Cache is a digest cache.
+type Cache interface {
+	// Get returns the digest stored under the given key or an error wrapping ErrNotFound.
+	Get(ctx context.Context, key Key) ([]byte, error)
+	// Put stores the digest under the given key.
+	Put(ctx context.Context, key Key, digest []byte) error
+}
+
+// KeyFor returns the key of the digest of msg for the given entity ID.
+func KeyFor(entityID string, msg proto.Message) Key {
+	return Key{EntityID: entityID, SchemaFingerprint: hashpb.SchemaFingerprint(msg.ProtoReflect().Descriptor())}
+}
+
+// Sum returns the cached digest of the entity if there is one, and otherwise computes the digest of msg using
+// hashpb.SumAuto and stores it in the cache. The options must be the same for every call with the same entity ID,
+// because they are not part of the key.
+func Sum(ctx context.Context, c Cache, entityID string, msg proto.Message, opts ...hashpb.Option) ([]byte, error) {
+	key := KeyFor(entityID, msg)
+	digest, err := c.Get(ctx, key)
+	if err == nil {
+		return digest, nil
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	digest, err = hashpb.SumAuto(nil, msg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Put(ctx, key, digest); err != nil {
+		return nil, fmt.Errorf("failed to put %s: %w", key, err)
+	}
+
+	return digest, nil
+}
+
+// MemoryCache is a Cache that keeps digests in memory.
+type MemoryCache struct {
+	digests map[Key][]byte
+	mu      sync.RWMutex
+}
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{digests: make(map[Key][]byte)}
+}
+
+func (c *MemoryCache) Get(_ context.Context, key Key) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	digest, ok := c.digests[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return append([]byte(nil), digest...), nil
+}
+
+func (c *MemoryCache) Put(_ context.Context, key Key, digest []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.digests[key] = append([]byte(nil), digest...)
+	return nil
+}
+
+// Len returns the number of digests in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.digests)
+}
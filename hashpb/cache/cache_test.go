@@ -0,0 +1,77 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/cache"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestSum(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+
+	msg := &pb.TestAllTypes{SingleString: "wibble"}
+	want, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := cache.Sum(ctx, c, "entity-1", msg)
+	if err != nil {
+		t.Fatalf("Failed to sum: %v", err)
+	}
+
+	if !bytes.Equal(want, have) || c.Len() != 1 {
+		t.Errorf("Unexpected digest: want=%x have=%x (%d cached)", want, have, c.Len())
+	}
+
+	// The cached digest is returned even though the message differs, because the entity ID is the same.
+	cached, err := cache.Sum(ctx, c, "entity-1", &pb.TestAllTypes{SingleString: "wobble"})
+	if err != nil {
+		t.Fatalf("Failed to sum: %v", err)
+	}
+
+	if !bytes.Equal(want, cached) {
+		t.Errorf("Expected cached digest: want=%x have=%x", want, cached)
+	}
+
+	// A different schema doesn't share the entry.
+	if _, err := cache.Sum(ctx, c, "entity-1", &pb.TestAllTypesOptional{}); err != nil {
+		t.Fatalf("Failed to sum: %v", err)
+	}
+
+	if c.Len() != 2 {
+		t.Errorf("Expected a separate entry per schema fingerprint, have %d", c.Len())
+	}
+}
+
+func TestKeyFor(t *testing.T) {
+	key := cache.KeyFor("entity-1", &pb.TestAllTypes{})
+	if key.SchemaFingerprint != pb.TestAllTypes_HashPBSchemaFingerprint {
+		t.Errorf("Unexpected fingerprint: want=%x have=%x", pb.TestAllTypes_HashPBSchemaFingerprint, key.SchemaFingerprint)
+	}
+}
+
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, cache.Key) ([]byte, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (failingCache) Put(context.Context, cache.Key, []byte) error {
+	return nil
+}
+
+func TestSumCacheError(t *testing.T) {
+	if _, err := cache.Sum(context.Background(), failingCache{}, "entity-1", &pb.TestAllTypes{}); err == nil {
+		t.Error("Expected error from the cache to be returned")
+	}
+}
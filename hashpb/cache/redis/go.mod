@@ -0,0 +1,20 @@
+module github.com/cerbos/protoc-gen-go-hashpb/hashpb/cache/redis
+
+go 1.19
+
+require (
+	github.com/alicebob/miniredis/v2 v2.31.1
+	github.com/cerbos/protoc-gen-go-hashpb v0.0.0
+	github.com/redis/go-redis/v9 v9.5.1
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/cerbos/protoc-gen-go-hashpb => ../../..
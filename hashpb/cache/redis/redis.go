@@ -0,0 +1,83 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis implements a digest cache backed by Redis, so that horizontally-scaled services can share computed
+// digests instead of recomputing them on every instance.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/cache"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const defaultPrefix = "hashpb:"
+
+type options struct {
+	prefix string
+	ttl    time.Duration
+}
+
+type Option func(*options)
+
+// WithPrefix sets the prefix of the Redis keys. Defaults to "hashpb:".
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithTTL sets the expiration of the stored digests. Defaults to no expiration.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// Cache is a cache.Cache that stores digests in Redis.
+type Cache struct {
+	client goredis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a Cache using the given client, which can be a single node, sentinel or cluster client.
+func New(client goredis.UniversalClient, opts ...Option) *Cache {
+	o := &options{prefix: defaultPrefix}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Cache{client: client, prefix: o.prefix, ttl: o.ttl}
+}
+
+func (c *Cache) Get(ctx context.Context, key cache.Key) ([]byte, error) {
+	digest, err := c.client.Get(ctx, c.redisKey(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, cache.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get digest from redis: %w", err)
+	}
+
+	return digest, nil
+}
+
+func (c *Cache) Put(ctx context.Context, key cache.Key, digest []byte) error {
+	if err := c.client.Set(ctx, c.redisKey(key), digest, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to put digest in redis: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cache) redisKey(key cache.Key) string {
+	return c.prefix + key.String()
+}
@@ -0,0 +1,75 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/cache"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/cache/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	srv := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	c := redis.New(client, redis.WithPrefix("test:"), redis.WithTTL(time.Minute))
+	msg, err := structpb.NewValue(map[string]any{"a": "b"})
+	if err != nil {
+		t.Fatalf("Failed to create message: %v", err)
+	}
+
+	key := cache.KeyFor("entity-1", msg)
+	if _, err := c.Get(ctx, key); !errors.Is(err, cache.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, have %v", err)
+	}
+
+	want, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := cache.Sum(ctx, c, "entity-1", msg)
+	if err != nil {
+		t.Fatalf("Failed to sum: %v", err)
+	}
+
+	if !bytes.Equal(want, have) {
+		t.Errorf("Unexpected digest: want=%x have=%x", want, have)
+	}
+
+	stored, err := srv.Get("test:" + key.String())
+	if err != nil {
+		t.Fatalf("Digest was not stored: %v", err)
+	}
+
+	if !bytes.Equal([]byte(stored), want) {
+		t.Errorf("Unexpected stored digest: want=%x have=%x", want, stored)
+	}
+
+	if ttl := srv.TTL("test:" + key.String()); ttl != time.Minute {
+		t.Errorf("Unexpected TTL: %s", ttl)
+	}
+
+	// A second instance sharing the server gets the digest from the cache.
+	other := redis.New(goredis.NewClient(&goredis.Options{Addr: srv.Addr()}), redis.WithPrefix("test:"))
+	cached, err := other.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Failed to get: %v", err)
+	}
+
+	if !bytes.Equal(want, cached) {
+		t.Errorf("Unexpected cached digest: want=%x have=%x", want, cached)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// Canonicalizer returns the canonical form of a message, which is hashed instead of the message itself. It is only
+// called for messages that are set, and it must not modify the message it is given.
+type Canonicalizer func(m protoreflect.Message) protoreflect.Message
+
+// WithCanonicalizer hashes the messages of the given type in the canonical form returned by fn, so that values with
+// the same meaning but different representations (for example, an amount of money with nanos greater than a unit)
+// have the same digest. The hashpb/wellknown package provides canonicalizers for common types. Setting a
+// canonicalizer for a type replaces the one set before. The generated methods don't support canonicalizers, so
+// SumAuto and Sum64Auto use reflection when one is set.
+func WithCanonicalizer(fullName protoreflect.FullName, fn Canonicalizer) Option {
+	return func(o *Options) {
+		if o.canonicalizers == nil {
+			o.canonicalizers = make(map[protoreflect.FullName]Canonicalizer)
+		}
+
+		o.canonicalizers[fullName] = fn
+	}
+}
+
+// canonical returns the canonical form of m if there is a canonicalizer for its type, or m itself otherwise.
+func canonical(canonicalizers map[protoreflect.FullName]Canonicalizer, m protoreflect.Message) protoreflect.Message {
+	if len(canonicalizers) == 0 || !m.IsValid() {
+		return m
+	}
+
+	if fn, ok := canonicalizers[m.Descriptor().FullName()]; ok {
+		return fn(m)
+	}
+
+	return m
+}
@@ -0,0 +1,126 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cas stores messages in a content-addressable blob store, keyed by their hashpb digest.
+// Identical messages are stored once, regardless of how many times they are put.
+package cas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"sync"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotFound is returned by stores when there is no blob with the requested key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a blob store. Keys are hex-encoded digests.
+type Store interface {
+	// Put stores the blob under the given key. Since keys are derived from the content, implementations can skip
+	// writing blobs that already exist.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the blob stored under the given key or an error wrapping ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type options struct {
+	hashFn func() hash.Hash
+}
+
+type Option func(*options)
+
+// WithHash sets the hash function used to derive keys. Defaults to SHA-256 because the digests of distinct messages
+// must not collide. The same hash function must be used to put and get a message.
+func WithHash(hashFn func() hash.Hash) Option {
+	return func(o *options) {
+		o.hashFn = hashFn
+	}
+}
+
+// Put stores the deterministic serialization of the message and returns its digest.
+func Put(ctx context.Context, store Store, msg proto.Message, opts ...Option) ([]byte, error) {
+	o := &options{hashFn: sha256.New}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	digest, err := hashpb.Sum(nil, msg, hashpb.WithHash(o.hashFn))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if err := store.Put(ctx, hex.EncodeToString(digest), data); err != nil {
+		return nil, fmt.Errorf("failed to store message: %w", err)
+	}
+
+	return digest, nil
+}
+
+// Get reads the message with the given digest into msg.
+func Get(ctx context.Context, store Store, digest []byte, msg proto.Message) error {
+	key := hex.EncodeToString(digest)
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// MemoryStore is a Store that keeps blobs in memory.
+type MemoryStore struct {
+	blobs map[string][]byte
+	mu    sync.RWMutex
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blobs[key]; !ok {
+		s.blobs[key] = append([]byte(nil), data...)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// Len returns the number of blobs in the store.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.blobs)
+}
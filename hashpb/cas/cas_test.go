@@ -0,0 +1,67 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package cas_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/cas"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPutGet(t *testing.T) {
+	ctx := context.Background()
+	store := cas.NewMemoryStore()
+
+	msg := &pb.TestAllTypes{
+		SingleString:    "wibble",
+		MapStringString: map[string]string{"a": "b", "c": "d", "e": "f"},
+	}
+
+	digest, err := cas.Put(ctx, store, msg)
+	if err != nil {
+		t.Fatalf("Failed to put: %v", err)
+	}
+
+	if len(digest) != 32 {
+		t.Errorf("Expected SHA-256 digest: %x", digest)
+	}
+
+	again, err := cas.Put(ctx, store, proto.Clone(msg))
+	if err != nil {
+		t.Fatalf("Failed to put: %v", err)
+	}
+
+	if !bytes.Equal(digest, again) || store.Len() != 1 {
+		t.Errorf("Identical message was not deduplicated: %x %x (%d blobs)", digest, again, store.Len())
+	}
+
+	have := &pb.TestAllTypes{}
+	if err := cas.Get(ctx, store, digest, have); err != nil {
+		t.Fatalf("Failed to get: %v", err)
+	}
+
+	if !proto.Equal(msg, have) {
+		t.Errorf("Unexpected message: want=%v have=%v", msg, have)
+	}
+
+	if err := cas.Get(ctx, store, []byte("wibble"), have); !errors.Is(err, cas.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound: %v", err)
+	}
+
+	short, err := cas.Put(ctx, store, msg, cas.WithHash(func() hash.Hash { return xxhash.New() }))
+	if err != nil {
+		t.Fatalf("Failed to put: %v", err)
+	}
+
+	if len(short) != 8 || store.Len() != 2 {
+		t.Errorf("Unexpected digest with custom hash: %x (%d blobs)", short, store.Len())
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cel provides a CEL library with functions that calculate the hashpb digests of messages, so that policy
+// expressions can compare message fingerprints directly.
+//
+// The library declares the following functions, which accept messages of any type:
+//
+//   - hashpb.sum64(msg) and hashpb.sum64(msg, ignore) return the 64-bit digest of the message as a uint.
+//   - hashpb.sumHex(msg) and hashpb.sumHex(msg, ignore) return the digest of the message as a hex-encoded string.
+//
+// The optional ignore argument is a list of fully-qualified names of fields to leave out of the digest, as in
+// hashpb.WithIgnore.
+package cel
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	sum64Fn  = "hashpb.sum64"
+	sumHexFn = "hashpb.sumHex"
+)
+
+var stringListType = reflect.TypeOf([]string{})
+
+// Lib returns a CEL environment option that declares the hashpb functions. The options are passed to the hashpb
+// package, for example to set the hash function. hashpb.sum64 requires a hash function that implements hash.Hash64.
+func Lib(opts ...hashpb.Option) cel.EnvOption {
+	return cel.Lib(&library{opts: opts})
+}
+
+type library struct {
+	opts []hashpb.Option
+}
+
+func (l *library) CompileOptions() []cel.EnvOption {
+	ignoreType := cel.ListType(cel.StringType)
+	return []cel.EnvOption{
+		cel.Function(sum64Fn,
+			cel.Overload("hashpb_sum64_dyn", []*cel.Type{cel.DynType}, cel.UintType,
+				cel.UnaryBinding(func(msg ref.Val) ref.Val {
+					return l.sum64(msg, nil)
+				})),
+			cel.Overload("hashpb_sum64_dyn_list_string", []*cel.Type{cel.DynType, ignoreType}, cel.UintType,
+				cel.BinaryBinding(l.sum64)),
+		),
+		cel.Function(sumHexFn,
+			cel.Overload("hashpb_sumHex_dyn", []*cel.Type{cel.DynType}, cel.StringType,
+				cel.UnaryBinding(func(msg ref.Val) ref.Val {
+					return l.sumHex(msg, nil)
+				})),
+			cel.Overload("hashpb_sumHex_dyn_list_string", []*cel.Type{cel.DynType, ignoreType}, cel.StringType,
+				cel.BinaryBinding(l.sumHex)),
+		),
+	}
+}
+
+func (l *library) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func (l *library) sum64(msgVal, ignoreVal ref.Val) ref.Val {
+	msg, opts, err := l.args(sum64Fn, msgVal, ignoreVal)
+	if err != nil {
+		return types.NewErr("%v", err)
+	}
+
+	digest, err := hashpb.Sum64(msg, opts...)
+	if err != nil {
+		return types.NewErr("%s: failed to hash %s: %v", sum64Fn, msgVal.Type().TypeName(), err)
+	}
+
+	return types.Uint(digest)
+}
+
+func (l *library) sumHex(msgVal, ignoreVal ref.Val) ref.Val {
+	msg, opts, err := l.args(sumHexFn, msgVal, ignoreVal)
+	if err != nil {
+		return types.NewErr("%v", err)
+	}
+
+	digest, err := hashpb.Sum(nil, msg, opts...)
+	if err != nil {
+		return types.NewErr("%s: failed to hash %s: %v", sumHexFn, msgVal.Type().TypeName(), err)
+	}
+
+	return types.String(hex.EncodeToString(digest))
+}
+
+// args converts the arguments of a function call to the message to hash and the options to hash it with.
+func (l *library) args(fn string, msgVal, ignoreVal ref.Val) (proto.Message, []hashpb.Option, error) {
+	msg, ok := msgVal.Value().(proto.Message)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: expected a message, got %s", fn, msgVal.Type().TypeName())
+	}
+
+	if ignoreVal == nil {
+		return msg, l.opts, nil
+	}
+
+	ignore, err := ignoreVal.ConvertToNative(stringListType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid ignore list: %w", fn, err)
+	}
+
+	opts := make([]hashpb.Option, 0, len(l.opts)+1)
+	opts = append(opts, l.opts...)
+	opts = append(opts, hashpb.WithIgnore(ignore.([]string)...))
+
+	return msg, opts, nil
+}
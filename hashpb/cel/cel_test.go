@@ -0,0 +1,122 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package cel_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	hashpbcel "github.com/cerbos/protoc-gen-go-hashpb/hashpb/cel"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+const ignoredField = "cerbos.hashpb.test.TestAllTypes.single_string"
+
+func TestLib(t *testing.T) {
+	msg := &pb.TestAllTypes{SingleString: "wibble", SingleInt64: 42, RepeatedString: []string{"wobble"}}
+
+	sum64, err := hashpb.Sum64(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	ignored, err := hashpb.Sum64(msg, hashpb.WithIgnore(ignoredField))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	sumHex, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	env := newEnv(t)
+	for _, tc := range []struct {
+		expr string
+		want any
+	}{
+		{expr: "hashpb.sum64(msg)", want: types.Uint(sum64)},
+		{expr: "hashpb.sum64(msg, ['" + ignoredField + "'])", want: types.Uint(ignored)},
+		{expr: "hashpb.sumHex(msg)", want: types.String(hex.EncodeToString(sumHex))},
+		{expr: "hashpb.sum64(msg) == hashpb.sum64(other)", want: types.False},
+		{expr: "hashpb.sum64(msg, ['" + ignoredField + "']) == hashpb.sum64(other, ['" + ignoredField + "'])", want: types.True},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			other := &pb.TestAllTypes{SingleString: "wubble", SingleInt64: 42, RepeatedString: []string{"wobble"}}
+			if have := eval(t, env, tc.expr, map[string]any{"msg": msg, "other": other}); have != tc.want {
+				t.Errorf("Unexpected result: want=%v have=%v", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestLibOptions(t *testing.T) {
+	msg := &pb.TestAllTypes{SingleString: "wibble"}
+	want, err := hashpb.Sum(nil, msg, hashpb.WithHash(sha256.New))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	env := newEnv(t, hashpb.WithHash(sha256.New))
+	if have := eval(t, env, "hashpb.sumHex(msg)", map[string]any{"msg": msg}); have != types.String(hex.EncodeToString(want)) {
+		t.Errorf("Unexpected result: want=%x have=%v", want, have)
+	}
+
+	ast, iss := env.Compile("hashpb.sum64(msg)")
+	if iss.Err() != nil {
+		t.Fatalf("Failed to compile: %v", iss.Err())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		t.Fatalf("Failed to create program: %v", err)
+	}
+
+	if _, _, err := prg.Eval(map[string]any{"msg": msg}); err == nil || !strings.Contains(err.Error(), "hash.Hash64") {
+		t.Errorf("Expected an error for a hash function that doesn't implement hash.Hash64, got %v", err)
+	}
+}
+
+func newEnv(t *testing.T, opts ...hashpb.Option) *cel.Env {
+	t.Helper()
+
+	msgType := cel.ObjectType("cerbos.hashpb.test.TestAllTypes")
+	env, err := cel.NewEnv(
+		cel.Types(&pb.TestAllTypes{}),
+		cel.Variable("msg", msgType),
+		cel.Variable("other", msgType),
+		hashpbcel.Lib(opts...),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+
+	return env
+}
+
+func eval(t *testing.T, env *cel.Env, expr string, vars map[string]any) any {
+	t.Helper()
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		t.Fatalf("Failed to compile %q: %v", expr, iss.Err())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		t.Fatalf("Failed to create program: %v", err)
+	}
+
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		t.Fatalf("Failed to evaluate %q: %v", expr, err)
+	}
+
+	return out
+}
@@ -0,0 +1,21 @@
+module github.com/cerbos/protoc-gen-go-hashpb/hashpb/cel
+
+go 1.20
+
+require (
+	github.com/cerbos/protoc-gen-go-hashpb v0.0.0
+	github.com/google/cel-go v0.20.1
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/antlr4-go/antlr/v4 v4.13.0 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/stoewer/go-strcase v1.2.0 // indirect
+	golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20230803162519-f966b187b2e5 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230803162519-f966b187b2e5 // indirect
+)
+
+replace github.com/cerbos/protoc-gen-go-hashpb => ../..
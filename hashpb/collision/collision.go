@@ -0,0 +1,200 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package collision hashes a corpus of messages with several hashing configurations and reports digest collisions
+// and near misses, to help choose a digest size for the expected data volumes.
+//
+// Two messages collide when they have the same digest but are distinct, which is decided by hashing them again with
+// the same options and SHA-256. Messages that only differ in ignored fields are therefore not distinct.
+package collision
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"google.golang.org/protobuf/proto"
+)
+
+// Config is a hashing configuration to analyse.
+type Config struct {
+	// Name identifies the configuration in the reports.
+	Name string
+	// Options are passed to the hashpb package, typically to set the scheme and the hash function.
+	Options []hashpb.Option
+}
+
+// Collision is a digest shared by distinct messages.
+type Collision struct {
+	// Digest is the shared digest.
+	Digest []byte
+	// Indexes are the positions in the corpus of the first occurrence of each distinct message with the digest.
+	Indexes []int
+}
+
+// Report holds the results of analysing a corpus with a configuration.
+type Report struct {
+	// Name is the name of the configuration.
+	Name string
+	// Bits is the size of the digests in bits.
+	Bits int
+	// Messages is the number of messages in the corpus.
+	Messages int
+	// Distinct is the number of distinct messages in the corpus.
+	Distinct int
+	// Collisions are the digests shared by distinct messages, ordered by the position of their first message in the corpus.
+	Collisions []Collision
+	// MaxCommonPrefix is the length in bits of the longest prefix shared by the digests of two distinct messages.
+	// It equals Bits if there are collisions.
+	MaxCommonPrefix int
+	// ExpectedCommonPrefix is the approximate length in bits of the longest prefix expected to be shared by two of
+	// Distinct random digests. A MaxCommonPrefix much larger than this suggests that the digests are not uniform.
+	ExpectedCommonPrefix float64
+	// Probability is the probability of at least one collision among Distinct random digests of the same size.
+	Probability float64
+}
+
+// Analyze hashes the corpus with each configuration and returns a report for each of them.
+func Analyze(corpus []proto.Message, configs ...Config) ([]Report, error) {
+	reports := make([]Report, len(configs))
+	for i, c := range configs {
+		r, err := analyze(corpus, c)
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = r
+	}
+
+	return reports, nil
+}
+
+type entry struct {
+	digest []byte
+	index  int
+}
+
+func analyze(corpus []proto.Message, c Config) (Report, error) {
+	r := Report{Name: c.Name, Messages: len(corpus)}
+	refOpts := append(append([]hashpb.Option{}, c.Options...), hashpb.WithHash(sha256.New))
+
+	seen := make(map[string]struct{}, len(corpus))
+	entries := make([]entry, 0, len(corpus))
+	for i, msg := range corpus {
+		ref, err := hashpb.Sum(nil, msg, refOpts...)
+		if err != nil {
+			return r, fmt.Errorf("failed to hash message %d with %s: %w", i, c.Name, err)
+		}
+
+		if _, ok := seen[string(ref)]; ok {
+			continue
+		}
+		seen[string(ref)] = struct{}{}
+
+		digest, err := hashpb.Sum(nil, msg, c.Options...)
+		if err != nil {
+			return r, fmt.Errorf("failed to hash message %d with %s: %w", i, c.Name, err)
+		}
+
+		r.Bits = len(digest) * 8
+		entries = append(entries, entry{digest: digest, index: i})
+	}
+
+	r.Distinct = len(entries)
+	r.ExpectedCommonPrefix = expectedCommonPrefix(r.Distinct)
+	r.Probability = Probability(uint64(r.Distinct), r.Bits)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].digest, entries[j].digest) < 0
+	})
+
+	for i := 0; i < len(entries); {
+		j := i + 1
+		for j < len(entries) && bytes.Equal(entries[i].digest, entries[j].digest) {
+			j++
+		}
+
+		if j-i > 1 {
+			col := Collision{Digest: entries[i].digest, Indexes: make([]int, 0, j-i)}
+			for _, e := range entries[i:j] {
+				col.Indexes = append(col.Indexes, e.index)
+			}
+			sort.Ints(col.Indexes)
+			r.Collisions = append(r.Collisions, col)
+		}
+
+		if j < len(entries) {
+			if p := commonPrefix(entries[j-1].digest, entries[j].digest); p > r.MaxCommonPrefix {
+				r.MaxCommonPrefix = p
+			}
+		}
+		i = j
+	}
+
+	if len(r.Collisions) > 0 {
+		r.MaxCommonPrefix = r.Bits
+		sort.Slice(r.Collisions, func(i, j int) bool { return r.Collisions[i].Indexes[0] < r.Collisions[j].Indexes[0] })
+	}
+
+	return r, nil
+}
+
+// commonPrefix returns the number of leading bits shared by a and b, which have the same length.
+func commonPrefix(a, b []byte) int {
+	for i := range a {
+		if x := a[i] ^ b[i]; x != 0 {
+			return i*8 + bits.LeadingZeros8(x)
+		}
+	}
+
+	return len(a) * 8
+}
+
+// expectedCommonPrefix approximates the longest prefix shared by two of n random digests, which is the base 2
+// logarithm of the number of pairs.
+func expectedCommonPrefix(n int) float64 {
+	if n < 2 {
+		return 0
+	}
+
+	return math.Log2(float64(n) * float64(n-1) / 2)
+}
+
+// Probability returns the probability of at least one collision among n random digests of the given size in bits,
+// using the birthday bound. It can be used to project the results of an analysis to larger data volumes.
+func Probability(n uint64, size int) float64 {
+	if n < 2 {
+		return 0
+	}
+
+	pairs := float64(n) * float64(n-1) / 2
+	return -math.Expm1(-pairs / math.Exp2(float64(size)))
+}
+
+// Mutations returns n variations of msg, each with a randomly chosen top-level field replaced by the value of a
+// populated message or cleared. The variations are close to msg, which makes them useful to look for near misses.
+func Mutations(msg proto.Message, n int, seed int64) []proto.Message {
+	rng := rand.New(rand.NewSource(seed))
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	mutations := make([]proto.Message, n)
+	for i := range mutations {
+		m := proto.Clone(msg)
+		if fields.Len() > 0 {
+			fd := fields.Get(rng.Intn(fields.Len()))
+			populated := testgen.NewPopulated(msg, rng.Int63n(math.MaxInt64-1)+1).ProtoReflect()
+			if populated.Has(fd) {
+				m.ProtoReflect().Set(fd, populated.Get(fd))
+			} else {
+				m.ProtoReflect().Clear(fd)
+			}
+		}
+		mutations[i] = m
+	}
+
+	return mutations
+}
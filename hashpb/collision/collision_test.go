@@ -0,0 +1,148 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package collision_test
+
+import (
+	"crypto/sha256"
+	"hash"
+	"hash/fnv"
+	"math"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/collision"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// tinyHash keeps the first byte of a 64-bit FNV digest, so collisions are certain in a corpus of a few hundred messages.
+type tinyHash struct {
+	hash.Hash64
+}
+
+func (h tinyHash) Sum(b []byte) []byte {
+	return append(b, h.Hash64.Sum(nil)[0])
+}
+
+func (h tinyHash) Size() int {
+	return 1
+}
+
+func TestAnalyze(t *testing.T) {
+	base := &pb.TestAllTypes{SingleString: "wibble", SingleInt64: 42}
+	corpus := []proto.Message{base, proto.Clone(base)}
+	for i := int64(0); i < 300; i++ {
+		corpus = append(corpus, &pb.TestAllTypes{SingleInt64: i})
+	}
+	corpus = append(corpus, collision.Mutations(base, 50, 1)...)
+
+	reports, err := collision.Analyze(corpus,
+		collision.Config{Name: "tiny", Options: []hashpb.Option{hashpb.WithHash(func() hash.Hash { return tinyHash{fnv.New64a()} })}},
+		collision.Config{Name: "sha256", Options: []hashpb.Option{hashpb.WithHash(sha256.New)}},
+		collision.Config{Name: "objecthash", Options: []hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}},
+	)
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	tiny := reports[0]
+	if tiny.Name != "tiny" || tiny.Bits != 8 || tiny.Messages != len(corpus) {
+		t.Errorf("Unexpected report: %+v", tiny)
+	}
+
+	if tiny.Distinct < 301 || tiny.Distinct >= len(corpus) {
+		t.Errorf("Unexpected number of distinct messages: %d", tiny.Distinct)
+	}
+
+	if len(tiny.Collisions) == 0 || tiny.MaxCommonPrefix != 8 || tiny.Probability != 1 {
+		t.Errorf("Expected collisions: %+v", tiny)
+	}
+
+	for _, c := range tiny.Collisions {
+		if len(c.Indexes) < 2 || len(c.Digest) != 1 {
+			t.Errorf("Unexpected collision: %+v", c)
+		}
+
+		for _, i := range c.Indexes {
+			if i == 1 {
+				t.Errorf("Duplicate message reported as a collision: %+v", c)
+			}
+		}
+	}
+
+	for _, r := range reports[1:] {
+		if r.Bits != 256 || r.Distinct != tiny.Distinct || len(r.Collisions) != 0 {
+			t.Errorf("Unexpected report for %s: %+v", r.Name, r)
+		}
+
+		if r.MaxCommonPrefix >= r.Bits || r.ExpectedCommonPrefix <= 0 || r.Probability > 1e-60 {
+			t.Errorf("Unexpected statistics for %s: %+v", r.Name, r)
+		}
+	}
+}
+
+func TestAnalyzeIgnore(t *testing.T) {
+	corpus := []proto.Message{
+		&pb.TestAllTypes{SingleString: "a", SingleInt64: 1},
+		&pb.TestAllTypes{SingleString: "b", SingleInt64: 1},
+		&pb.TestAllTypes{SingleString: "c", SingleInt64: 2},
+	}
+
+	reports, err := collision.Analyze(corpus, collision.Config{
+		Name:    "ignore",
+		Options: []hashpb.Option{hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_string")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	if have := reports[0]; have.Distinct != 2 || len(have.Collisions) != 0 || have.Bits != 64 {
+		t.Errorf("Unexpected report: %+v", have)
+	}
+}
+
+func TestProbability(t *testing.T) {
+	for _, tc := range []struct {
+		n    uint64
+		bits int
+		want float64
+	}{
+		{n: 0, bits: 64, want: 0},
+		{n: 1, bits: 64, want: 0},
+		{n: 1 << 32, bits: 64, want: 1 - math.Exp(-0.5)},
+		{n: 1 << 20, bits: 128, want: 1.6155871338926322e-27},
+	} {
+		if have := collision.Probability(tc.n, tc.bits); math.Abs(have-tc.want) > tc.want*1e-6 {
+			t.Errorf("Unexpected probability for n=%d bits=%d: want=%g have=%g", tc.n, tc.bits, tc.want, have)
+		}
+	}
+}
+
+func TestMutations(t *testing.T) {
+	base := &pb.TestAllTypes{SingleString: "wibble"}
+	a := collision.Mutations(base, 20, 1)
+	b := collision.Mutations(base, 20, 1)
+	if len(a) != 20 {
+		t.Fatalf("Expected 20 mutations, got %d", len(a))
+	}
+
+	var changed int
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			t.Errorf("Mutation %d is not deterministic", i)
+		}
+
+		if !proto.Equal(a[i], base) {
+			changed++
+		}
+	}
+
+	if changed == 0 {
+		t.Error("Expected mutations to differ from the message")
+	}
+
+	if base.SingleString != "wibble" {
+		t.Error("Message was modified")
+	}
+}
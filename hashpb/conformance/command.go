@@ -0,0 +1,77 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+)
+
+// Main runs the hashpb-conformance command with the given arguments (without the program name) and returns the exit
+// code. The hashpb-conformance binary doesn't link any generated code, so it only checks the hashpb package. To check
+// generated code as well, build a runner that imports the generated packages (generated with registry=true), which
+// registers their hash functions with hashpbreg, and calls Main:
+//
+//	package main
+//
+//	import (
+//		"os"
+//
+//		"github.com/cerbos/protoc-gen-go-hashpb/hashpb/conformance"
+//		_ "example.com/my/gen/pkg"
+//	)
+//
+//	func main() {
+//		os.Exit(conformance.Main(os.Args[1:], os.Stdout, os.Stderr))
+//	}
+func Main(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("hashpb-conformance", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	descriptors := flags.String("descriptors", "", "Path to a serialized FileDescriptorSet containing the message types")
+	vectors := flags.String("vectors", ".", "Directory containing the test vector files (*.json)")
+	requireGenerated := flags.Bool("require-generated", false, "Fail if a vector can't be checked against generated code registered with hashpbreg")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *descriptors == "" {
+		fmt.Fprintln(stderr, "-descriptors is required")
+		flags.Usage()
+		return 2
+	}
+
+	files, err := descset.LoadFiles(*descriptors)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	report, err := Run(files, *vectors)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	for _, m := range report.Mismatches {
+		fmt.Fprintln(stdout, m)
+	}
+
+	fmt.Fprintf(stdout, "Checked %d vectors (%d against generated code): %d mismatches\n", report.Vectors, report.Generated, len(report.Mismatches))
+	if report.Generated < report.Vectors {
+		fmt.Fprintf(stderr, "%d vectors were only checked against the hashpb package because their generated code is not registered with hashpbreg\n", report.Vectors-report.Generated)
+		if *requireGenerated {
+			return 1
+		}
+	}
+
+	if !report.OK() {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,200 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conformance checks test vectors produced with the gen_vectors plugin parameter against the hashpb package
+// and against generated code registered with hashpbreg.
+//
+// Generated code is only checked if it is linked into the binary. To check your own generated code, build a runner that
+// imports the generated packages (generated with registry=true) and calls Run or Main.
+package conformance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpbreg"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	ImplReflection = "reflection"
+	ImplGenerated  = "generated"
+)
+
+// Mismatch describes a test vector that produced an unexpected digest.
+type Mismatch struct {
+	File string
+	Type string
+	// Impl is the implementation that produced the digest (ImplReflection or ImplGenerated).
+	Impl string
+	// Hash is the name of the hash function (xxhash64 or sha256).
+	Hash string
+	Want string
+	Have string
+	// Reason is set if the vector could not be checked at all.
+	Reason string
+	Index  int
+	Seed   int64
+}
+
+func (m Mismatch) String() string {
+	if m.Reason != "" {
+		return fmt.Sprintf("%s[%d] %s (seed %d): %s", m.File, m.Index, m.Type, m.Seed, m.Reason)
+	}
+
+	return fmt.Sprintf("%s[%d] %s (seed %d): %s %s digest mismatch: want=%s have=%s", m.File, m.Index, m.Type, m.Seed, m.Impl, m.Hash, m.Want, m.Have)
+}
+
+// Report is the result of a conformance run.
+type Report struct {
+	Mismatches []Mismatch
+	// Vectors is the number of vectors checked.
+	Vectors int
+	// Generated is the number of vectors that were also checked against generated code.
+	Generated int
+}
+
+// OK reports whether all vectors produced the expected digests.
+func (r *Report) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Run checks all the vector files (*.json) in dir. Message types are resolved using files,
+// or protoregistry.GlobalFiles if files is nil.
+func Run(files *protoregistry.Files, dir string) (*Report, error) {
+	if files == nil {
+		files = protoregistry.GlobalFiles
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vector files in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	report := &Report{}
+	for _, path := range paths {
+		if err := runFile(files, path, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func runFile(files *protoregistry.Files, path string, report *Report) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var corpus testgen.VectorCorpus
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	fileName := filepath.Base(path)
+	for i, v := range corpus.Vectors {
+		report.Vectors++
+		mismatch := Mismatch{File: fileName, Index: i, Type: v.Type, Seed: v.Seed}
+
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(v.Type))
+		if err != nil {
+			mismatch.Reason = fmt.Sprintf("failed to find message descriptor: %v", err)
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+
+		md, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			mismatch.Reason = "not a message type"
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+
+		dynMsg := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(v.Input, dynMsg); err != nil {
+			mismatch.Reason = fmt.Sprintf("failed to unmarshal input: %v", err)
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+
+		check(report, mismatch, v, ImplReflection, func(hasher hash.Hash) error {
+			return hashpb.Hash(hasher, dynMsg, v.Options()...)
+		})
+
+		fn, ok := hashpbreg.Lookup(v.Type)
+		if !ok {
+			continue
+		}
+
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+		if err != nil {
+			continue
+		}
+
+		msg := mt.New().Interface()
+		if err := proto.Unmarshal(v.Input, msg); err != nil {
+			mismatch.Reason = fmt.Sprintf("failed to unmarshal input into %T: %v", msg, err)
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+
+		report.Generated++
+		ignore := make(map[string]struct{}, len(v.Ignore))
+		for _, fn := range v.Ignore {
+			ignore[fn] = struct{}{}
+		}
+
+		check(report, mismatch, v, ImplGenerated, func(hasher hash.Hash) error {
+			if !fn(msg, hasher, ignore) {
+				return fmt.Errorf("registered hash function does not accept %T", msg)
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func check(report *Report, mismatch Mismatch, v testgen.Vector, impl string, hashFn func(hash.Hash) error) {
+	mismatch.Impl = impl
+
+	xxh := xxhash.New()
+	if err := hashFn(xxh); err != nil {
+		mismatch.Reason = fmt.Sprintf("%s: %v", impl, err)
+		report.Mismatches = append(report.Mismatches, mismatch)
+		return
+	}
+
+	if have := fmt.Sprintf("%016x", xxh.Sum64()); have != v.XXHash {
+		m := mismatch
+		m.Hash, m.Want, m.Have = "xxhash64", v.XXHash, have
+		report.Mismatches = append(report.Mismatches, m)
+	}
+
+	sha := sha256.New()
+	if err := hashFn(sha); err != nil {
+		mismatch.Reason = fmt.Sprintf("%s: %v", impl, err)
+		report.Mismatches = append(report.Mismatches, mismatch)
+		return
+	}
+
+	if have := hex.EncodeToString(sha.Sum(nil)); have != v.SHA256 {
+		m := mismatch
+		m.Hash, m.Want, m.Have = "sha256", v.SHA256, have
+		report.Mismatches = append(report.Mismatches, m)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/conformance"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRun(t *testing.T) {
+	vectorsDir := filepath.Join("..", "..", "internal", "pb")
+
+	t.Run("valid", func(t *testing.T) {
+		report, err := conformance.Run(nil, vectorsDir)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !report.OK() {
+			t.Fatalf("Unexpected mismatches: %v", report.Mismatches)
+		}
+
+		if report.Vectors == 0 || report.Generated != report.Vectors {
+			t.Fatalf("Expected all vectors to be checked against generated code: vectors=%d generated=%d", report.Vectors, report.Generated)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(vectorsDir, "all_types_hashpb_vectors.json"))
+		if err != nil {
+			t.Fatalf("Failed to read vectors: %v", err)
+		}
+
+		var corpus hashpbtest.VectorCorpus
+		if err := json.Unmarshal(data, &corpus); err != nil {
+			t.Fatalf("Failed to unmarshal vectors: %v", err)
+		}
+
+		corpus.Vectors = corpus.Vectors[:1]
+		corpus.Vectors[0].XXHash = "0000000000000000"
+
+		out, err := json.Marshal(corpus)
+		if err != nil {
+			t.Fatalf("Failed to marshal vectors: %v", err)
+		}
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "tampered.json"), out, 0o600); err != nil {
+			t.Fatalf("Failed to write vectors: %v", err)
+		}
+
+		report, err := conformance.Run(nil, dir)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(report.Mismatches) != 2 {
+			t.Fatalf("Expected a mismatch for each implementation: %v", report.Mismatches)
+		}
+	})
+}
+
+func TestMainCommand(t *testing.T) {
+	data, err := proto.Marshal(descset.Build(pb.File_internal_pb_all_types_proto))
+	if err != nil {
+		t.Fatalf("Failed to marshal descriptors: %v", err)
+	}
+
+	descriptors := filepath.Join(t.TempDir(), "descriptors.binpb")
+	if err := os.WriteFile(descriptors, data, 0o600); err != nil {
+		t.Fatalf("Failed to write descriptors: %v", err)
+	}
+
+	vectorsDir := filepath.Join("..", "..", "internal", "pb")
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-descriptors", descriptors, "-vectors", vectorsDir, "-require-generated"}
+	if code := conformance.Main(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s%s", code, stdout.String(), stderr.String())
+	}
+
+	// The generated code of internal/pb is linked into the test binary, so every vector is checked against it.
+	if have := stdout.String(); !strings.Contains(have, "against generated code): 0 mismatches") || strings.Contains(have, "(0 against generated code)") {
+		t.Errorf("Unexpected output: %s", have)
+	}
+
+	if code := conformance.Main([]string{"-vectors", vectorsDir}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected usage error without descriptors: exit code %d", code)
+	}
+
+	t.Run("unregistered", func(t *testing.T) {
+		vectors, err := hashpbtest.MakeVectors(&durationpb.Duration{})
+		if err != nil {
+			t.Fatalf("Failed to make vectors: %v", err)
+		}
+
+		out, err := json.Marshal(hashpbtest.VectorCorpus{Vectors: vectors})
+		if err != nil {
+			t.Fatalf("Failed to marshal vectors: %v", err)
+		}
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "duration.json"), out, 0o600); err != nil {
+			t.Fatalf("Failed to write vectors: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		if code := conformance.Main([]string{"-descriptors", descriptors, "-vectors", dir}, &stdout, &stderr); code != 0 {
+			t.Errorf("Expected exit code 0, got %d: %s%s", code, stdout.String(), stderr.String())
+		}
+
+		if !strings.Contains(stderr.String(), "not registered with hashpbreg") {
+			t.Errorf("Expected a warning about unregistered generated code: %s", stderr.String())
+		}
+
+		if code := conformance.Main([]string{"-descriptors", descriptors, "-vectors", dir, "-require-generated"}, &stdout, &stderr); code != 1 {
+			t.Errorf("Expected exit code 1 with -require-generated, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package connectmw provides a connect-go interceptor that computes hashpb digests of requests and responses.
+//
+// On the handler side, the request digest is available through RequestDigest. Unary handlers send the request and
+// response digests to the client as response headers. Streaming handlers send the digests of the responses as
+// response trailers, one value per response in the order they were sent. Digests of responses that can't be hashed are
+// left out. Messages are hashed using the generated methods if they have them (see hashpb.SumAuto). On the client side, the digest of unary requests is sent to the server as a request header,
+// which allows servers to use it as an idempotency key.
+package connectmw
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// RequestDigestHeader is the header containing the hex-encoded request digest.
+	RequestDigestHeader = "X-Hashpb-Request-Digest"
+	// ResponseDigestHeader is the header (or trailer, for streams) containing the hex-encoded response digest.
+	ResponseDigestHeader = "X-Hashpb-Response-Digest"
+)
+
+type options struct {
+	requestOpts  []hashpb.Option
+	responseOpts []hashpb.Option
+	headers      bool
+}
+
+type Option func(*options)
+
+// WithRequestOptions sets the options used to hash requests. For example, use hashpb.WithIgnore to exclude volatile
+// fields such as timestamps or trace IDs from the digest.
+func WithRequestOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.requestOpts = opts
+	}
+}
+
+// WithResponseOptions sets the options used to hash responses.
+func WithResponseOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.responseOpts = opts
+	}
+}
+
+// WithoutHeaders stops the interceptor from sending the digests to the other party.
+// The request digest is still available to handlers through RequestDigest.
+func WithoutHeaders() Option {
+	return func(o *options) {
+		o.headers = false
+	}
+}
+
+type digestKey struct{}
+
+// digestHolder holds the digest of the latest request received by the call.
+type digestHolder struct {
+	digest []byte
+	mu     sync.RWMutex
+}
+
+func (h *digestHolder) set(digest []byte) {
+	h.mu.Lock()
+	h.digest = digest
+	h.mu.Unlock()
+}
+
+func (h *digestHolder) get() []byte {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.digest
+}
+
+// RequestDigest returns the digest of the request being handled. For streaming calls, it is the digest of the latest
+// message received from the client. The second return value is false if no digest has been computed.
+func RequestDigest(ctx context.Context) ([]byte, bool) {
+	h, ok := ctx.Value(digestKey{}).(*digestHolder)
+	if !ok {
+		return nil, false
+	}
+
+	digest := h.get()
+	return digest, digest != nil
+}
+
+// Interceptor computes the digests of requests and responses.
+type Interceptor struct {
+	opts *options
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor creates an interceptor that can be used with both handlers and clients.
+func NewInterceptor(opts ...Option) *Interceptor {
+	o := &options{headers: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Interceptor{opts: o}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		var digest []byte
+		if msg, ok := req.Any().(proto.Message); ok {
+			var err error
+			if digest, err = hashpb.SumAuto(nil, msg, i.opts.requestOpts...); err != nil {
+				return nil, err
+			}
+		}
+
+		if req.Spec().IsClient {
+			if digest != nil && i.opts.headers {
+				req.Header().Set(RequestDigestHeader, hex.EncodeToString(digest))
+			}
+			return next(ctx, req)
+		}
+
+		h := &digestHolder{digest: digest}
+		resp, err := next(context.WithValue(ctx, digestKey{}, h), req)
+		if err != nil || !i.opts.headers {
+			return resp, err
+		}
+
+		if digest != nil {
+			resp.Header().Set(RequestDigestHeader, hex.EncodeToString(digest))
+		}
+
+		// The digest is informational, so failing to hash the response leaves it out instead of failing the call.
+		if msg, ok := resp.Any().(proto.Message); ok {
+			if respDigest, err := hashpb.SumAuto(nil, msg, i.opts.responseOpts...); err == nil {
+				resp.Header().Set(ResponseDigestHeader, hex.EncodeToString(respDigest))
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient returns next unchanged because the request headers of a stream are sent before any messages.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		h := &digestHolder{}
+		return next(context.WithValue(ctx, digestKey{}, h), &handlerConn{StreamingHandlerConn: conn, holder: h, opts: i.opts})
+	}
+}
+
+type handlerConn struct {
+	connect.StreamingHandlerConn
+	holder *digestHolder
+	opts   *options
+}
+
+func (c *handlerConn) Receive(m any) error {
+	if err := c.StreamingHandlerConn.Receive(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		digest, err := hashpb.SumAuto(nil, msg, c.opts.requestOpts...)
+		if err != nil {
+			return err
+		}
+		c.holder.set(digest)
+	}
+
+	return nil
+}
+
+func (c *handlerConn) Send(m any) error {
+	if msg, ok := m.(proto.Message); ok && c.opts.headers {
+		if digest, err := hashpb.SumAuto(nil, msg, c.opts.responseOpts...); err == nil {
+			c.ResponseTrailer().Add(ResponseDigestHeader, hex.EncodeToString(digest))
+		}
+	}
+
+	return c.StreamingHandlerConn.Send(m)
+}
@@ -0,0 +1,153 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package connectmw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/connectmw"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	unaryProcedure  = "/test.EchoService/Echo"
+	streamProcedure = "/test.EchoService/EchoStream"
+)
+
+type digests struct {
+	handler []byte
+	client  string
+}
+
+func TestInterceptor(t *testing.T) {
+	seen := make(chan digests, 1)
+	interceptor := connect.WithInterceptors(connectmw.NewInterceptor())
+
+	mux := http.NewServeMux()
+	mux.Handle(unaryProcedure, connect.NewUnaryHandler(unaryProcedure,
+		func(ctx context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			digest, _ := connectmw.RequestDigest(ctx)
+			seen <- digests{handler: digest, client: req.Header().Get(connectmw.RequestDigestHeader)}
+			return connect.NewResponse(wrapperspb.String(req.Msg.GetValue() + "!")), nil
+		}, interceptor))
+	mux.Handle(streamProcedure, connect.NewServerStreamHandler(streamProcedure,
+		func(ctx context.Context, req *connect.Request[wrapperspb.StringValue], stream *connect.ServerStream[wrapperspb.StringValue]) error {
+			digest, _ := connectmw.RequestDigest(ctx)
+			seen <- digests{handler: digest}
+			return stream.Send(wrapperspb.String(req.Msg.GetValue() + "!"))
+		}, interceptor))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	req := wrapperspb.String("wibble")
+	wantReq := mustSum(t, req)
+	wantResp := mustSum(t, wrapperspb.String("wibble!"))
+
+	t.Run("unary", func(t *testing.T) {
+		client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+unaryProcedure, interceptor)
+		resp, err := client.CallUnary(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		have := <-seen
+		if hex.EncodeToString(have.handler) != wantReq {
+			t.Errorf("Unexpected request digest in context: want=%s have=%x", wantReq, have.handler)
+		}
+
+		if have.client != wantReq {
+			t.Errorf("Unexpected request digest from client: want=%s have=%s", wantReq, have.client)
+		}
+
+		checkHeader(t, resp.Header(), connectmw.RequestDigestHeader, wantReq)
+		checkHeader(t, resp.Header(), connectmw.ResponseDigestHeader, wantResp)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+streamProcedure)
+		stream, err := client.CallServerStream(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		for stream.Receive() {
+		}
+
+		if err := stream.Err(); err != nil {
+			t.Fatalf("Stream failed: %v", err)
+		}
+
+		if have := <-seen; hex.EncodeToString(have.handler) != wantReq {
+			t.Errorf("Unexpected request digest in context: want=%s have=%x", wantReq, have.handler)
+		}
+
+		checkHeader(t, stream.ResponseTrailer(), connectmw.ResponseDigestHeader, wantResp)
+	})
+}
+
+func TestInterceptorOptions(t *testing.T) {
+	seen := make(chan []byte, 1)
+	interceptor := connect.WithInterceptors(connectmw.NewInterceptor(
+		connectmw.WithRequestOptions(hashpb.WithIgnore("google.protobuf.StringValue.value")),
+		connectmw.WithoutHeaders(),
+	))
+
+	mux := http.NewServeMux()
+	mux.Handle(unaryProcedure, connect.NewUnaryHandler(unaryProcedure,
+		func(ctx context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			digest, _ := connectmw.RequestDigest(ctx)
+			seen <- digest
+			return connect.NewResponse(req.Msg), nil
+		}, interceptor))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+unaryProcedure)
+	var have [][]byte
+	for _, v := range []string{"wibble", "wobble"} {
+		resp, err := client.CallUnary(context.Background(), connect.NewRequest(wrapperspb.String(v)))
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		if values := resp.Header().Values(connectmw.ResponseDigestHeader); len(values) != 0 {
+			t.Errorf("Unexpected header: %v", values)
+		}
+
+		have = append(have, <-seen)
+	}
+
+	if !bytes.Equal(have[0], have[1]) {
+		t.Errorf("Ignored field was hashed: %x != %x", have[0], have[1])
+	}
+}
+
+func checkHeader(t *testing.T, header http.Header, key, want string) {
+	t.Helper()
+
+	if values := header.Values(key); len(values) != 1 || values[0] != want {
+		t.Errorf("Unexpected %s header: want=%s have=%v", key, want, values)
+	}
+}
+
+func mustSum(t *testing.T, msg proto.Message) string {
+	t.Helper()
+
+	digest, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	return hex.EncodeToString(digest)
+}
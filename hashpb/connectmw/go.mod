@@ -0,0 +1,16 @@
+module github.com/cerbos/protoc-gen-go-hashpb/hashpb/connectmw
+
+go 1.20
+
+require (
+	connectrpc.com/connect v1.16.0
+	github.com/cerbos/protoc-gen-go-hashpb v0.0.0
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/cerbos/protoc-gen-go-hashpb => ../..
@@ -0,0 +1,37 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"math"
+	"strconv"
+)
+
+// WithDecimalFloats hashes float and double values as canonical decimal strings instead of their IEEE 754 bits,
+// which makes the scheme easier to reproduce in languages that don't give bit-exact control over floating point
+// values. Each value is written like a string field (a varint length followed by the bytes) containing the shortest
+// decimal representation that round-trips to the same float or double, in exponent form with at least two exponent
+// digits (for example "1.5e+00", "-2.5e-07" and "1e+21"). Negative zero is written as "-0e+00", infinities as "+Inf"
+// and "-Inf", and every NaN as "NaN".
+// The generated methods always hash the IEEE 754 bits, so SumAuto and Sum64Auto use reflection when it is set.
+// It has no effect with SchemeObjectHash, which has its own canonical float encoding.
+func WithDecimalFloats() Option {
+	return func(o *Options) {
+		o.decimal = true
+	}
+}
+
+// decimalFloat returns the canonical decimal representation of f, which is a float or a double depending on bitSize.
+func decimalFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'e', -1, bitSize)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecimalFloat(t *testing.T) {
+	testCases := []struct {
+		value   float64
+		bitSize int
+		want    string
+	}{
+		{value: 1.5, bitSize: 64, want: "1.5e+00"},
+		{value: -2.5e-7, bitSize: 64, want: "-2.5e-07"},
+		{value: 1e21, bitSize: 64, want: "1e+21"},
+		{value: 0.1, bitSize: 64, want: "1e-01"},
+		{value: float64(float32(0.1)), bitSize: 32, want: "1e-01"},
+		{value: 0, bitSize: 64, want: "0e+00"},
+		{value: math.Copysign(0, -1), bitSize: 64, want: "-0e+00"},
+		{value: math.Inf(1), bitSize: 32, want: "+Inf"},
+		{value: math.Inf(-1), bitSize: 64, want: "-Inf"},
+		{value: math.NaN(), bitSize: 64, want: "NaN"},
+	}
+
+	for _, tc := range testCases {
+		if have := decimalFloat(tc.value, tc.bitSize); have != tc.want {
+			t.Errorf("decimalFloat(%v, %d): want=%q have=%q", tc.value, tc.bitSize, tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+// WithImplicitDefaults hashes unset singular message fields as if they were set to an empty message, so that every
+// field declared in the schema of a nested message contributes its default value to the hash, even if the message
+// is unset. Unset scalar fields always contribute their default value, which is what the generated HashPB methods do
+// as well; this option extends that behaviour to message fields, making digests sensitive to the shape of the schema
+// rather than only to the populated values. Recursive message types are expanded once per traversal path. Oneofs,
+// repeated fields and maps that are unset still contribute nothing.
+// The generated methods don't support this option, so SumAuto and Sum64Auto use reflection when it is set.
+// It has no effect with SchemeObjectHash, which leaves unset fields out of the hash.
+func WithImplicitDefaults() Option {
+	return func(o *Options) {
+		o.defaults = true
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithImplicitDefaults(t *testing.T) {
+	unset := &pb.NestedTestAllTypes{}
+	empty := &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{}}
+
+	for name, sumFn := range map[string]func(proto.Message, ...hashpb.Option) (uint64, error){
+		"Sum64":     hashpb.Sum64,
+		"Sum64Auto": hashpb.Sum64Auto,
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := sumFn(unset, hashpb.WithImplicitDefaults())
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			want, err := sumFn(empty, hashpb.WithImplicitDefaults())
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if have != want {
+				t.Errorf("Unset message hashed differently from empty message: want=%x have=%x", want, have)
+			}
+
+			plain, err := sumFn(unset)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if plain == have {
+				t.Error("Expected implicit defaults to change the digest of a message with unset fields")
+			}
+		})
+	}
+
+	ignored, err := hashpb.Sum64(empty, hashpb.WithImplicitDefaults(),
+		hashpb.WithIgnore("cerbos.hashpb.test.NestedTestAllTypes.payload"), hashpb.WithIgnoreMode(hashpb.IgnoreAsUnset))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	want, err := hashpb.Sum64(unset, hashpb.WithImplicitDefaults())
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if ignored != want {
+		t.Errorf("Ignored message field not hashed as its default instance: want=%x have=%x", want, ignored)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrMaxDepth is returned when the nesting depth of a message exceeds the configured limit.
+var ErrMaxDepth = errors.New("maximum message nesting depth exceeded")
+
+// WithMaxDepth makes hashing fail with ErrMaxDepth if messages are nested more than maxDepth levels deep, counting
+// the message being hashed as the first level. Use it to protect against stack exhaustion when hashing untrusted
+// input with recursive message types. A value of zero or less disables the limit, which is the default.
+// When the limit is set, SumAuto and Sum64Auto use the generated HashPBWithMaxDepth method if the message has one
+// and fall back to reflection otherwise.
+func WithMaxDepth(maxDepth int) Option {
+	return func(o *Options) {
+		o.maxDepth = maxDepth
+		if o.maxDepth < 0 {
+			o.maxDepth = 0
+		}
+	}
+}
+
+func maxDepthError(m protoreflect.Message, maxDepth int) error {
+	return fmt.Errorf("%w: %s is nested more than %d levels deep", ErrMaxDepth, m.Descriptor().FullName(), maxDepth)
+}
+
+// DepthLimitedHashable is implemented by messages with HashPBWithMaxDepth methods generated by protoc-gen-go-hashpb
+// using the depth_limit=true parameter. The method returns ErrMaxDepth instead of hashing messages nested more than
+// maxDepth levels deep, counting the message itself as the first level.
+type DepthLimitedHashable interface {
+	HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error
+}
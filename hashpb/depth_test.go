@@ -0,0 +1,92 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHashPBWithMaxDepth(t *testing.T) {
+	// the innermost payload is five levels deep because of the struct field
+	// (payload > Struct > Value > Struct > Value)
+	msg := mkNestedTestAllTypesMsg(3)
+	depth := 8
+
+	want := xxhash.New()
+	msg.HashPB(want, nil)
+
+	have := xxhash.New()
+	if err := msg.HashPBWithMaxDepth(have, nil, depth); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if want.Sum64() != have.Sum64() {
+		t.Errorf("Digest mismatch: want=%d have=%d", want.Sum64(), have.Sum64())
+	}
+
+	if err := msg.HashPBWithMaxDepth(xxhash.New(), nil, depth-1); !errors.Is(err, hashpb.ErrMaxDepth) {
+		t.Errorf("Expected ErrMaxDepth: %v", err)
+	}
+
+	if err := (&pb.TestAllTypes{}).HashPBWithMaxDepth(xxhash.New(), nil, 0); !errors.Is(err, hashpb.ErrMaxDepth) {
+		t.Errorf("Expected ErrMaxDepth: %v", err)
+	}
+
+	if err := (*pb.TestAllTypes)(nil).HashPBWithMaxDepth(xxhash.New(), nil, 0); err != nil {
+		t.Errorf("Unexpected error for nil message: %v", err)
+	}
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(3)
+	depth := 8
+
+	want, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		opts := []hashpb.Option{hashpb.WithScheme(scheme)}
+		unlimited, err := hashpb.Sum(nil, msg, opts...)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		for name, sumFn := range map[string]func([]byte, proto.Message, ...hashpb.Option) ([]byte, error){"Sum": hashpb.Sum, "SumAuto": hashpb.SumAuto} {
+			have, err := sumFn(nil, msg, append(opts, hashpb.WithMaxDepth(depth))...)
+			if err != nil {
+				t.Fatalf("%s failed with scheme %d: %v", name, scheme, err)
+			}
+
+			if !bytes.Equal(unlimited, have) {
+				t.Errorf("%s digest mismatch with scheme %d: want=%x have=%x", name, scheme, unlimited, have)
+			}
+
+			if _, err := sumFn(nil, msg, append(opts, hashpb.WithMaxDepth(depth-1))...); !errors.Is(err, hashpb.ErrMaxDepth) {
+				t.Errorf("%s with scheme %d: expected ErrMaxDepth: %v", name, scheme, err)
+			}
+		}
+	}
+
+	if _, err := hashpb.Sum64(msg, hashpb.WithMaxDepth(depth-1)); !errors.Is(err, hashpb.ErrMaxDepth) {
+		t.Errorf("Expected ErrMaxDepth: %v", err)
+	}
+
+	have, err := hashpb.Sum(nil, msg, hashpb.WithMaxDepth(0))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if !bytes.Equal(want, have) {
+		t.Errorf("Digest mismatch without limit: want=%x have=%x", want, have)
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// DefaultFraming describes how the encoded values are combined by the default scheme.
+	DefaultFraming = "Values are written to the hash function in traversal order without field tags or separators. " +
+		"Only strings and bytes carry a length prefix."
+	// ObjectHashFraming describes how the encoded values are combined by SchemeObjectHash.
+	ObjectHashFraming = "Every value is reduced to a SHA-256 digest as described by ObjectHash. Messages are dictionaries " +
+		"mapping the digest of each populated field number to the digest of its value, lists are lists of the digests of " +
+		"their elements and maps are dictionaries of the digests of their keys and values."
+	// MapKeyOrder describes the order of map entries in the default scheme.
+	MapKeyOrder = "Ascending key order (false before true for bool keys, numeric order for integer keys, byte-wise order for string keys). Keys are not hashed."
+)
+
+// SchemeDescription describes how messages are hashed with a given set of options, in a form that tooling can use to
+// generate or validate implementations of the scheme in other languages.
+type SchemeDescription struct {
+	Scheme         string               `json:"scheme"`
+	Framing        string               `json:"framing"`
+	Normalizations []string             `json:"normalizations,omitempty"`
+	Messages       []MessageDescription `json:"messages"`
+}
+
+// MessageDescription describes the traversal of a message.
+type MessageDescription struct {
+	Name   string             `json:"name"`
+	Fields []FieldDescription `json:"fields"`
+}
+
+// FieldDescription describes how a field (or a oneof) is hashed. Fields are listed in traversal order.
+type FieldDescription struct {
+	Number         int32              `json:"number,omitempty"`
+	Name           string             `json:"name"`
+	IgnoreKey      string             `json:"ignoreKey,omitempty"`
+	StableKey      string             `json:"stableIgnoreKey,omitempty"`
+	Ignored        bool               `json:"ignored,omitempty"`
+	Kind           string             `json:"kind"`
+	Type           string             `json:"type,omitempty"`
+	Cardinality    string             `json:"cardinality,omitempty"`
+	Encoding       string             `json:"encoding,omitempty"`
+	Normalizations []string           `json:"normalizations,omitempty"`
+	Unset          string             `json:"unset,omitempty"`
+	SampleEvery    int                `json:"sampleEvery,omitempty"`
+	SortBy         string             `json:"sortBy,omitempty"`
+	Unordered      bool               `json:"unordered,omitempty"`
+	KeyKind        string             `json:"keyKind,omitempty"`
+	KeyOrder       string             `json:"keyOrder,omitempty"`
+	Value          *FieldDescription  `json:"value,omitempty"`
+	Members        []FieldDescription `json:"members,omitempty"`
+}
+
+// DescribeScheme describes how the message and all the messages reachable from it are hashed with the given options.
+// Messages are sorted by name. The description covers the traversal order, the encoding of each value, the framing,
+// the handling of unset values and the normalizations in effect. Options that don't affect the digests, such as
+// WithHash and WithObserver, are not described.
+func DescribeScheme(md protoreflect.MessageDescriptor, opts ...Option) *SchemeDescription {
+	o := newOptions(opts).resolveIgnore(md)
+
+	msgs := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	collectMessageTypes(msgs, md)
+
+	names := make([]string, 0, len(msgs))
+	for name := range msgs {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	d := &SchemeDescription{Scheme: "hashpb", Framing: DefaultFraming, Normalizations: o.normalizations()}
+	if o.scheme == SchemeObjectHash {
+		d.Scheme = "objecthash"
+		d.Framing = ObjectHashFraming
+	} else if o.parallel > 0 {
+		d.Framing += " The top-level fields (and oneofs) of the hashed message are hashed into separate digests, which are " +
+			"written as varint-length-prefixed byte strings in traversal order. Ignored fields don't contribute a digest."
+	}
+
+	d.Messages = make([]MessageDescription, len(names))
+	for i, name := range names {
+		d.Messages[i] = o.describeMessage(msgs[protoreflect.FullName(name)])
+	}
+
+	return d
+}
+
+func collectMessageTypes(msgs map[protoreflect.FullName]protoreflect.MessageDescriptor, md protoreflect.MessageDescriptor) {
+	if md.IsMapEntry() {
+		if vmd := md.Fields().ByNumber(2).Message(); vmd != nil {
+			collectMessageTypes(msgs, vmd)
+		}
+		return
+	}
+
+	if _, ok := msgs[md.FullName()]; ok {
+		return
+	}
+
+	msgs[md.FullName()] = md
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fmd := fields.Get(i).Message(); fmd != nil {
+			collectMessageTypes(msgs, fmd)
+		}
+	}
+}
+
+// normalizations describes the options that change how values are hashed.
+func (o *Options) normalizations() []string {
+	var n []string
+	if o.nfc {
+		n = append(n, "String values are normalized to Unicode NFC.")
+	}
+
+	names := make([]string, 0, len(o.canonicalizers))
+	for name := range o.canonicalizers {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		n = append(n, fmt.Sprintf("Messages of type %s are hashed in canonical form.", name))
+	}
+
+	if o.wrappers {
+		n = append(n, "Well-known wrapper types are hashed like optional scalar fields of the wrapped type.")
+	}
+
+	if o.nullAsUnset {
+		n = append(n, "Null google.protobuf.Value messages are hashed as if they were absent.")
+	}
+
+	if o.shallow {
+		n = append(n, "Message values are hashed as the fully-qualified names of their types, without descending into them.")
+	}
+
+	// the remaining options have no effect with SchemeObjectHash.
+	if o.scheme == SchemeObjectHash {
+		return n
+	}
+
+	if o.decimal {
+		n = append(n, "Float and double values are hashed as their shortest round-trip decimal representation.")
+	}
+
+	if o.defaults {
+		n = append(n, "Unset message fields are hashed as default instances of their type.")
+	}
+
+	if o.presence {
+		n = append(n, "Each message with fields with explicit presence starts with a length-prefixed bitmap of the fields that are set.")
+	}
+
+	if o.ignoreAs == IgnoreAsUnset && (len(o.ignore) > 0 || o.fieldFilter != nil) {
+		n = append(n, "Ignored fields are hashed as if they were unset.")
+	}
+
+	if o.emptyAs == EmptyAsSet {
+		n = append(n, "Bytes fields with explicit presence that are not set are left out, so they are distinct from empty values.")
+	}
+
+	return n
+}
+
+func (o *Options) describeMessage(md protoreflect.MessageDescriptor) MessageDescription {
+	msg := MessageDescription{Name: string(md.FullName())}
+	for _, d := range fieldSubtrees(sortedFields(md)) {
+		fd, ok := d.(protoreflect.FieldDescriptor)
+		if ok {
+			msg.Fields = append(msg.Fields, o.describeField(fd))
+			continue
+		}
+
+		od := d.(protoreflect.OneofDescriptor)
+		_, ignored := o.ignore[string(od.FullName())]
+		oneOf := FieldDescription{
+			Name:      string(od.Name()),
+			IgnoreKey: string(od.FullName()),
+			Ignored:   ignored,
+			Kind:      "oneof",
+			Unset:     "Nothing is hashed if no member is set. Otherwise the value of the set member is hashed.",
+		}
+		if o.scheme == SchemeObjectHash {
+			oneOf.Unset = "Only the member that is set is hashed, like any other field."
+		}
+
+		members := od.Fields()
+		for i := 0; i < members.Len(); i++ {
+			member := o.describeValue(members.Get(i))
+			member.Name = string(members.Get(i).Name())
+			member.Number = int32(members.Get(i).Number())
+			oneOf.Members = append(oneOf.Members, member)
+		}
+
+		msg.Fields = append(msg.Fields, oneOf)
+	}
+
+	return msg
+}
+
+func (o *Options) describeField(fd protoreflect.FieldDescriptor) FieldDescription {
+	var f FieldDescription
+	switch {
+	case fd.IsMap():
+		value := o.describeValue(fd.MapValue())
+		f = FieldDescription{
+			Kind:     "map",
+			KeyKind:  fd.MapKey().Kind().String(),
+			KeyOrder: MapKeyOrder,
+			Value:    &value,
+			Unset:    "Nothing is hashed if the map is empty.",
+		}
+		if o.scheme == SchemeObjectHash {
+			key := o.describeValue(fd.MapKey())
+			f.KeyOrder = "Entries are sorted by the digests of their keys and values. Keys are hashed with " + key.Encoding + "."
+			f.Unset = "The field is left out if the map is empty."
+		}
+	case fd.IsList():
+		value := o.describeValue(fd)
+		order := "Each element is hashed in order."
+		var sortBy string
+		if key := SortBy(fd); key != nil {
+			sortBy = string(key.Name())
+			order = fmt.Sprintf("The elements are sorted by their %s field, keeping the order of elements with equal values, and hashed in that order.", sortBy)
+		}
+		unordered := Unordered(fd)
+		if unordered {
+			order = "Each element is hashed into its own SHA-256 digest, and the sorted digests are hashed as length-prefixed byte strings."
+			if o.scheme == SchemeObjectHash {
+				order = "The elements are hashed as a set (tag 's') of the sorted digests of the elements."
+			}
+		}
+
+		f = FieldDescription{
+			Kind:      "list",
+			Value:     &value,
+			SortBy:    sortBy,
+			Unordered: unordered,
+			Unset:     order + " Nothing is hashed if the list is empty.",
+		}
+		if o.scheme == SchemeObjectHash {
+			f.Unset = order + " The field is left out if the list is empty."
+		}
+
+		if k := SampleEvery(fd); k > 0 {
+			f.SampleEvery = k
+			f.Unset = fmt.Sprintf("SAMPLED: the length followed by every %d-th element, starting from the first, is hashed in order. Nothing is hashed if the list is empty.", k)
+			if sortBy != "" {
+				f.Unset += " The elements are sorted by their " + sortBy + " field before they are sampled."
+			}
+		}
+	default:
+		f = o.describeValue(fd)
+		if o.emptyAs == EmptyAsSet && o.scheme != SchemeObjectHash && EmptyModeApplies(fd) {
+			f.Unset = "Nothing is hashed if the field is not set. An empty value that is set is hashed."
+		}
+	}
+
+	f.Name = string(fd.Name())
+	f.Number = int32(fd.Number())
+	f.IgnoreKey = string(fd.FullName())
+	f.StableKey = StableIgnoreKey(fd)
+	f.Ignored = fieldExcluded(o.ignore, o.fieldFilter, fd)
+	f.Cardinality = fd.Cardinality().String()
+
+	return f
+}
+
+func (o *Options) describeValue(fd protoreflect.FieldDescriptor) FieldDescription {
+	f := FieldDescription{Kind: fd.Kind().String(), Encoding: Encoding(fd.Kind())}
+	switch {
+	case o.scheme == SchemeObjectHash:
+		f.Encoding = objectHashEncoding(fd.Kind())
+	case o.decimal && (fd.Kind() == protoreflect.FloatKind || fd.Kind() == protoreflect.DoubleKind):
+		f.Encoding = "varint length followed by the shortest round-trip decimal representation in exponent form (NaN, +Inf and -Inf for special values)"
+	}
+
+	if fd.Kind() == protoreflect.StringKind {
+		a := annotationsOf(fd)
+		if o.nfc {
+			f.Normalizations = append(f.Normalizations, "nfc")
+		}
+
+		if a.trimSpace {
+			f.Normalizations = append(f.Normalizations, "trim_space")
+		}
+
+		if a.caseInsensitive {
+			f.Normalizations = append(f.Normalizations, "lower_case")
+		}
+	}
+
+	switch {
+	case fd.Message() != nil:
+		f.Type = string(fd.Message().FullName())
+		f.Unset = "Nothing is hashed if the message is not set."
+		if o.defaults && o.scheme != SchemeObjectHash {
+			f.Unset = "A default instance of the message is hashed if it is not set."
+		}
+	case fd.Enum() != nil:
+		f.Type = string(fd.Enum().FullName())
+		f.Unset = "The default value is hashed."
+	default:
+		f.Unset = "The default value is hashed."
+	}
+
+	if o.scheme == SchemeObjectHash {
+		f.Unset = "The field is left out if it is not populated."
+	}
+
+	return f
+}
+
+// Encoding describes how values of the given kind are encoded by the default scheme.
+func Encoding(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.BoolKind:
+		return "varint (0 or 1)"
+	case protoreflect.EnumKind, protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return "varint of the value sign-extended to 64 bits"
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return "varint of the zigzag-encoded value"
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return "varint"
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		return "fixed32 (little-endian)"
+	case protoreflect.FloatKind:
+		return "fixed32 (little-endian) of the IEEE 754 bits"
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		return "fixed64 (little-endian)"
+	case protoreflect.DoubleKind:
+		return "fixed64 (little-endian) of the IEEE 754 bits"
+	case protoreflect.StringKind:
+		return "varint length followed by the UTF-8 bytes"
+	case protoreflect.BytesKind:
+		return "varint length followed by the bytes"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "fields of the message in traversal order"
+	default:
+		return "unsupported"
+	}
+}
+
+func objectHashEncoding(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.BoolKind:
+		return "ObjectHash boolean (tag 'b')"
+	case protoreflect.EnumKind, protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind,
+		protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return "ObjectHash integer (tag 'i') of the decimal representation"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "ObjectHash float (tag 'f') of the normalized value"
+	case protoreflect.StringKind:
+		return "ObjectHash string (tag 'u') of the UTF-8 bytes"
+	case protoreflect.BytesKind:
+		return "ObjectHash raw bytes (tag 'r')"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "ObjectHash dictionary (tag 'd') of the populated fields keyed by field number"
+	default:
+		return "unsupported"
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDescribeScheme(t *testing.T) {
+	md := (&pb.TestAllTypes{}).ProtoReflect().Descriptor()
+
+	t.Run("default", func(t *testing.T) {
+		d := hashpb.DescribeScheme(md)
+		if d.Scheme != "hashpb" || d.Framing != hashpb.DefaultFraming || len(d.Normalizations) != 0 {
+			t.Errorf("Unexpected description: scheme=%q framing=%q normalizations=%v", d.Scheme, d.Framing, d.Normalizations)
+		}
+
+		f := findField(t, d, "cerbos.hashpb.test.TestAllTypes", "single_double")
+		if f.Encoding != hashpb.Encoding(protoreflect.DoubleKind) {
+			t.Errorf("Unexpected encoding of double field: %q", f.Encoding)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		d := hashpb.DescribeScheme(md,
+			hashpb.WithNormalizeUnicode(),
+			hashpb.WithDecimalFloats(),
+			hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_int32"),
+		)
+
+		if len(d.Normalizations) != 2 {
+			t.Errorf("Expected normalizations to be described, got %v", d.Normalizations)
+		}
+
+		if f := findField(t, d, "cerbos.hashpb.test.TestAllTypes", "single_int32"); !f.Ignored {
+			t.Error("Expected ignored field to be marked as ignored")
+		}
+
+		if f := findField(t, d, "cerbos.hashpb.test.TestAllTypes", "single_string"); !reflect.DeepEqual(f.Normalizations, []string{"nfc"}) {
+			t.Errorf("Unexpected normalizations of string field: %v", f.Normalizations)
+		}
+
+		if f := findField(t, d, "cerbos.hashpb.test.TestAllTypes", "single_double"); f.Encoding == hashpb.Encoding(protoreflect.DoubleKind) {
+			t.Errorf("Expected decimal encoding of double field, got %q", f.Encoding)
+		}
+	})
+
+	t.Run("objecthash", func(t *testing.T) {
+		d := hashpb.DescribeScheme(md, hashpb.WithScheme(hashpb.SchemeObjectHash), hashpb.WithDecimalFloats())
+		if d.Scheme != "objecthash" || d.Framing != hashpb.ObjectHashFraming || len(d.Normalizations) != 0 {
+			t.Errorf("Unexpected description: scheme=%q framing=%q normalizations=%v", d.Scheme, d.Framing, d.Normalizations)
+		}
+	})
+
+	t.Run("annotations", func(t *testing.T) {
+		amd := annotatedStringDescriptor(t, &optionspb.FieldOptions{TrimSpace: true, CaseInsensitive: true, SampleEvery: 10})
+		d := hashpb.DescribeScheme(amd)
+
+		if f := findField(t, d, "annotated.User", "email"); !reflect.DeepEqual(f.Normalizations, []string{"trim_space", "lower_case"}) {
+			t.Errorf("Unexpected normalizations of annotated field: %v", f.Normalizations)
+		}
+
+		if f := findField(t, d, "annotated.User", "aliases"); f.SampleEvery != 10 || !reflect.DeepEqual(f.Value.Normalizations, []string{"trim_space", "lower_case"}) {
+			t.Errorf("Unexpected description of annotated list: %+v", f)
+		}
+	})
+}
+
+func findField(t *testing.T, d *hashpb.SchemeDescription, msgName, fieldName string) hashpb.FieldDescription {
+	t.Helper()
+
+	for _, m := range d.Messages {
+		if m.Name != msgName {
+			continue
+		}
+
+		for _, f := range m.Fields {
+			if f.Name == fieldName {
+				return f
+			}
+		}
+	}
+
+	t.Fatalf("Field %s.%s not found in description", msgName, fieldName)
+	return hashpb.FieldDescription{}
+}
@@ -0,0 +1,214 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Diff compares the per-field digests of two messages of the same type and returns the paths of the fields that differ
+// (for example, payload.child.repeated_nested_message[1].bb or map_string_string["a"]).
+// Fields are compared in traversal order and ignored fields are not reported.
+func Diff(a, b proto.Message, opts ...Option) ([]string, error) {
+	if a == nil || b == nil {
+		return nil, errNilMessage
+	}
+
+	ma, mb := a.ProtoReflect(), b.ProtoReflect()
+	if ma.Descriptor().FullName() != mb.Descriptor().FullName() {
+		return nil, fmt.Errorf("%w: cannot compare %s with %s", ErrInvalidMessage, ma.Descriptor().FullName(), mb.Descriptor().FullName())
+	}
+
+	o := newOptions(opts)
+	d := &differ{ignore: o.ignoreFor(ma.Descriptor()), filter: o.fieldFilter}
+	if err := d.message("", ma, mb); err != nil {
+		return nil, err
+	}
+
+	return d.paths, nil
+}
+
+type differ struct {
+	ignore map[string]struct{}
+	filter func(protoreflect.FieldDescriptor) bool
+	paths  []string
+}
+
+func (d *differ) walker() *walker {
+	return &walker{hasher: xxhash.New(), ignore: d.ignore, fieldFilter: d.filter}
+}
+
+func (d *differ) sum(fn func(*walker) error) (uint64, error) {
+	w := d.walker()
+	if err := fn(w); err != nil {
+		return 0, err
+	}
+
+	return w.hasher.(*xxhash.Digest).Sum64(), nil
+}
+
+func (d *differ) differs(fnA, fnB func(*walker) error) (bool, error) {
+	sumA, err := d.sum(fnA)
+	if err != nil {
+		return false, err
+	}
+
+	sumB, err := d.sum(fnB)
+	if err != nil {
+		return false, err
+	}
+
+	return sumA != sumB, nil
+}
+
+func (d *differ) message(path string, a, b protoreflect.Message) error {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.paths = append(d.paths, path)
+		}
+		return nil
+	}
+
+	oneOfs := make(map[protoreflect.FullName]struct{})
+	for _, fd := range sortedFields(a.Descriptor()) {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if _, ok := oneOfs[od.FullName()]; ok {
+				continue
+			}
+			oneOfs[od.FullName()] = struct{}{}
+
+			if err := d.oneOf(path, a, b, od); err != nil {
+				return err
+			}
+			continue
+		}
+
+		differs, err := d.differs(
+			func(w *walker) error { return w.field(a, fd) },
+			func(w *walker) error { return w.field(b, fd) },
+		)
+		if err != nil {
+			return err
+		}
+
+		if differs {
+			if err := d.field(fieldPath(path, fd), fd, a.Get(fd), b.Get(fd)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) oneOf(path string, a, b protoreflect.Message, od protoreflect.OneofDescriptor) error {
+	differs, err := d.differs(
+		func(w *walker) error { return w.oneOf(a, od) },
+		func(w *walker) error { return w.oneOf(b, od) },
+	)
+	if err != nil || !differs {
+		return err
+	}
+
+	fdA, fdB := a.WhichOneof(od), b.WhichOneof(od)
+	if fdA != nil && fdA == fdB {
+		return d.value(fieldPath(path, fdA), fdA, a.Get(fdA), b.Get(fdB))
+	}
+
+	for _, fd := range []protoreflect.FieldDescriptor{fdA, fdB} {
+		if fd != nil {
+			d.paths = append(d.paths, fieldPath(path, fd))
+		}
+	}
+
+	return nil
+}
+
+// field is called with values that are known to differ. It descends into messages to find the differing leaves.
+func (d *differ) field(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Value) error {
+	switch {
+	case fd.IsList():
+		listA, listB := sortList(fd, a.List()), sortList(fd, b.List())
+		if fd.Message() == nil || Unordered(fd) || listA.Len() != listB.Len() {
+			d.paths = append(d.paths, path)
+			return nil
+		}
+
+		for i := 0; i < listA.Len(); i++ {
+			if err := d.value(fmt.Sprintf("%s[%d]", path, i), fd, listA.Get(i), listB.Get(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case fd.IsMap():
+		mapA, mapB := a.Map(), b.Map()
+		if mapA.Len() != mapB.Len() {
+			d.paths = append(d.paths, path)
+			return nil
+		}
+
+		keys := make([]protoreflect.MapKey, 0, mapA.Len())
+		sameKeys := true
+		mapA.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			sameKeys = mapB.Has(k)
+			return sameKeys
+		})
+
+		if !sameKeys {
+			d.paths = append(d.paths, path)
+			return nil
+		}
+
+		sortMapKeys(fd.MapKey().Kind(), keys)
+		for _, k := range keys {
+			if err := d.value(mapKeyPath(path, fd.MapKey(), k), fd.MapValue(), mapA.Get(k), mapB.Get(k)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return d.value(path, fd, a, b)
+	}
+}
+
+// value compares singular values and reports the path if they differ.
+func (d *differ) value(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Value) error {
+	differs, err := d.differs(
+		func(w *walker) error { return w.value(fd, a) },
+		func(w *walker) error { return w.value(fd, b) },
+	)
+	if err != nil || !differs {
+		return err
+	}
+
+	if fd.Message() != nil {
+		return d.message(path, a.Message(), b.Message())
+	}
+
+	d.paths = append(d.paths, path)
+	return nil
+}
+
+func fieldPath(path string, fd protoreflect.FieldDescriptor) string {
+	if path == "" {
+		return string(fd.Name())
+	}
+
+	return path + "." + string(fd.Name())
+}
+
+func mapKeyPath(path string, kd protoreflect.FieldDescriptor, k protoreflect.MapKey) string {
+	if kd.Kind() == protoreflect.StringKind {
+		return fmt.Sprintf("%s[%q]", path, k.String())
+	}
+
+	return fmt.Sprintf("%s[%v]", path, k.Interface())
+}
@@ -0,0 +1,99 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDiff(t *testing.T) {
+	base := mkNestedTestAllTypesMsg(2)
+
+	testCases := []struct {
+		name   string
+		mutate func(*pb.NestedTestAllTypes)
+		opts   []hashpb.Option
+		want   []string
+	}{
+		{
+			name:   "equal",
+			mutate: func(*pb.NestedTestAllTypes) {},
+		},
+		{
+			name:   "scalar",
+			mutate: func(m *pb.NestedTestAllTypes) { m.Payload.SingleInt32++ },
+			want:   []string{"payload.single_int32"},
+		},
+		{
+			name: "nested",
+			mutate: func(m *pb.NestedTestAllTypes) {
+				m.Child.Payload.SingleString = "wobble"
+			},
+			want: []string{"child.payload.single_string"},
+		},
+		{
+			name: "list_element",
+			mutate: func(m *pb.NestedTestAllTypes) {
+				m.Payload.RepeatedNestedMessage[1].Bb = 42
+			},
+			want: []string{"payload.repeated_nested_message[1].bb"},
+		},
+		{
+			name:   "map_value",
+			mutate: func(m *pb.NestedTestAllTypes) { m.Payload.MapStringString["a"] = "changed" },
+			want:   []string{`payload.map_string_string["a"]`},
+		},
+		{
+			name: "list_length",
+			mutate: func(m *pb.NestedTestAllTypes) {
+				m.Payload.RepeatedNestedMessage = m.Payload.RepeatedNestedMessage[:1]
+			},
+			want: []string{"payload.repeated_nested_message"},
+		},
+		{
+			name: "oneof_member",
+			mutate: func(m *pb.NestedTestAllTypes) {
+				m.Payload.NestedType = &pb.TestAllTypes_SingleNestedEnum{SingleNestedEnum: pb.TestAllTypes_BAR}
+			},
+			want: []string{"payload.single_nested_message", "payload.single_nested_enum"},
+		},
+		{
+			name:   "well_known",
+			mutate: func(m *pb.NestedTestAllTypes) { m.Payload.SingleStruct.Fields["a"] = structpb.NewBoolValue(true) },
+			want:   []string{`payload.single_struct.fields["a"].number_value`, `payload.single_struct.fields["a"].bool_value`},
+		},
+		{
+			name:   "ignored",
+			mutate: func(m *pb.NestedTestAllTypes) { m.Payload.SingleInt32++ },
+			opts:   []hashpb.Option{hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_int32")},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			other := proto.Clone(base).(*pb.NestedTestAllTypes)
+			tc.mutate(other)
+
+			have, err := hashpb.Diff(base, other, tc.opts...)
+			if err != nil {
+				t.Fatalf("Failed to diff: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.want, have) {
+				t.Errorf("Unexpected paths: want=%q have=%q", tc.want, have)
+			}
+		})
+	}
+
+	if _, err := hashpb.Diff(base, base.Payload); err == nil {
+		t.Error("Expected error when comparing different types")
+	}
+}
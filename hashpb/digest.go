@@ -0,0 +1,90 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Digest is a message digest that can be stored in databases, logged and round-tripped through APIs.
+// It is encoded as a lower-case hexadecimal string in text, JSON and SQL.
+type Digest []byte
+
+// SumDigest calculates the hash of the message and returns it as a Digest.
+func SumDigest(msg proto.Message, opts ...Option) (Digest, error) {
+	digest, err := Sum(nil, msg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Digest(digest), nil
+}
+
+// ParseDigest decodes a digest from its hexadecimal representation.
+func ParseDigest(s string) (Digest, error) {
+	d, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest %q: %w", s, err)
+	}
+
+	return Digest(d), nil
+}
+
+// String returns the hexadecimal representation of the digest.
+func (d Digest) String() string {
+	return hex.EncodeToString(d)
+}
+
+// Equal reports whether the digests are identical.
+func (d Digest) Equal(other Digest) bool {
+	return bytes.Equal(d, other)
+}
+
+// MarshalText implements encoding.TextMarshaler, which also makes the digest marshal to a JSON string.
+func (d Digest) MarshalText() ([]byte, error) {
+	text := make([]byte, hex.EncodedLen(len(d)))
+	hex.Encode(text, d)
+	return text, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which also makes the digest unmarshal from a JSON string.
+func (d *Digest) UnmarshalText(text []byte) error {
+	decoded := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(decoded, text); err != nil {
+		return fmt.Errorf("invalid digest %q: %w", text, err)
+	}
+
+	*d = decoded
+	return nil
+}
+
+// Value implements driver.Valuer. The digest is stored as a hexadecimal string, and a nil digest is stored as NULL.
+func (d Digest) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the hexadecimal representation of the digest as a string or byte slice,
+// and NULL, which results in a nil digest.
+func (d *Digest) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a digest", src)
+	}
+}
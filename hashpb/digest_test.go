@@ -0,0 +1,89 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestDigest(t *testing.T) {
+	digest, err := hashpb.SumDigest(&pb.TestAllTypes{SingleString: "foo"})
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	t.Run("text", func(t *testing.T) {
+		parsed, err := hashpb.ParseDigest(digest.String())
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+
+		if !parsed.Equal(digest) {
+			t.Errorf("Digest mismatch: want=%s have=%s", digest, parsed)
+		}
+
+		if _, err := hashpb.ParseDigest("xyz"); err == nil {
+			t.Error("Expected error parsing invalid digest")
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		type record struct {
+			Digest hashpb.Digest `json:"digest"`
+		}
+
+		out, err := json.Marshal(record{Digest: digest})
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+
+		if want := `{"digest":"` + digest.String() + `"}`; string(out) != want {
+			t.Errorf("Unexpected JSON: want=%s have=%s", want, out)
+		}
+
+		var r record
+		if err := json.Unmarshal(out, &r); err != nil {
+			t.Fatalf("Failed to unmarshal: %v", err)
+		}
+
+		if !r.Digest.Equal(digest) {
+			t.Errorf("Digest mismatch: want=%s have=%s", digest, r.Digest)
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		v, err := digest.Value()
+		if err != nil {
+			t.Fatalf("Failed to get value: %v", err)
+		}
+
+		for _, src := range []any{v, []byte(v.(string))} {
+			var scanned hashpb.Digest
+			if err := scanned.Scan(src); err != nil {
+				t.Fatalf("Failed to scan %T: %v", src, err)
+			}
+
+			if !scanned.Equal(digest) {
+				t.Errorf("Digest mismatch scanning %T: want=%s have=%s", src, digest, scanned)
+			}
+		}
+
+		if v, err := hashpb.Digest(nil).Value(); err != nil || v != nil {
+			t.Errorf("Expected nil digest to be stored as NULL, got %v (%v)", v, err)
+		}
+
+		scanned := hashpb.Digest{1}
+		if err := scanned.Scan(nil); err != nil || scanned != nil {
+			t.Errorf("Expected NULL to be scanned as a nil digest, got %v (%v)", scanned, err)
+		}
+
+		if err := scanned.Scan(42); err == nil {
+			t.Error("Expected error scanning unsupported type")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"hash"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// SumEach calculates the 64-bit hash of each message, like Sum64, reusing a single hasher and set of options across
+// the slice. The hash function must implement hash.Hash64.
+func SumEach(msgs []proto.Message, opts ...Option) ([]uint64, error) {
+	return sumEach(len(msgs), func(i int) proto.Message { return msgs[i] }, opts)
+}
+
+// SumEachT is SumEach for slices of a concrete message type, which saves converting them to []proto.Message.
+func SumEachT[T proto.Message](msgs []T, opts ...Option) ([]uint64, error) {
+	return sumEach(len(msgs), func(i int) proto.Message { return msgs[i] }, opts)
+}
+
+func sumEach(n int, msg func(int) proto.Message, opts []Option) ([]uint64, error) {
+	o := newOptions(opts)
+	if o.scheme == SchemeObjectHash {
+		return nil, errObjectHashSum64
+	}
+
+	hasher, ok := o.hashFn().(hash.Hash64)
+	if !ok {
+		return nil, fmt.Errorf("%w: hash function does not implement hash.Hash64", ErrUnsupportedHash)
+	}
+
+	sums := make([]uint64, n)
+	for i := range sums {
+		hasher.Reset()
+		if err := observe(hasher, msg(i), o, hashMsg); err != nil {
+			return nil, fmt.Errorf("failed to hash message %d: %w", i, err)
+		}
+
+		sums[i] = hasher.Sum64()
+	}
+
+	return sums, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSumEach(t *testing.T) {
+	msgs := []*pb.TestAllTypes{{SingleInt32: 1}, {SingleString: "foo"}, {}}
+	ignore := hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_string")
+
+	want := make([]uint64, len(msgs))
+	generic := make([]proto.Message, len(msgs))
+	for i, m := range msgs {
+		sum, err := hashpb.Sum64(m, ignore)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+		want[i] = sum
+		generic[i] = m
+	}
+
+	for name, fn := range map[string]func() ([]uint64, error){
+		"SumEach":  func() ([]uint64, error) { return hashpb.SumEach(generic, ignore) },
+		"SumEachT": func() ([]uint64, error) { return hashpb.SumEachT(msgs, ignore) },
+	} {
+		have, err := fn()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+
+		for i := range want {
+			if want[i] != have[i] {
+				t.Errorf("%s mismatch for message %d: want=%d have=%d", name, i, want[i], have[i])
+			}
+		}
+	}
+
+	if _, err := hashpb.SumEach([]proto.Message{msgs[0], nil}); !errors.Is(err, hashpb.ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage, got %v", err)
+	}
+
+	if _, err := hashpb.SumEachT(msgs, hashpb.WithHash(sha256.New)); !errors.Is(err, hashpb.ErrUnsupportedHash) {
+		t.Errorf("Expected ErrUnsupportedHash, got %v", err)
+	}
+}
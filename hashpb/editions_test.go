@@ -0,0 +1,84 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestDelimitedEncoding(t *testing.T) {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("e.proto"),
+		Package: proto.String("e"),
+		Syntax:  proto.String("editions"),
+		Edition: descriptorpb.Edition_EDITION_2023.Enum(),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("M"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("g"),
+				JsonName: proto.String("g"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".e.M.G"),
+				Options: &descriptorpb.FieldOptions{
+					Features: &descriptorpb.FeatureSet{MessageEncoding: descriptorpb.FeatureSet_DELIMITED.Enum()},
+				},
+			}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("G"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     proto.String("x"),
+					JsonName: proto.String("x"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				}},
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	md := fd.Messages().Get(0)
+	g := md.Fields().ByName("g")
+	if g.Kind() != protoreflect.GroupKind {
+		t.Fatalf("Expected delimited field to be a group, got %s", g.Kind())
+	}
+
+	mkMsg := func(x string) *dynamicpb.Message {
+		inner := dynamicpb.NewMessage(g.Message())
+		inner.Set(g.Message().Fields().ByName("x"), protoreflect.ValueOfString(x))
+
+		msg := dynamicpb.NewMessage(md)
+		msg.Set(g, protoreflect.ValueOfMessage(inner))
+		return msg
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		a, err := hashpb.Sum(nil, mkMsg("a"), hashpb.WithScheme(scheme))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		b, err := hashpb.Sum(nil, mkMsg("b"), hashpb.WithScheme(scheme))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if string(a) == string(b) {
+			t.Errorf("Expected the delimited field to contribute to the digest with scheme %v", scheme)
+		}
+	}
+}
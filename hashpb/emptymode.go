@@ -0,0 +1,47 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// EmptyMode determines whether empty bytes values are distinguished from unset ones.
+//
+// Nil and empty slices of bytes fields without explicit presence and of repeated fields always hash identically,
+// because protobuf doesn't distinguish them either: neither is serialized, and both are reported as unset by
+// reflection. An empty repeated field contributes nothing to the hash, and an empty bytes field without explicit
+// presence contributes the empty byte string, like its default value.
+type EmptyMode int
+
+const (
+	// EmptyAsUnset hashes bytes fields with explicit presence (proto3 optional fields and editions fields with
+	// EXPLICIT presence) that are set to an empty value like unset ones, which contribute the empty byte string.
+	// It is the behaviour of the generated methods unless the empty_as_set=true plugin parameter is used.
+	EmptyAsUnset EmptyMode = iota
+	// EmptyAsSet leaves bytes fields with explicit presence that are not set out of the hash, so that they are
+	// distinct from fields set to an empty value, which contribute the empty byte string.
+	// It is the behaviour of the methods generated with the empty_as_set=true plugin parameter.
+	EmptyAsSet
+)
+
+// WithEmptyMode sets whether empty bytes values of fields with explicit presence are distinguished from unset ones.
+// Defaults to EmptyAsUnset. The generated methods implement the mode chosen with the empty_as_set plugin parameter,
+// so SumAuto and Sum64Auto use reflection when the mode is EmptyAsSet. Both modes produce the same digests with
+// SchemeObjectHash, which leaves unset fields out of the hash and hashes empty values that are set.
+func WithEmptyMode(mode EmptyMode) Option {
+	return func(o *Options) {
+		o.emptyAs = mode
+	}
+}
+
+// EmptyModeApplies reports whether the field is a singular bytes field with explicit presence that is not a member of
+// a oneof, which are the fields affected by EmptyAsSet. Members of oneofs are only hashed when they are set.
+// The generator uses it to choose the fields affected by the empty_as_set plugin parameter.
+func EmptyModeApplies(fd protoreflect.FieldDescriptor) bool {
+	if fd.Kind() != protoreflect.BytesKind || fd.IsList() || !fd.HasPresence() {
+		return false
+	}
+
+	od := fd.ContainingOneof()
+	return od == nil || od.IsSynthetic()
+}
@@ -0,0 +1,112 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestWithEmptyMode(t *testing.T) {
+	sum := func(m proto.Message, opts ...hashpb.Option) []byte {
+		t.Helper()
+
+		digest, err := hashpb.Sum(nil, m, opts...)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		return digest
+	}
+
+	unset := &pb.TestAllTypesOptional{}
+	empty := &pb.TestAllTypesOptional{SingleBytes: []byte{}}
+
+	if !bytes.Equal(sum(unset), sum(empty)) {
+		t.Error("Expected unset and empty bytes to hash identically with EmptyAsUnset")
+	}
+
+	asSet := hashpb.WithEmptyMode(hashpb.EmptyAsSet)
+	if bytes.Equal(sum(unset, asSet), sum(empty, asSet)) {
+		t.Error("Expected unset and empty bytes to hash differently with EmptyAsSet")
+	}
+
+	if !bytes.Equal(sum(empty), sum(empty, asSet)) {
+		t.Error("Expected empty bytes that are set to hash identically in both modes")
+	}
+
+	t.Run("implicit presence", func(t *testing.T) {
+		for _, m := range []hashpb.EmptyMode{hashpb.EmptyAsUnset, hashpb.EmptyAsSet} {
+			opt := hashpb.WithEmptyMode(m)
+			if !bytes.Equal(sum(&pb.TestAllTypes{}, opt), sum(&pb.TestAllTypes{SingleBytes: []byte{}, RepeatedBytes: [][]byte{}}, opt)) {
+				t.Errorf("Expected nil and empty values to hash identically with mode %v", m)
+			}
+		}
+	})
+
+	// Repeated and map fields have no presence, so the empty mode deliberately doesn't apply to them: nil and empty
+	// values hash identically in both modes, with reflection and with the generated methods.
+	t.Run("repeated fields", func(t *testing.T) {
+		nilFields := &pb.TestAllTypes{}
+		emptyFields := &pb.TestAllTypes{
+			RepeatedBytes:         [][]byte{},
+			RepeatedInt32:         []int32{},
+			RepeatedNestedMessage: []*pb.TestAllTypes_NestedMessage{},
+			MapInt32String:        map[int32]string{},
+		}
+
+		for _, m := range []hashpb.EmptyMode{hashpb.EmptyAsUnset, hashpb.EmptyAsSet} {
+			opt := hashpb.WithEmptyMode(m)
+			if !bytes.Equal(sum(nilFields, opt), sum(emptyFields, opt)) {
+				t.Errorf("Expected nil and empty repeated fields to hash identically with mode %v", m)
+			}
+		}
+
+		generated := func(m *pb.TestAllTypes) []byte {
+			hasher := sha256.New()
+			m.HashPB(hasher, nil)
+			return hasher.Sum(nil)
+		}
+
+		if !bytes.Equal(generated(nilFields), generated(emptyFields)) {
+			t.Error("Expected nil and empty repeated fields to hash identically with the generated methods")
+		}
+	})
+
+	t.Run("objecthash", func(t *testing.T) {
+		for _, m := range []proto.Message{unset, empty} {
+			scheme := hashpb.WithScheme(hashpb.SchemeObjectHash)
+			if !bytes.Equal(sum(m, scheme), sum(m, scheme, asSet)) {
+				t.Errorf("Expected both modes to produce the same objecthash digest for %v", m)
+			}
+		}
+	})
+}
+
+func TestEmptyModeApplies(t *testing.T) {
+	testCases := []struct {
+		msg   proto.Message
+		field string
+		want  bool
+	}{
+		{msg: &pb.TestAllTypesOptional{}, field: "single_bytes", want: true},
+		{msg: &pb.TestAllTypesOptional{}, field: "single_string", want: false},
+		{msg: &pb.TestAllTypes{}, field: "single_bytes", want: false},
+		{msg: &pb.TestAllTypes{}, field: "repeated_bytes", want: false},
+	}
+
+	for _, tc := range testCases {
+		md := tc.msg.ProtoReflect().Descriptor()
+		fd := md.Fields().ByName(protoreflect.Name(tc.field))
+		if have := hashpb.EmptyModeApplies(fd); have != tc.want {
+			t.Errorf("EmptyModeApplies(%s): want=%t have=%t", fd.FullName(), tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// ErrInvalidMessage is returned when a message can't be hashed or compared, for example because it is nil or it is
+	// not of the expected type.
+	ErrInvalidMessage = errors.New("invalid message")
+	// ErrUnsupportedHash is returned when the hash function or the scheme doesn't support the operation, for example
+	// when Sum64 is called with a hash function that doesn't implement hash.Hash64.
+	ErrUnsupportedHash = errors.New("unsupported hash function")
+	// ErrUnsupportedKind is returned when a field has a kind that can't be hashed.
+	ErrUnsupportedKind = errors.New("unsupported field kind")
+)
+
+var errNilMessage = fmt.Errorf("%w: message is nil", ErrInvalidMessage)
+
+// FieldError is returned by the hashing functions when the value of a field can't be hashed. Use errors.As to get the
+// location of the field and errors.Is to check the cause, such as ErrInvalidUTF8 or ErrMaxDepth.
+type FieldError struct {
+	// Err is the cause of the error.
+	Err error
+	// Message is the fully-qualified name of the message that Path starts at, which is the message being hashed.
+	Message protoreflect.FullName
+	// Path is the path of the field from Message, in the same syntax as the paths returned by Diff, for example
+	// payload.items[2].labels["env"].
+	Path string
+	// Kind is the kind of the field that failed to hash. For lists and maps, it is the kind of the elements or values.
+	Kind protoreflect.Kind
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError prepends the name of the field to the path of err. It returns nil if err is nil.
+func fieldError(err error, fd protoreflect.FieldDescriptor) error {
+	if err == nil {
+		return nil
+	}
+
+	fe := prependPath(err, string(fd.Name()))
+	fe.Message = fd.ContainingMessage().FullName()
+	switch {
+	case fe.Kind != 0:
+	case fd.IsMap():
+		fe.Kind = fd.MapValue().Kind()
+	default:
+		fe.Kind = fd.Kind()
+	}
+
+	return fe
+}
+
+// indexError prepends the index of a list element to the path of err. It returns nil if err is nil.
+func indexError(err error, i int) error {
+	if err == nil {
+		return nil
+	}
+
+	return prependPath(err, fmt.Sprintf("[%d]", i))
+}
+
+// mapKeyError prepends the key of a map entry to the path of err. It returns nil if err is nil.
+func mapKeyError(err error, fd protoreflect.FieldDescriptor, k protoreflect.MapKey) error {
+	if err == nil {
+		return nil
+	}
+
+	return prependPath(err, mapKeyPath("", fd.MapKey(), k))
+}
+
+func prependPath(err error, segment string) *FieldError {
+	// the path is only extended if the error comes straight from the nested field, so that errors wrapped with more
+	// context on the way up keep the path they were given.
+	fe, ok := err.(*FieldError)
+	if !ok {
+		return &FieldError{Err: err, Path: segment}
+	}
+
+	ext := *fe
+	if strings.HasPrefix(fe.Path, "[") {
+		ext.Path = segment + fe.Path
+	} else {
+		ext.Path = segment + "." + fe.Path
+	}
+
+	return &ext
+}
@@ -0,0 +1,82 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	if _, err := hashpb.Sum(nil, nil); !errors.Is(err, hashpb.ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage for nil message, got %v", err)
+	}
+
+	if _, err := hashpb.Diff(&pb.TestAllTypes{}, &pb.NestedTestAllTypes{}); !errors.Is(err, hashpb.ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage for mismatched types, got %v", err)
+	}
+
+	if _, err := hashpb.Sum64(&pb.TestAllTypes{}, hashpb.WithHash(sha256.New)); !errors.Is(err, hashpb.ErrUnsupportedHash) {
+		t.Errorf("Expected ErrUnsupportedHash for a hash function without Sum64, got %v", err)
+	}
+
+	if _, err := hashpb.Sum64(&pb.TestAllTypes{}, hashpb.WithScheme(hashpb.SchemeObjectHash)); !errors.Is(err, hashpb.ErrUnsupportedHash) {
+		t.Errorf("Expected ErrUnsupportedHash for objecthash Sum64, got %v", err)
+	}
+
+	if !errors.Is(hashpb.ErrNotResumable, hashpb.ErrUnsupportedHash) {
+		t.Error("Expected ErrNotResumable to be an ErrUnsupportedHash")
+	}
+}
+
+func TestErrorPath(t *testing.T) {
+	invalid := string([]byte{0xff, 'a'})
+
+	testCases := []struct {
+		msg  proto.Message
+		path string
+	}{
+		{
+			msg:  &pb.NestedTestAllTypes{Child: &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleString: invalid}}},
+			path: "child.payload.single_string",
+		},
+		{
+			msg:  &pb.NestedTestAllTypes{Child: &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{RepeatedString: []string{"a", invalid}}}},
+			path: "child.payload.repeated_string[1]",
+		},
+		{
+			msg:  &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{MapInt32String: map[int32]string{1: "a", -2: invalid}}},
+			path: "payload.map_int32_string[-2]",
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+			_, err := hashpb.Sum(nil, tc.msg, hashpb.WithScheme(scheme), hashpb.WithStrictUTF8())
+			if !errors.Is(err, hashpb.ErrInvalidUTF8) {
+				t.Fatalf("Expected ErrInvalidUTF8 with scheme %v, got %v", scheme, err)
+			}
+
+			if !strings.HasPrefix(err.Error(), tc.path+": ") {
+				t.Errorf("Expected error with scheme %v to start with %q: %v", scheme, tc.path, err)
+			}
+
+			var fe *hashpb.FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Expected a FieldError, got %T", err)
+			}
+
+			if fe.Message != "cerbos.hashpb.test.NestedTestAllTypes" || fe.Path != tc.path || fe.Kind != protoreflect.StringKind {
+				t.Errorf("Unexpected FieldError with scheme %v: %+v", scheme, fe)
+			}
+		}
+	}
+}
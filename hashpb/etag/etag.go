@@ -0,0 +1,161 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package etag derives HTTP entity tags from the hashpb digests of response messages.
+//
+// Plain HTTP handlers can call Check before writing the response. grpc-gateway servers can wrap the gateway mux with
+// Middleware and register ForwardResponseOption using runtime.WithForwardResponseOption.
+package etag
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+type options struct {
+	hashOpts []hashpb.Option
+}
+
+type Option func(*options)
+
+// WithHashOptions sets the options used to hash response messages. Use hashpb.WithIgnore to exclude volatile fields
+// such as generation timestamps from the entity tag.
+func WithHashOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.hashOpts = opts
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Of returns the strong entity tag of msg, including the surrounding quotes.
+func Of(msg proto.Message, opts ...Option) (string, error) {
+	digest, err := hashpb.Sum(nil, msg, newOptions(opts).hashOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	return `"` + hex.EncodeToString(digest) + `"`, nil
+}
+
+// Check sets the ETag header of the response to the entity tag of msg. If the request is a GET or HEAD request with an
+// If-None-Match header matching the entity tag, Check writes a 304 Not Modified status and returns true. In that case,
+// the handler must not write the response body.
+func Check(w http.ResponseWriter, r *http.Request, msg proto.Message, opts ...Option) (bool, error) {
+	tag, err := Of(msg, opts...)
+	if err != nil {
+		return false, err
+	}
+
+	w.Header().Set("ETag", tag)
+	if !notModified(r.Method, r.Header.Get("If-None-Match"), tag) {
+		return false, nil
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true, nil
+}
+
+// notModified reports whether a request with the given method and If-None-Match header should get a 304 response.
+// If-None-Match uses weak comparison, so weak validators match the strong tags produced by Of.
+func notModified(method, ifNoneMatch, tag string) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+type stateKey struct{}
+
+// state records the conditional request headers and whether the response was found to be unmodified.
+type state struct {
+	method      string
+	ifNoneMatch string
+	notModified bool
+}
+
+// Middleware makes the request's conditional headers available to ForwardResponseOption and turns the response into
+// a 304 Not Modified response, without a body, when the entity tag matches.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := &state{method: r.Method, ifNoneMatch: r.Header.Get("If-None-Match")}
+		next.ServeHTTP(&responseWriter{ResponseWriter: w, state: s}, r.WithContext(context.WithValue(r.Context(), stateKey{}, s)))
+	})
+}
+
+// ForwardResponseOption returns a grpc-gateway forward response option that sets the ETag header of unary responses.
+// It must be used together with Middleware to respond with 304 Not Modified to matching conditional requests.
+func ForwardResponseOption(opts ...Option) func(context.Context, http.ResponseWriter, proto.Message) error {
+	return func(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+		tag, err := Of(msg, opts...)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("ETag", tag)
+		if s, ok := ctx.Value(stateKey{}).(*state); ok {
+			s.notModified = notModified(s.method, s.ifNoneMatch, tag)
+		}
+
+		return nil
+	}
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	state       *state
+	wroteHeader bool
+	discard     bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if w.state.notModified && code == http.StatusOK {
+		code = http.StatusNotModified
+		w.discard = true
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.discard {
+		return len(b), nil
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package etag_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/etag"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const ignoreField = "cerbos.hashpb.test.TestAllTypes.single_timestamp"
+
+func TestCheck(t *testing.T) {
+	msg := &pb.TestAllTypes{SingleString: "wibble"}
+	tag, err := etag.Of(msg)
+	if err != nil {
+		t.Fatalf("Failed to compute entity tag: %v", err)
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notModified, err := etag.Check(w, r, msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if !notModified {
+			_, _ = w.Write([]byte("wibble"))
+		}
+	})
+
+	runCases(t, handler, tag)
+}
+
+func TestMiddleware(t *testing.T) {
+	msg := &pb.TestAllTypes{SingleString: "wibble"}
+	tag, err := etag.Of(msg, etag.WithHashOptions(hashpb.WithIgnore(ignoreField)))
+	if err != nil {
+		t.Fatalf("Failed to compute entity tag: %v", err)
+	}
+
+	// Mimics what grpc-gateway does with forward response options.
+	forward := etag.ForwardResponseOption(etag.WithHashOptions(hashpb.WithIgnore(ignoreField)))
+	handler := etag.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := &pb.TestAllTypes{SingleString: "wibble"}
+		if r.URL.Query().Has("volatile") {
+			resp.SingleTimestamp = timestamppb.Now()
+		}
+
+		if err := forward(r.Context(), w, resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := protojson.Marshal(resp)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+
+	runCases(t, handler, tag)
+
+	req := httptest.NewRequest(http.MethodGet, "/?volatile", nil)
+	req.Header.Set("If-None-Match", tag)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Ignored field changed the entity tag: status %d", rec.Code)
+	}
+}
+
+func runCases(t *testing.T, handler http.Handler, tag string) {
+	t.Helper()
+
+	testCases := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "unconditional", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "match", method: http.MethodGet, ifNoneMatch: tag, wantStatus: http.StatusNotModified},
+		{name: "weak_match", method: http.MethodHead, ifNoneMatch: `"wibble", W/` + tag, wantStatus: http.StatusNotModified},
+		{name: "wildcard", method: http.MethodGet, ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+		{name: "mismatch", method: http.MethodGet, ifNoneMatch: `"wibble"`, wantStatus: http.StatusOK},
+		{name: "unsafe_method", method: http.MethodPost, ifNoneMatch: tag, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/", nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("Unexpected status: want=%d have=%d", tc.wantStatus, rec.Code)
+			}
+
+			if have := rec.Header().Get("ETag"); have != tag {
+				t.Errorf("Unexpected ETag: want=%s have=%s", tag, have)
+			}
+
+			if tc.wantStatus == http.StatusNotModified && rec.Body.Len() > 0 {
+				t.Errorf("Unexpected body in 304 response: %q", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FilteredHashable is implemented by messages with HashPBFiltered methods generated by protoc-gen-go-hashpb using the
+// filtered=true parameter. The method produces the same digest as hashing the message with a WithFieldFilter predicate
+// that calls the filter with the fully-qualified name of the field.
+type FilteredHashable interface {
+	HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool)
+}
+
+// WithFieldFilter excludes the fields for which the filter returns false from the hash, in the same way as the fields
+// set using WithIgnore. It is called with every field of the message and of the messages nested in it (for oneofs,
+// with the member that is set), so inclusion rules based on field options, names or types can be expressed without
+// building the ignore set of every reachable message up front. The filter must be deterministic and, when used with
+// WithParallel, safe for concurrent use.
+// The generated methods can't call the filter, so SumAuto and Sum64Auto use reflection when it is set.
+func WithFieldFilter(filter func(protoreflect.FieldDescriptor) bool) Option {
+	return func(o *Options) {
+		o.fieldFilter = filter
+	}
+}
+
+// fieldExcluded reports whether the field is in the ignore set or rejected by the filter.
+func fieldExcluded(ignore map[string]struct{}, filter func(protoreflect.FieldDescriptor) bool, fd protoreflect.FieldDescriptor) bool {
+	return fieldIgnored(ignore, fd) || (filter != nil && !filter(fd))
+}
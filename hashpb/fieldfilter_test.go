@@ -0,0 +1,57 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestWithFieldFilter(t *testing.T) {
+	filter := hashpb.WithFieldFilter(func(fd protoreflect.FieldDescriptor) bool {
+		return fd.Name() != "single_int32" && fd.Name() != "single_nested_enum"
+	})
+
+	msg := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt32: 1, SingleString: "x", NestedType: &pb.TestAllTypes_SingleNestedEnum{SingleNestedEnum: pb.TestAllTypes_BAR}},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 2}},
+	}
+	changed := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt32: 3, SingleString: "x"},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 4}},
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		want, err := hashpb.Sum(nil, changed, hashpb.WithScheme(scheme), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_int32"))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		for _, input := range []*pb.NestedTestAllTypes{msg, changed} {
+			have, err := hashpb.SumAuto(nil, input, hashpb.WithScheme(scheme), filter)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if !bytes.Equal(want, have) {
+				t.Errorf("Hash mismatch with scheme %v: want=%x have=%x", scheme, want, have)
+			}
+		}
+	}
+
+	t.Run("diff", func(t *testing.T) {
+		paths, err := hashpb.Diff(msg, changed, filter)
+		if err != nil {
+			t.Fatalf("Failed to diff: %v", err)
+		}
+
+		if len(paths) != 0 {
+			t.Errorf("Expected no differences, got %v", paths)
+		}
+	})
+}
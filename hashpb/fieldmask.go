@@ -0,0 +1,34 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const fieldMaskName protoreflect.FullName = "google.protobuf.FieldMask"
+
+// WithCanonicalFieldMasks hashes google.protobuf.FieldMask values in their canonical form, with the paths sorted and
+// the duplicate paths and the paths covered by a shorter path removed, so that masks selecting the same fields have the
+// same digest regardless of the order in which the paths were listed. The paths are not validated against any message
+// type. The generated methods hash the paths as they are, so SumAuto and Sum64Auto use reflection when it is set.
+func WithCanonicalFieldMasks() Option {
+	return func(o *Options) {
+		WithCanonicalizer(fieldMaskName, canonicalFieldMask)(o)
+	}
+}
+
+// canonicalFieldMask returns the field mask m in canonical form.
+func canonicalFieldMask(m protoreflect.Message) protoreflect.Message {
+	fd := m.Descriptor().Fields().ByName("paths")
+	list := m.Get(fd).List()
+	fm := &fieldmaskpb.FieldMask{Paths: make([]string, list.Len())}
+	for i := range fm.Paths {
+		fm.Paths[i] = list.Get(i).String()
+	}
+	fm.Normalize()
+
+	return fm.ProtoReflect()
+}
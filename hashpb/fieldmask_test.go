@@ -0,0 +1,48 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestWithCanonicalFieldMasks(t *testing.T) {
+	want := &fieldmaskpb.FieldMask{Paths: []string{"a", "b.c", "d"}}
+	same := &fieldmaskpb.FieldMask{Paths: []string{"d", "b.c", "a", "b.c", "a.x"}}
+	different := &fieldmaskpb.FieldMask{Paths: []string{"a", "b", "d"}}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(m proto.Message, opts ...hashpb.Option) []byte {
+			t.Helper()
+
+			digest, err := hashpb.SumAuto(nil, m, append(opts, hashpb.WithScheme(scheme))...)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		if bytes.Equal(sum(want), sum(same)) {
+			t.Errorf("Expected masks to hash differently without the option with scheme %v", scheme)
+		}
+
+		if !bytes.Equal(sum(want, hashpb.WithCanonicalFieldMasks()), sum(same, hashpb.WithCanonicalFieldMasks())) {
+			t.Errorf("Expected equivalent masks to hash the same with scheme %v", scheme)
+		}
+
+		if bytes.Equal(sum(want, hashpb.WithCanonicalFieldMasks()), sum(different, hashpb.WithCanonicalFieldMasks())) {
+			t.Errorf("Expected different masks to hash differently with scheme %v", scheme)
+		}
+	}
+
+	if len(same.Paths) != 5 {
+		t.Error("Expected the hashed message not to be modified")
+	}
+}
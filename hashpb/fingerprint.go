@@ -0,0 +1,262 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SchemaFingerprint returns a fingerprint of the schema of the message and all the messages reachable from it.
+// It covers the field names, numbers, types, cardinalities and oneof memberships that determine how the message is hashed.
+// Store the fingerprint alongside digests to detect digests that were computed under an older version of the schema.
+func SchemaFingerprint(md protoreflect.MessageDescriptor) uint64 {
+	msgs := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	collectSchema(msgs, md)
+
+	names := make([]string, 0, len(msgs))
+	for name := range msgs {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	digest := xxhash.New()
+	_, _ = fmt.Fprintf(digest, "root %s\n", md.FullName())
+	for _, name := range names {
+		writeSchema(digest, msgs[protoreflect.FullName(name)])
+	}
+
+	return digest.Sum64()
+}
+
+func collectSchema(msgs map[protoreflect.FullName]protoreflect.MessageDescriptor, md protoreflect.MessageDescriptor) {
+	if _, ok := msgs[md.FullName()]; ok {
+		return
+	}
+
+	msgs[md.FullName()] = md
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fmd := fields.Get(i).Message(); fmd != nil {
+			collectSchema(msgs, fmd)
+		}
+	}
+}
+
+func writeSchema(w io.Writer, md protoreflect.MessageDescriptor) {
+	fields := sortedFields(md)
+
+	_, _ = fmt.Fprintf(w, "message %s\n", md.FullName())
+	for _, fd := range fields {
+		var typeName protoreflect.FullName
+		switch {
+		case fd.Message() != nil:
+			typeName = fd.Message().FullName()
+		case fd.Enum() != nil:
+			typeName = fd.Enum().FullName()
+		}
+
+		var oneOf protoreflect.Name
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			oneOf = od.Name()
+		}
+
+		_, _ = fmt.Fprintf(w, "field %d %s %s %s %s %s\n", fd.Number(), fd.Name(), fd.Cardinality(), fd.Kind(), typeName, oneOf)
+	}
+}
+
+// FileFingerprint returns a fingerprint of the file and all the files it imports, directly or transitively.
+// It is the same as the fingerprint returned by DescriptorSetFingerprint for a set containing those files.
+func FileFingerprint(fd protoreflect.FileDescriptor) uint64 {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]struct{})
+
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if _, ok := seen[fd.Path()]; ok {
+			return
+		}
+		seen[fd.Path()] = struct{}{}
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	add(fd)
+
+	return DescriptorSetFingerprint(set)
+}
+
+// DescriptorSetFingerprint returns a fingerprint of the files in the set, usable as a schema version identifier.
+// Source code info, the order of the files, the order of the imports and the order of the declarations in each file
+// don't affect the fingerprint, so recompiling the same schema with a different tool, or reformatting it, produces the
+// same fingerprint. The exceptions are oneofs (other than the synthetic oneofs of proto3 optional fields) and enum
+// values, whose order is part of the schema.
+func DescriptorSetFingerprint(set *descriptorpb.FileDescriptorSet) uint64 {
+	files := make([]*descriptorpb.FileDescriptorProto, 0, len(set.GetFile()))
+	for _, f := range set.GetFile() {
+		f = proto.Clone(f).(*descriptorpb.FileDescriptorProto)
+		normalizeFile(f)
+		files = append(files, f)
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].GetName() < files[j].GetName() })
+
+	digest := xxhash.New()
+	for _, f := range files {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(f)
+		if err != nil {
+			// A descriptor that was cloned from a valid message can always be marshalled.
+			panic(fmt.Errorf("failed to marshal %s: %w", f.GetName(), err))
+		}
+
+		_, _ = fmt.Fprintf(digest, "file %s %d\n", f.GetName(), len(data))
+		_, _ = digest.Write(data)
+	}
+
+	return digest.Sum64()
+}
+
+// normalizeFile removes the parts of the file that don't change the schema and sorts the declarations whose order doesn't matter.
+// Oneofs and enum values keep their order because fields refer to oneofs by index and the first enum value is the default in proto2.
+// Synthetic oneofs are the exception: they follow the order of their fields, so they are sorted too.
+func normalizeFile(f *descriptorpb.FileDescriptorProto) {
+	f.SourceCodeInfo = nil
+	normalizeDependencies(f)
+
+	sortByName(f.MessageType)
+	sortByName(f.EnumType)
+	sortByName(f.Service)
+	normalizeFields(f.Extension)
+	sortByName(f.Extension)
+
+	for _, md := range f.MessageType {
+		normalizeMessage(md)
+	}
+
+	for _, sd := range f.Service {
+		sortByName(sd.Method)
+	}
+}
+
+// normalizeDependencies sorts the imports of the file and updates the indexes of the public and weak imports to match.
+func normalizeDependencies(f *descriptorpb.FileDescriptorProto) {
+	deps := make([]string, len(f.Dependency))
+	copy(deps, f.Dependency)
+	sort.Strings(deps)
+
+	index := make(map[string]int32, len(deps))
+	for i, dep := range deps {
+		index[dep] = int32(i)
+	}
+
+	remap := func(indexes []int32) {
+		for i, idx := range indexes {
+			if idx >= 0 && int(idx) < len(f.Dependency) {
+				indexes[i] = index[f.Dependency[idx]]
+			}
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	}
+	remap(f.PublicDependency)
+	remap(f.WeakDependency)
+
+	f.Dependency = deps
+}
+
+// normalizeSyntheticOneofs sorts the synthetic oneofs of proto3 optional fields, which always follow the other oneofs,
+// by name and updates the oneof indexes of the fields to match.
+func normalizeSyntheticOneofs(md *descriptorpb.DescriptorProto) {
+	synthetic := make(map[int32]bool)
+	for _, fd := range md.Field {
+		if fd.GetProto3Optional() && fd.OneofIndex != nil {
+			synthetic[fd.GetOneofIndex()] = true
+		}
+	}
+
+	if len(synthetic) < 2 {
+		return
+	}
+
+	first := int32(len(md.OneofDecl) - len(synthetic))
+	for i := first; i < int32(len(md.OneofDecl)); i++ {
+		if !synthetic[i] {
+			// Not the layout produced by compilers, so leave it alone.
+			return
+		}
+	}
+
+	sorted := make([]*descriptorpb.OneofDescriptorProto, len(md.OneofDecl)-int(first))
+	copy(sorted, md.OneofDecl[first:])
+	sortByName(sorted)
+
+	index := make(map[string]int32, len(sorted))
+	for i, od := range sorted {
+		index[od.GetName()] = first + int32(i)
+	}
+
+	for _, fd := range md.Field {
+		if fd.OneofIndex != nil && fd.GetOneofIndex() >= first {
+			fd.OneofIndex = proto.Int32(index[md.OneofDecl[fd.GetOneofIndex()].GetName()])
+		}
+	}
+
+	copy(md.OneofDecl[first:], sorted)
+}
+
+func normalizeMessage(md *descriptorpb.DescriptorProto) {
+	normalizeFields(md.Field)
+	normalizeSyntheticOneofs(md)
+	sort.SliceStable(md.Field, func(i, j int) bool { return md.Field[i].GetNumber() < md.Field[j].GetNumber() })
+
+	sortByName(md.NestedType)
+	sortByName(md.EnumType)
+	normalizeFields(md.Extension)
+	sortByName(md.Extension)
+
+	for _, nested := range md.NestedType {
+		normalizeMessage(nested)
+	}
+}
+
+// normalizeFields drops JSON names that are the same as the default ones, because compilers disagree on whether to populate them.
+func normalizeFields(fields []*descriptorpb.FieldDescriptorProto) {
+	for _, fd := range fields {
+		if fd.JsonName != nil && fd.GetJsonName() == defaultJSONName(fd.GetName()) {
+			fd.JsonName = nil
+		}
+	}
+}
+
+func defaultJSONName(name string) string {
+	var sb strings.Builder
+	upper := false
+	for _, c := range name {
+		switch {
+		case c == '_':
+			upper = true
+		case upper && 'a' <= c && c <= 'z':
+			sb.WriteRune(c - 'a' + 'A')
+			upper = false
+		default:
+			sb.WriteRune(c)
+			upper = false
+		}
+	}
+
+	return sb.String()
+}
+
+func sortByName[T interface{ GetName() string }](decls []T) {
+	sort.SliceStable(decls, func(i, j int) bool { return decls[i].GetName() < decls[j].GetName() })
+}
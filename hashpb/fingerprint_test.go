@@ -0,0 +1,131 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFileFingerprint(t *testing.T) {
+	if have := hashpb.FileFingerprint(pb.File_internal_pb_all_types_proto); have != pb.File_internal_pb_all_types_proto_HashPBSchemaFingerprint {
+		t.Errorf("Fingerprint mismatch: want=%x have=%x", pb.File_internal_pb_all_types_proto_HashPBSchemaFingerprint, have)
+	}
+}
+
+func TestDescriptorSetFingerprint(t *testing.T) {
+	set := descset.Build(pb.File_internal_pb_all_types_proto)
+	want := hashpb.DescriptorSetFingerprint(set)
+
+	t.Run("file order", func(t *testing.T) {
+		reordered := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		files := reordered.File
+		for i, j := 0, len(files)-1; i < j; i, j = i+1, j-1 {
+			files[i], files[j] = files[j], files[i]
+		}
+
+		if have := hashpb.DescriptorSetFingerprint(reordered); have != want {
+			t.Errorf("Fingerprint changed after reordering files: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("declaration order and source info", func(t *testing.T) {
+		reordered := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		file := reordered.File[len(reordered.File)-1]
+		msgs := file.MessageType
+		msgs[0], msgs[len(msgs)-1] = msgs[len(msgs)-1], msgs[0]
+		fields := msgs[0].Field
+		fields[0], fields[len(fields)-1] = fields[len(fields)-1], fields[0]
+		for _, fd := range fields {
+			fd.JsonName = proto.String(fd.GetJsonName())
+		}
+		file.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{{Path: []int32{4, 0}, Span: []int32{1, 0, 10}}},
+		}
+
+		if have := hashpb.DescriptorSetFingerprint(reordered); have != want {
+			t.Errorf("Fingerprint changed after reordering declarations: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("import order", func(t *testing.T) {
+		reordered := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		file := reordered.File[len(reordered.File)-1]
+		deps := file.Dependency
+		if len(deps) < 2 {
+			t.Fatalf("Expected %s to have several imports", file.GetName())
+		}
+
+		public := deps[0]
+		for i, j := 0, len(deps)-1; i < j; i, j = i+1, j-1 {
+			deps[i], deps[j] = deps[j], deps[i]
+		}
+		file.PublicDependency = []int32{int32(len(deps) - 1)}
+
+		original := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		original.File[len(original.File)-1].PublicDependency = []int32{0}
+		if original.File[len(original.File)-1].Dependency[0] != public {
+			t.Fatal("Unexpected import order")
+		}
+
+		if want, have := hashpb.DescriptorSetFingerprint(original), hashpb.DescriptorSetFingerprint(reordered); have != want {
+			t.Errorf("Fingerprint changed after reordering imports: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("proto3 optional field order", func(t *testing.T) {
+		reordered := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		md := findMessage(t, reordered.File[len(reordered.File)-1], "TestAllTypesOptional")
+
+		// Swap the first and last proto3 optional fields along with their synthetic oneofs, as a compiler would if
+		// their declarations were swapped.
+		var optional []int
+		for i, fd := range md.Field {
+			if fd.GetProto3Optional() {
+				optional = append(optional, i)
+			}
+		}
+		if len(optional) < 2 {
+			t.Fatalf("Expected %s to have several proto3 optional fields", md.GetName())
+		}
+
+		a, b := md.Field[optional[0]], md.Field[optional[len(optional)-1]]
+		ai, bi := a.GetOneofIndex(), b.GetOneofIndex()
+		md.OneofDecl[ai], md.OneofDecl[bi] = md.OneofDecl[bi], md.OneofDecl[ai]
+		a.OneofIndex, b.OneofIndex = proto.Int32(bi), proto.Int32(ai)
+		md.Field[optional[0]], md.Field[optional[len(optional)-1]] = b, a
+
+		if have := hashpb.DescriptorSetFingerprint(reordered); have != want {
+			t.Errorf("Fingerprint changed after reordering proto3 optional fields: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("schema change", func(t *testing.T) {
+		changed := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+		file := changed.File[len(changed.File)-1]
+		file.MessageType[0].Field[0].Name = proto.String("renamed")
+
+		if have := hashpb.DescriptorSetFingerprint(changed); have == want {
+			t.Error("Expected fingerprint to change after renaming a field")
+		}
+	})
+}
+
+func findMessage(t *testing.T, file *descriptorpb.FileDescriptorProto, name string) *descriptorpb.DescriptorProto {
+	t.Helper()
+
+	for _, md := range file.MessageType {
+		if md.GetName() == name {
+			return md
+		}
+	}
+
+	t.Fatalf("Message %s not found in %s", name, file.GetName())
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProto2Group(t *testing.T) {
+	md := proto2GroupDescriptor(t)
+	group := md.Fields().ByName("result")
+	repeated := md.Fields().ByName("item")
+	if group.Kind() != protoreflect.GroupKind || repeated.Kind() != protoreflect.GroupKind {
+		t.Fatalf("Expected group fields, got %s and %s", group.Kind(), repeated.Kind())
+	}
+
+	mkMsg := func(values ...string) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(md)
+		for i, v := range values {
+			inner := dynamicpb.NewMessage(group.Message())
+			inner.Set(group.Message().Fields().ByName("url"), protoreflect.ValueOfString(v))
+			if i == 0 {
+				msg.Set(group, protoreflect.ValueOfMessage(inner))
+				continue
+			}
+
+			item := dynamicpb.NewMessage(repeated.Message())
+			item.Set(repeated.Message().Fields().ByName("name"), protoreflect.ValueOfString(v))
+			msg.Mutable(repeated).List().Append(protoreflect.ValueOfMessage(item))
+		}
+
+		return msg
+	}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		digests := make(map[string][]byte)
+		for name, msg := range map[string]*dynamicpb.Message{
+			"a":   mkMsg("a"),
+			"b":   mkMsg("b"),
+			"a,x": mkMsg("a", "x"),
+			"a,y": mkMsg("a", "y"),
+		} {
+			digest, err := hashpb.Sum(nil, msg, hashpb.WithScheme(scheme))
+			if err != nil {
+				t.Fatalf("Failed to hash %s: %v", name, err)
+			}
+
+			for other, d := range digests {
+				if bytes.Equal(d, digest) {
+					t.Errorf("Messages %s and %s have the same digest with scheme %v", name, other, scheme)
+				}
+			}
+			digests[name] = digest
+		}
+
+		ignored, err := hashpb.Sum(nil, mkMsg("a", "x"), hashpb.WithScheme(scheme), hashpb.WithIgnore("g.M.item"))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if !bytes.Equal(ignored, digests["a"]) {
+			t.Errorf("Ignored group field contributed to the digest with scheme %v", scheme)
+		}
+	}
+}
+
+// proto2GroupDescriptor returns the descriptor of a proto2 message with a singular and a repeated group.
+//
+//	message M {
+//	  optional group Result = 1 { optional string url = 2; }
+//	  repeated group Item = 3 { optional string name = 4; }
+//	}
+func proto2GroupDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		}
+
+		return fd
+	}
+
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("g.proto"),
+		Package: proto.String("g"),
+		Syntax:  proto.String("proto2"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("M"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("result", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_GROUP, ".g.M.Result"),
+				field("item", 3, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, descriptorpb.FieldDescriptorProto_TYPE_GROUP, ".g.M.Item"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				{
+					Name:  proto.String("Result"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("url", 2, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+				},
+				{
+					Name:  proto.String("Item"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("name", 4, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+				},
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	return fd.Messages().Get(0)
+}
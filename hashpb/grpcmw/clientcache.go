@@ -0,0 +1,227 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcmw
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultCacheTTL is the default time for which responses are cached by UnaryClientCacheInterceptor.
+const DefaultCacheTTL = time.Minute
+
+// ErrNotFound is returned by response stores when there is no response for the requested key.
+var ErrNotFound = errors.New("response not found")
+
+// ResponseStore stores serialized responses. Keys are made of the full method name and the hex-encoded request digest
+// separated by @, followed by @ and the key extension if WithCacheKeyFunc returns one.
+type ResponseStore interface {
+	// Get returns the response stored under the given key or an error wrapping ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores the response under the given key for the given time.
+	Put(ctx context.Context, key string, response []byte, ttl time.Duration) error
+}
+
+// WithCacheTTL sets the time for which UnaryClientCacheInterceptor caches responses. Defaults to DefaultCacheTTL.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithCachedMethods restricts UnaryClientCacheInterceptor to caching the responses of the given full method names
+// (for example, /my.pkg.MyService/GetThing). By default, the responses of all methods are cached.
+func WithCachedMethods(methods ...string) Option {
+	return func(o *options) {
+		if o.cachedMethods == nil {
+			o.cachedMethods = make(map[string]struct{}, len(methods))
+		}
+
+		for _, m := range methods {
+			o.cachedMethods[m] = struct{}{}
+		}
+	}
+}
+
+// WithCacheKeyFunc adds the string returned by fn for the context of each call to the keys of the cached responses.
+// Use it to keep apart the responses of calls that are made on behalf of different callers, for example by returning
+// the tenant or the subject of the credentials found in the outgoing metadata. An empty string adds nothing to the key.
+func WithCacheKeyFunc(fn func(ctx context.Context) string) Option {
+	return func(o *options) {
+		o.cacheKeyFn = fn
+	}
+}
+
+// WithCacheErrorHandler sets the function called with the full method name when UnaryClientCacheInterceptor fails to
+// hash a request or to get, decode or store a cached response. The call is sent to the server as if caching was
+// disabled. By default, these errors are ignored.
+func WithCacheErrorHandler(fn func(ctx context.Context, method string, err error)) Option {
+	return func(o *options) {
+		o.cacheErrFn = fn
+	}
+}
+
+// UnaryClientCacheInterceptor returns a client interceptor that caches responses in the store, keyed by the method
+// name and the request digest, so that identical queries are not sent to the server again while the cached response is
+// fresh. Use WithRequestOptions to exclude fields that don't affect the response, such as trace IDs, from the digest.
+// Requests are hashed using SHA-256 unless the options set another hash function. Only the responses of successful
+// calls are cached. Header and trailer call options are not populated for responses served from the cache.
+//
+// The keys don't include the outgoing metadata of the calls, so calls made with different credentials or on behalf of
+// different tenants share cached responses unless WithCacheKeyFunc adds the identity of the caller to the keys.
+// Failures of the store don't fail the calls: they are reported to the function set by WithCacheErrorHandler.
+func UnaryClientCacheInterceptor(store ResponseStore, opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+	hashOpts := append([]hashpb.Option{hashpb.WithHash(sha256.New)}, o.requestOpts...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		reqMsg, reqOK := req.(proto.Message)
+		replyMsg, replyOK := reply.(proto.Message)
+		if !reqOK || !replyOK || !o.cached(method) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		digest, err := hashpb.Sum(nil, reqMsg, hashOpts...)
+		if err != nil {
+			o.cacheError(ctx, method, fmt.Errorf("failed to hash request of %s: %w", method, err))
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		key := method + "@" + hex.EncodeToString(digest)
+		if o.cacheKeyFn != nil {
+			if ext := o.cacheKeyFn(ctx); ext != "" {
+				key += "@" + ext
+			}
+		}
+
+		response, err := store.Get(ctx, key)
+		switch {
+		case err == nil:
+			if err = proto.Unmarshal(response, replyMsg); err == nil {
+				return nil
+			}
+
+			proto.Reset(replyMsg)
+			o.cacheError(ctx, method, fmt.Errorf("failed to unmarshal cached response %s: %w", key, err))
+		case !errors.Is(err, ErrNotFound):
+			o.cacheError(ctx, method, fmt.Errorf("failed to get cached response %s: %w", key, err))
+		}
+
+		if err := invoker(ctx, method, req, reply, cc, callOpts...); err != nil {
+			return err
+		}
+
+		response, err = proto.Marshal(replyMsg)
+		if err != nil {
+			o.cacheError(ctx, method, fmt.Errorf("failed to marshal response of %s: %w", method, err))
+			return nil
+		}
+
+		if err := store.Put(ctx, key, response, o.cacheTTL); err != nil {
+			o.cacheError(ctx, method, fmt.Errorf("failed to cache response %s: %w", key, err))
+		}
+
+		return nil
+	}
+}
+
+func (o *options) cacheError(ctx context.Context, method string, err error) {
+	if o.cacheErrFn != nil {
+		o.cacheErrFn(ctx, method, err)
+	}
+}
+
+func (o *options) cached(method string) bool {
+	if o.cachedMethods == nil {
+		return true
+	}
+
+	_, ok := o.cachedMethods[method]
+	return ok
+}
+
+type storedResponse struct {
+	expires  time.Time
+	response []byte
+}
+
+// MemoryStore is a ResponseStore that keeps responses in memory. Expired responses are removed when they are looked up
+// and, once the earliest response in the store has expired, when a response is stored, so that responses that are never
+// looked up again don't accumulate.
+type MemoryStore struct {
+	responses  map[string]storedResponse
+	nextExpiry time.Time
+	mu         sync.Mutex
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{responses: make(map[string]storedResponse)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.responses[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if time.Now().After(r.expires) {
+		delete(s.responses, key)
+		return nil, ErrNotFound
+	}
+
+	return append([]byte(nil), r.response...), nil
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, response []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if len(s.responses) > 0 && now.After(s.nextExpiry) {
+		s.sweep(now)
+	}
+
+	expires := now.Add(ttl)
+	if len(s.responses) == 0 || expires.Before(s.nextExpiry) {
+		s.nextExpiry = expires
+	}
+
+	s.responses[key] = storedResponse{expires: expires, response: append([]byte(nil), response...)}
+	return nil
+}
+
+// sweep removes the expired responses and finds the next expiry time. It must be called with the lock held.
+func (s *MemoryStore) sweep(now time.Time) {
+	s.nextExpiry = time.Time{}
+	for key, r := range s.responses {
+		if now.After(r.expires) {
+			delete(s.responses, key)
+			continue
+		}
+
+		if s.nextExpiry.IsZero() || r.expires.Before(s.nextExpiry) {
+			s.nextExpiry = r.expires
+		}
+	}
+}
+
+// Len returns the number of responses in the store, including expired responses that haven't been removed yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.responses)
+}
@@ -0,0 +1,209 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcmw_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/grpcmw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type countingServer struct {
+	*health.Server
+	calls atomic.Int32
+}
+
+func (s *countingServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.calls.Add(1)
+	return s.Server.Check(ctx, req)
+}
+
+func TestUnaryClientCacheInterceptor(t *testing.T) {
+	srv := &countingServer{Server: health.NewServer()}
+	srv.SetServingStatus("wibble", healthpb.HealthCheckResponse_SERVING)
+	srv.SetServingStatus("wobble", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	store := grpcmw.NewMemoryStore()
+	client := startCachingClient(t, srv, grpc.WithUnaryInterceptor(grpcmw.UnaryClientCacheInterceptor(store, grpcmw.WithCacheTTL(50*time.Millisecond))))
+
+	check := func(t *testing.T, service string, want healthpb.HealthCheckResponse_ServingStatus, wantCalls int32) {
+		t.Helper()
+
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		if resp.GetStatus() != want {
+			t.Errorf("Unexpected status: want=%s have=%s", want, resp.GetStatus())
+		}
+
+		if have := srv.calls.Load(); have != wantCalls {
+			t.Errorf("Unexpected number of calls to the server: want=%d have=%d", wantCalls, have)
+		}
+	}
+
+	check(t, "wibble", healthpb.HealthCheckResponse_SERVING, 1)
+	check(t, "wibble", healthpb.HealthCheckResponse_SERVING, 1)
+	check(t, "wobble", healthpb.HealthCheckResponse_NOT_SERVING, 2)
+
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 cached responses, got %d", store.Len())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	check(t, "wibble", healthpb.HealthCheckResponse_SERVING, 3)
+
+	if store.Len() != 1 {
+		t.Errorf("Expected expired responses to be removed, got %d cached responses", store.Len())
+	}
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "wubble"}); err == nil {
+		t.Fatal("Expected error for unknown service")
+	}
+
+	if store.Len() != 1 {
+		t.Errorf("Expected failed calls not to be cached, got %d cached responses", store.Len())
+	}
+}
+
+func TestUnaryClientCacheInterceptorOptions(t *testing.T) {
+	srv := &countingServer{Server: health.NewServer()}
+	srv.SetServingStatus("wibble", healthpb.HealthCheckResponse_SERVING)
+	srv.SetServingStatus("wobble", healthpb.HealthCheckResponse_SERVING)
+
+	store := grpcmw.NewMemoryStore()
+	client := startCachingClient(t, srv, grpc.WithUnaryInterceptor(grpcmw.UnaryClientCacheInterceptor(store,
+		grpcmw.WithRequestOptions(hashpb.WithIgnore("grpc.health.v1.HealthCheckRequest.service")),
+		grpcmw.WithCachedMethods(healthpb.Health_Check_FullMethodName),
+	)))
+
+	for _, service := range []string{"wibble", "wobble"} {
+		if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service}); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+
+	if have := srv.calls.Load(); have != 1 {
+		t.Errorf("Expected requests differing only in ignored fields to share a cached response, got %d calls", have)
+	}
+
+	uncached := startCachingClient(t, srv, grpc.WithUnaryInterceptor(grpcmw.UnaryClientCacheInterceptor(store,
+		grpcmw.WithCachedMethods("/my.pkg.MyService/Get"),
+	)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := uncached.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "wibble"}); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+
+	if have := srv.calls.Load(); have != 3 {
+		t.Errorf("Expected responses of other methods not to be cached, got %d calls", have)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func (failingStore) Put(context.Context, string, []byte, time.Duration) error {
+	return errors.New("store unavailable")
+}
+
+func TestUnaryClientCacheInterceptorStoreErrors(t *testing.T) {
+	srv := &countingServer{Server: health.NewServer()}
+	srv.SetServingStatus("wibble", healthpb.HealthCheckResponse_SERVING)
+
+	var cacheErrs atomic.Int32
+	client := startCachingClient(t, srv, grpc.WithUnaryInterceptor(grpcmw.UnaryClientCacheInterceptor(failingStore{},
+		grpcmw.WithCacheErrorHandler(func(context.Context, string, error) { cacheErrs.Add(1) }),
+	)))
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "wibble"})
+		if err != nil {
+			t.Fatalf("Expected store errors not to fail the call: %v", err)
+		}
+
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Unexpected status: %s", resp.GetStatus())
+		}
+	}
+
+	if have := srv.calls.Load(); have != 2 {
+		t.Errorf("Expected calls to be sent to the server, got %d calls", have)
+	}
+
+	if have := cacheErrs.Load(); have != 4 {
+		t.Errorf("Expected 4 store errors to be reported, got %d", have)
+	}
+}
+
+func TestUnaryClientCacheInterceptorKeyFunc(t *testing.T) {
+	srv := &countingServer{Server: health.NewServer()}
+	srv.SetServingStatus("wibble", healthpb.HealthCheckResponse_SERVING)
+
+	const tenantKey = "x-tenant"
+	store := grpcmw.NewMemoryStore()
+	client := startCachingClient(t, srv, grpc.WithUnaryInterceptor(grpcmw.UnaryClientCacheInterceptor(store,
+		grpcmw.WithCacheKeyFunc(func(ctx context.Context) string {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			return strings.Join(md.Get(tenantKey), ",")
+		}),
+	)))
+
+	for _, tenant := range []string{"acme", "acme", "initech"} {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), tenantKey, tenant)
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "wibble"}); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+
+	if have := srv.calls.Load(); have != 2 {
+		t.Errorf("Expected tenants not to share cached responses, got %d calls", have)
+	}
+
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 cached responses, got %d", store.Len())
+	}
+}
+
+func startCachingClient(t *testing.T, srv healthpb.HealthServer, opts ...grpc.DialOption) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, srv)
+
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
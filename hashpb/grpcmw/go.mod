@@ -0,0 +1,20 @@
+module github.com/cerbos/protoc-gen-go-hashpb/hashpb/grpcmw
+
+go 1.19
+
+require (
+	github.com/cerbos/protoc-gen-go-hashpb v0.0.0
+	google.golang.org/grpc v1.62.1
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.20.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80 // indirect
+)
+
+replace github.com/cerbos/protoc-gen-go-hashpb => ../..
@@ -0,0 +1,210 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpcmw provides gRPC server interceptors that compute hashpb digests of requests and responses, and a client
+// interceptor that caches responses keyed by the digests of the requests.
+//
+// The request digest is available to handlers through RequestDigest. Unary interceptors send the request and response
+// digests to the client as header metadata. Stream interceptors send the digest of the last response as trailer
+// metadata. Messages are hashed using the generated methods if they have them (see hashpb.SumAuto).
+package grpcmw
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// RequestDigestKey is the metadata key of the hex-encoded request digest.
+	RequestDigestKey = "x-hashpb-request-digest"
+	// ResponseDigestKey is the metadata key of the hex-encoded response digest.
+	ResponseDigestKey = "x-hashpb-response-digest"
+)
+
+type options struct {
+	requestOpts   []hashpb.Option
+	responseOpts  []hashpb.Option
+	metadata      bool
+	cacheTTL      time.Duration
+	cachedMethods map[string]struct{}
+	cacheKeyFn    func(context.Context) string
+	cacheErrFn    func(context.Context, string, error)
+}
+
+type Option func(*options)
+
+// WithRequestOptions sets the options used to hash requests. For example, use hashpb.WithIgnore to exclude volatile
+// fields such as timestamps or trace IDs from the digest.
+func WithRequestOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.requestOpts = opts
+	}
+}
+
+// WithResponseOptions sets the options used to hash responses.
+func WithResponseOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.responseOpts = opts
+	}
+}
+
+// WithoutMetadata stops the interceptors from sending the digests to the client.
+// The request digest is still available to handlers through RequestDigest.
+func WithoutMetadata() Option {
+	return func(o *options) {
+		o.metadata = false
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{metadata: true, cacheTTL: DefaultCacheTTL}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+type digestKey struct{}
+
+// digestHolder holds the digest of the latest request received by the call.
+type digestHolder struct {
+	digest []byte
+	mu     sync.RWMutex
+}
+
+func (h *digestHolder) set(digest []byte) {
+	h.mu.Lock()
+	h.digest = digest
+	h.mu.Unlock()
+}
+
+func (h *digestHolder) get() []byte {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.digest
+}
+
+// RequestDigest returns the digest of the request being handled. For streaming calls, it is the digest of the latest
+// message received from the client. The second return value is false if no digest has been computed.
+func RequestDigest(ctx context.Context) ([]byte, bool) {
+	h, ok := ctx.Value(digestKey{}).(*digestHolder)
+	if !ok {
+		return nil, false
+	}
+
+	digest := h.get()
+	return digest, digest != nil
+}
+
+// UnaryServerInterceptor returns an interceptor that computes the digests of unary requests and responses. Calls fail if
+// the request can't be hashed, but the response digest is left out of the header if the response can't be hashed.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		h := &digestHolder{}
+		if msg, ok := req.(proto.Message); ok {
+			digest, err := hashpb.SumAuto(nil, msg, o.requestOpts...)
+			if err != nil {
+				return nil, err
+			}
+			h.set(digest)
+		}
+
+		resp, err := handler(context.WithValue(ctx, digestKey{}, h), req)
+		if err != nil || !o.metadata {
+			return resp, err
+		}
+
+		md := metadata.MD{}
+		if digest := h.get(); digest != nil {
+			md.Set(RequestDigestKey, hex.EncodeToString(digest))
+		}
+
+		if msg, ok := resp.(proto.Message); ok {
+			if digest, err := hashpb.SumAuto(nil, msg, o.responseOpts...); err == nil {
+				md.Set(ResponseDigestKey, hex.EncodeToString(digest))
+			}
+		}
+
+		// The digests are informational, so failing to hash the response or to send the header doesn't fail the call.
+		if len(md) > 0 {
+			_ = grpc.SetHeader(ctx, md)
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that computes the digests of the messages of streaming calls. The
+// trailer is set once, when the handler returns, to the digest of the last response sent. It is left out if the last
+// response can't be hashed.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		h := &digestHolder{}
+		s := &serverStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), digestKey{}, h),
+			holder:       h,
+			sent:         &digestHolder{},
+			opts:         o,
+		}
+
+		err := handler(srv, s)
+		if digest := s.sent.get(); digest != nil {
+			ss.SetTrailer(metadata.Pairs(ResponseDigestKey, hex.EncodeToString(digest)))
+		}
+
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	holder *digestHolder
+	sent   *digestHolder
+	opts   *options
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		digest, err := hashpb.SumAuto(nil, msg, s.opts.requestOpts...)
+		if err != nil {
+			return err
+		}
+		s.holder.set(digest)
+	}
+
+	return nil
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok && s.opts.metadata {
+		digest, _ := hashpb.SumAuto(nil, msg, s.opts.responseOpts...)
+		s.sent.set(digest)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcmw_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/grpcmw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type healthServer struct {
+	*health.Server
+	requestDigests chan []byte
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	digest, _ := grpcmw.RequestDigest(ctx)
+	s.requestDigests <- digest
+	return s.Server.Check(ctx, req)
+}
+
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	digest, _ := grpcmw.RequestDigest(stream.Context())
+	s.requestDigests <- digest
+	if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}); err != nil {
+		return err
+	}
+
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING})
+}
+
+func TestInterceptors(t *testing.T) {
+	srv := &healthServer{Server: health.NewServer(), requestDigests: make(chan []byte, 1)}
+	client := startServer(t, srv,
+		grpc.UnaryInterceptor(grpcmw.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpcmw.StreamServerInterceptor()),
+	)
+
+	req := &healthpb.HealthCheckRequest{Service: "wibble"}
+	srv.SetServingStatus("wibble", healthpb.HealthCheckResponse_SERVING)
+
+	t.Run("unary", func(t *testing.T) {
+		var header metadata.MD
+		resp, err := client.Check(context.Background(), req, grpc.Header(&header))
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		wantReq := mustSum(t, req)
+		if have := <-srv.requestDigests; hex.EncodeToString(have) != wantReq {
+			t.Errorf("Unexpected request digest in context: want=%s have=%x", wantReq, have)
+		}
+
+		checkMD(t, header, grpcmw.RequestDigestKey, wantReq)
+		checkMD(t, header, grpcmw.ResponseDigestKey, mustSum(t, resp))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream, err := client.Watch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+
+		var resp *healthpb.HealthCheckResponse
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				break
+			}
+			resp = msg
+		}
+
+		if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Fatalf("Expected to receive both responses, last status is %s", resp.GetStatus())
+		}
+
+		if have, want := hex.EncodeToString(<-srv.requestDigests), mustSum(t, req); have != want {
+			t.Errorf("Unexpected request digest in context: want=%s have=%s", want, have)
+		}
+
+		checkMD(t, stream.Trailer(), grpcmw.ResponseDigestKey, mustSum(t, resp))
+	})
+}
+
+func TestInterceptorOptions(t *testing.T) {
+	srv := &healthServer{Server: health.NewServer(), requestDigests: make(chan []byte, 1)}
+	client := startServer(t, srv, grpc.UnaryInterceptor(grpcmw.UnaryServerInterceptor(
+		grpcmw.WithRequestOptions(hashpb.WithIgnore("grpc.health.v1.HealthCheckRequest.service")),
+		grpcmw.WithoutMetadata(),
+	)))
+
+	var digests [][]byte
+	var header metadata.MD
+	for _, service := range []string{"wibble", "wobble"} {
+		srv.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+		if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service}, grpc.Header(&header)); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+		digests = append(digests, <-srv.requestDigests)
+	}
+
+	if !bytes.Equal(digests[0], digests[1]) {
+		t.Errorf("Ignored field was hashed: %x != %x", digests[0], digests[1])
+	}
+
+	if values := header.Get(grpcmw.ResponseDigestKey); len(values) != 0 {
+		t.Errorf("Unexpected metadata: %v", values)
+	}
+}
+
+func startServer(t *testing.T, srv healthpb.HealthServer, opts ...grpc.ServerOption) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(s, srv)
+
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func checkMD(t *testing.T, md metadata.MD, key, want string) {
+	t.Helper()
+
+	if values := md.Get(key); len(values) != 1 || values[0] != want {
+		t.Errorf("Unexpected %s metadata: want=%s have=%v", key, want, values)
+	}
+}
+
+func mustSum(t *testing.T, msg proto.Message) string {
+	t.Helper()
+
+	digest, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	return hex.EncodeToString(digest)
+}
@@ -0,0 +1,506 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hashpb computes hashes of protobuf messages using protobuf reflection.
+// The traversal order and encoding are identical to the code generated by protoc-gen-go-hashpb,
+// so the digests produced by this package match the ones produced by the generated HashPB methods.
+package hashpb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Options is a set of options for the hashing functions. Create it with NewOptions and pass it to the hashing functions
+// with WithOptions, so that frameworks can build an option set once, inspect it and pass it through layers without
+// applying every option again on each call.
+type Options struct {
+	hashFn         func() hash.Hash
+	ignore         map[string]struct{}
+	observer       func(Observation)
+	scheme         Scheme
+	maxDepth       int
+	hashers        []hash.Hash
+	tee            io.Writer
+	ignoreAs       IgnoreMode
+	defaults       bool
+	presence       bool
+	required       bool
+	decimal        bool
+	nfc            bool
+	parallel       int
+	canonicalizers map[protoreflect.FullName]Canonicalizer
+	wrappers       bool
+	nullAsUnset    bool
+	progress       func(int64)
+	stats          *Stats
+	ignoreMatcher  *IgnoreMatcher
+	shallow        bool
+	strictUTF8     bool
+	emptyAs        EmptyMode
+	fieldFilter    func(protoreflect.FieldDescriptor) bool
+}
+
+// Option configures the behaviour of the hashing functions.
+type Option func(*Options)
+
+// WithHash sets the hash function used to calculate the digest. Defaults to xxhash.
+func WithHash(hashFn func() hash.Hash) Option {
+	return func(o *Options) {
+		o.hashFn = hashFn
+	}
+}
+
+// WithIgnore excludes the given fully-qualified field names (pkg.msg.field) from the hash.
+func WithIgnore(fieldNames ...string) Option {
+	return func(o *Options) {
+		if o.ignore == nil {
+			o.ignore = make(map[string]struct{}, len(fieldNames))
+		}
+
+		for _, fn := range fieldNames {
+			o.ignore[fn] = struct{}{}
+		}
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	o := &Options{hashFn: func() hash.Hash { return xxhash.New() }}
+	return o.Apply(opts...)
+}
+
+// Sum calculates the hash of the message and appends it to dst.
+func Sum(dst []byte, msg proto.Message, opts ...Option) ([]byte, error) {
+	return sum(dst, msg, newOptions(opts), hashMsg)
+}
+
+// Sum64 calculates the 64-bit hash of the message. The hash function must implement hash.Hash64.
+func Sum64(msg proto.Message, opts ...Option) (uint64, error) {
+	return sum64(msg, newOptions(opts), hashMsg)
+}
+
+// Hash writes the message to the given hasher. The hash function set using WithHash is ignored, except by WithParallel,
+// which uses it to calculate the digests of the top-level fields because new hashers can't be created from the given
+// one. Set it to the same hash function as the hasher to get the same digests as Sum.
+func Hash(hasher hash.Hash, msg proto.Message, opts ...Option) error {
+	return observe(hasher, msg, newOptions(opts), hashMsg)
+}
+
+// HashField writes a single field of the message to the given hasher, using the same encoding as Hash. Members of a
+// oneof are only written if they are the member that is set. As with Hash, the hash function set using WithHash is
+// only used by WithParallel.
+func HashField(hasher hash.Hash, m protoreflect.Message, fd protoreflect.FieldDescriptor, opts ...Option) error {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && m.WhichOneof(od) != fd {
+		return nil
+	}
+
+	o := newOptions(opts).resolveIgnore(m.Descriptor())
+	w := newWalker(hasher, o)
+	return w.field(m, fd)
+}
+
+type hashMsgFunc func(hash.Hash, proto.Message, *Options) error
+
+func sum(dst []byte, msg proto.Message, o *Options, fn hashMsgFunc) ([]byte, error) {
+	var hasher hash.Hash
+	if o.scheme == SchemeObjectHash {
+		hasher = &digestHasher{}
+	} else {
+		hasher = o.hashFn()
+	}
+
+	if err := observe(hasher, msg, o, fn); err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(dst), nil
+}
+
+func sum64(msg proto.Message, o *Options, fn hashMsgFunc) (uint64, error) {
+	if o.scheme == SchemeObjectHash {
+		return 0, errObjectHashSum64
+	}
+
+	hasher, ok := o.hashFn().(hash.Hash64)
+	if !ok {
+		return 0, fmt.Errorf("%w: hash function does not implement hash.Hash64", ErrUnsupportedHash)
+	}
+
+	if err := observe(hasher, msg, o, fn); err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
+
+func hashMsg(hasher hash.Hash, msg proto.Message, o *Options) error {
+	if msg == nil {
+		return errNilMessage
+	}
+
+	if o.scheme == SchemeObjectHash {
+		return objectHashMsg(hasher, msg, o)
+	}
+
+	w := newWalker(hasher, o)
+	return w.message(msg.ProtoReflect())
+}
+
+type walker struct {
+	hasher         hash.Hash
+	ignore         map[string]struct{}
+	ignoreAs       IgnoreMode
+	defaults       bool
+	presence       bool
+	required       bool
+	decimal        bool
+	nfc            bool
+	canonicalizers map[protoreflect.FullName]Canonicalizer
+	wrappers       bool
+	nullAsUnset    bool
+	parallel       int
+	hashFn         func() hash.Hash
+	buf            []byte
+	maxDepth       int
+	depth          int
+	stats          *Stats
+	shallow        bool
+	strictUTF8     bool
+	emptyAs        EmptyMode
+	fieldFilter    func(protoreflect.FieldDescriptor) bool
+	// expanding holds the types of the unset messages being hashed as default instances, to stop recursive types
+	// from being expanded forever.
+	expanding map[protoreflect.FullName]struct{}
+}
+
+func newWalker(hasher hash.Hash, o *Options) *walker {
+	return &walker{hasher: hasher, ignore: o.ignore, ignoreAs: o.ignoreAs, defaults: o.defaults, presence: o.presence, required: o.required, decimal: o.decimal, nfc: o.nfc, canonicalizers: o.canonicalizers, wrappers: o.wrappers, nullAsUnset: o.nullAsUnset, parallel: o.parallel, hashFn: o.hashFn, maxDepth: o.maxDepth, stats: o.stats, shallow: o.shallow, strictUTF8: o.strictUTF8, emptyAs: o.emptyAs, fieldFilter: o.fieldFilter}
+}
+
+func (w *walker) ignored(name protoreflect.FullName) bool {
+	_, ok := w.ignore[string(name)]
+	return ok
+}
+
+// filtered reports whether the field is rejected by the filter set using WithFieldFilter.
+func (w *walker) filtered(fd protoreflect.FieldDescriptor) bool {
+	return w.fieldFilter != nil && !w.fieldFilter(fd)
+}
+
+func (w *walker) message(m protoreflect.Message) error {
+	if !m.IsValid() {
+		if !w.defaults {
+			return nil
+		}
+
+		name := m.Descriptor().FullName()
+		if _, ok := w.expanding[name]; ok {
+			return nil
+		}
+
+		if w.expanding == nil {
+			w.expanding = make(map[protoreflect.FullName]struct{})
+		}
+		w.expanding[name] = struct{}{}
+		defer delete(w.expanding, name)
+	}
+
+	m = canonical(w.canonicalizers, m)
+
+	if w.maxDepth > 0 || w.stats != nil {
+		w.depth++
+		defer func() { w.depth-- }()
+
+		if w.maxDepth > 0 && w.depth > w.maxDepth {
+			return maxDepthError(m, w.maxDepth)
+		}
+
+		if w.stats != nil {
+			w.stats.enter(w.depth)
+		}
+	}
+
+	if w.required {
+		if err := checkRequired(m, w.ignore, w.fieldFilter); err != nil {
+			return err
+		}
+	}
+
+	fields := sortedFields(m.Descriptor())
+
+	if w.presence {
+		if err := w.presenceBitmap(m, fields); err != nil {
+			return err
+		}
+	}
+
+	subtrees := fieldSubtrees(fields)
+	if w.parallel > 0 {
+		return w.parallelSubtrees(m, subtrees)
+	}
+
+	for _, d := range subtrees {
+		if err := w.subtree(m, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldSubtrees returns the fields of a message in traversal order, replacing the members of each oneof by the
+// descriptor of the oneof at the position of its first member.
+func fieldSubtrees(fields []protoreflect.FieldDescriptor) []protoreflect.Descriptor {
+	subtrees := make([]protoreflect.Descriptor, 0, len(fields))
+	var oneOfs map[protoreflect.FullName]struct{}
+	for _, fd := range fields {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if _, ok := oneOfs[od.FullName()]; ok {
+				continue
+			}
+
+			if oneOfs == nil {
+				oneOfs = make(map[protoreflect.FullName]struct{})
+			}
+			oneOfs[od.FullName()] = struct{}{}
+
+			subtrees = append(subtrees, od)
+			continue
+		}
+
+		subtrees = append(subtrees, fd)
+	}
+
+	return subtrees
+}
+
+func (w *walker) subtree(m protoreflect.Message, d protoreflect.Descriptor) error {
+	if od, ok := d.(protoreflect.OneofDescriptor); ok {
+		return w.oneOf(m, od)
+	}
+
+	return w.field(m, d.(protoreflect.FieldDescriptor))
+}
+
+func sortedFields(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := range fields {
+		fields[i] = md.Fields().Get(i)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+
+	return fields
+}
+
+func (w *walker) field(m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if fieldExcluded(w.ignore, w.fieldFilter, fd) {
+		if w.ignoreAs == IgnoreAsUnset {
+			return fieldError(w.unset(m, fd), fd)
+		}
+
+		return nil
+	}
+
+	if w.emptyAs == EmptyAsSet && EmptyModeApplies(fd) && !m.Has(fd) {
+		return nil
+	}
+
+	if w.stats != nil {
+		w.stats.Fields++
+	}
+
+	var err error
+	switch {
+	case fd.IsList():
+		err = w.list(fd, m.Get(fd).List())
+	case fd.IsMap():
+		err = w.mapField(fd, m.Get(fd).Map())
+	default:
+		err = w.value(fd, m.Get(fd))
+	}
+
+	return fieldError(err, fd)
+}
+
+func (w *walker) oneOf(m protoreflect.Message, od protoreflect.OneofDescriptor) error {
+	fd := m.WhichOneof(od)
+	if fd == nil || w.ignored(od.FullName()) || w.filtered(fd) {
+		return nil
+	}
+
+	if w.stats != nil {
+		w.stats.Fields++
+	}
+
+	return fieldError(w.value(fd, m.Get(fd)), fd)
+}
+
+func (w *walker) list(fd protoreflect.FieldDescriptor, list protoreflect.List) error {
+	if Unordered(fd) {
+		return w.unorderedList(fd, list)
+	}
+
+	list = sortList(fd, list)
+	step := 1
+	if k := SampleEvery(fd); k > 0 && list.Len() > 0 {
+		step = k
+		w.buf = protowire.AppendVarint(w.buf[:0], uint64(list.Len()))
+		if _, err := w.hasher.Write(w.buf); err != nil {
+			return fmt.Errorf("failed to write length of %s: %w", fd.FullName(), err)
+		}
+	}
+
+	for i := 0; i < list.Len(); i += step {
+		if err := w.value(fd, list.Get(i)); err != nil {
+			return indexError(err, i)
+		}
+	}
+
+	return nil
+}
+
+// unorderedList hashes each element of the list into its own SHA-256 digest and writes the sorted digests as
+// length-prefixed byte strings.
+func (w *walker) unorderedList(fd protoreflect.FieldDescriptor, list protoreflect.List) error {
+	digests := make([][]byte, list.Len())
+	for i := range digests {
+		hasher := sha256.New()
+		if err := w.fork(hasher).value(fd, list.Get(i)); err != nil {
+			return indexError(err, i)
+		}
+		digests[i] = hasher.Sum(nil)
+	}
+
+	sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+
+	for _, digest := range digests {
+		w.buf = protowire.AppendBytes(w.buf[:0], digest)
+		if _, err := w.hasher.Write(w.buf); err != nil {
+			return fmt.Errorf("failed to write element digest of %s: %w", fd.FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (w *walker) mapField(fd protoreflect.FieldDescriptor, mapVal protoreflect.Map) error {
+	if mapVal.Len() == 0 {
+		return nil
+	}
+
+	keys := make([]protoreflect.MapKey, 0, mapVal.Len())
+	mapVal.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	sortMapKeys(fd.MapKey().Kind(), keys)
+
+	valueDesc := fd.MapValue()
+	for _, k := range keys {
+		if err := w.value(valueDesc, mapVal.Get(k)); err != nil {
+			return mapKeyError(err, fd, k)
+		}
+	}
+
+	return nil
+}
+
+func sortMapKeys(kind protoreflect.Kind, keys []protoreflect.MapKey) {
+	sort.Slice(keys, func(i, j int) bool { return mapKeyLess(kind, keys[i], keys[j]) })
+}
+
+func mapKeyLess(kind protoreflect.Kind, a, b protoreflect.MapKey) bool {
+	switch kind {
+	case protoreflect.BoolKind:
+		return !a.Bool() && b.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return a.Int() < b.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return a.Uint() < b.Uint()
+	default:
+		return a.String() < b.String()
+	}
+}
+
+func (w *walker) value(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	b := w.buf[:0]
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+	case protoreflect.EnumKind:
+		b = protowire.AppendVarint(b, uint64(v.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		b = protowire.AppendVarint(b, uint64(v.Int()))
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int()))
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		b = protowire.AppendVarint(b, v.Uint())
+	case protoreflect.Sfixed32Kind:
+		b = protowire.AppendFixed32(b, uint32(v.Int()))
+	case protoreflect.Fixed32Kind:
+		b = protowire.AppendFixed32(b, uint32(v.Uint()))
+	case protoreflect.FloatKind:
+		if w.decimal {
+			b = protowire.AppendString(b, decimalFloat(v.Float(), 32))
+			break
+		}
+		b = protowire.AppendFixed32(b, math.Float32bits(float32(v.Float())))
+	case protoreflect.Sfixed64Kind:
+		b = protowire.AppendFixed64(b, uint64(v.Int()))
+	case protoreflect.Fixed64Kind:
+		b = protowire.AppendFixed64(b, v.Uint())
+	case protoreflect.DoubleKind:
+		if w.decimal {
+			b = protowire.AppendString(b, decimalFloat(v.Float(), 64))
+			break
+		}
+		b = protowire.AppendFixed64(b, math.Float64bits(v.Float()))
+	case protoreflect.StringKind:
+		if w.strictUTF8 {
+			if err := checkUTF8(fd, v.String()); err != nil {
+				return err
+			}
+		}
+		b = protowire.AppendString(b, stringValue(fd, v.String(), w.nfc))
+	case protoreflect.BytesKind:
+		b = protowire.AppendBytes(b, v.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if vfd := wrappedField(fd.Message()); vfd != nil && w.wrappers {
+			return w.value(vfd, v.Message().Get(vfd))
+		}
+
+		if w.nullAsUnset && isNullValue(v.Message()) {
+			return nil
+		}
+
+		if w.shallow {
+			return w.shallowMessage(fd, v.Message())
+		}
+		return w.message(v.Message())
+	default:
+		return fmt.Errorf("%w %s for %s", ErrUnsupportedKind, fd.Kind(), fd.FullName())
+	}
+
+	w.buf = b
+	if _, err := w.hasher.Write(b); err != nil {
+		return fmt.Errorf("failed to write value of %s: %w", fd.FullName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"testing"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type hashable interface {
+	proto.Message
+	hashpb.Hashable
+}
+
+func testCases() []struct {
+	name   string
+	input  hashable
+	ignore []string
+} {
+	return []struct {
+		name   string
+		input  hashable
+		ignore []string
+	}{
+		{name: "nil", input: (*pb.TestAllTypes)(nil)},
+		{name: "empty", input: &pb.TestAllTypes{}},
+		{name: "fully populated", input: mkTestAllTypesMsg()},
+		{name: "fully populated nested", input: mkNestedTestAllTypesMsg(3)},
+		{name: "fully populated optional", input: mkTestAllTypesOptionalMsg()},
+		{name: "empty optional", input: &pb.TestAllTypesOptional{}},
+		{
+			name:  "ignore fields",
+			input: mkNestedTestAllTypesMsg(3),
+			ignore: []string{
+				"cerbos.hashpb.test.TestAllTypes.single_timestamp",
+				"cerbos.hashpb.test.TestAllTypes.map_bool_string",
+				"cerbos.hashpb.test.TestAllTypes.nested_type",
+				"cerbos.hashpb.test.TestAllTypes.NestedMessage.bb",
+			},
+		},
+	}
+}
+
+func TestMatchesGenerated(t *testing.T) {
+	for _, tc := range testCases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			want := generatedSum(t, tc.input, tc.ignore, sha256.New)
+
+			have, err := hashpb.Sum(nil, tc.input, hashpb.WithHash(sha256.New), hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !bytes.Equal(want, have) {
+				t.Fatalf("Digest mismatch: want=%x have=%x", want, have)
+			}
+
+			have64, err := hashpb.Sum64(tc.input, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			want64 := xxhash.New()
+			tc.input.HashPB(want64, ignoreSet(tc.ignore))
+			if want64.Sum64() != have64 {
+				t.Fatalf("Digest mismatch: want=%d have=%d", want64.Sum64(), have64)
+			}
+		})
+	}
+}
+
+func TestV1(t *testing.T) {
+	for _, tc := range testCases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := hashpb.Sum64(tc.input, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			have, err := hashpb.Sum64V1(protoadapt.MessageV1Of(tc.input), hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if want != have {
+				t.Fatalf("Digest mismatch: want=%d have=%d", want, have)
+			}
+		})
+	}
+}
+
+func TestAuto(t *testing.T) {
+	for _, tc := range testCases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := hashpb.Sum64(tc.input, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			have, err := hashpb.Sum64Auto(tc.input, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if want != have {
+				t.Fatalf("Digest mismatch: want=%d have=%d", want, have)
+			}
+
+			if !tc.input.ProtoReflect().IsValid() {
+				return
+			}
+
+			dynMsg := dynamicpb.NewMessage(tc.input.ProtoReflect().Descriptor())
+			proto.Merge(dynMsg, tc.input)
+
+			haveDyn, err := hashpb.Sum64Auto(dynMsg, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if want != haveDyn {
+				t.Fatalf("Digest mismatch for dynamic message: want=%d have=%d", want, haveDyn)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := hashpb.Sum64(nil); err == nil {
+		t.Fatal("Expected error for nil message")
+	}
+
+	if _, err := hashpb.Sum64(mkTestAllTypesMsg(), hashpb.WithHash(sha256.New)); err == nil {
+		t.Fatal("Expected error for hash function without 64-bit digests")
+	}
+}
+
+func TestHashPBErr(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(2)
+
+	want := xxhash.New()
+	msg.HashPB(want, nil)
+
+	have := xxhash.New()
+	if err := msg.HashPBErr(have, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if want.Sum64() != have.Sum64() {
+		t.Errorf("Digest mismatch: want=%d have=%d", want.Sum64(), have.Sum64())
+	}
+
+	errWrite := errors.New("write failed")
+	for _, limit := range []int64{0, 10, 100} {
+		if err := msg.HashPBErr(&failingHasher{Hash: xxhash.New(), limit: limit, err: errWrite}, nil); !errors.Is(err, errWrite) {
+			t.Errorf("Expected write error after %d bytes: %v", limit, err)
+		}
+	}
+
+	if err := hashpb.Hash(&failingHasher{Hash: xxhash.New(), err: errWrite}, msg); !errors.Is(err, errWrite) {
+		t.Errorf("Expected write error from reflection: %v", err)
+	}
+
+	if _, err := hashpb.SumAuto(nil, msg, hashpb.WithHash(func() hash.Hash { return &failingHasher{Hash: xxhash.New(), err: errWrite} })); !errors.Is(err, errWrite) {
+		t.Errorf("Expected write error from SumAuto: %v", err)
+	}
+}
+
+// failingHasher fails writes once more than limit bytes have been written.
+type failingHasher struct {
+	hash.Hash
+	limit   int64
+	written int64
+	err     error
+}
+
+func (h *failingHasher) Write(p []byte) (int, error) {
+	h.written += int64(len(p))
+	if h.written > h.limit {
+		return 0, h.err
+	}
+
+	return h.Hash.Write(p)
+}
+
+func generatedSum(t *testing.T, m hashable, ignore []string, hashFn func() hash.Hash) []byte {
+	t.Helper()
+
+	h := hashFn()
+	m.HashPB(h, ignoreSet(ignore))
+	return h.Sum(nil)
+}
+
+func ignoreSet(fieldNames []string) map[string]struct{} {
+	if len(fieldNames) == 0 {
+		return nil
+	}
+
+	ignore := make(map[string]struct{}, len(fieldNames))
+	for _, fn := range fieldNames {
+		ignore[fn] = struct{}{}
+	}
+
+	return ignore
+}
+
+func mkNestedTestAllTypesMsg(nesting int) *pb.NestedTestAllTypes {
+	m := &pb.NestedTestAllTypes{
+		Payload: mkTestAllTypesMsg(),
+	}
+
+	if nesting <= 1 {
+		return m
+	}
+
+	m.Child = mkNestedTestAllTypesMsg(nesting - 1)
+	return m
+}
+
+func mkTestAllTypesMsg() *pb.TestAllTypes {
+	anyVal, err := anypb.New(wrapperspb.String("wibble wobble"))
+	if err != nil {
+		panic(err)
+	}
+
+	structVal, err := structpb.NewStruct(map[string]any{"a": 1, "b": []any{"c", true, nil}, "d": map[string]any{"e": 4.2}})
+	if err != nil {
+		panic(err)
+	}
+
+	return &pb.TestAllTypes{
+		SingleInt32:           -42,
+		SingleInt64:           -42,
+		SingleUint32:          42,
+		SingleUint64:          42,
+		SingleSint32:          -42,
+		SingleSint64:          -42,
+		SingleFixed32:         42,
+		SingleFixed64:         42,
+		SingleSfixed32:        -42,
+		SingleSfixed64:        -42,
+		SingleFloat:           42.42,
+		SingleDouble:          42.42,
+		SingleBool:            true,
+		SingleString:          "wibble wobble",
+		SingleBytes:           []byte("wibble wobble"),
+		StandaloneEnum:        pb.TestAllTypes_BAZ,
+		SingleAny:             anyVal,
+		SingleDuration:        durationpb.New(10 * time.Minute),
+		SingleTimestamp:       timestamppb.New(time.Unix(1642694886, 0)),
+		SingleStruct:          structVal,
+		SingleValue:           structpb.NewStringValue("wibble"),
+		SingleInt64Wrapper:    wrapperspb.Int64(42),
+		SingleStringWrapper:   wrapperspb.String("wibble wobble"),
+		NestedType:            &pb.TestAllTypes_SingleNestedMessage{SingleNestedMessage: &pb.TestAllTypes_NestedMessage{Bb: 42}},
+		RepeatedInt32:         []int32{1, -2, 3},
+		RepeatedInt64:         []int64{1, -2, 3},
+		RepeatedUint32:        []uint32{1, 2, 3},
+		RepeatedUint64:        []uint64{1, 2, 3},
+		RepeatedSint32:        []int32{1, -2, 3},
+		RepeatedSint64:        []int64{1, -2, 3},
+		RepeatedFixed32:       []uint32{1, 2, 3},
+		RepeatedFixed64:       []uint64{1, 2, 3},
+		RepeatedSfixed32:      []int32{1, -2, 3},
+		RepeatedSfixed64:      []int64{1, -2, 3},
+		RepeatedFloat:         []float32{1.2, 2.3, 3.4},
+		RepeatedDouble:        []float64{1.2, 2.3, 3.4},
+		RepeatedBool:          []bool{true, false, true},
+		RepeatedString:        []string{"wibble", "wobble", "flub"},
+		RepeatedBytes:         [][]byte{[]byte("wibble"), []byte("wobble"), []byte("flub")},
+		RepeatedNestedMessage: []*pb.TestAllTypes_NestedMessage{{Bb: 1}, {Bb: 2}, {Bb: 3}},
+		RepeatedNestedEnum:    []pb.TestAllTypes_NestedEnum{pb.TestAllTypes_BAR, pb.TestAllTypes_BAZ},
+		MapStringString:       map[string]string{"a": "b", "c": "d", "e": "f"},
+		MapUint64String:       map[uint64]string{1: "a", 2: "b", 3: "c"},
+		MapInt32String:        map[int32]string{-1: "a", 2: "b", 3: "c"},
+		MapBoolString:         map[bool]string{true: "a", false: "b"},
+		MapInt64NestedType:    map[int64]*pb.TestAllTypes_NestedMessage{1: {Bb: 1}, -2: {Bb: 2}},
+	}
+}
+
+func mkTestAllTypesOptionalMsg() *pb.TestAllTypesOptional {
+	return &pb.TestAllTypesOptional{
+		SingleInt32:         proto.Int32(42),
+		SingleInt64:         proto.Int64(42),
+		SingleUint32:        proto.Uint32(42),
+		SingleUint64:        proto.Uint64(42),
+		SingleSint32:        proto.Int32(42),
+		SingleSint64:        proto.Int64(42),
+		SingleFixed32:       proto.Uint32(42),
+		SingleFixed64:       proto.Uint64(42),
+		SingleSfixed32:      proto.Int32(42),
+		SingleSfixed64:      proto.Int64(42),
+		SingleFloat:         proto.Float32(42.42),
+		SingleDouble:        proto.Float64(42.42),
+		SingleBool:          proto.Bool(true),
+		SingleString:        proto.String("wibble wobble"),
+		SingleBytes:         []byte("wibble wobble"),
+		StandaloneEnum:      pb.TestAllTypesOptional_BAR.Enum(),
+		SingleDuration:      durationpb.New(10 * time.Minute),
+		SingleTimestamp:     timestamppb.New(time.Unix(1642694886, 0)),
+		SingleInt64Wrapper:  wrapperspb.Int64(42),
+		SingleStringWrapper: wrapperspb.String("wibble wobble"),
+		SingleNestedMessage: &pb.TestAllTypesOptional_NestedMessage{Bb: proto.Int32(42)},
+	}
+}
+
+func TestSchemaFingerprint(t *testing.T) {
+	testCases := []struct {
+		msg  proto.Message
+		want uint64
+	}{
+		{msg: &pb.TestAllTypes{}, want: pb.TestAllTypes_HashPBSchemaFingerprint},
+		{msg: &pb.NestedTestAllTypes{}, want: pb.NestedTestAllTypes_HashPBSchemaFingerprint},
+		{msg: &pb.TestAllTypesOptional{}, want: pb.TestAllTypesOptional_HashPBSchemaFingerprint},
+	}
+
+	for _, tc := range testCases {
+		if have := hashpb.SchemaFingerprint(tc.msg.ProtoReflect().Descriptor()); have != tc.want {
+			t.Errorf("Fingerprint mismatch for %T: want=%x have=%x", tc.msg, tc.want, have)
+		}
+	}
+
+	if pb.TestAllTypes_HashPBSchemaFingerprint == pb.TestAllTypesOptional_HashPBSchemaFingerprint {
+		t.Error("Expected different fingerprints for different schemas")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// BenchmarkSeed is the seed used to populate the messages hashed by BenchmarkHashPB, so that results are comparable
+// across runs and releases.
+const BenchmarkSeed = 1
+
+// BenchmarkHashPB benchmarks hashing a populated instance of the type of msg, with a sub-benchmark for the generated
+// HashPB method and another for the hashpb package using reflection. The options are passed to the hashpb package.
+// The reported throughput is based on the size of the message in the protobuf wire format.
+func BenchmarkHashPB(b *testing.B, msg Message, opts ...hashpb.Option) {
+	b.Helper()
+
+	m, ok := NewPopulated(msg, BenchmarkSeed).(Message)
+	if !ok {
+		b.Fatalf("%T does not implement HashPB", msg)
+	}
+
+	size := int64(proto.Size(m))
+
+	b.Run("generated", func(b *testing.B) {
+		digest := xxhash.New()
+		b.SetBytes(size)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			digest.Reset()
+			m.HashPB(digest, nil)
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.SetBytes(size)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := hashpb.Sum64(m, opts...); err != nil {
+				b.Fatalf("Failed to hash %T: %v", m, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ConformanceSeeds is the number of populated instances checked by CheckConformance.
+const ConformanceSeeds = 10
+
+// Message is a message with a generated HashPB method.
+type Message interface {
+	proto.Message
+	hashpb.Hashable
+}
+
+// CheckConformance populates instances of the type of msg and fails the test if the digest produced by the
+// generated HashPB method differs from the digest produced by the hashpb package using reflection.
+// If the message has a generated ObjectHashPB method, its digest is checked against hashpb.SchemeObjectHash as well.
+// If the message has generated HashPBWithMaxDepth, HashPBErr, Sum64HashPB, AppendHashPB, HashPBMulti or WriteHashPB
+// methods, they must produce the same digest as HashPB. A generated HashPBFiltered method is checked against
+// hashpb.WithFieldFilter as well. The options are passed to the hashpb package, for example to
+// match the normalization applied by code generated with the normalize_unicode=true parameter.
+func CheckConformance(t testing.TB, msg Message, opts ...hashpb.Option) {
+	t.Helper()
+
+	for seed := int64(0); seed < ConformanceSeeds; seed++ {
+		m, ok := NewPopulated(msg, seed).(Message)
+		if !ok {
+			t.Fatalf("%T does not implement HashPB", msg)
+		}
+
+		digest := xxhash.New()
+		m.HashPB(digest, nil)
+		want := digest.Sum64()
+
+		have, err := hashpb.Sum64(m, opts...)
+		if err != nil {
+			t.Fatalf("Failed to hash %T with seed %d: %v", m, seed, err)
+		}
+
+		if want != have {
+			t.Errorf("Digest mismatch for %T with seed %d: generated=%d reflection=%d", m, seed, want, have)
+		}
+
+		if dl, ok := m.(hashpb.DepthLimitedHashable); ok {
+			limited := xxhash.New()
+			if err := dl.HashPBWithMaxDepth(limited, nil, math.MaxInt); err != nil {
+				t.Fatalf("Failed to hash %T with seed %d using HashPBWithMaxDepth: %v", m, seed, err)
+			}
+
+			if have := limited.Sum64(); want != have {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d depth-limited=%d", m, seed, want, have)
+			}
+		}
+
+		if eh, ok := m.(hashpb.ErrHashable); ok {
+			withErr := xxhash.New()
+			if err := eh.HashPBErr(withErr, nil); err != nil {
+				t.Fatalf("Failed to hash %T with seed %d using HashPBErr: %v", m, seed, err)
+			}
+
+			if have := withErr.Sum64(); want != have {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d error-propagating=%d", m, seed, want, have)
+			}
+		}
+
+		if nh, ok := m.(hashpb.NoIgnoreHashable); ok {
+			noIgnore := xxhash.New()
+			nh.HashPBNoIgnore(noIgnore)
+			if have := noIgnore.Sum64(); want != have {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d no-ignore=%d", m, seed, want, have)
+			}
+		}
+
+		if sh, ok := m.(hashpb.ShallowHashable); ok {
+			checkShallow(t, m, sh, seed, opts)
+		}
+
+		if fh, ok := m.(hashpb.FilteredHashable); ok {
+			checkFiltered(t, m, fh, want, seed, opts)
+		}
+
+		if sh, ok := m.(hashpb.Sum64Hashable); ok {
+			if have := sh.Sum64HashPB(nil); want != have {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d sum64=%d", m, seed, want, have)
+			}
+		}
+
+		if ah, ok := m.(hashpb.AppendHashable); ok {
+			prefix := []byte("prefix")
+			if have := ah.AppendHashPB(prefix, nil); !bytes.Equal(have, digest.Sum(prefix)) {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%x append=%x", m, seed, digest.Sum(prefix), have)
+			}
+		}
+
+		if mh, ok := m.(hashpb.MultiHashable); ok {
+			first, second := xxhash.New(), xxhash.New()
+			mh.HashPBMulti(nil, first, second)
+			if have1, have2 := first.Sum64(), second.Sum64(); want != have1 || want != have2 {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d multi=[%d %d]", m, seed, want, have1, have2)
+			}
+		}
+
+		if wh, ok := m.(hashpb.WriterHashable); ok {
+			var buf bytes.Buffer
+			if err := wh.WriteHashPB(&buf, nil); err != nil {
+				t.Fatalf("Failed to write %T with seed %d using WriteHashPB: %v", m, seed, err)
+			}
+
+			if have := xxhash.Sum64(buf.Bytes()); want != have {
+				t.Errorf("Digest mismatch for %T with seed %d: generated=%d writer=%d", m, seed, want, have)
+			}
+		}
+
+		if oh, ok := m.(hashpb.ObjectHashable); ok {
+			checkObjectHash(t, m, oh, seed, opts)
+		}
+	}
+}
+
+func checkShallow(t testing.TB, m proto.Message, sh hashpb.ShallowHashable, seed int64, opts []hashpb.Option) {
+	t.Helper()
+
+	digest := xxhash.New()
+	sh.HashPBShallow(digest, nil)
+	want := digest.Sum64()
+
+	have, err := hashpb.Sum64(m, append([]hashpb.Option{hashpb.WithShallow()}, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to hash %T with seed %d using WithShallow: %v", m, seed, err)
+	}
+
+	if want != have {
+		t.Errorf("Shallow digest mismatch for %T with seed %d: generated=%d reflection=%d", m, seed, want, have)
+	}
+}
+
+func checkFiltered(t testing.TB, m proto.Message, fh hashpb.FilteredHashable, want uint64, seed int64, opts []hashpb.Option) {
+	t.Helper()
+
+	all := xxhash.New()
+	fh.HashPBFiltered(all, func(string) bool { return true })
+	if have := all.Sum64(); want != have {
+		t.Errorf("Digest mismatch for %T with seed %d: generated=%d filtered=%d", m, seed, want, have)
+	}
+
+	// leave out an arbitrary but deterministic subset of the fields.
+	filter := func(name string) bool { return len(name)%2 == 0 }
+	some := xxhash.New()
+	fh.HashPBFiltered(some, filter)
+
+	have, err := hashpb.Sum64(m, append([]hashpb.Option{hashpb.WithFieldFilter(func(fd protoreflect.FieldDescriptor) bool {
+		return filter(string(fd.FullName()))
+	})}, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to hash %T with seed %d using WithFieldFilter: %v", m, seed, err)
+	}
+
+	if generated := some.Sum64(); generated != have {
+		t.Errorf("Filtered digest mismatch for %T with seed %d: generated=%d reflection=%d", m, seed, generated, have)
+	}
+}
+
+func checkObjectHash(t testing.TB, m proto.Message, oh hashpb.ObjectHashable, seed int64, opts []hashpb.Option) {
+	t.Helper()
+
+	want := oh.ObjectHashPB(nil)
+	have, err := hashpb.Sum(nil, m, append([]hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to objecthash %T with seed %d: %v", m, seed, err)
+	}
+
+	if !bytes.Equal(want[:], have) {
+		t.Errorf("Objecthash mismatch for %T with seed %d: generated=%x reflection=%x", m, seed, want, have)
+	}
+}
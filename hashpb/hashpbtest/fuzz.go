@@ -0,0 +1,49 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// FuzzConformance fuzzes the type of msg by decoding arbitrary bytes into it and fails if the digest produced by the
+// generated HashPB method differs from the digest produced by the hashpb package using reflection.
+// The corpus is seeded with the encoded forms of populated messages. The options are passed to the hashpb package.
+func FuzzConformance(f *testing.F, msg Message, opts ...hashpb.Option) {
+	for seed := int64(0); seed < ConformanceSeeds; seed++ {
+		input, err := proto.MarshalOptions{Deterministic: true}.Marshal(NewPopulated(msg, seed))
+		if err != nil {
+			f.Fatalf("Failed to marshal %T with seed %d: %v", msg, seed, err)
+		}
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		m, ok := msg.ProtoReflect().New().Interface().(Message)
+		if !ok {
+			t.Fatalf("%T does not implement HashPB", msg)
+		}
+
+		if err := proto.Unmarshal(input, m); err != nil {
+			t.Skip()
+		}
+
+		digest := xxhash.New()
+		m.HashPB(digest, nil)
+		want := digest.Sum64()
+
+		have, err := hashpb.Sum64(m, opts...)
+		if err != nil {
+			t.Fatalf("Failed to hash %T: %v", m, err)
+		}
+
+		if want != have {
+			t.Errorf("Digest mismatch for %T: generated=%d reflection=%d", m, want, have)
+		}
+	})
+}
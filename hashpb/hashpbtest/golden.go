@@ -0,0 +1,52 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// GoldenSeeds is the number of golden vectors generated for each message.
+const GoldenSeeds = testgen.GoldenSeeds
+
+// Golden is the expected digest of a message populated using the given seed.
+// Seed 0 denotes an empty message.
+type Golden = testgen.Golden
+
+// MakeGolden computes the golden vectors for the message type of msg using the hashpb package with the given options,
+// which must make the hashpb package hash messages like the generated code.
+func MakeGolden(msg proto.Message, opts ...hashpb.Option) ([]Golden, error) {
+	return testgen.MakeGolden(msg, opts...)
+}
+
+// CheckGolden fails the test if the digests produced by the generated HashPB method differ from the golden vectors.
+func CheckGolden(t testing.TB, msg Message, golden []Golden) {
+	t.Helper()
+
+	for _, g := range golden {
+		m, ok := NewPopulated(msg, g.Seed).(Message)
+		if !ok {
+			t.Fatalf("%T does not implement HashPB", msg)
+		}
+
+		xxh := xxhash.New()
+		m.HashPB(xxh, nil)
+		if have := xxh.Sum64(); have != g.XXHash {
+			t.Errorf("xxhash digest mismatch for %T with seed %d: want=%d have=%d", m, g.Seed, g.XXHash, have)
+		}
+
+		sha := sha256.New()
+		m.HashPB(sha, nil)
+		if have := hex.EncodeToString(sha.Sum(nil)); have != g.SHA256 {
+			t.Errorf("sha256 digest mismatch for %T with seed %d: want=%s have=%s", m, g.Seed, g.SHA256, have)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MapOrderShuffles is the number of copies of each populated message built by CheckMapOrder.
+const MapOrderShuffles = 5
+
+// CheckMapOrder populates instances of the type of msg, copies each of them several times inserting the entries of
+// every map in a different random order, and fails the test if the digest of any copy differs from the digest of the
+// original. The digests are calculated using the generated HashPB method, the hashpb package using reflection and,
+// if the message has a generated ObjectHashPB method, both implementations of hashpb.SchemeObjectHash.
+// The options are passed to the hashpb package.
+func CheckMapOrder(t testing.TB, msg Message, opts ...hashpb.Option) {
+	t.Helper()
+
+	objectHashOpts := append([]hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}, opts...)
+	for seed := int64(1); seed < ConformanceSeeds; seed++ {
+		m, ok := NewPopulated(msg, seed).(Message)
+		if !ok {
+			t.Fatalf("%T does not implement HashPB", msg)
+		}
+
+		want := mapOrderDigests(t, m, opts, objectHashOpts)
+		rnd := rand.New(rand.NewSource(seed))
+		for i := 0; i < MapOrderShuffles; i++ {
+			shuffled, ok := Shuffled(m, rnd.Int63()).(Message)
+			if !ok {
+				t.Fatalf("%T does not implement HashPB", msg)
+			}
+
+			have := mapOrderDigests(t, shuffled, opts, objectHashOpts)
+			for name, digest := range want {
+				if !bytes.Equal(digest, have[name]) {
+					t.Errorf("%s digest of %T with seed %d depends on map insertion order: want=%x have=%x", name, m, seed, digest, have[name])
+				}
+			}
+		}
+	}
+}
+
+func mapOrderDigests(t testing.TB, m Message, opts, objectHashOpts []hashpb.Option) map[string][]byte {
+	t.Helper()
+
+	digest := xxhash.New()
+	m.HashPB(digest, nil)
+	digests := map[string][]byte{"Generated": digest.Sum(nil)}
+
+	reflection, err := hashpb.Sum(nil, m, opts...)
+	if err != nil {
+		t.Fatalf("Failed to hash %T: %v", m, err)
+	}
+	digests["Reflection"] = reflection
+
+	if oh, ok := m.(hashpb.ObjectHashable); ok {
+		generated := oh.ObjectHashPB(nil)
+		digests["Generated objecthash"] = generated[:]
+
+		reflection, err := hashpb.Sum(nil, m, objectHashOpts...)
+		if err != nil {
+			t.Fatalf("Failed to objecthash %T: %v", m, err)
+		}
+		digests["Reflection objecthash"] = reflection
+	}
+
+	return digests
+}
+
+// Shuffled returns a deep copy of msg built by inserting the entries of every map, including the maps of nested
+// messages, in a random order determined by the given seed.
+func Shuffled(msg proto.Message, seed int64) proto.Message {
+	dst := msg.ProtoReflect().New()
+	shuffleCopy(dst, msg.ProtoReflect(), rand.New(rand.NewSource(seed)))
+
+	return dst.Interface()
+}
+
+func shuffleCopy(dst, src protoreflect.Message, rnd *rand.Rand) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			srcList, dstList := v.List(), dst.Mutable(fd).List()
+			for i := 0; i < srcList.Len(); i++ {
+				dstList.Append(shuffleValue(fd, srcList.Get(i), dstList.NewElement, rnd))
+			}
+		case fd.IsMap():
+			srcMap, dstMap := v.Map(), dst.Mutable(fd).Map()
+			keys := make([]protoreflect.MapKey, 0, srcMap.Len())
+			srcMap.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+
+			rnd.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+			for _, k := range keys {
+				dstMap.Set(k, shuffleValue(fd.MapValue(), srcMap.Get(k), dstMap.NewValue, rnd))
+			}
+		default:
+			dst.Set(fd, shuffleValue(fd, v, func() protoreflect.Value { return dst.NewField(fd) }, rnd))
+		}
+
+		return true
+	})
+
+	if unknown := src.GetUnknown(); len(unknown) > 0 {
+		dst.SetUnknown(append(protoreflect.RawFields(nil), unknown...))
+	}
+}
+
+func shuffleValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, newValue func() protoreflect.Value, rnd *rand.Rand) protoreflect.Value {
+	if !testgen.IsMessage(fd) {
+		return v
+	}
+
+	dst := newValue()
+	shuffleCopy(dst.Message(), v.Message(), rnd)
+
+	return dst
+}
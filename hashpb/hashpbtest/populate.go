@@ -0,0 +1,25 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hashpbtest provides helpers for testing code generated by protoc-gen-go-hashpb.
+package hashpbtest
+
+import (
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxDepth is the maximum nesting depth of messages created by Populate.
+const MaxDepth = testgen.MaxDepth
+
+// Populate sets the fields of msg to pseudo-random values derived from seed.
+// The same seed always produces the same message, which makes it suitable for golden tests.
+func Populate(msg proto.Message, seed int64) {
+	testgen.Populate(msg, seed)
+}
+
+// NewPopulated returns a new instance of the message type of msg populated using the given seed.
+// Seed 0 returns an empty message.
+func NewPopulated(msg proto.Message, seed int64) proto.Message {
+	return testgen.NewPopulated(msg, seed)
+}
@@ -0,0 +1,25 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpbtest
+
+import (
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"google.golang.org/protobuf/proto"
+)
+
+// Vector is a language-neutral test vector for the hashing scheme.
+// Input is the deterministic binary protobuf encoding of the message. NormalizeUnicode and EmptyAsSet record the
+// options that were used to compute the digests, which Options returns.
+type Vector = testgen.Vector
+
+// VectorCorpus is a collection of test vectors.
+type VectorCorpus = testgen.VectorCorpus
+
+// MakeVectors computes test vectors for the message type of msg using the hashpb package with the given options, which
+// must make the hashpb package hash messages like the generated code. Only WithNormalizeUnicode and WithEmptyMode can be
+// recorded in the vectors. In addition to the golden seeds, it produces a vector that exercises the ignore option.
+func MakeVectors(msg proto.Message, opts ...hashpb.Option) ([]Vector, error) {
+	return testgen.MakeVectors(msg, opts...)
+}
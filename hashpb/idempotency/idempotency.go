@@ -0,0 +1,135 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package idempotency derives idempotency keys for retry-safe mutations from the hashpb digests of request messages.
+//
+// A key identifies a request by the identity of the caller, the time bucket in which it was made and the digest of
+// the request message, so retries of the same request by the same caller within a bucket get the same key without the
+// client having to generate and store one. Keys are formatted as version 8 UUIDs (RFC 9562), the format recommended for
+// the values of the Idempotency-Key HTTP header.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// HeaderName is the name of the HTTP header that carries the idempotency key of a request.
+const HeaderName = "Idempotency-Key"
+
+// DefaultWindow is the default width of the time buckets.
+const DefaultWindow = 24 * time.Hour
+
+type options struct {
+	hashOpts []hashpb.Option
+	window   time.Duration
+	now      func() time.Time
+}
+
+type Option func(*options)
+
+// WithHashOptions sets the options used to hash request messages. Use hashpb.WithIgnore to exclude fields that differ
+// between retries of the same request, such as trace IDs and client timestamps, from the key. Request messages are
+// hashed using SHA-256 unless the options set another hash function.
+func WithHashOptions(opts ...hashpb.Option) Option {
+	return func(o *options) {
+		o.hashOpts = opts
+	}
+}
+
+// WithWindow sets the width of the time buckets. Identical requests made by the same caller in different buckets get
+// different keys, so the window should be at least as long as the period during which clients retry requests.
+// Note that retries on both sides of a bucket boundary get different keys. A window of zero disables time buckets.
+// Defaults to DefaultWindow.
+func WithWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.window = window
+	}
+}
+
+// WithClock sets the function that returns the current time. Defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// Generator derives idempotency keys from request messages. It is safe for concurrent use.
+type Generator struct {
+	hashOpts []hashpb.Option
+	window   time.Duration
+	now      func() time.Time
+}
+
+// New creates a Generator with the given options.
+func New(opts ...Option) *Generator {
+	o := &options{window: DefaultWindow, now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Generator{
+		hashOpts: append([]hashpb.Option{hashpb.WithHash(sha256.New)}, o.hashOpts...),
+		window:   o.window,
+		now:      o.now,
+	}
+}
+
+// Key returns the idempotency key of the request made by the caller at the current time. The caller is an opaque
+// identity, such as the subject of an authentication token or a tenant ID, that keeps identical requests made by
+// different callers apart.
+func (g *Generator) Key(caller string, req proto.Message) (string, error) {
+	return g.KeyAt(caller, req, g.now())
+}
+
+// KeyAt returns the idempotency key of the request made by the caller at the given time.
+func (g *Generator) KeyAt(caller string, req proto.Message, t time.Time) (string, error) {
+	digest, err := hashpb.Sum(nil, req, g.hashOpts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	var bucket int64
+	if g.window > 0 {
+		bucket = t.UTC().Truncate(g.window).Unix()
+	}
+
+	b := protowire.AppendString(nil, caller)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(bucket))
+	b = protowire.AppendString(b, string(req.ProtoReflect().Descriptor().FullName()))
+	b = protowire.AppendBytes(b, digest)
+
+	sum := sha256.Sum256(b)
+	return formatUUID(sum[:16]), nil
+}
+
+// HeaderValue returns the value of the Idempotency-Key header for the key, which is the key as a structured field
+// string (RFC 8941).
+func HeaderValue(key string) string {
+	return `"` + key + `"`
+}
+
+// formatUUID formats the 16 bytes as a version 8 UUID, overwriting the version and variant bits.
+func formatUUID(u []byte) string {
+	u[6] = (u[6] & 0x0f) | 0x80
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:16])
+
+	return string(buf[:])
+}
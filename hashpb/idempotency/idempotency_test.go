@@ -0,0 +1,84 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package idempotency_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/idempotency"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const ignoreField = "cerbos.hashpb.test.TestAllTypes.single_timestamp"
+
+var uuidV8 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestKey(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	g := idempotency.New(
+		idempotency.WithHashOptions(hashpb.WithIgnore(ignoreField)),
+		idempotency.WithWindow(time.Hour),
+		idempotency.WithClock(func() time.Time { return now }),
+	)
+
+	req := &pb.TestAllTypes{SingleString: "wibble", SingleTimestamp: timestamppb.New(now)}
+	key := mustKey(t, g, "alice", req, now)
+	if !uuidV8.MatchString(key) {
+		t.Errorf("Key is not a version 8 UUID: %s", key)
+	}
+
+	if have, err := g.Key("alice", req); err != nil || have != key {
+		t.Errorf("Expected Key to use the clock: want=%s have=%s err=%v", key, have, err)
+	}
+
+	retry := &pb.TestAllTypes{SingleString: "wibble", SingleTimestamp: timestamppb.New(now.Add(time.Minute))}
+	if have := mustKey(t, g, "alice", retry, now.Add(time.Minute)); have != key {
+		t.Errorf("Expected a retry in the same bucket to get the same key: want=%s have=%s", key, have)
+	}
+
+	for name, tc := range map[string]struct {
+		caller string
+		req    proto.Message
+		at     time.Time
+	}{
+		"caller":  {caller: "bob", req: req, at: now},
+		"bucket":  {caller: "alice", req: req, at: now.Add(time.Hour)},
+		"content": {caller: "alice", req: &pb.TestAllTypes{SingleString: "wobble"}, at: now},
+		"type":    {caller: "alice", req: &pb.NestedTestAllTypes{}, at: now},
+	} {
+		if have := mustKey(t, g, tc.caller, tc.req, tc.at); have == key {
+			t.Errorf("Expected a different key for a different %s", name)
+		}
+	}
+
+	noWindow := idempotency.New(idempotency.WithWindow(0))
+	if a, b := mustKey(t, noWindow, "alice", req, now), mustKey(t, noWindow, "alice", req, now.AddDate(1, 0, 0)); a != b {
+		t.Errorf("Expected the same key without time buckets: %s != %s", a, b)
+	}
+
+	if _, err := g.KeyAt("alice", nil, now); !errors.Is(err, hashpb.ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage, got %v", err)
+	}
+
+	if have, want := idempotency.HeaderValue(key), `"`+key+`"`; have != want {
+		t.Errorf("Header value mismatch: want=%s have=%s", want, have)
+	}
+}
+
+func mustKey(t *testing.T, g *idempotency.Generator, caller string, req proto.Message, at time.Time) string {
+	t.Helper()
+
+	key, err := g.KeyAt(caller, req, at)
+	if err != nil {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+
+	return key
+}
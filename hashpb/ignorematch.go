@@ -0,0 +1,208 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// IgnoreMatcher is a compiled set of ignore patterns. Patterns are fully-qualified field or oneof names whose
+// dot-separated segments can be "*", which matches any single segment, or "**", which matches any number of segments
+// (including none). For example, "my.pkg.*.etag" ignores the etag field of every message in my.pkg and "**.etag"
+// ignores every field named etag.
+//
+// Create it once with CompileIgnore and reuse it: the set of fields it ignores in each message type is resolved the
+// first time the type is hashed and cached, so large and wildcard ignore sets cost the same as a plain map lookup per
+// field afterwards. An IgnoreMatcher is safe for concurrent use.
+type IgnoreMatcher struct {
+	exact    map[string]struct{}
+	root     *ignoreNode
+	resolved sync.Map
+}
+
+type ignoreNode struct {
+	children map[string]*ignoreNode
+	star     *ignoreNode
+	globstar *ignoreNode
+	terminal bool
+}
+
+// CompileIgnore compiles the ignore patterns into a matcher.
+func CompileIgnore(patterns ...string) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{exact: make(map[string]struct{})}
+	for _, p := range patterns {
+		if !strings.Contains(p, "*") {
+			m.exact[p] = struct{}{}
+			continue
+		}
+
+		if m.root == nil {
+			m.root = &ignoreNode{}
+		}
+
+		node := m.root
+		for _, seg := range strings.Split(p, ".") {
+			switch {
+			case seg == "":
+				return nil, fmt.Errorf("invalid ignore pattern %q: empty segment", p)
+			case seg == "**":
+				if node.globstar == nil {
+					node.globstar = &ignoreNode{}
+				}
+				node = node.globstar
+			case seg == "*":
+				if node.star == nil {
+					node.star = &ignoreNode{}
+				}
+				node = node.star
+			case strings.Contains(seg, "*"):
+				return nil, fmt.Errorf("invalid ignore pattern %q: wildcards must match whole segments", p)
+			default:
+				if node.children == nil {
+					node.children = make(map[string]*ignoreNode)
+				}
+				child, ok := node.children[seg]
+				if !ok {
+					child = &ignoreNode{}
+					node.children[seg] = child
+				}
+				node = child
+			}
+		}
+		node.terminal = true
+	}
+
+	return m, nil
+}
+
+// WithIgnoreMatcher excludes the fields matched by the compiled ignore patterns from the hash, in addition to the
+// fields set using WithIgnore.
+func WithIgnoreMatcher(m *IgnoreMatcher) Option {
+	return func(o *Options) {
+		o.ignoreMatcher = m
+	}
+}
+
+// Match reports whether the fully-qualified name matches any of the patterns.
+func (m *IgnoreMatcher) Match(name string) bool {
+	if _, ok := m.exact[name]; ok {
+		return true
+	}
+
+	return m.root != nil && m.root.match(strings.Split(name, "."))
+}
+
+func (n *ignoreNode) match(segs []string) bool {
+	if len(segs) == 0 {
+		return n.terminal || (n.globstar != nil && n.globstar.match(segs))
+	}
+
+	if child, ok := n.children[segs[0]]; ok && child.match(segs[1:]) {
+		return true
+	}
+
+	if n.star != nil && n.star.match(segs[1:]) {
+		return true
+	}
+
+	if n.globstar != nil {
+		// ** consumes any number of segments.
+		for i := 0; i <= len(segs); i++ {
+			if n.globstar.match(segs[i:]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// For returns the ignore set of the message type: the fully-qualified names of the fields and oneofs of the message
+// and of the messages reachable from it that match the patterns (under their full names or stable ignore keys).
+// Pass it to the generated HashPB methods to use the matcher with generated code. The returned map is cached and
+// must not be modified.
+func (m *IgnoreMatcher) For(md protoreflect.MessageDescriptor) map[string]struct{} {
+	if ignore, ok := m.resolved.Load(md); ok {
+		return ignore.(map[string]struct{})
+	}
+
+	ignore := make(map[string]struct{})
+	m.resolve(ignore, md, make(map[protoreflect.FullName]struct{}))
+
+	actual, _ := m.resolved.LoadOrStore(md, ignore)
+	return actual.(map[string]struct{})
+}
+
+func (m *IgnoreMatcher) resolve(ignore map[string]struct{}, md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]struct{}) {
+	if _, ok := seen[md.FullName()]; ok {
+		return
+	}
+	seen[md.FullName()] = struct{}{}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		if name := string(oneofs.Get(i).FullName()); m.Match(name) {
+			ignore[name] = struct{}{}
+		}
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if m.Match(string(fd.FullName())) {
+			ignore[string(fd.FullName())] = struct{}{}
+		} else if key := StableIgnoreKey(fd); key != "" && m.Match(key) {
+			ignore[key] = struct{}{}
+		}
+
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+
+		if fmd := fd.Message(); fmd != nil {
+			m.resolve(ignore, fmd, seen)
+		}
+	}
+}
+
+// ignoreFor returns the ignore set to use for the message type, which combines the fields set using WithIgnore with
+// the fields matched by the matcher set using WithIgnoreMatcher.
+func (o *Options) ignoreFor(md protoreflect.MessageDescriptor) map[string]struct{} {
+	if o.ignoreMatcher == nil || md == nil {
+		return o.ignore
+	}
+
+	matched := o.ignoreMatcher.For(md)
+	if len(o.ignore) == 0 {
+		return matched
+	}
+
+	ignore := make(map[string]struct{}, len(o.ignore)+len(matched))
+	for k := range o.ignore {
+		ignore[k] = struct{}{}
+	}
+
+	for k := range matched {
+		ignore[k] = struct{}{}
+	}
+
+	return ignore
+}
+
+// resolveIgnore returns options whose ignore set includes the fields of the message type matched by the matcher set
+// using WithIgnoreMatcher. It returns the options themselves if there is no matcher.
+func (o *Options) resolveIgnore(md protoreflect.MessageDescriptor) *Options {
+	if o.ignoreMatcher == nil || md == nil {
+		return o
+	}
+
+	r := *o
+	r.ignore = o.ignoreFor(md)
+	r.ignoreMatcher = nil
+	return &r
+}
@@ -0,0 +1,94 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestCompileIgnore(t *testing.T) {
+	m, err := hashpb.CompileIgnore("a.b.c", "a.*.d", "**.e", "x.**.y")
+	if err != nil {
+		t.Fatalf("Failed to compile: %v", err)
+	}
+
+	testCases := map[string]bool{
+		"a.b.c":     true,
+		"a.b":       false,
+		"a.x.d":     true,
+		"a.x.y.d":   false,
+		"e":         true,
+		"p.q.e":     true,
+		"p.q.e.f":   false,
+		"x.y":       true,
+		"x.p.q.y":   true,
+		"x.p.q.y.z": false,
+	}
+
+	for name, want := range testCases {
+		if have := m.Match(name); have != want {
+			t.Errorf("Match(%q): want=%t have=%t", name, want, have)
+		}
+	}
+
+	for _, p := range []string{"a.*..b", "a.b*.c"} {
+		if _, err := hashpb.CompileIgnore(p); err == nil {
+			t.Errorf("Expected error compiling %q", p)
+		}
+	}
+}
+
+func TestWithIgnoreMatcher(t *testing.T) {
+	m, err := hashpb.CompileIgnore("cerbos.hashpb.test.*.single_int32")
+	if err != nil {
+		t.Fatalf("Failed to compile: %v", err)
+	}
+
+	msg := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt32: 1, SingleString: "x"},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 2}},
+	}
+	changed := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt32: 3, SingleString: "x"},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 4}},
+	}
+
+	want, err := hashpb.Sum(nil, msg, hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_int32"))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	for _, input := range []*pb.NestedTestAllTypes{msg, changed} {
+		have, err := hashpb.Sum(nil, input, hashpb.WithIgnoreMatcher(m))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if !bytes.Equal(want, have) {
+			t.Errorf("Hash mismatch: want=%x have=%x", want, have)
+		}
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		ignore := m.For(msg.ProtoReflect().Descriptor())
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok || len(ignore) != 1 {
+			t.Errorf("Unexpected ignore set: %v", ignore)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		paths, err := hashpb.Diff(msg, changed, hashpb.WithIgnoreMatcher(m))
+		if err != nil {
+			t.Fatalf("Failed to diff: %v", err)
+		}
+
+		if len(paths) != 0 {
+			t.Errorf("Expected no differences, got %v", paths)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// IgnoreMode determines how ignored fields contribute to the hash.
+type IgnoreMode int
+
+const (
+	// IgnoreSkip leaves ignored fields out of the hash entirely. It is the behaviour of the generated HashPB methods.
+	IgnoreSkip IgnoreMode = iota
+	// IgnoreAsUnset hashes ignored fields as if they were unset, so a message with ignored fields has the same digest
+	// as the message with those fields cleared. Singular scalar fields contribute their default value, like unset
+	// fields do, while message, repeated and map fields and oneofs contribute nothing (unless WithImplicitDefaults
+	// is used, in which case message fields contribute their default instance).
+	IgnoreAsUnset
+)
+
+// WithIgnoreMode sets how the fields excluded using WithIgnore are hashed. Defaults to IgnoreSkip.
+// The generated methods always skip ignored fields, so SumAuto and Sum64Auto use reflection when the mode is
+// IgnoreAsUnset and the ignore set is not empty. Both modes produce the same digests with SchemeObjectHash, which
+// leaves unset fields out of the hash.
+func WithIgnoreMode(mode IgnoreMode) Option {
+	return func(o *Options) {
+		o.ignoreAs = mode
+	}
+}
+
+// unset writes the value hashed for the field of m when it is not set.
+func (w *walker) unset(m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if fd.IsList() || fd.IsMap() {
+		return nil
+	}
+
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return nil
+	}
+
+	if w.emptyAs == EmptyAsSet && EmptyModeApplies(fd) {
+		return nil
+	}
+
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		if vfd := wrappedField(fd.Message()); vfd != nil && w.wrappers {
+			return w.value(vfd, vfd.Default())
+		}
+
+		if !w.defaults {
+			return nil
+		}
+
+		return w.message(m.Get(fd).Message().Type().Zero())
+	}
+
+	return w.value(fd, fd.Default())
+}
@@ -0,0 +1,66 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestWithIgnoreMode(t *testing.T) {
+	ignore := []string{
+		"cerbos.hashpb.test.TestAllTypes.single_int32",
+		"cerbos.hashpb.test.TestAllTypes.single_string",
+		"cerbos.hashpb.test.TestAllTypes.single_string_wrapper",
+		"cerbos.hashpb.test.TestAllTypes.repeated_int32",
+		"cerbos.hashpb.test.TestAllTypes.map_string_string",
+	}
+
+	msg := mkTestAllTypesMsg()
+	cleared := proto.Clone(msg)
+	for _, fn := range ignore {
+		fd := cleared.ProtoReflect().Descriptor().Fields().ByName(protoName(fn))
+		if fd == nil {
+			t.Fatalf("Unknown field %s", fn)
+		}
+		cleared.ProtoReflect().Clear(fd)
+	}
+
+	want, err := hashpb.Sum64(cleared)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	for name, sumFn := range map[string]func(proto.Message, ...hashpb.Option) (uint64, error){
+		"Sum64":     hashpb.Sum64,
+		"Sum64Auto": hashpb.Sum64Auto,
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := sumFn(msg, hashpb.WithIgnore(ignore...), hashpb.WithIgnoreMode(hashpb.IgnoreAsUnset))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if have != want {
+				t.Errorf("Digest differs from the digest of the cleared message: want=%x have=%x", want, have)
+			}
+
+			skipped, err := sumFn(msg, hashpb.WithIgnore(ignore...))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if skipped == want {
+				t.Error("Expected skipping ignored fields to differ from hashing them as unset")
+			}
+		})
+	}
+}
+
+func protoName(fullName string) protoreflect.Name {
+	return protoreflect.FullName(fullName).Name()
+}
@@ -0,0 +1,25 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// SumJSON unmarshals the protobuf JSON encoding of a message of the type described by md into a dynamic message and
+// returns its hash, so that messages stored as JSON can be hashed without their generated Go types. The digest is the
+// same as the digest of the message unmarshaled into its generated type. The types of google.protobuf.Any values
+// must be registered in protoregistry.GlobalTypes.
+func SumJSON(md protoreflect.MessageDescriptor, jsonBytes []byte, opts ...Option) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	if err := protojson.Unmarshal(jsonBytes, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s from JSON: %w", md.FullName(), err)
+	}
+
+	return Sum(nil, msg, opts...)
+}
@@ -0,0 +1,44 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestSumJSON(t *testing.T) {
+	msg := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt64: 42, SingleString: "foo", MapInt32String: map[int32]string{1: "a", 2: "b"}},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}}},
+	}
+
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	ignore := hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_string")
+	want, err := hashpb.Sum(nil, msg, ignore)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := hashpb.SumJSON(msg.ProtoReflect().Descriptor(), data, ignore)
+	if err != nil {
+		t.Fatalf("Failed to hash JSON: %v", err)
+	}
+
+	if !bytes.Equal(want, have) {
+		t.Errorf("Hash mismatch: want=%x have=%x", want, have)
+	}
+
+	if _, err := hashpb.SumJSON(msg.ProtoReflect().Descriptor(), []byte(`{"payload": 1}`)); err == nil {
+		t.Error("Expected error hashing invalid JSON")
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keys derives stable partition and routing keys from a subset of the fields of a message.
+//
+// A key is the digest of the selected fields, written in the order of their field number paths using the same
+// encoding as the hashpb package. Fields that are not selected don't affect the key, so producers using different
+// versions of a schema derive the same keys as long as the selected fields are unchanged.
+package keys
+
+import (
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type options struct {
+	hashFn func() hash.Hash
+}
+
+type Option func(*options)
+
+// WithHash sets the hash function used to calculate the key. Defaults to xxhash.
+func WithHash(hashFn func() hash.Hash) Option {
+	return func(o *options) {
+		o.hashFn = hashFn
+	}
+}
+
+// Deriver derives keys for messages of a single type.
+type Deriver struct {
+	md     protoreflect.MessageDescriptor
+	hashFn func() hash.Hash
+	paths  []path
+}
+
+// path is a sequence of fields starting at the root message. All fields except the last are singular message fields.
+type path []protoreflect.FieldDescriptor
+
+func (p path) String() string {
+	names := make([]string, len(p))
+	for i, fd := range p {
+		names[i] = string(fd.Name())
+	}
+
+	return strings.Join(names, ".")
+}
+
+func (p path) less(other path) bool {
+	for i := 0; i < len(p) && i < len(other); i++ {
+		if p[i].Number() != other[i].Number() {
+			return p[i].Number() < other[i].Number()
+		}
+	}
+
+	return len(p) < len(other)
+}
+
+// FromFieldMask creates a Deriver that derives keys from the fields selected by the mask.
+func FromFieldMask(md protoreflect.MessageDescriptor, mask *fieldmaskpb.FieldMask, opts ...Option) (*Deriver, error) {
+	paths := make([]path, 0, len(mask.GetPaths()))
+	for _, p := range mask.GetPaths() {
+		fp, err := resolvePath(md, p)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, fp)
+	}
+
+	return newDeriver(md, paths, opts)
+}
+
+// FromAnnotation creates a Deriver that derives keys from the fields of the message that are annotated with the
+// given boolean field option set to true. For example, given the following definitions, the key of an Order is derived
+// from the tenant and id fields.
+//
+//	extend google.protobuf.FieldOptions {
+//	  bool identity = 50000;
+//	}
+//
+//	message Order {
+//	  string tenant = 1 [(identity) = true];
+//	  string id = 2 [(identity) = true];
+//	  string note = 3;
+//	}
+func FromAnnotation(md protoreflect.MessageDescriptor, xt protoreflect.ExtensionType, opts ...Option) (*Deriver, error) {
+	xd := xt.TypeDescriptor()
+	if xd.ContainingMessage().FullName() != "google.protobuf.FieldOptions" || xd.Kind() != protoreflect.BoolKind || xd.IsList() {
+		return nil, fmt.Errorf("%s is not a boolean field option", xd.FullName())
+	}
+
+	types := new(protoregistry.Types)
+	if err := types.RegisterExtension(xt); err != nil {
+		return nil, fmt.Errorf("failed to register %s: %w", xd.FullName(), err)
+	}
+
+	var paths []path
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		annotated, err := hasAnnotation(fd, xt, types)
+		if err != nil {
+			return nil, err
+		}
+
+		if annotated {
+			paths = append(paths, path{fd})
+		}
+	}
+
+	return newDeriver(md, paths, opts)
+}
+
+func hasAnnotation(fd protoreflect.FieldDescriptor, xt protoreflect.ExtensionType, types *protoregistry.Types) (bool, error) {
+	fieldOpts := fd.Options()
+	if fieldOpts == nil {
+		return false, nil
+	}
+
+	if !proto.HasExtension(fieldOpts, xt) {
+		// Options of descriptors that were built at runtime keep extensions that were unknown at the time as unknown fields.
+		if len(fieldOpts.ProtoReflect().GetUnknown()) == 0 {
+			return false, nil
+		}
+
+		data, err := proto.Marshal(fieldOpts)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal options of %s: %w", fd.FullName(), err)
+		}
+
+		resolved := fieldOpts.ProtoReflect().New().Interface()
+		if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(data, resolved); err != nil {
+			return false, fmt.Errorf("failed to unmarshal options of %s: %w", fd.FullName(), err)
+		}
+
+		fieldOpts = resolved
+	}
+
+	annotated, _ := proto.GetExtension(fieldOpts, xt).(bool)
+	return annotated, nil
+}
+
+func resolvePath(md protoreflect.MessageDescriptor, p string) (path, error) {
+	var fp path
+	current := md
+	for _, name := range strings.Split(p, ".") {
+		if current == nil {
+			return nil, fmt.Errorf("invalid path %q: %s is not a singular message field", p, fp[len(fp)-1].FullName())
+		}
+
+		fd := current.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("invalid path %q: %s has no field named %s", p, current.FullName(), name)
+		}
+		fp = append(fp, fd)
+
+		current = nil
+		if fd.Message() != nil && fd.Cardinality() != protoreflect.Repeated {
+			current = fd.Message()
+		}
+	}
+
+	return fp, nil
+}
+
+func newDeriver(md protoreflect.MessageDescriptor, paths []path, opts []Option) (*Deriver, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no fields of %s are selected", md.FullName())
+	}
+
+	o := &options{hashFn: func() hash.Hash { return xxhash.New() }}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	sort.Slice(paths, func(i, j int) bool { return paths[i].less(paths[j]) })
+
+	// Drop duplicates.
+	deduped := paths[:1]
+	for _, p := range paths[1:] {
+		if p.String() != deduped[len(deduped)-1].String() {
+			deduped = append(deduped, p)
+		}
+	}
+
+	return &Deriver{md: md, hashFn: o.hashFn, paths: deduped}, nil
+}
+
+// Paths returns the paths of the selected fields in the order they are hashed.
+func (d *Deriver) Paths() []string {
+	paths := make([]string, len(d.paths))
+	for i, p := range d.paths {
+		paths[i] = p.String()
+	}
+
+	return paths
+}
+
+// Key calculates the key of the message and appends it to dst.
+func (d *Deriver) Key(dst []byte, msg proto.Message) ([]byte, error) {
+	hasher := d.hashFn()
+	if err := d.write(hasher, msg); err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(dst), nil
+}
+
+// Key64 calculates the 64-bit key of the message. The hash function must implement hash.Hash64.
+func (d *Deriver) Key64(msg proto.Message) (uint64, error) {
+	hasher, ok := d.hashFn().(hash.Hash64)
+	if !ok {
+		return 0, fmt.Errorf("%w: hash function does not implement hash.Hash64", hashpb.ErrUnsupportedHash)
+	}
+
+	if err := d.write(hasher, msg); err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
+
+func (d *Deriver) write(hasher hash.Hash, msg proto.Message) error {
+	if msg == nil {
+		return fmt.Errorf("%w: message is nil", hashpb.ErrInvalidMessage)
+	}
+
+	m := msg.ProtoReflect()
+	if m.Descriptor().FullName() != d.md.FullName() {
+		return fmt.Errorf("%w: expected message of type %s, got %s", hashpb.ErrInvalidMessage, d.md.FullName(), m.Descriptor().FullName())
+	}
+
+	for _, p := range d.paths {
+		// Unset intermediate messages are treated as empty messages.
+		current := m
+		for _, fd := range p[:len(p)-1] {
+			current = current.Get(fd).Message()
+		}
+
+		if err := hashpb.HashField(hasher, current, p[len(p)-1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
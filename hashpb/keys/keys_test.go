@@ -0,0 +1,172 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package keys_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/keys"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFromFieldMask(t *testing.T) {
+	md := (&pb.NestedTestAllTypes{}).ProtoReflect().Descriptor()
+	d, err := keys.FromFieldMask(md, &fieldmaskpb.FieldMask{Paths: []string{"payload.single_string", "child.payload.single_int32", "payload.single_int32"}})
+	if err != nil {
+		t.Fatalf("Failed to create deriver: %v", err)
+	}
+
+	wantPaths := []string{"child.payload.single_int32", "payload.single_int32", "payload.single_string"}
+	if have := d.Paths(); !reflect.DeepEqual(have, wantPaths) {
+		t.Errorf("Unexpected paths: want=%v have=%v", wantPaths, have)
+	}
+
+	msg := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt32: 42, SingleString: "wibble", SingleBool: true},
+	}
+
+	// The child is unset, so its fields hash to their defaults.
+	payloadMD := md.Fields().ByName("payload").Message()
+	hasher := xxhash.New()
+	mustHashField(t, hasher, (&pb.TestAllTypes{}).ProtoReflect(), payloadMD.Fields().ByName("single_int32"))
+	mustHashField(t, hasher, msg.Payload.ProtoReflect(), payloadMD.Fields().ByName("single_int32"))
+	mustHashField(t, hasher, msg.Payload.ProtoReflect(), payloadMD.Fields().ByName("single_string"))
+
+	key, err := d.Key64(msg)
+	if err != nil {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+
+	if want := hasher.Sum64(); key != want {
+		t.Errorf("Unexpected key: want=%x have=%x", want, key)
+	}
+
+	// Fields that are not selected don't affect the key.
+	other := proto.Clone(msg).(*pb.NestedTestAllTypes)
+	other.Payload.SingleBool = false
+	other.Payload.RepeatedString = []string{"wobble"}
+	if otherKey, err := d.Key64(other); err != nil || otherKey != key {
+		t.Errorf("Unselected fields changed the key: %x != %x (%v)", otherKey, key, err)
+	}
+
+	for _, paths := range [][]string{{}, {"wibble"}, {"payload.repeated_nested_message.bb"}, {"payload.single_string.wibble"}} {
+		if _, err := keys.FromFieldMask(md, &fieldmaskpb.FieldMask{Paths: paths}); err == nil {
+			t.Errorf("Expected error for paths %v", paths)
+		}
+	}
+
+	if _, err := d.Key64(msg.Payload); err == nil {
+		t.Error("Expected error for message of a different type")
+	}
+}
+
+func TestFromAnnotation(t *testing.T) {
+	xt, md := mkAnnotatedTypes(t)
+	d, err := keys.FromAnnotation(md, xt)
+	if err != nil {
+		t.Fatalf("Failed to create deriver: %v", err)
+	}
+
+	if have, want := d.Paths(), []string{"tenant", "id"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("Unexpected paths: want=%v have=%v", want, have)
+	}
+
+	mkOrder := func(tenant, id, note string) proto.Message {
+		msg := dynamicpb.NewMessage(md)
+		msg.Set(md.Fields().ByName("tenant"), protoreflect.ValueOfString(tenant))
+		msg.Set(md.Fields().ByName("id"), protoreflect.ValueOfString(id))
+		msg.Set(md.Fields().ByName("note"), protoreflect.ValueOfString(note))
+		return msg
+	}
+
+	key1, err := d.Key(nil, mkOrder("acme", "42", "wibble"))
+	if err != nil {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+
+	key2, err := d.Key(nil, mkOrder("acme", "42", "wobble"))
+	if err != nil {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+
+	key3, err := d.Key(nil, mkOrder("acme", "43", "wibble"))
+	if err != nil {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+
+	if string(key1) != string(key2) {
+		t.Error("Unannotated field changed the key")
+	}
+
+	if string(key1) == string(key3) {
+		t.Error("Annotated field did not change the key")
+	}
+
+	if _, err := keys.FromAnnotation((&pb.TestAllTypes{}).ProtoReflect().Descriptor(), xt); err == nil {
+		t.Error("Expected error for message without annotated fields")
+	}
+}
+
+// mkAnnotatedTypes builds an identity field option and an Order message using it.
+func mkAnnotatedTypes(t *testing.T) (protoreflect.ExtensionType, protoreflect.MessageDescriptor) {
+	t.Helper()
+
+	identity := func() *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		unknown := protowire.AppendTag(nil, 50000, protowire.VarintType)
+		opts.ProtoReflect().SetUnknown(protowire.AppendVarint(unknown, 1))
+		return opts
+	}
+
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("keys_test.proto"),
+		Package:    proto.String("cerbos.hashpb.keys.test"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Syntax:     proto.String("proto3"),
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("identity"),
+			Number:   proto.Int32(50000),
+			Label:    optional,
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+			Extendee: proto.String(".google.protobuf.FieldOptions"),
+			JsonName: proto.String("identity"),
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("note"), Number: proto.Int32(3), Label: optional, Type: str, JsonName: proto.String("note")},
+				{Name: proto.String("id"), Number: proto.Int32(2), Label: optional, Type: str, JsonName: proto.String("id"), Options: identity()},
+				{Name: proto.String("tenant"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("tenant"), Options: identity()},
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("Failed to build file: %v", err)
+	}
+
+	return dynamicpb.NewExtensionType(fd.Extensions().Get(0)), fd.Messages().Get(0)
+}
+
+func mustHashField(t *testing.T, hasher *xxhash.Digest, m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	t.Helper()
+
+	if err := hashpb.HashField(hasher, m, fd); err != nil {
+		t.Fatalf("Failed to hash field: %v", err)
+	}
+}
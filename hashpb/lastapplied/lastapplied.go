@@ -0,0 +1,94 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lastapplied records the hash of a resource specification in an annotation so that controllers can cheaply
+// detect whether the specification changed since it was last reconciled.
+//
+// The annotations are plain maps so that the package can be used with Kubernetes ObjectMeta annotations as well as
+// with protobuf-defined resources that carry their own metadata.
+package lastapplied
+
+import (
+	"encoding/hex"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultAnnotation is the annotation used to store the hash unless WithAnnotation is used.
+const DefaultAnnotation = "hashpb.cerbos.dev/last-applied-hash"
+
+type Option func(*Tracker)
+
+// WithAnnotation sets the annotation used to store the hash.
+func WithAnnotation(key string) Option {
+	return func(t *Tracker) {
+		t.annotation = key
+	}
+}
+
+// WithHashOptions sets the options used to hash the specification. Use hashpb.WithIgnore to exclude status and
+// metadata fields when hashing a whole resource.
+func WithHashOptions(opts ...hashpb.Option) Option {
+	return func(t *Tracker) {
+		t.hashOpts = opts
+	}
+}
+
+// Tracker reads and writes the hash annotation.
+type Tracker struct {
+	annotation string
+	hashOpts   []hashpb.Option
+}
+
+// New creates a Tracker that stores the hash in DefaultAnnotation unless WithAnnotation is used.
+func New(opts ...Option) *Tracker {
+	t := &Tracker{annotation: DefaultAnnotation}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Hash returns the hex-encoded hash of the specification.
+func (t *Tracker) Hash(spec proto.Message) (string, error) {
+	digest, err := hashpb.Sum(nil, spec, t.hashOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(digest), nil
+}
+
+// Changed reports whether the hash of the specification differs from the one recorded in the annotations.
+// It returns true if no hash has been recorded.
+func (t *Tracker) Changed(annotations map[string]string, spec proto.Message) (bool, error) {
+	recorded, ok := annotations[t.annotation]
+	if !ok {
+		return true, nil
+	}
+
+	h, err := t.Hash(spec)
+	if err != nil {
+		return false, err
+	}
+
+	return h != recorded, nil
+}
+
+// Record stores the hash of the specification in the annotations. If annotations is nil, a new map is allocated.
+// The annotations are returned to allow assigning the result back to a nil field.
+func (t *Tracker) Record(annotations map[string]string, spec proto.Message) (map[string]string, error) {
+	h, err := t.Hash(spec)
+	if err != nil {
+		return annotations, err
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[t.annotation] = h
+
+	return annotations, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package lastapplied_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/lastapplied"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestTracker(t *testing.T) {
+	// The payload plays the role of the spec and the child the role of the status.
+	tracker := lastapplied.New(
+		lastapplied.WithAnnotation("example.com/spec-hash"),
+		lastapplied.WithHashOptions(hashpb.WithIgnore("cerbos.hashpb.test.NestedTestAllTypes.child")),
+	)
+
+	resource := &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleString: "wibble"}}
+
+	mustChanged(t, tracker, nil, resource, true)
+
+	annotations, err := tracker.Record(nil, resource)
+	if err != nil {
+		t.Fatalf("Failed to record hash: %v", err)
+	}
+
+	if _, ok := annotations["example.com/spec-hash"]; !ok {
+		t.Fatalf("Annotation not recorded: %v", annotations)
+	}
+
+	mustChanged(t, tracker, annotations, resource, false)
+
+	resource.Child = &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 42}}
+	mustChanged(t, tracker, annotations, resource, false)
+
+	resource.Payload.SingleString = "wobble"
+	mustChanged(t, tracker, annotations, resource, true)
+
+	if _, err := tracker.Record(annotations, resource); err != nil {
+		t.Fatalf("Failed to record hash: %v", err)
+	}
+
+	mustChanged(t, tracker, annotations, resource, false)
+	mustChanged(t, lastapplied.New(), annotations, resource, true)
+}
+
+func mustChanged(t *testing.T, tracker *lastapplied.Tracker, annotations map[string]string, spec *pb.NestedTestAllTypes, want bool) {
+	t.Helper()
+
+	have, err := tracker.Changed(annotations, spec)
+	if err != nil {
+		t.Fatalf("Failed to check hash: %v", err)
+	}
+
+	if have != want {
+		t.Errorf("Unexpected result: want=%t have=%t", want, have)
+	}
+}
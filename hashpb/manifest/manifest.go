@@ -0,0 +1,155 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manifest records the digests of sample instances of message types so that changes to the digests
+// (caused by schema changes or changes to the hashing scheme) can be detected before they are deployed.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Entry holds the digests of the sample instances of a message type.
+type Entry struct {
+	Type    string   `json:"type"`
+	Digests []string `json:"digests"`
+}
+
+// Manifest is a list of entries sorted by message type.
+type Manifest struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Build computes the manifest for all message types in files.
+// The sample instances are created using testgen.NewPopulated with the seeds 0 to testgen.GoldenSeeds-1.
+func Build(files *protoregistry.Files) (*Manifest, error) {
+	var msgs []protoreflect.MessageDescriptor
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		msgs = collectMessages(msgs, fd.Messages())
+		return true
+	})
+
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].FullName() < msgs[j].FullName()
+	})
+
+	m := &Manifest{Entries: make([]Entry, len(msgs))}
+	for i, md := range msgs {
+		entry := Entry{Type: string(md.FullName()), Digests: make([]string, testgen.GoldenSeeds)}
+		for seed := int64(0); seed < testgen.GoldenSeeds; seed++ {
+			digest, err := hashpb.Sum64(testgen.NewPopulated(dynamicpb.NewMessage(md), seed))
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash %s with seed %d: %w", md.FullName(), seed, err)
+			}
+			entry.Digests[seed] = fmt.Sprintf("%016x", digest)
+		}
+		m.Entries[i] = entry
+	}
+
+	return m, nil
+}
+
+func collectMessages(msgs []protoreflect.MessageDescriptor, mds protoreflect.MessageDescriptors) []protoreflect.MessageDescriptor {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if !md.IsMapEntry() {
+			msgs = append(msgs, md)
+		}
+		msgs = collectMessages(msgs, md.Messages())
+	}
+
+	return msgs
+}
+
+// Load reads a manifest from path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// Write writes the manifest to path.
+func (m *Manifest) Write(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Diff lists the message types that differ between two manifests.
+type Diff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// Empty reports whether the manifests are identical.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Compare returns the message types that were added, removed, or whose digests changed in current compared to previous.
+func Compare(previous, current *Manifest) Diff {
+	prevEntries := make(map[string]Entry, len(previous.Entries))
+	for _, e := range previous.Entries {
+		prevEntries[e.Type] = e
+	}
+
+	var diff Diff
+	for _, e := range current.Entries {
+		prev, ok := prevEntries[e.Type]
+		if !ok {
+			diff.Added = append(diff.Added, e.Type)
+			continue
+		}
+
+		delete(prevEntries, e.Type)
+		if !equal(prev.Digests, e.Digests) {
+			diff.Changed = append(diff.Changed, e.Type)
+		}
+	}
+
+	for t := range prevEntries {
+		diff.Removed = append(diff.Removed, t)
+	}
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/manifest"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestManifest(t *testing.T) {
+	files := &protoregistry.Files{}
+	registerFile(t, files, pb.File_internal_pb_all_types_proto)
+
+	current, err := manifest.Build(files)
+	if err != nil {
+		t.Fatalf("Failed to build manifest: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := current.Write(path); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	previous, err := manifest.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	if diff := manifest.Compare(previous, current); !diff.Empty() {
+		t.Fatalf("Expected empty diff: %+v", diff)
+	}
+
+	previous.Entries[0].Digests[1] = "0000000000000000"
+	previous.Entries = previous.Entries[:len(previous.Entries)-1]
+	previous.Entries = append(previous.Entries, manifest.Entry{Type: "removed.Msg"})
+
+	want := manifest.Diff{
+		Added:   []string{current.Entries[len(current.Entries)-1].Type},
+		Removed: []string{"removed.Msg"},
+		Changed: []string{current.Entries[0].Type},
+	}
+	if have := manifest.Compare(previous, current); !reflect.DeepEqual(want, have) {
+		t.Fatalf("Unexpected diff: want=%+v have=%+v", want, have)
+	}
+}
+
+func registerFile(t *testing.T, files *protoregistry.Files, fd protoreflect.FileDescriptor) {
+	t.Helper()
+
+	if _, err := files.FindFileByPath(fd.Path()); err == nil {
+		return
+	}
+
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		registerFile(t, files, imports.Get(i).FileDescriptor)
+	}
+
+	if err := files.RegisterFile(fd); err != nil {
+		t.Fatalf("Failed to register %s: %v", fd.Path(), err)
+	}
+}
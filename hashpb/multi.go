@@ -0,0 +1,69 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+)
+
+// WithHashers feeds the bytes written to the hash function to the given hashers as well, so that several digests can be
+// computed in a single traversal of the message. The digest returned by Sum and Sum64 is the digest of the hash function
+// set using WithHash; call Sum on the given hashers afterwards to get theirs. With SchemeObjectHash, the hashers receive
+// the ObjectHash digest of the message.
+func WithHashers(hashers ...hash.Hash) Option {
+	return func(o *Options) {
+		o.hashers = append(o.hashers, hashers...)
+	}
+}
+
+// NewMultiHasher returns a hash.Hash that writes to all the given hashers, like io.MultiWriter. Sum, Size and BlockSize
+// are those of the first hasher. Pass it to a generated HashPB method to compute several digests in a single traversal.
+// It panics if no hashers are given.
+func NewMultiHasher(hashers ...hash.Hash) hash.Hash {
+	if len(hashers) == 0 {
+		panic("hashpb: NewMultiHasher requires at least one hasher")
+	}
+
+	if len(hashers) == 1 {
+		return hashers[0]
+	}
+
+	return multiHasher(hashers)
+}
+
+type multiHasher []hash.Hash
+
+func (m multiHasher) Write(p []byte) (int, error) {
+	for _, h := range m {
+		if n, err := h.Write(p); err != nil {
+			return n, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (m multiHasher) Sum(b []byte) []byte {
+	return m[0].Sum(b)
+}
+
+func (m multiHasher) Reset() {
+	for _, h := range m {
+		h.Reset()
+	}
+}
+
+func (m multiHasher) Size() int {
+	return m[0].Size()
+}
+
+func (m multiHasher) BlockSize() int {
+	return m[0].BlockSize()
+}
+
+// MultiHashable is implemented by messages with HashPBMulti methods generated by protoc-gen-go-hashpb using the
+// multi_hash=true parameter. The method writes the message to all the given hashers in a single traversal.
+type MultiHashable interface {
+	HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash)
+}
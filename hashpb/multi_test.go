@@ -0,0 +1,73 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+)
+
+func TestWithHashers(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(2)
+
+	wantXX, err := hashpb.Sum64(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	wantSHA, err := hashpb.Sum(nil, msg, hashpb.WithHash(sha256.New))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	for name, sum := range map[string]func(...hashpb.Option) (uint64, error){
+		"reflection": func(opts ...hashpb.Option) (uint64, error) { return hashpb.Sum64(msg, opts...) },
+		"auto":       func(opts ...hashpb.Option) (uint64, error) { return hashpb.Sum64Auto(msg, opts...) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			sha := sha256.New()
+			have, err := sum(hashpb.WithHashers(sha))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if have != wantXX {
+				t.Errorf("Digest mismatch: want=%x have=%x", wantXX, have)
+			}
+
+			if haveSHA := sha.Sum(nil); !bytes.Equal(haveSHA, wantSHA) {
+				t.Errorf("Extra digest mismatch: want=%x have=%x", wantSHA, haveSHA)
+			}
+		})
+	}
+}
+
+func TestHashPBMulti(t *testing.T) {
+	msg := mkTestAllTypesMsg()
+	ignore := map[string]struct{}{"cerbos.hashpb.test.TestAllTypes.single_string": {}}
+
+	xx, sha := xxhash.New(), sha256.New()
+	msg.HashPBMulti(ignore, xx, sha)
+
+	wantXX, wantSHA := xxhash.New(), sha256.New()
+	msg.HashPB(wantXX, ignore)
+	msg.HashPB(wantSHA, ignore)
+
+	if want, have := wantXX.Sum(nil), xx.Sum(nil); !bytes.Equal(want, have) {
+		t.Errorf("xxhash digest mismatch: want=%x have=%x", want, have)
+	}
+
+	if want, have := wantSHA.Sum(nil), sha.Sum(nil); !bytes.Equal(want, have) {
+		t.Errorf("sha256 digest mismatch: want=%x have=%x", want, have)
+	}
+
+	multi := hashpb.NewMultiHasher(xxhash.New(), sha256.New())
+	if multi.Size() != 8 {
+		t.Errorf("Expected size of the first hasher, was %d", multi.Size())
+	}
+}
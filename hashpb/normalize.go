@@ -0,0 +1,27 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "golang.org/x/text/unicode/norm"
+
+// WithNormalizeUnicode normalizes the values of string fields to Unicode Normalization Form C (NFC) before hashing
+// them, so that visually identical strings using different code point sequences (for example, the decomposed forms
+// produced by macOS) have the same digest. Map keys are normalized as well when using SchemeObjectHash, which hashes
+// them, but not with the default scheme, which uses them only to order the map entries.
+// Code generated with the normalize_unicode=true parameter normalizes strings in the same way. SumAuto and Sum64Auto
+// can't tell whether the generated methods normalize strings, so they use reflection when this option is set.
+func WithNormalizeUnicode() Option {
+	return func(o *Options) {
+		o.nfc = true
+	}
+}
+
+// normalizeString returns s in NFC if normalization is enabled.
+func normalizeString(s string, nfc bool) string {
+	if !nfc {
+		return s
+	}
+
+	return norm.NFC.String(s)
+}
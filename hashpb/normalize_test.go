@@ -0,0 +1,38 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestWithNormalizeUnicode(t *testing.T) {
+	composed := &pb.TestAllTypes{SingleString: "caf\u00e9", MapStringString: map[string]string{"k": "\u00e9"}}
+	decomposed := &pb.TestAllTypes{SingleString: "cafe\u0301", MapStringString: map[string]string{"k": "e\u0301"}}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(m *pb.TestAllTypes, opts ...hashpb.Option) []byte {
+			t.Helper()
+
+			digest, err := hashpb.SumAuto(nil, m, append(opts, hashpb.WithScheme(scheme))...)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		if bytes.Equal(sum(composed), sum(decomposed)) {
+			t.Errorf("Expected different digests without normalization with scheme %v", scheme)
+		}
+
+		if !bytes.Equal(sum(composed, hashpb.WithNormalizeUnicode()), sum(decomposed, hashpb.WithNormalizeUnicode())) {
+			t.Errorf("Expected identical digests with normalization with scheme %v", scheme)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+const valueName protoreflect.FullName = "google.protobuf.Value"
+
+// WithNullAsUnset hashes google.protobuf.Value messages that are null (set to null_value or without any kind set) as
+// if they were absent, because JSON round-trips produce both forms interchangeably. Singular Value fields that are
+// null are hashed like unset fields, entries of maps (such as the fields of a google.protobuf.Struct) with null
+// values are left out, and null elements of lists are hashed like a Value without any kind set.
+// By default, a Value set to null_value is hashed like any other value, so it is distinct from an absent one.
+// The generated methods don't support this option, so SumAuto and Sum64Auto use reflection when it is set.
+func WithNullAsUnset() Option {
+	return func(o *Options) {
+		o.nullAsUnset = true
+	}
+}
+
+// isNullValue reports whether m is a google.protobuf.Value that is null.
+func isNullValue(m protoreflect.Message) bool {
+	md := m.Descriptor()
+	if md.FullName() != valueName {
+		return false
+	}
+
+	if !m.IsValid() {
+		return true
+	}
+
+	fd := m.WhichOneof(md.Oneofs().ByName("kind"))
+	return fd == nil || fd.Name() == "null_value"
+}
+
+// isNullField reports whether v is the value of a singular field of type google.protobuf.Value that is null.
+func isNullField(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	return !fd.IsList() && !fd.IsMap() && fd.Message() != nil && isNullValue(v.Message())
+}
@@ -0,0 +1,83 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestWithNullAsUnset(t *testing.T) {
+	mkStruct := func(fields map[string]*structpb.Value) *structpb.Struct {
+		return &structpb.Struct{Fields: fields}
+	}
+
+	testCases := []struct {
+		name   string
+		null   proto.Message
+		absent proto.Message
+	}{
+		{
+			name:   "singular",
+			null:   &pb.TestAllTypes{SingleValue: structpb.NewNullValue()},
+			absent: &pb.TestAllTypes{},
+		},
+		{
+			name:   "no_kind",
+			null:   &pb.TestAllTypes{SingleValue: &structpb.Value{}},
+			absent: &pb.TestAllTypes{},
+		},
+		{
+			name:   "struct_field",
+			null:   mkStruct(map[string]*structpb.Value{"a": structpb.NewNullValue(), "b": structpb.NewBoolValue(true)}),
+			absent: mkStruct(map[string]*structpb.Value{"b": structpb.NewBoolValue(true)}),
+		},
+		{
+			name:   "list_element",
+			null:   &structpb.ListValue{Values: []*structpb.Value{structpb.NewNullValue(), structpb.NewNumberValue(1)}},
+			absent: &structpb.ListValue{Values: []*structpb.Value{{}, structpb.NewNumberValue(1)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+				sum := func(m proto.Message, opts ...hashpb.Option) []byte {
+					t.Helper()
+
+					digest, err := hashpb.SumAuto(nil, m, append(opts, hashpb.WithScheme(scheme))...)
+					if err != nil {
+						t.Fatalf("Failed to hash: %v", err)
+					}
+
+					return digest
+				}
+
+				if !bytes.Equal(sum(tc.null, hashpb.WithNullAsUnset()), sum(tc.absent, hashpb.WithNullAsUnset())) {
+					t.Errorf("Expected null value to hash like an absent value with scheme %v", scheme)
+				}
+			}
+		})
+	}
+
+	if bytes.Equal(sumBytes(t, &pb.TestAllTypes{SingleValue: structpb.NewNullValue()}), sumBytes(t, &pb.TestAllTypes{})) {
+		t.Error("Expected null value to be distinct from an absent value without the option")
+	}
+}
+
+func sumBytes(t *testing.T, m proto.Message, opts ...hashpb.Option) []byte {
+	t.Helper()
+
+	digest, err := hashpb.Sum(nil, m, opts...)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	return digest
+}
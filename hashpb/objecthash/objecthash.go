@@ -0,0 +1,187 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package objecthash implements the primitives of the ObjectHash algorithm (https://github.com/benlaurie/objecthash)
+// as used by objecthash-proto (https://github.com/deepmind/objecthash-proto).
+//
+// Every value is hashed as SHA-256(tag || content):
+//
+//   - booleans use tag 'b' and content "1" or "0"
+//   - integers use tag 'i' and their decimal representation
+//   - floats use tag 'f' and the normalized representation described by ObjectHash
+//   - strings use tag 'u' and their UTF-8 bytes
+//   - bytes use tag 'r' and the raw bytes
+//   - lists use tag 'l' and the concatenated digests of their elements
+//   - unordered collections use tag 's' and the sorted concatenation of the digests of their elements
+//   - dictionaries use tag 'd' and the sorted concatenation of the digest of each key followed by the digest of its value
+//
+// Messages are hashed as dictionaries keyed by field number. Fields that are not populated are omitted.
+// The hashpb package computes message digests using reflection and the generated ObjectHashPB methods use this
+// package directly.
+package objecthash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Size is the size of a digest in bytes.
+const Size = sha256.Size
+
+func hash(tag byte, content []byte) [Size]byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte{tag})
+	_, _ = h.Write(content)
+
+	var digest [Size]byte
+	h.Sum(digest[:0])
+	return digest
+}
+
+// Bool returns the digest of a boolean.
+func Bool(b bool) [Size]byte {
+	if b {
+		return hash('b', []byte("1"))
+	}
+
+	return hash('b', []byte("0"))
+}
+
+// Int returns the digest of a signed integer.
+func Int(i int64) [Size]byte {
+	return hash('i', strconv.AppendInt(nil, i, 10))
+}
+
+// Uint returns the digest of an unsigned integer.
+func Uint(u uint64) [Size]byte {
+	return hash('i', strconv.AppendUint(nil, u, 10))
+}
+
+// Float returns the digest of a floating point number. 32-bit floats must be converted to float64 first.
+func Float(f float64) [Size]byte {
+	switch {
+	case math.IsNaN(f):
+		return hash('f', []byte("NaN"))
+	case math.IsInf(f, 1):
+		return hash('f', []byte("Infinity"))
+	case math.IsInf(f, -1):
+		return hash('f', []byte("-Infinity"))
+	default:
+		return hash('f', normalizeFloat(f))
+	}
+}
+
+// normalizeFloat returns the sign, the binary exponent and the bits of the mantissa, in the range (0.5, 1], of f.
+func normalizeFloat(f float64) []byte {
+	if f == 0 {
+		return []byte("+0:")
+	}
+
+	b := []byte{'+'}
+	if f < 0 {
+		b[0] = '-'
+		f = -f
+	}
+
+	e := 0
+	for f > 1 {
+		f /= 2
+		e++
+	}
+
+	for f <= 0.5 {
+		f *= 2
+		e--
+	}
+
+	b = strconv.AppendInt(b, int64(e), 10)
+	b = append(b, ':')
+
+	for f != 0 {
+		if f >= 1 {
+			b = append(b, '1')
+			f--
+		} else {
+			b = append(b, '0')
+		}
+		f *= 2
+	}
+
+	return b
+}
+
+// String returns the digest of a string.
+func String(s string) [Size]byte {
+	return hash('u', []byte(s))
+}
+
+// Bytes returns the digest of a byte slice.
+func Bytes(b []byte) [Size]byte {
+	return hash('r', b)
+}
+
+// List accumulates the digests of the elements of a list.
+type List struct {
+	buf []byte
+}
+
+// Add appends the digest of the next element.
+func (l *List) Add(elem [Size]byte) {
+	l.buf = append(l.buf, elem[:]...)
+}
+
+// Sum returns the digest of the list.
+func (l *List) Sum() [Size]byte {
+	return hash('l', l.buf)
+}
+
+// Set accumulates the digests of the elements of an unordered collection. Elements can be added in any order and
+// duplicate elements are kept, so it hashes multisets.
+type Set struct {
+	elems [][Size]byte
+}
+
+// Add adds the digest of an element.
+func (s *Set) Add(elem [Size]byte) {
+	s.elems = append(s.elems, elem)
+}
+
+// Sum returns the digest of the set, which uses tag 's' and the sorted concatenation of the digests of its elements.
+func (s *Set) Sum() [Size]byte {
+	sort.Slice(s.elems, func(i, j int) bool { return bytes.Compare(s.elems[i][:], s.elems[j][:]) < 0 })
+
+	buf := make([]byte, 0, len(s.elems)*Size)
+	for _, e := range s.elems {
+		buf = append(buf, e[:]...)
+	}
+
+	return hash('s', buf)
+}
+
+// Dict accumulates the digests of the entries of a dictionary. Entries can be added in any order.
+type Dict struct {
+	entries [][2 * Size]byte
+}
+
+// Add adds an entry with the given key and value digests.
+func (d *Dict) Add(key, value [Size]byte) {
+	var entry [2 * Size]byte
+	copy(entry[:Size], key[:])
+	copy(entry[Size:], value[:])
+	d.entries = append(d.entries, entry)
+}
+
+// Sum returns the digest of the dictionary.
+func (d *Dict) Sum() [Size]byte {
+	sort.Slice(d.entries, func(i, j int) bool { return bytes.Compare(d.entries[i][:], d.entries[j][:]) < 0 })
+
+	buf := make([]byte, 0, len(d.entries)*2*Size)
+	for _, e := range d.entries {
+		buf = append(buf, e[:]...)
+	}
+
+	return hash('d', buf)
+}
@@ -0,0 +1,176 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package objecthash_test
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+)
+
+// The expected digests are taken from the ObjectHash test suite.
+func TestPrimitives(t *testing.T) {
+	list := func(elems ...[objecthash.Size]byte) [objecthash.Size]byte {
+		var l objecthash.List
+		for _, e := range elems {
+			l.Add(e)
+		}
+		return l.Sum()
+	}
+
+	dict := func(kvs ...[objecthash.Size]byte) [objecthash.Size]byte {
+		var d objecthash.Dict
+		for i := 0; i < len(kvs); i += 2 {
+			d.Add(kvs[i], kvs[i+1])
+		}
+		return d.Sum()
+	}
+
+	testCases := []struct {
+		name   string
+		digest [objecthash.Size]byte
+		want   string
+	}{
+		{name: "empty_list", digest: list(), want: "acac86c0e609ca906f632b0e2dacccb2b77d22b0621f20ebece1a4835b93f6f0"},
+		{name: "string_list", digest: list(objecthash.String("foo")), want: "268bc27d4974d9d576222e4cdbb8f7c6bd6791894098645a19eeca9c102d0964"},
+		{name: "string_list_2", digest: list(objecthash.String("foo"), objecthash.String("bar")), want: "32ae896c413cfdc79eec68be9139c86ded8b279238467c216cf2bec4d5f1e4a2"},
+		{name: "int_list", digest: list(objecthash.Int(123)), want: "1b93f704451e1a7a1b8c03626ffcd6dec0bc7ace947ff60d52e1b69b4658ccaa"},
+		{name: "uint_list", digest: list(objecthash.Uint(123)), want: "1b93f704451e1a7a1b8c03626ffcd6dec0bc7ace947ff60d52e1b69b4658ccaa"},
+		{name: "empty_dict", digest: dict(), want: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4"},
+		{name: "dict", digest: dict(objecthash.String("foo"), objecthash.String("bar")), want: "7ef5237c3027d6c58100afadf37796b3d351025cf28038280147d42fdc53b960"},
+		{name: "float", digest: objecthash.Float(1.2345), want: "844e08b1195a93563db4e5d4faa59759ba0e0397caf065f3b6bc0825499754e0"},
+		{name: "negative_float", digest: objecthash.Float(-10.1234), want: "59b49ae24998519925833e3ff56727e5d4868aba4ecf4c53653638ebff53c366"},
+		{name: "unicode", digest: objecthash.String("ԱԲաբ"), want: "2a2a4485a4e338d8df683971956b1090d2f5d33955a81ecaad1a75125f7a316c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := hex.EncodeToString(tc.digest[:]); have != tc.want {
+				t.Errorf("Digest mismatch: want=%s have=%s", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestDictOrder(t *testing.T) {
+	var d1, d2 objecthash.Dict
+	d1.Add(objecthash.Int(1), objecthash.String("a"))
+	d1.Add(objecthash.Int(2), objecthash.String("b"))
+	d2.Add(objecthash.Int(2), objecthash.String("b"))
+	d2.Add(objecthash.Int(1), objecthash.String("a"))
+
+	if d1.Sum() != d2.Sum() {
+		t.Error("Dictionary digest depends on insertion order")
+	}
+}
+
+func TestSetOrder(t *testing.T) {
+	var s1, s2, s3 objecthash.Set
+	s1.Add(objecthash.String("a"))
+	s1.Add(objecthash.String("b"))
+	s2.Add(objecthash.String("b"))
+	s2.Add(objecthash.String("a"))
+	s3.Add(objecthash.String("a"))
+	s3.Add(objecthash.String("b"))
+	s3.Add(objecthash.String("a"))
+
+	if s1.Sum() != s2.Sum() {
+		t.Error("Set digest depends on insertion order")
+	}
+
+	if s1.Sum() == s3.Sum() {
+		t.Error("Expected duplicate elements to affect the digest")
+	}
+
+	var l objecthash.List
+	l.Add(objecthash.String("a"))
+	l.Add(objecthash.String("b"))
+	if s1.Sum() == l.Sum() {
+		t.Error("Expected sets and lists with the same elements to have different digests")
+	}
+}
+
+// The expected digests are the golden message digests of the hashpb tests (see TestSchemeObjectHashVectors), which
+// follow the objecthash-proto rules: messages are dictionaries keyed by field number and fields set to their default
+// values are omitted.
+func TestMessages(t *testing.T) {
+	list := func(elems ...[objecthash.Size]byte) [objecthash.Size]byte {
+		var l objecthash.List
+		for _, e := range elems {
+			l.Add(e)
+		}
+		return l.Sum()
+	}
+
+	dict := func(kvs ...[objecthash.Size]byte) [objecthash.Size]byte {
+		var d objecthash.Dict
+		for i := 0; i < len(kvs); i += 2 {
+			d.Add(kvs[i], kvs[i+1])
+		}
+		return d.Sum()
+	}
+
+	field := objecthash.Int
+
+	testCases := []struct {
+		name   string
+		digest [objecthash.Size]byte
+		want   string
+	}{
+		{
+			name:   "proto3_defaults_omitted",
+			digest: dict(field(2), objecthash.Int(7)),
+			want:   "b6e010905e9fe319f84d1db4135e3fe239d1c3b5cbde63e0e0881a8054c1fd87",
+		},
+		{
+			name:   "unsigned_integers",
+			digest: dict(field(4), objecthash.Uint(math.MaxUint64), field(7), objecthash.Uint(42)),
+			want:   "edbb1d439a091176f4908be10eeb0550fef95e07b763d6a36a2e09df8804e713",
+		},
+		{
+			name:   "enums",
+			digest: dict(field(22), objecthash.Int(2), field(51), list(objecthash.Int(1), objecthash.Int(0))),
+			want:   "45f8d26c0464be319e8b96c7215cfa9fbc435530ef16a51a6f66cf2ec3dcbd77",
+		},
+		{
+			name: "repeated",
+			digest: dict(
+				field(44), list(objecthash.String("foo"), objecthash.String("bar")),
+				field(36), list(objecthash.Int(1), objecthash.Int(-2)),
+				field(45), list(objecthash.Bytes([]byte{0xff})),
+			),
+			want: "112707fc84945fc4300b6cfd93b3f19d3f966c4f8cef8d8396a9983cec30f9ca",
+		},
+		{
+			name: "maps",
+			digest: dict(
+				field(58), dict(objecthash.String("a"), objecthash.String("x"), objecthash.String("b"), objecthash.String("y")),
+				field(60), dict(objecthash.Int(-1), objecthash.String("minus one")),
+				field(61), dict(objecthash.Bool(true), objecthash.String("yes")),
+				field(62), dict(objecthash.Int(3), dict(field(1), objecthash.Int(4))),
+			),
+			want: "f7bd733c0efce93fc7a5e98a24b0ea9e0920591bb407b16414897f4c023ea9c2",
+		},
+		{
+			name:   "nested_messages",
+			digest: dict(field(1), dict(field(2), dict(field(14), objecthash.String("foo"))), field(2), dict()),
+			want:   "cf7fb2001568f19d6322fd42c0a39093314631dcdd537ee3d9e92316e989f43f",
+		},
+		{
+			name:   "oneof_set_to_default_value",
+			digest: dict(field(21), objecthash.Int(0)),
+			want:   "f489eee320af3bace38a52bd16dc080b73e65d46fbf3f1c9b5520fe73501e3a8",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := hex.EncodeToString(tc.digest[:]); have != tc.want {
+				t.Errorf("Digest mismatch: want=%s have=%s", tc.want, have)
+			}
+		})
+	}
+}
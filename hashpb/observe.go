@@ -0,0 +1,93 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Observation describes a completed hashing operation.
+type Observation struct {
+	// MessageType is the full name of the hashed message type. It is empty if the message is nil.
+	MessageType protoreflect.FullName
+	// Err is the error returned by the operation, if any.
+	Err error
+	// Bytes is the number of bytes written to the hasher.
+	Bytes int64
+	// Duration is the time taken to hash the message.
+	Duration time.Duration
+}
+
+// WithObserver registers a function that is called after each message is hashed. It can be used to record metrics or
+// traces of the hashing cost. Observing adds some overhead, so avoid it in hot paths that don't need it.
+func WithObserver(observer func(Observation)) Option {
+	return func(o *Options) {
+		o.observer = observer
+	}
+}
+
+type countingHasher struct {
+	hash.Hash
+	n int64
+}
+
+func (c *countingHasher) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return c.Hash.Write(p)
+}
+
+// observe calls fn, feeding the extra hashers and writers set using WithHashers and WithTee as well, and reports the
+// result to the observer and the statistics set using WithStats, if there are any.
+func observe(hasher hash.Hash, msg proto.Message, o *Options, fn hashMsgFunc) error {
+	if msg != nil {
+		o = o.resolveIgnore(msg.ProtoReflect().Descriptor())
+	}
+
+	if len(o.hashers) > 0 {
+		hasher = multiHasher(append([]hash.Hash{hasher}, o.hashers...))
+	}
+
+	if o.tee != nil {
+		hasher = &teeHasher{Hash: hasher, w: o.tee}
+	}
+
+	if o.progress != nil {
+		p := newProgressHasher(hasher, o.progress)
+		defer p.done()
+		hasher = p
+	}
+
+	if o.observer == nil && o.stats == nil {
+		return fn(hasher, msg, o)
+	}
+
+	var msgType protoreflect.FullName
+	if msg != nil {
+		msgType = msg.ProtoReflect().Descriptor().FullName()
+	}
+
+	if o.stats != nil {
+		*o.stats = Stats{MessageType: msgType}
+	}
+
+	counter := &countingHasher{Hash: hasher}
+	start := time.Now()
+	err := fn(counter, msg, o)
+
+	if o.stats != nil {
+		o.stats.Bytes = counter.n
+	}
+
+	if o.observer == nil {
+		return err
+	}
+
+	o.observer(Observation{MessageType: msgType, Err: err, Bytes: counter.n, Duration: time.Since(start)})
+
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+)
+
+func TestObserver(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(2)
+
+	var observations []hashpb.Observation
+	observer := hashpb.WithObserver(func(o hashpb.Observation) { observations = append(observations, o) })
+
+	want, err := hashpb.Sum64(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := hashpb.Sum64(msg, observer)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if have != want {
+		t.Errorf("Observer changed the digest: want=%x have=%x", want, have)
+	}
+
+	if _, err := hashpb.SumAuto(nil, msg, observer); err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if err := hashpb.Hash(xxhash.New(), nil, observer); err == nil {
+		t.Fatal("Expected error for nil message")
+	}
+
+	if len(observations) != 3 {
+		t.Fatalf("Expected 3 observations, got %d", len(observations))
+	}
+
+	for i, o := range observations[:2] {
+		if o.MessageType != "cerbos.hashpb.test.NestedTestAllTypes" || o.Err != nil || o.Bytes == 0 || o.Duration < 0 {
+			t.Errorf("Unexpected observation %d: %+v", i, o)
+		}
+	}
+
+	if observations[0].Bytes != observations[1].Bytes {
+		t.Errorf("Generated and reflection-based hashing wrote different amounts: %d != %d", observations[0].Bytes, observations[1].Bytes)
+	}
+
+	if o := observations[2]; o.Err == nil || o.MessageType != "" {
+		t.Errorf("Unexpected observation for nil message: %+v", o)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewOptions returns an option set with the given options applied to the defaults.
+func NewOptions(opts ...Option) *Options {
+	return newOptions(opts)
+}
+
+// Apply applies the options to the option set in order and returns it.
+func (o *Options) Apply(opts ...Option) *Options {
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Clone returns a copy of the option set that can be modified without affecting the original.
+// The hashers set using WithHashers are shared.
+func (o *Options) Clone() *Options {
+	c := *o
+	c.hashers = append([]hash.Hash(nil), o.hashers...)
+
+	if o.ignore != nil {
+		c.ignore = make(map[string]struct{}, len(o.ignore))
+		for k := range o.ignore {
+			c.ignore[k] = struct{}{}
+		}
+	}
+
+	if o.canonicalizers != nil {
+		c.canonicalizers = make(map[protoreflect.FullName]Canonicalizer, len(o.canonicalizers))
+		for k, v := range o.canonicalizers {
+			c.canonicalizers[k] = v
+		}
+	}
+
+	return &c
+}
+
+// WithOptions replaces the options applied so far with a copy of the given option set. Options that follow it are
+// applied on top of the copy, without modifying the option set. A nil option set leaves the options unchanged.
+func WithOptions(opts *Options) Option {
+	return func(o *Options) {
+		if opts == nil {
+			return
+		}
+
+		hashFn := o.hashFn
+		*o = *opts.Clone()
+		if o.hashFn == nil {
+			o.hashFn = hashFn
+		}
+	}
+}
+
+// HashFunc returns the hash function used to calculate the digest.
+func (o *Options) HashFunc() func() hash.Hash {
+	return o.hashFn
+}
+
+// Ignored returns the sorted names of the ignored fields and oneofs.
+func (o *Options) Ignored() []string {
+	names := make([]string, 0, len(o.ignore))
+	for name := range o.ignore {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// IgnoreMode returns how ignored fields are hashed.
+func (o *Options) IgnoreMode() IgnoreMode {
+	return o.ignoreAs
+}
+
+// NormalizeUnicode reports whether strings are normalized to Unicode NFC before they are hashed.
+func (o *Options) NormalizeUnicode() bool {
+	return o.nfc
+}
+
+// EmptyMode returns whether empty bytes values of fields with explicit presence are distinguished from unset ones.
+func (o *Options) EmptyMode() EmptyMode {
+	return o.emptyAs
+}
+
+// Scheme returns the hashing scheme.
+func (o *Options) Scheme() Scheme {
+	return o.scheme
+}
+
+// MaxDepth returns the maximum nesting depth of messages, or zero if it is not limited.
+func (o *Options) MaxDepth() int {
+	return o.maxDepth
+}
+
+// Parallel returns the number of goroutines used to hash top-level fields, or zero if they are hashed sequentially.
+func (o *Options) Parallel() int {
+	return o.parallel
+}
+
+// Canonicalized returns the sorted names of the message types hashed in canonical form.
+func (o *Options) Canonicalized() []protoreflect.FullName {
+	names := make([]protoreflect.FullName, 0, len(o.canonicalizers))
+	for name := range o.canonicalizers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
+
+// Normalizations describes the options in effect that change how values are hashed, in the same form as
+// SchemeDescription.Normalizations.
+func (o *Options) Normalizations() []string {
+	return o.normalizations()
+}
@@ -0,0 +1,62 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+func TestOptions(t *testing.T) {
+	msg := mkTestAllTypesMsg()
+	ignore := []string{"cerbos.hashpb.test.TestAllTypes.single_string", "cerbos.hashpb.test.TestAllTypes.single_int32"}
+
+	opts := hashpb.NewOptions(hashpb.WithIgnore(ignore...), hashpb.WithHash(sha256.New))
+	if have, want := opts.Ignored(), []string{ignore[1], ignore[0]}; !reflect.DeepEqual(have, want) {
+		t.Errorf("Unexpected ignored fields: want=%v have=%v", want, have)
+	}
+
+	if opts.Scheme() != hashpb.SchemeDefault || opts.MaxDepth() != 0 || len(opts.Normalizations()) != 0 {
+		t.Errorf("Unexpected defaults: scheme=%v maxDepth=%d normalizations=%v", opts.Scheme(), opts.MaxDepth(), opts.Normalizations())
+	}
+
+	want, err := hashpb.Sum(nil, msg, hashpb.WithIgnore(ignore...), hashpb.WithHash(sha256.New))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := hashpb.Sum(nil, msg, hashpb.WithOptions(opts))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if string(have) != string(want) {
+		t.Errorf("Digest differs from the digest with the same options: want=%x have=%x", want, have)
+	}
+
+	if _, err := hashpb.Sum(nil, msg, hashpb.WithOptions(opts), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_bool")); err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	withNil, err := hashpb.Sum(nil, msg, hashpb.WithHash(sha256.New), hashpb.WithIgnore(ignore...), hashpb.WithOptions(nil))
+	if err != nil {
+		t.Fatalf("Failed to hash with nil options: %v", err)
+	}
+
+	if string(withNil) != string(want) {
+		t.Errorf("Expected nil options to leave the options unchanged: want=%x have=%x", want, withNil)
+	}
+
+	clone := opts.Clone().Apply(hashpb.WithNormalizeUnicode(), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_bytes"))
+	if len(opts.Ignored()) != 2 || len(opts.Normalizations()) != 0 {
+		t.Errorf("Expected the option set not to be modified: ignored=%v normalizations=%v", opts.Ignored(), opts.Normalizations())
+	}
+
+	if len(clone.Ignored()) != 3 || len(clone.Normalizations()) != 1 {
+		t.Errorf("Unexpected options of the clone: ignored=%v normalizations=%v", clone.Ignored(), clone.Normalizations())
+	}
+}
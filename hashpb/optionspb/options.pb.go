@@ -0,0 +1,251 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: hashpb/optionspb/options.proto
+
+package optionspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldOptions controls how a field is hashed by protoc-gen-go-hashpb and the hashpb package.
+type FieldOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Alternative name of the field for matching ignore entries. A field with a stable name is ignored if the ignore
+	// set contains either its fully-qualified name or the name of its message followed by a dot and the stable name.
+	// Set it to the original name of the field when renaming it, so that existing ignore configurations keep working.
+	StableName string `protobuf:"bytes,1,opt,name=stable_name,json=stableName,proto3" json:"stable_name,omitempty"`
+	// Hash the values of a singular or repeated string field case-insensitively by converting them to lower case (as
+	// Go's strings.ToLower does) before hashing them. Use it for identifiers such as email addresses that are matched
+	// case-insensitively.
+	CaseInsensitive bool `protobuf:"varint,2,opt,name=case_insensitive,json=caseInsensitive,proto3" json:"case_insensitive,omitempty"`
+	// Remove leading and trailing white space (as Go's strings.TrimSpace does) from the values of a singular or repeated
+	// string field before hashing them, so that cosmetic formatting differences in user-entered text don't change the
+	// digest. White space is trimmed before the value is converted to lower case by case_insensitive.
+	TrimSpace bool `protobuf:"varint,3,opt,name=trim_space,json=trimSpace,proto3" json:"trim_space,omitempty"`
+	// Hash a deterministic sample of a repeated field instead of every element: the number of elements followed by
+	// every sample_every-th element, starting from the first one. Digests then only change when the length or the
+	// sampled elements change, so use it only for change detection over enormous lists that tolerates approximation.
+	// Values of 0 and 1 hash every element. It has no effect on singular and map fields.
+	SampleEvery uint32 `protobuf:"varint,4,opt,name=sample_every,json=sampleEvery,proto3" json:"sample_every,omitempty"`
+	// Name of a singular scalar field of the element message by which the elements of a repeated message field are
+	// sorted before hashing them, so that the digest doesn't depend on the order in which the elements are stored. Use
+	// it for lists that are semantically collections keyed by that field. Elements are compared by the value of the
+	// field (the default value if it is unset), and elements with equal values keep their relative order. Floating
+	// point fields can't be used as sort keys. The elements are sorted before they are sampled by sample_every.
+	SortBy string `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Hash the elements of a repeated message field as an unordered collection: each element is hashed into its own
+	// SHA-256 digest, and the digests are sorted and hashed in that order. The digest then doesn't depend on the order
+	// of the elements, without requiring a sort key. Duplicate elements are kept, so the number of times an element
+	// occurs still matters. It can't be combined with sort_by and sample_every.
+	Unordered bool `protobuf:"varint,6,opt,name=unordered,proto3" json:"unordered,omitempty"`
+}
+
+func (x *FieldOptions) Reset() {
+	*x = FieldOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hashpb_optionspb_options_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOptions) ProtoMessage() {}
+
+func (x *FieldOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_hashpb_optionspb_options_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldOptions.ProtoReflect.Descriptor instead.
+func (*FieldOptions) Descriptor() ([]byte, []int) {
+	return file_hashpb_optionspb_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldOptions) GetStableName() string {
+	if x != nil {
+		return x.StableName
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetCaseInsensitive() bool {
+	if x != nil {
+		return x.CaseInsensitive
+	}
+	return false
+}
+
+func (x *FieldOptions) GetTrimSpace() bool {
+	if x != nil {
+		return x.TrimSpace
+	}
+	return false
+}
+
+func (x *FieldOptions) GetSampleEvery() uint32 {
+	if x != nil {
+		return x.SampleEvery
+	}
+	return 0
+}
+
+func (x *FieldOptions) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetUnordered() bool {
+	if x != nil {
+		return x.Unordered
+	}
+	return false
+}
+
+var file_hashpb_optionspb_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldOptions)(nil),
+		Field:         51210,
+		Name:          "hashpb.field",
+		Tag:           "bytes,51210,opt,name=field",
+		Filename:      "hashpb/optionspb/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional hashpb.FieldOptions field = 51210;
+	E_Field = &file_hashpb_optionspb_options_proto_extTypes[0]
+)
+
+var File_hashpb_optionspb_options_proto protoreflect.FileDescriptor
+
+var file_hashpb_optionspb_options_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x06, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x01, 0x0a, 0x0c, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x69, 0x6d, 0x5f,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x69,
+	0x6d, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x72,
+	0x74, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74,
+	0x42, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x6e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64,
+	0x3a, 0x4b, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x8a, 0x90, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x39, 0x5a,
+	0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x62,
+	0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f,
+	0x2d, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hashpb_optionspb_options_proto_rawDescOnce sync.Once
+	file_hashpb_optionspb_options_proto_rawDescData = file_hashpb_optionspb_options_proto_rawDesc
+)
+
+func file_hashpb_optionspb_options_proto_rawDescGZIP() []byte {
+	file_hashpb_optionspb_options_proto_rawDescOnce.Do(func() {
+		file_hashpb_optionspb_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_hashpb_optionspb_options_proto_rawDescData)
+	})
+	return file_hashpb_optionspb_options_proto_rawDescData
+}
+
+var file_hashpb_optionspb_options_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_hashpb_optionspb_options_proto_goTypes = []interface{}{
+	(*FieldOptions)(nil),              // 0: hashpb.FieldOptions
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_hashpb_optionspb_options_proto_depIdxs = []int32{
+	1, // 0: hashpb.field:extendee -> google.protobuf.FieldOptions
+	0, // 1: hashpb.field:type_name -> hashpb.FieldOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hashpb_optionspb_options_proto_init() }
+func file_hashpb_optionspb_options_proto_init() {
+	if File_hashpb_optionspb_options_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hashpb_optionspb_options_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hashpb_optionspb_options_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_hashpb_optionspb_options_proto_goTypes,
+		DependencyIndexes: file_hashpb_optionspb_options_proto_depIdxs,
+		MessageInfos:      file_hashpb_optionspb_options_proto_msgTypes,
+		ExtensionInfos:    file_hashpb_optionspb_options_proto_extTypes,
+	}.Build()
+	File_hashpb_optionspb_options_proto = out.File
+	file_hashpb_optionspb_options_proto_rawDesc = nil
+	file_hashpb_optionspb_options_proto_goTypes = nil
+	file_hashpb_optionspb_options_proto_depIdxs = nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otelhashpb records OpenTelemetry metrics and span events for hashing operations.
+//
+// The following metrics are recorded, with a hashpb.message_type attribute containing the full name of the hashed
+// message type and an error attribute set to true if hashing failed:
+//
+//   - hashpb.messages: number of messages hashed
+//   - hashpb.bytes: number of bytes written to the hasher
+//   - hashpb.duration: time taken to hash each message, in seconds
+//
+// If the context contains a recording span, a hashpb.hash event is added to it for each message.
+package otelhashpb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/cerbos/protoc-gen-go-hashpb/hashpb/otelhashpb"
+
+	messageTypeKey = attribute.Key("hashpb.message_type")
+	errorKey       = attribute.Key("error")
+	bytesKey       = attribute.Key("hashpb.bytes")
+	durationKey    = attribute.Key("hashpb.duration_ms")
+)
+
+type options struct {
+	meterProvider metric.MeterProvider
+}
+
+type Option func(*options)
+
+// WithMeterProvider sets the meter provider used to create the instruments. Defaults to the global meter provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meterProvider = mp
+	}
+}
+
+// Instrumentation holds the instruments used to record hashing operations.
+type Instrumentation struct {
+	messages metric.Int64Counter
+	bytes    metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// New creates the instruments.
+func New(opts ...Option) (*Instrumentation, error) {
+	o := &options{meterProvider: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	meter := o.meterProvider.Meter(instrumentationName)
+
+	messages, err := meter.Int64Counter("hashpb.messages", metric.WithDescription("Number of messages hashed"), metric.WithUnit("{message}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create messages counter: %w", err)
+	}
+
+	bytes, err := meter.Int64Counter("hashpb.bytes", metric.WithDescription("Number of bytes written to the hasher"), metric.WithUnit("By"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bytes counter: %w", err)
+	}
+
+	duration, err := meter.Float64Histogram("hashpb.duration", metric.WithDescription("Time taken to hash a message"), metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duration histogram: %w", err)
+	}
+
+	return &Instrumentation{messages: messages, bytes: bytes, duration: duration}, nil
+}
+
+// Option returns a hashpb option that records the hashing operations it is used with. The context is used to find the
+// span to add events to and is passed to the instruments.
+//
+//	digest, err := hashpb.Sum(nil, msg, instrumentation.Option(ctx))
+func (i *Instrumentation) Option(ctx context.Context) hashpb.Option {
+	return hashpb.WithObserver(func(obs hashpb.Observation) {
+		attrs := metric.WithAttributes(messageTypeKey.String(string(obs.MessageType)), errorKey.Bool(obs.Err != nil))
+		i.messages.Add(ctx, 1, attrs)
+		i.bytes.Add(ctx, obs.Bytes, attrs)
+		i.duration.Record(ctx, obs.Duration.Seconds(), attrs)
+
+		if span := trace.SpanFromContext(ctx); span.IsRecording() {
+			span.AddEvent("hashpb.hash", trace.WithAttributes(
+				messageTypeKey.String(string(obs.MessageType)),
+				errorKey.Bool(obs.Err != nil),
+				bytesKey.Int64(obs.Bytes),
+				durationKey.Float64(float64(obs.Duration.Microseconds())/1000),
+			))
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhashpb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/otelhashpb"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestInstrumentation(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := otelhashpb.New(otelhashpb.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+	if err != nil {
+		t.Fatalf("Failed to create instrumentation: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	msg, err := structpb.NewStruct(map[string]any{"wibble": "wobble"})
+	if err != nil {
+		t.Fatalf("Failed to create message: %v", err)
+	}
+
+	ctx, span := tracer.Start(context.Background(), "test")
+	for i := 0; i < 2; i++ {
+		if _, err := hashpb.Sum(nil, msg, instrumentation.Option(ctx)); err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+	}
+	span.End()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	messages, ok := metrics["hashpb.messages"].(metricdata.Sum[int64])
+	if !ok || len(messages.DataPoints) != 1 || messages.DataPoints[0].Value != 2 {
+		t.Errorf("Unexpected messages metric: %+v", metrics["hashpb.messages"])
+	} else if v, _ := messages.DataPoints[0].Attributes.Value("hashpb.message_type"); v.AsString() != "google.protobuf.Struct" {
+		t.Errorf("Unexpected message type attribute: %v", v.AsString())
+	}
+
+	if bytes, ok := metrics["hashpb.bytes"].(metricdata.Sum[int64]); !ok || len(bytes.DataPoints) != 1 || bytes.DataPoints[0].Value == 0 {
+		t.Errorf("Unexpected bytes metric: %+v", metrics["hashpb.bytes"])
+	}
+
+	if duration, ok := metrics["hashpb.duration"].(metricdata.Histogram[float64]); !ok || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 2 {
+		t.Errorf("Unexpected duration metric: %+v", metrics["hashpb.duration"])
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	events := spans[0].Events()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Name != "hashpb.hash" {
+		t.Errorf("Unexpected event name: %s", events[0].Name)
+	}
+}
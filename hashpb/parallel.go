@@ -0,0 +1,171 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"hash"
+	"sync"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// WithParallel hashes the top-level fields of the message concurrently, using up to the given number of goroutines,
+// so that hashing a single very large message can use more than one core. Nested messages are hashed sequentially
+// by the goroutine hashing the top-level field that contains them, so it only helps messages whose size is spread
+// across several top-level fields. Values less than 1 disable it.
+//
+// With the default scheme, each top-level field (or oneof) is hashed into its own digest, calculated with the hash
+// function set using WithHash, and the digests are written as length-prefixed byte strings in field number order.
+// This is also the case when Hash is given a hasher of another type, which only receives the combined digests.
+// Fields ignored with IgnoreSkip don't contribute a digest. The result is deterministic but differs from the
+// sequential digest, so SumAuto and Sum64Auto use reflection when it is set.
+// SchemeObjectHash already combines independent per-field digests, so it produces the same digests with or without
+// this option.
+func WithParallel(workers int) Option {
+	return func(o *Options) {
+		o.parallel = workers
+	}
+}
+
+// parallelSubtrees hashes the subtrees of the message into separate digests concurrently and writes the digests to
+// the hasher in order.
+func (w *walker) parallelSubtrees(m protoreflect.Message, subtrees []protoreflect.Descriptor) error {
+	if w.ignoreAs != IgnoreAsUnset {
+		kept := subtrees[:0:0]
+		for _, d := range subtrees {
+			if !w.subtreeIgnored(d) {
+				kept = append(kept, d)
+			}
+		}
+		subtrees = kept
+	}
+
+	digests := make([][]byte, len(subtrees))
+	stats := make([]Stats, len(subtrees))
+	err := runParallel(len(subtrees), w.parallel, func(i int) error {
+		hasher := w.hashFn()
+		sub := w.fork(hasher)
+		if w.stats != nil {
+			sub.stats = &stats[i]
+		}
+
+		if err := sub.subtree(m, subtrees[i]); err != nil {
+			return err
+		}
+
+		digests[i] = hasher.Sum(nil)
+		return nil
+	})
+
+	if w.stats != nil {
+		for i := range stats {
+			w.stats.merge(&stats[i])
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for i, digest := range digests {
+		w.buf = protowire.AppendBytes(w.buf[:0], digest)
+		if _, err := w.hasher.Write(w.buf); err != nil {
+			return fmt.Errorf("failed to write digest of %s: %w", subtrees[i].FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (w *walker) subtreeIgnored(d protoreflect.Descriptor) bool {
+	if od, ok := d.(protoreflect.OneofDescriptor); ok {
+		return w.ignored(od.FullName())
+	}
+
+	return fieldExcluded(w.ignore, w.fieldFilter, d.(protoreflect.FieldDescriptor))
+}
+
+// fork returns a sequential copy of the walker that writes to the given hasher.
+func (w *walker) fork(hasher hash.Hash) *walker {
+	sub := *w
+	sub.hasher = hasher
+	sub.parallel = 0
+	sub.buf = nil
+	if w.expanding != nil {
+		sub.expanding = make(map[protoreflect.FullName]struct{}, len(w.expanding))
+		for k, v := range w.expanding {
+			sub.expanding[k] = v
+		}
+	}
+
+	return &sub
+}
+
+type fieldValue struct {
+	fd protoreflect.FieldDescriptor
+	v  protoreflect.Value
+}
+
+// parallelFields calculates the digests of the fields concurrently and adds them to the dictionary.
+func (oh *objectHasher) parallelFields(d *objecthash.Dict, fields []fieldValue) error {
+	digests := make([][objecthash.Size]byte, len(fields))
+	stats := make([]Stats, len(fields))
+	err := runParallel(len(fields), oh.parallel, func(i int) (err error) {
+		sub := *oh
+		sub.parallel = 0
+		if oh.stats != nil {
+			sub.stats = &stats[i]
+		}
+
+		digests[i], err = sub.field(fields[i].fd, fields[i].v)
+		return err
+	})
+
+	if oh.stats != nil {
+		for i := range stats {
+			oh.stats.merge(&stats[i])
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for i, f := range fields {
+		d.Add(objecthash.Int(int64(f.fd.Number())), digests[i])
+	}
+
+	return nil
+}
+
+// runParallel calls fn for each index from 0 to n-1 using up to the given number of goroutines, and returns the error
+// returned for the lowest index, if any.
+func runParallel(n, workers int, fn func(int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+func TestWithParallel(t *testing.T) {
+	msg := mkTestAllTypesMsg()
+
+	t.Run("default", func(t *testing.T) {
+		sequential, err := hashpb.Sum64(msg)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		want, err := hashpb.Sum64(msg, hashpb.WithParallel(1))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if want == sequential {
+			t.Error("Expected parallel digest to differ from the sequential digest")
+		}
+
+		for i := 0; i < 10; i++ {
+			have, err := hashpb.Sum64(msg, hashpb.WithParallel(8))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if have != want {
+				t.Fatalf("Parallel digest depends on the number of workers: want=%x have=%x", want, have)
+			}
+		}
+
+		auto, err := hashpb.Sum64Auto(msg, hashpb.WithParallel(4))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if auto != want {
+			t.Errorf("Sum64Auto digest differs: want=%x have=%x", want, auto)
+		}
+
+		changed := mkTestAllTypesMsg()
+		changed.SingleStruct.Fields["a"].Kind = nil
+		if have, _ := hashpb.Sum64(changed, hashpb.WithParallel(4)); have == want {
+			t.Error("Expected a change in a nested message to change the digest")
+		}
+
+		ignored, err := hashpb.Sum64(changed, hashpb.WithParallel(4), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_struct"))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if have, _ := hashpb.Sum64(msg, hashpb.WithParallel(4), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_struct")); have != ignored {
+			t.Error("Expected ignored fields not to affect the digest")
+		}
+	})
+
+	t.Run("caller hasher", func(t *testing.T) {
+		want, err := hashpb.Sum(nil, msg, hashpb.WithHash(sha256.New), hashpb.WithParallel(4))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		// The top-level fields are hashed with the hash function set using WithHash, not the type of the hasher.
+		hasher := sha256.New()
+		if err := hashpb.Hash(hasher, msg, hashpb.WithParallel(4)); err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if bytes.Equal(want, hasher.Sum(nil)) {
+			t.Error("Expected the top-level fields to be hashed with the default hash function")
+		}
+
+		hasher.Reset()
+		if err := hashpb.Hash(hasher, msg, hashpb.WithHash(sha256.New), hashpb.WithParallel(4)); err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if have := hasher.Sum(nil); !bytes.Equal(want, have) {
+			t.Errorf("Expected the same digest as Sum with the same hash function: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("objecthash", func(t *testing.T) {
+		want, err := hashpb.Sum(nil, msg, hashpb.WithScheme(hashpb.SchemeObjectHash))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		have, err := hashpb.Sum(nil, msg, hashpb.WithScheme(hashpb.SchemeObjectHash), hashpb.WithParallel(4))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if !bytes.Equal(want, have) {
+			t.Errorf("Expected parallel hashing not to change the digest: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+			_, err := hashpb.Sum(nil, msg, hashpb.WithScheme(scheme), hashpb.WithParallel(4), hashpb.WithMaxDepth(1))
+			if !errors.Is(err, hashpb.ErrMaxDepth) {
+				t.Errorf("Expected ErrMaxDepth with scheme %v, got %v", scheme, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// WithPresenceBitmap hashes a bitmap recording which fields with explicit presence are set before the values of each
+// message, so that a field explicitly set to its default value (for example, an optional int32 set to 0) produces a
+// different digest from an unset field. Use it for audit trails where the difference matters.
+//
+// The bitmap has one bit per field with explicit presence (optional scalars, message fields and members of oneofs) in
+// field number order, starting from the least significant bit of the first byte. It is written as a length-prefixed
+// byte string, and it is left out for messages without such fields. Ignored fields don't get a bit unless the ignore
+// mode is IgnoreAsUnset, in which case their bit is always clear.
+// The generated methods don't support this option, so SumAuto and Sum64Auto use reflection when it is set.
+// It has no effect with SchemeObjectHash, which leaves unset fields out of the hash.
+func WithPresenceBitmap() Option {
+	return func(o *Options) {
+		o.presence = true
+	}
+}
+
+func (w *walker) presenceBitmap(m protoreflect.Message, fields []protoreflect.FieldDescriptor) error {
+	var bitmap []byte
+	n := 0
+	for _, fd := range fields {
+		if !fd.HasPresence() {
+			continue
+		}
+
+		set := m.Has(fd)
+		if w.presenceIgnored(fd) {
+			if w.ignoreAs != IgnoreAsUnset {
+				continue
+			}
+			set = false
+		}
+
+		if n%8 == 0 {
+			bitmap = append(bitmap, 0)
+		}
+
+		if set {
+			bitmap[n/8] |= 1 << (n % 8)
+		}
+		n++
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	w.buf = protowire.AppendBytes(w.buf[:0], bitmap)
+	if _, err := w.hasher.Write(w.buf); err != nil {
+		return fmt.Errorf("failed to write presence of %s: %w", m.Descriptor().FullName(), err)
+	}
+
+	return nil
+}
+
+// presenceIgnored reports whether the field, or the oneof containing it, is in the ignore set.
+func (w *walker) presenceIgnored(fd protoreflect.FieldDescriptor) bool {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return w.ignored(od.FullName()) || w.filtered(fd)
+	}
+
+	return fieldExcluded(w.ignore, w.fieldFilter, fd)
+}
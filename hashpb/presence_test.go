@@ -0,0 +1,51 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithPresenceBitmap(t *testing.T) {
+	unset := &pb.TestAllTypesOptional{}
+	zero := &pb.TestAllTypesOptional{SingleInt32: proto.Int32(0)}
+
+	sum := func(m proto.Message, opts ...hashpb.Option) uint64 {
+		t.Helper()
+
+		digest, err := hashpb.Sum64Auto(m, opts...)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		return digest
+	}
+
+	if sum(unset) != sum(zero) {
+		t.Fatal("Expected unset and zero values to hash the same without the presence bitmap")
+	}
+
+	if sum(unset, hashpb.WithPresenceBitmap()) == sum(zero, hashpb.WithPresenceBitmap()) {
+		t.Error("Expected unset and zero values to hash differently with the presence bitmap")
+	}
+
+	ignore := hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypesOptional.single_int32")
+	if sum(unset, hashpb.WithPresenceBitmap(), ignore) != sum(zero, hashpb.WithPresenceBitmap(), ignore) {
+		t.Error("Expected ignored fields to be left out of the presence bitmap")
+	}
+
+	asUnset := hashpb.WithIgnoreMode(hashpb.IgnoreAsUnset)
+	if sum(unset, hashpb.WithPresenceBitmap(), ignore, asUnset) != sum(zero, hashpb.WithPresenceBitmap(), ignore, asUnset) {
+		t.Error("Expected ignored fields to be recorded as unset in the presence bitmap")
+	}
+
+	noPresence := &pb.TestAllTypes_NestedMessage{Bb: 42}
+	if sum(noPresence) != sum(noPresence, hashpb.WithPresenceBitmap()) {
+		t.Error("Expected the presence bitmap to be left out for messages without fields with explicit presence")
+	}
+}
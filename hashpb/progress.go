@@ -0,0 +1,47 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "hash"
+
+// ProgressInterval is the number of bytes written to the hash function between two calls to the function set using
+// WithProgress.
+const ProgressInterval = 1 << 20
+
+// WithProgress calls fn with the total number of bytes written to the hash function so far every time another
+// ProgressInterval bytes have been written, and once more with the final total when the message has been hashed, so
+// that CLIs and background jobs hashing huge messages can report progress or keep track of byte budgets.
+// The function is called synchronously from the hashing goroutine, so it should return quickly.
+func WithProgress(fn func(bytesHashed int64)) Option {
+	return func(o *Options) {
+		o.progress = fn
+	}
+}
+
+type progressHasher struct {
+	hash.Hash
+	fn   func(int64)
+	n    int64
+	next int64
+}
+
+func newProgressHasher(hasher hash.Hash, fn func(int64)) *progressHasher {
+	return &progressHasher{Hash: hasher, fn: fn, next: ProgressInterval}
+}
+
+func (p *progressHasher) Write(b []byte) (int, error) {
+	n, err := p.Hash.Write(b)
+	p.n += int64(n)
+	if p.n >= p.next {
+		p.fn(p.n)
+		p.next = (p.n/ProgressInterval + 1) * ProgressInterval
+	}
+
+	return n, err
+}
+
+// done reports the final total.
+func (p *progressHasher) done() {
+	p.fn(p.n)
+}
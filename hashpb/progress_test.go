@@ -0,0 +1,55 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestWithProgress(t *testing.T) {
+	msg := &pb.TestAllTypes{}
+	for i := 0; i < 40; i++ {
+		msg.RepeatedString = append(msg.RepeatedString, strings.Repeat("x", 100<<10))
+	}
+
+	for name, sumFn := range map[string]func(dst []byte, m *pb.TestAllTypes, opts ...hashpb.Option) ([]byte, error){
+		"Sum": func(dst []byte, m *pb.TestAllTypes, opts ...hashpb.Option) ([]byte, error) {
+			return hashpb.Sum(dst, m, opts...)
+		},
+		"SumAuto": func(dst []byte, m *pb.TestAllTypes, opts ...hashpb.Option) ([]byte, error) {
+			return hashpb.SumAuto(dst, m, opts...)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var reports []int64
+			var total int64
+			_, err := sumFn(nil, msg,
+				hashpb.WithProgress(func(n int64) { reports = append(reports, n) }),
+				hashpb.WithObserver(func(o hashpb.Observation) { total = o.Bytes }),
+			)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if len(reports) < 4 {
+				t.Fatalf("Expected at least one report per MiB and a final report, got %v", reports)
+			}
+
+			// every report but the final one crosses another multiple of the interval.
+			for i := 1; i < len(reports)-1; i++ {
+				if reports[i]/hashpb.ProgressInterval <= reports[i-1]/hashpb.ProgressInterval {
+					t.Errorf("Expected a report every %d bytes: %v", hashpb.ProgressInterval, reports)
+				}
+			}
+
+			if final := reports[len(reports)-1]; final != total {
+				t.Errorf("Expected the final report to be the total number of bytes: want=%d have=%d", total, final)
+			}
+		})
+	}
+}
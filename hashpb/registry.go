@@ -0,0 +1,38 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpbreg"
+	"google.golang.org/protobuf/proto"
+)
+
+// SumByName calculates the hash of the message using the generated hash function registered for fullName in hashpbreg.
+// It falls back to reflection if there is no registered function, if msg is not of the generated Go type, if a
+// scheme other than SchemeDefault is used or if the options change the digests in ways that the generated functions
+// don't support, such as WithMaxDepth, WithShallow or WithImplicitDefaults.
+func SumByName(fullName string, msg proto.Message, opts ...Option) ([]byte, error) {
+	if msg == nil {
+		return nil, errNilMessage
+	}
+
+	if name := msg.ProtoReflect().Descriptor().FullName(); string(name) != fullName {
+		return nil, fmt.Errorf("%w: message type %s does not match %s", ErrInvalidMessage, name, fullName)
+	}
+
+	return sum(nil, msg, newOptions(opts), func(hasher hash.Hash, msg proto.Message, o *Options) error {
+		if !o.plainGenerated() {
+			return hashMsg(hasher, msg, o)
+		}
+
+		if fn, ok := hashpbreg.Lookup(fullName); ok && fn(msg, hasher, o.ignore) {
+			return nil
+		}
+
+		return hashMsg(hasher, msg, o)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpbreg"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestSumByName(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(3)
+	fullName := string(msg.ProtoReflect().Descriptor().FullName())
+
+	if _, ok := hashpbreg.Lookup(fullName); !ok {
+		t.Fatalf("No hash function registered for %s", fullName)
+	}
+
+	want, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		have, err := hashpb.SumByName(fullName, msg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !bytes.Equal(want, have) {
+			t.Fatalf("Digest mismatch: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("dynamic", func(t *testing.T) {
+		dynMsg := dynamicpb.NewMessage(msg.ProtoReflect().Descriptor())
+		proto.Merge(dynMsg, msg)
+
+		have, err := hashpb.SumByName(fullName, dynMsg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !bytes.Equal(want, have) {
+			t.Fatalf("Digest mismatch: want=%x have=%x", want, have)
+		}
+	})
+
+	t.Run("mismatched name", func(t *testing.T) {
+		if _, err := hashpb.SumByName(fullName, &pb.TestAllTypes{}); err == nil {
+			t.Fatal("Expected error for mismatched name")
+		}
+	})
+}
+
+func TestSumByNameOptions(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(3)
+	fullName := string(msg.ProtoReflect().Descriptor().FullName())
+	ignored := "cerbos.hashpb.test.TestAllTypes.single_int32"
+
+	testCases := []struct {
+		name string
+		opts []hashpb.Option
+	}{
+		{name: "no options"},
+		{name: "ignore", opts: []hashpb.Option{hashpb.WithIgnore(ignored)}},
+		{name: "ignore as unset", opts: []hashpb.Option{hashpb.WithIgnore(ignored), hashpb.WithIgnoreMode(hashpb.IgnoreAsUnset)}},
+		{name: "sha256", opts: []hashpb.Option{hashpb.WithHash(sha256.New)}},
+		{name: "objecthash", opts: []hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}},
+		{name: "max depth", opts: []hashpb.Option{hashpb.WithMaxDepth(2)}},
+		{name: "shallow", opts: []hashpb.Option{hashpb.WithShallow()}},
+		{name: "implicit defaults", opts: []hashpb.Option{hashpb.WithImplicitDefaults()}},
+		{name: "presence bitmap", opts: []hashpb.Option{hashpb.WithPresenceBitmap()}},
+		{name: "strict required", opts: []hashpb.Option{hashpb.WithStrictRequired()}},
+		{name: "decimal floats", opts: []hashpb.Option{hashpb.WithDecimalFloats()}},
+		{name: "normalize unicode", opts: []hashpb.Option{hashpb.WithNormalizeUnicode()}},
+		{name: "canonical field masks", opts: []hashpb.Option{hashpb.WithCanonicalFieldMasks()}},
+		{name: "canonicalizer", opts: []hashpb.Option{hashpb.WithCanonicalizer("cerbos.hashpb.test.TestAllTypes", func(m protoreflect.Message) protoreflect.Message {
+			c := proto.Clone(m.Interface()).(*pb.TestAllTypes)
+			c.SingleInt32 = 0
+			return c.ProtoReflect()
+		})}},
+		{name: "wrappers as optional", opts: []hashpb.Option{hashpb.WithWrappersAsOptional()}},
+		{name: "null as unset", opts: []hashpb.Option{hashpb.WithNullAsUnset()}},
+		{name: "parallel", opts: []hashpb.Option{hashpb.WithParallel(2)}},
+		{name: "stats", opts: []hashpb.Option{hashpb.WithStats(&hashpb.Stats{})}},
+		{name: "strict utf8", opts: []hashpb.Option{hashpb.WithStrictUTF8()}},
+		{name: "empty as set", opts: []hashpb.Option{hashpb.WithEmptyMode(hashpb.EmptyAsSet)}},
+		{name: "field filter", opts: []hashpb.Option{hashpb.WithFieldFilter(func(fd protoreflect.FieldDescriptor) bool {
+			return fd.Name() != "single_string"
+		})}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			want, wantErr := hashpb.Sum(nil, msg, tc.opts...)
+			have, haveErr := hashpb.SumByName(fullName, msg, tc.opts...)
+
+			if (wantErr != nil) != (haveErr != nil) {
+				t.Fatalf("Error mismatch: want=%v have=%v", wantErr, haveErr)
+			}
+
+			if !bytes.Equal(want, have) {
+				t.Fatalf("Digest mismatch: want=%x have=%x", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrMissingRequired is returned by hashing functions using WithStrictRequired when a required field is not set.
+var ErrMissingRequired = errors.New("required field is not set")
+
+// WithStrictRequired makes hashing fail with ErrMissingRequired if a proto2 required field (or an editions field with
+// LEGACY_REQUIRED presence) of the message, or of any message nested in it, is not set. Such messages would fail
+// serialization anyway, so this makes sure that digests are never computed over structurally invalid messages.
+// Ignored required fields are not checked.
+// The generated methods don't check required fields, so SumAuto and Sum64Auto use reflection when it is set.
+func WithStrictRequired() Option {
+	return func(o *Options) {
+		o.required = true
+	}
+}
+
+// checkRequired returns an error if any of the required fields of m that are not excluded by the ignore set or the
+// filter is not set.
+func checkRequired(m protoreflect.Message, ignore map[string]struct{}, filter func(protoreflect.FieldDescriptor) bool) error {
+	if !m.IsValid() {
+		return nil
+	}
+
+	md := m.Descriptor()
+	required := md.RequiredNumbers()
+	for i := 0; i < required.Len(); i++ {
+		fd := md.Fields().ByNumber(required.Get(i))
+		if fd == nil || fieldExcluded(ignore, filter, fd) {
+			continue
+		}
+
+		if !m.Has(fd) {
+			return fmt.Errorf("%w: %s", ErrMissingRequired, fd.FullName())
+		}
+	}
+
+	return nil
+}
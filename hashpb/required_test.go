@@ -0,0 +1,93 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestWithStrictRequired(t *testing.T) {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("r.proto"),
+		Package: proto.String("r"),
+		Syntax:  proto.String("proto2"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("R"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("id"),
+					JsonName: proto.String("id"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("child"),
+					JsonName: proto.String("child"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".r.R"),
+				},
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	md := fd.Messages().Get(0)
+	id := md.Fields().ByName("id")
+	child := md.Fields().ByName("child")
+
+	valid := dynamicpb.NewMessage(md)
+	valid.Set(id, protoreflect.ValueOfString("a"))
+
+	missingNested := dynamicpb.NewMessage(md)
+	missingNested.Set(id, protoreflect.ValueOfString("a"))
+	missingNested.Set(child, protoreflect.ValueOfMessage(dynamicpb.NewMessage(md)))
+
+	testCases := []struct {
+		name    string
+		msg     proto.Message
+		opts    []hashpb.Option
+		wantErr bool
+	}{
+		{name: "valid", msg: valid},
+		{name: "missing", msg: dynamicpb.NewMessage(md), wantErr: true},
+		{name: "missing_nested", msg: missingNested, wantErr: true},
+		{name: "missing_ignored", msg: dynamicpb.NewMessage(md), opts: []hashpb.Option{hashpb.WithIgnore("r.R.id")}},
+	}
+
+	for _, tc := range testCases {
+		for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+			opts := append([]hashpb.Option{hashpb.WithScheme(scheme), hashpb.WithStrictRequired()}, tc.opts...)
+			_, err := hashpb.SumAuto(nil, tc.msg, opts...)
+			if tc.wantErr {
+				if !errors.Is(err, hashpb.ErrMissingRequired) {
+					t.Errorf("%s: expected ErrMissingRequired with scheme %v, got %v", tc.name, scheme, err)
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Errorf("%s: unexpected error with scheme %v: %v", tc.name, scheme, err)
+			}
+		}
+	}
+
+	if _, err := hashpb.Sum(nil, dynamicpb.NewMessage(md)); err != nil {
+		t.Errorf("Unexpected error without strict mode: %v", err)
+	}
+}
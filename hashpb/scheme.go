@@ -0,0 +1,281 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Scheme identifies the algorithm used to hash messages.
+type Scheme int
+
+const (
+	// SchemeDefault is the scheme implemented by the generated HashPB methods.
+	SchemeDefault Scheme = iota
+	// SchemeObjectHash produces SHA-256 digests compatible with objecthash-proto, using field numbers as keys.
+	// The hash function set using WithHash is ignored and Sum64 returns an error because the digests are 256 bits long.
+	// Hash writes the digest of the message to the hasher.
+	SchemeObjectHash
+)
+
+var errObjectHashSum64 = fmt.Errorf("%w: the objecthash scheme produces 256-bit digests", ErrUnsupportedHash)
+
+// WithScheme sets the hashing scheme. Defaults to SchemeDefault.
+func WithScheme(scheme Scheme) Option {
+	return func(o *Options) {
+		o.scheme = scheme
+	}
+}
+
+// ObjectHashable is implemented by messages with ObjectHashPB methods generated by protoc-gen-go-hashpb using the
+// objecthash=true parameter.
+type ObjectHashable interface {
+	ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte
+}
+
+// digestHasher is used as the hasher for schemes that calculate the digest themselves.
+// Its sum is the data written to it.
+type digestHasher struct {
+	digest []byte
+}
+
+func (h *digestHasher) Write(p []byte) (int, error) {
+	h.digest = append(h.digest, p...)
+	return len(p), nil
+}
+
+func (h *digestHasher) Sum(b []byte) []byte {
+	return append(b, h.digest...)
+}
+
+func (h *digestHasher) Reset() {
+	h.digest = h.digest[:0]
+}
+
+func (h *digestHasher) Size() int {
+	return objecthash.Size
+}
+
+func (h *digestHasher) BlockSize() int {
+	return objecthash.Size
+}
+
+func objectHashMsg(hasher hash.Hash, msg proto.Message, o *Options) error {
+	if msg == nil {
+		return errNilMessage
+	}
+
+	oh := &objectHasher{ignore: o.ignore, required: o.required, nfc: o.nfc, canonicalizers: o.canonicalizers, wrappers: o.wrappers, nullAsUnset: o.nullAsUnset, parallel: o.parallel, maxDepth: o.maxDepth, stats: o.stats, shallow: o.shallow, strictUTF8: o.strictUTF8, fieldFilter: o.fieldFilter}
+	digest, err := oh.message(msg.ProtoReflect())
+	if err != nil {
+		return err
+	}
+
+	_, err = hasher.Write(digest[:])
+	return err
+}
+
+func objectHashAuto(hasher hash.Hash, msg proto.Message, o *Options) error {
+	if h, ok := msg.(ObjectHashable); ok && o.maxDepth == 0 && !o.required && !o.nfc && len(o.canonicalizers) == 0 && !o.wrappers && !o.nullAsUnset && o.stats == nil && !o.shallow && !o.strictUTF8 && o.fieldFilter == nil {
+		digest := h.ObjectHashPB(o.ignore)
+		_, err := hasher.Write(digest[:])
+		return err
+	}
+
+	return objectHashMsg(hasher, msg, o)
+}
+
+type objectHasher struct {
+	ignore         map[string]struct{}
+	required       bool
+	nfc            bool
+	canonicalizers map[protoreflect.FullName]Canonicalizer
+	wrappers       bool
+	nullAsUnset    bool
+	parallel       int
+	maxDepth       int
+	depth          int
+	stats          *Stats
+	shallow        bool
+	strictUTF8     bool
+	fieldFilter    func(protoreflect.FieldDescriptor) bool
+}
+
+func (oh *objectHasher) message(m protoreflect.Message) (digest [objecthash.Size]byte, err error) {
+	m = canonical(oh.canonicalizers, m)
+
+	if (oh.maxDepth > 0 || oh.stats != nil) && m.IsValid() {
+		oh.depth++
+		defer func() { oh.depth-- }()
+
+		if oh.maxDepth > 0 && oh.depth > oh.maxDepth {
+			return digest, maxDepthError(m, oh.maxDepth)
+		}
+
+		if oh.stats != nil {
+			oh.stats.enter(oh.depth)
+		}
+	}
+
+	if oh.required {
+		if err := checkRequired(m, oh.ignore, oh.fieldFilter); err != nil {
+			return digest, err
+		}
+	}
+
+	var d objecthash.Dict
+	var fields []fieldValue
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if _, ok := oh.ignore[string(od.FullName())]; ok || (oh.fieldFilter != nil && !oh.fieldFilter(fd)) {
+				return true
+			}
+		} else if fieldExcluded(oh.ignore, oh.fieldFilter, fd) {
+			return true
+		}
+
+		if oh.nullAsUnset && isNullField(fd, v) {
+			return true
+		}
+
+		if oh.stats != nil {
+			oh.stats.Fields++
+		}
+
+		if oh.parallel > 0 {
+			fields = append(fields, fieldValue{fd: fd, v: v})
+			return true
+		}
+
+		var fieldDigest [objecthash.Size]byte
+		if fieldDigest, err = oh.field(fd, v); err != nil {
+			return false
+		}
+
+		d.Add(objecthash.Int(int64(fd.Number())), fieldDigest)
+		return true
+	})
+
+	if err != nil {
+		return digest, err
+	}
+
+	if oh.parallel > 0 {
+		if err := oh.parallelFields(&d, fields); err != nil {
+			return digest, err
+		}
+	}
+
+	return d.Sum(), nil
+}
+
+func (oh *objectHasher) field(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([objecthash.Size]byte, error) {
+	digest, err := oh.fieldDigest(fd, v)
+	return digest, fieldError(err, fd)
+}
+
+func (oh *objectHasher) fieldDigest(fd protoreflect.FieldDescriptor, v protoreflect.Value) (digest [objecthash.Size]byte, err error) {
+	switch {
+	case fd.IsList() && Unordered(fd):
+		var s objecthash.Set
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			elem, err := oh.value(fd, list.Get(i))
+			if err != nil {
+				return digest, indexError(err, i)
+			}
+			s.Add(elem)
+		}
+		return s.Sum(), nil
+	case fd.IsList():
+		var l objecthash.List
+		list := sortList(fd, v.List())
+		step := 1
+		if k := SampleEvery(fd); k > 0 {
+			step = k
+			l.Add(objecthash.Int(int64(list.Len())))
+		}
+
+		for i := 0; i < list.Len(); i += step {
+			elem, err := oh.value(fd, list.Get(i))
+			if err != nil {
+				return digest, indexError(err, i)
+			}
+			l.Add(elem)
+		}
+		return l.Sum(), nil
+	case fd.IsMap():
+		var d objecthash.Dict
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			if oh.nullAsUnset && isNullField(fd.MapValue(), mv) {
+				return true
+			}
+
+			var key, value [objecthash.Size]byte
+			if key, err = oh.value(fd.MapKey(), k.Value()); err != nil {
+				err = mapKeyError(err, fd, k)
+				return false
+			}
+
+			if value, err = oh.value(fd.MapValue(), mv); err != nil {
+				err = mapKeyError(err, fd, k)
+				return false
+			}
+
+			d.Add(key, value)
+			return true
+		})
+
+		if err != nil {
+			return digest, err
+		}
+		return d.Sum(), nil
+	default:
+		return oh.value(fd, v)
+	}
+}
+
+func (oh *objectHasher) value(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([objecthash.Size]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return objecthash.Bool(v.Bool()), nil
+	case protoreflect.EnumKind:
+		return objecthash.Int(int64(v.Enum())), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return objecthash.Int(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return objecthash.Uint(v.Uint()), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return objecthash.Float(v.Float()), nil
+	case protoreflect.StringKind:
+		if oh.strictUTF8 {
+			if err := checkUTF8(fd, v.String()); err != nil {
+				return [objecthash.Size]byte{}, err
+			}
+		}
+		return objecthash.String(stringValue(fd, v.String(), oh.nfc)), nil
+	case protoreflect.BytesKind:
+		return objecthash.Bytes(v.Bytes()), nil
+	default:
+		if vfd := wrappedField(fd.Message()); vfd != nil && oh.wrappers {
+			return oh.value(vfd, v.Message().Get(vfd))
+		}
+
+		if oh.nullAsUnset && isNullValue(v.Message()) {
+			var d objecthash.Dict
+			return d.Sum(), nil
+		}
+
+		if oh.shallow {
+			return objecthash.String(string(fd.Message().FullName())), nil
+		}
+		return oh.message(v.Message())
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSchemeObjectHash(t *testing.T) {
+	objectHash := hashpb.WithScheme(hashpb.SchemeObjectHash)
+
+	t.Run("empty", func(t *testing.T) {
+		// objecthash of {}
+		want := "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4"
+		for _, msg := range []proto.Message{&pb.TestAllTypes{}, &pb.TestAllTypesOptional{}, &pb.NoFields{}} {
+			have, err := hashpb.Sum(nil, msg, objectHash)
+			if err != nil {
+				t.Fatalf("Failed to hash %T: %v", msg, err)
+			}
+
+			if hex.EncodeToString(have) != want {
+				t.Errorf("Unexpected digest for %T: %x", msg, have)
+			}
+		}
+	})
+
+	t.Run("single field", func(t *testing.T) {
+		var d objecthash.Dict
+		d.Add(objecthash.Int(14), objecthash.String("foo"))
+		want := d.Sum()
+
+		have, err := hashpb.Sum(nil, &pb.TestAllTypes{SingleString: "foo"}, objectHash)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if !bytes.Equal(want[:], have) {
+			t.Errorf("Unexpected digest: want=%x have=%x", want, have)
+		}
+	})
+
+	for _, tc := range testCases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			oh, ok := tc.input.(hashpb.ObjectHashable)
+			if !ok {
+				t.Skipf("%T does not implement ObjectHashPB", tc.input)
+			}
+
+			want := oh.ObjectHashPB(ignoreSet(tc.ignore))
+
+			have, err := hashpb.Sum(nil, tc.input, objectHash, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if !bytes.Equal(want[:], have) {
+				t.Errorf("Digest mismatch: generated=%x reflection=%x", want, have)
+			}
+
+			auto, err := hashpb.SumAuto(nil, tc.input, objectHash, hashpb.WithIgnore(tc.ignore...))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if !bytes.Equal(want[:], auto) {
+				t.Errorf("Digest mismatch: generated=%x auto=%x", want, auto)
+			}
+		})
+	}
+
+	t.Run("hash", func(t *testing.T) {
+		msg := mkNestedTestAllTypesMsg(2)
+		digest, err := hashpb.Sum(nil, msg, objectHash)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		want := xxhash.Sum64(digest)
+
+		hasher := xxhash.New()
+		if err := hashpb.Hash(hasher, msg, objectHash); err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if have := hasher.Sum64(); have != want {
+			t.Errorf("Digest mismatch: want=%d have=%d", want, have)
+		}
+	})
+
+	t.Run("sum64", func(t *testing.T) {
+		if _, err := hashpb.Sum64(&pb.TestAllTypes{}, objectHash); err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
+
+// TestSchemeObjectHashVectors checks the digests of messages against golden digests. The expected digests follow the
+// rules of objecthash-proto: messages are dictionaries keyed by field number, proto3 fields set to their default
+// values are omitted, enums are integers, repeated fields are lists, maps are dictionaries and the member of a oneof
+// is included whenever it is set. Each golden digest is also checked against objectHashValue, which computes the
+// ObjectHash of the equivalent value directly from the ObjectHash specification.
+func TestSchemeObjectHashVectors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		msg   proto.Message
+		value any
+		want  string
+	}{
+		{
+			name:  "proto3 defaults omitted",
+			msg:   &pb.TestAllTypes{SingleInt64: 7, SingleString: "", SingleBool: false, SingleDouble: 0},
+			value: map[any]any{int64(2): int64(7)},
+			want:  "b6e010905e9fe319f84d1db4135e3fe239d1c3b5cbde63e0e0881a8054c1fd87",
+		},
+		{
+			name:  "unsigned integers",
+			msg:   &pb.TestAllTypes{SingleUint64: math.MaxUint64, SingleFixed32: 42},
+			value: map[any]any{int64(4): uint64(math.MaxUint64), int64(7): uint64(42)},
+			want:  "edbb1d439a091176f4908be10eeb0550fef95e07b763d6a36a2e09df8804e713",
+		},
+		{
+			name:  "strings, bytes and bools",
+			msg:   &pb.TestAllTypes{SingleString: "ԱԲաբ", SingleBytes: []byte{0, 1, 2}, SingleBool: true},
+			value: map[any]any{int64(14): "ԱԲաբ", int64(15): []byte{0, 1, 2}, int64(13): true},
+			want:  "d7355f1fc20c6a3cd8234bae5b4b760df876e349640303a449b6af20870b35c1",
+		},
+		{
+			name: "enums",
+			msg: &pb.TestAllTypes{
+				StandaloneEnum:     pb.TestAllTypes_BAZ,
+				RepeatedNestedEnum: []pb.TestAllTypes_NestedEnum{pb.TestAllTypes_BAR, pb.TestAllTypes_FOO},
+			},
+			value: map[any]any{int64(22): int64(2), int64(51): []any{int64(1), int64(0)}},
+			want:  "45f8d26c0464be319e8b96c7215cfa9fbc435530ef16a51a6f66cf2ec3dcbd77",
+		},
+		{
+			name: "repeated",
+			msg: &pb.TestAllTypes{
+				RepeatedString: []string{"foo", "bar"},
+				RepeatedSint64: []int64{1, -2},
+				RepeatedBytes:  [][]byte{{0xff}},
+			},
+			value: map[any]any{
+				int64(44): []any{"foo", "bar"},
+				int64(36): []any{int64(1), int64(-2)},
+				int64(45): []any{[]byte{0xff}},
+			},
+			want: "112707fc84945fc4300b6cfd93b3f19d3f966c4f8cef8d8396a9983cec30f9ca",
+		},
+		{
+			name: "maps",
+			msg: &pb.TestAllTypes{
+				MapStringString:    map[string]string{"a": "x", "b": "y"},
+				MapInt32String:     map[int32]string{-1: "minus one"},
+				MapBoolString:      map[bool]string{true: "yes"},
+				MapInt64NestedType: map[int64]*pb.TestAllTypes_NestedMessage{3: {Bb: 4}},
+			},
+			value: map[any]any{
+				int64(58): map[any]any{"a": "x", "b": "y"},
+				int64(60): map[any]any{int64(-1): "minus one"},
+				int64(61): map[any]any{true: "yes"},
+				int64(62): map[any]any{int64(3): map[any]any{int64(1): int64(4)}},
+			},
+			want: "f7bd733c0efce93fc7a5e98a24b0ea9e0920591bb407b16414897f4c023ea9c2",
+		},
+		{
+			name: "nested messages",
+			msg: &pb.NestedTestAllTypes{
+				Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleString: "foo"}},
+				Payload: &pb.TestAllTypes{},
+			},
+			value: map[any]any{
+				int64(1): map[any]any{int64(2): map[any]any{int64(14): "foo"}},
+				int64(2): map[any]any{},
+			},
+			want: "cf7fb2001568f19d6322fd42c0a39093314631dcdd537ee3d9e92316e989f43f",
+		},
+		{
+			name:  "oneof set to default value",
+			msg:   &pb.TestAllTypes{NestedType: &pb.TestAllTypes_SingleNestedEnum{SingleNestedEnum: pb.TestAllTypes_FOO}},
+			value: map[any]any{int64(21): int64(0)},
+			want:  "f489eee320af3bace38a52bd16dc080b73e65d46fbf3f1c9b5520fe73501e3a8",
+		},
+		{
+			name:  "oneof set to empty message",
+			msg:   &pb.TestAllTypes{NestedType: &pb.TestAllTypes_SingleNestedMessage{SingleNestedMessage: &pb.TestAllTypes_NestedMessage{}}},
+			value: map[any]any{int64(18): map[any]any{}},
+			want:  "691e2d579f60313dec0355e60bd49a0f4305f49fb8b7a8b577ca8aa2176e9162",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if have := hex.EncodeToString(objectHashValue(t, tc.value)); have != tc.want {
+				t.Fatalf("Golden digest does not match the ObjectHash specification: want=%s have=%s", tc.want, have)
+			}
+
+			have, err := hashpb.Sum(nil, tc.msg, hashpb.WithScheme(hashpb.SchemeObjectHash))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if hex.EncodeToString(have) != tc.want {
+				t.Errorf("Unexpected digest: want=%s have=%x", tc.want, have)
+			}
+
+			if oh, ok := tc.msg.(hashpb.ObjectHashable); ok {
+				if have := oh.ObjectHashPB(nil); hex.EncodeToString(have[:]) != tc.want {
+					t.Errorf("Unexpected digest from ObjectHashPB: want=%s have=%x", tc.want, have)
+				}
+			}
+		})
+	}
+}
+
+// objectHashValue computes the ObjectHash of a value made of booleans, 64-bit integers, strings, byte slices, lists
+// and dictionaries without using the objecthash package.
+func objectHashValue(t *testing.T, v any) []byte {
+	t.Helper()
+
+	sum := func(tag byte, content []byte) []byte {
+		h := sha256.Sum256(append([]byte{tag}, content...))
+		return h[:]
+	}
+
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return sum('b', []byte("1"))
+		}
+		return sum('b', []byte("0"))
+	case int64:
+		return sum('i', []byte(strconv.FormatInt(v, 10)))
+	case uint64:
+		return sum('i', []byte(strconv.FormatUint(v, 10)))
+	case string:
+		return sum('u', []byte(v))
+	case []byte:
+		return sum('r', v)
+	case []any:
+		var content []byte
+		for _, elem := range v {
+			content = append(content, objectHashValue(t, elem)...)
+		}
+		return sum('l', content)
+	case map[any]any:
+		entries := make([][]byte, 0, len(v))
+		for key, value := range v {
+			entries = append(entries, append(objectHashValue(t, key), objectHashValue(t, value)...))
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i], entries[j]) < 0 })
+		return sum('d', bytes.Join(entries, nil))
+	default:
+		t.Fatalf("Unsupported value %T", v)
+		return nil
+	}
+}
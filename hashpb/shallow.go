@@ -0,0 +1,44 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+	"hash"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ShallowHashable is implemented by messages with HashPBShallow methods generated by protoc-gen-go-hashpb using the
+// shallow=true parameter. The method produces the same digest as hashing the message with WithShallow.
+type ShallowHashable interface {
+	HashPBShallow(hasher hash.Hash, ignore map[string]struct{})
+}
+
+// WithShallow hashes the scalar fields of the message fully but its message fields only by presence and type: each
+// message value that is set (including the elements of repeated fields and the values of maps) is hashed as the
+// fully-qualified name of its type, without descending into it. Shallow digests are cheap to compute on very large
+// object graphs, so they can be used as a "probably changed?" pre-check before computing the full digest. Changes
+// to nested messages that don't add, remove or replace them are invisible to shallow digests.
+// SumAuto and Sum64Auto use the generated HashPBShallow method if the message has one and reflection otherwise.
+func WithShallow() Option {
+	return func(o *Options) {
+		o.shallow = true
+	}
+}
+
+// shallowMessage writes the fully-qualified name of the type of the message value of the field if it is set.
+func (w *walker) shallowMessage(fd protoreflect.FieldDescriptor, m protoreflect.Message) error {
+	if !m.IsValid() && !w.defaults {
+		return nil
+	}
+
+	w.buf = protowire.AppendString(w.buf[:0], string(fd.Message().FullName()))
+	if _, err := w.hasher.Write(w.buf); err != nil {
+		return fmt.Errorf("failed to write type of %s: %w", fd.FullName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestWithShallow(t *testing.T) {
+	base := &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 1}}
+	deepChange := &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt32: 2}}
+	unset := &pb.NestedTestAllTypes{}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		sum := func(msg *pb.NestedTestAllTypes) []byte {
+			t.Helper()
+
+			digest, err := hashpb.Sum(nil, msg, hashpb.WithScheme(scheme), hashpb.WithShallow())
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			return digest
+		}
+
+		if !bytes.Equal(sum(base), sum(deepChange)) {
+			t.Errorf("Expected changes to nested messages not to change the shallow digest with scheme %v", scheme)
+		}
+
+		if bytes.Equal(sum(base), sum(unset)) {
+			t.Errorf("Expected the presence of nested messages to change the shallow digest with scheme %v", scheme)
+		}
+	}
+
+	t.Run("auto", func(t *testing.T) {
+		want, err := hashpb.Sum64(base, hashpb.WithShallow())
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		have, err := hashpb.Sum64Auto(base, hashpb.WithShallow())
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if want != have {
+			t.Errorf("Digest mismatch: reflection=%d auto=%d", want, have)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// StableIgnoreKey returns the alternative ignore key of the field derived from its (hashpb.field).stable_name option:
+// the full name of the containing message followed by a dot and the stable name. It returns an empty string if the
+// field doesn't have a stable name.
+func StableIgnoreKey(fd protoreflect.FieldDescriptor) string {
+	return annotationsOf(fd).stableKey
+}
+
+// Ignored reports whether any of the given keys is in the ignore set. Generated code uses it to check fields with
+// stable names, which are ignored if the set contains either their fully-qualified name or their stable ignore key.
+func Ignored(ignore map[string]struct{}, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := ignore[k]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldIgnored reports whether the field is in the ignore set under its fully-qualified name or its stable ignore key.
+func fieldIgnored(ignore map[string]struct{}, fd protoreflect.FieldDescriptor) bool {
+	if len(ignore) == 0 {
+		return false
+	}
+
+	if _, ok := ignore[string(fd.FullName())]; ok {
+		return true
+	}
+
+	if key := StableIgnoreKey(fd); key != "" {
+		_, ok := ignore[key]
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestStableName(t *testing.T) {
+	md := stableNameDescriptor(t)
+	renamed := md.Fields().ByName("renamed")
+	plain := md.Fields().ByName("plain")
+
+	if have := hashpb.StableIgnoreKey(renamed); have != "stable.M.original" {
+		t.Errorf("Unexpected stable ignore key: %q", have)
+	}
+
+	if have := hashpb.StableIgnoreKey(plain); have != "" {
+		t.Errorf("Unexpected stable ignore key for field without stable name: %q", have)
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(renamed, protoreflect.ValueOfString("a"))
+	msg.Set(plain, protoreflect.ValueOfString("b"))
+
+	other := dynamicpb.NewMessage(md)
+	other.Set(renamed, protoreflect.ValueOfString("c"))
+	other.Set(plain, protoreflect.ValueOfString("b"))
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		for _, ignore := range []string{"stable.M.renamed", "stable.M.original"} {
+			want, err := hashpb.Sum(nil, other, hashpb.WithScheme(scheme), hashpb.WithIgnore(ignore))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			have, err := hashpb.Sum(nil, msg, hashpb.WithScheme(scheme), hashpb.WithIgnore(ignore))
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if !bytes.Equal(have, want) {
+				t.Errorf("Field not ignored by %q with scheme %v", ignore, scheme)
+			}
+		}
+	}
+
+	if !hashpb.Ignored(map[string]struct{}{"stable.M.original": {}}, "stable.M.renamed", "stable.M.original") {
+		t.Error("Expected field to be ignored by its stable ignore key")
+	}
+
+	if hashpb.Ignored(map[string]struct{}{"stable.M.plain": {}}, "stable.M.renamed", "stable.M.original") {
+		t.Error("Unexpected match")
+	}
+}
+
+func TestStableNameGenerated(t *testing.T) {
+	msg := &pb.TestFieldOptions{Renamed: "a", Email: "alice@example.com"}
+	other := &pb.TestFieldOptions{Renamed: "b", Email: "alice@example.com"}
+
+	for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+		for _, ignore := range []string{"cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original"} {
+			opts := []hashpb.Option{hashpb.WithScheme(scheme), hashpb.WithIgnore(ignore)}
+
+			want, err := hashpb.Sum(nil, other, opts...)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			have, err := hashpb.SumAuto(nil, msg, opts...)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			if !bytes.Equal(have, want) {
+				t.Errorf("Field not ignored by %q in generated code with scheme %v", ignore, scheme)
+			}
+		}
+	}
+}
+
+func stableNameDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, &optionspb.FieldOptions{StableName: "original"})
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("stable.proto"),
+		Package: proto.String("stable"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("M"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("renamed"),
+					JsonName: proto.String("renamed"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					Options:  opts,
+				},
+				{
+					Name:     proto.String("plain"),
+					JsonName: proto.String("plain"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	return fd.Messages().Get(0)
+}
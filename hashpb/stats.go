@@ -0,0 +1,48 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// Stats describes the work done to hash a message.
+type Stats struct {
+	// MessageType is the full name of the hashed message type. It is empty if the message is nil.
+	MessageType protoreflect.FullName
+	// Bytes is the number of bytes written to the hasher.
+	Bytes int64
+	// Fields is the number of fields visited. The default scheme visits every field of a message that is not ignored,
+	// counting a oneof with a member set as a single field, and the objecthash scheme only visits fields that are set.
+	Fields int64
+	// Messages is the number of messages traversed, including the hashed message.
+	Messages int64
+	// MaxDepth is the nesting depth of the most deeply nested message traversed, where the hashed message has depth 1.
+	MaxDepth int
+}
+
+// WithStats records statistics about the hashing call in stats, which is reset at the start of the call. It can be
+// used to find out which message types dominate the hashing cost in production profiles.
+// Collecting statistics requires traversing messages using reflection, so the generated methods are not used.
+// The same Stats must not be used by concurrent calls.
+func WithStats(stats *Stats) Option {
+	return func(o *Options) {
+		o.stats = stats
+	}
+}
+
+// enter records visiting a message at the given depth.
+func (s *Stats) enter(depth int) {
+	s.Messages++
+	if depth > s.MaxDepth {
+		s.MaxDepth = depth
+	}
+}
+
+// merge adds the statistics collected by a parallel worker.
+func (s *Stats) merge(other *Stats) {
+	s.Fields += other.Fields
+	s.Messages += other.Messages
+	if other.MaxDepth > s.MaxDepth {
+		s.MaxDepth = other.MaxDepth
+	}
+}
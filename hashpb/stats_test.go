@@ -0,0 +1,77 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestWithStats(t *testing.T) {
+	msg := &pb.NestedTestAllTypes{Child: &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleInt64: 1}}}
+
+	// the default scheme visits every field of the three messages, except the unset oneofs of the payload.
+	var payloadFields int64
+	fields := (&pb.TestAllTypes{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if od := fields.Get(i).ContainingOneof(); od == nil || od.IsSynthetic() {
+			payloadFields++
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		opts   []hashpb.Option
+		fields int64
+	}{
+		{name: "default", fields: 4 + payloadFields},
+		{name: "ignore", opts: []hashpb.Option{hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_string")}, fields: 3 + payloadFields},
+		{name: "parallel", opts: []hashpb.Option{hashpb.WithParallel(4)}, fields: 4 + payloadFields},
+		{name: "objecthash", opts: []hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash)}, fields: 3},
+		{name: "objecthash_parallel", opts: []hashpb.Option{hashpb.WithScheme(hashpb.SchemeObjectHash), hashpb.WithParallel(4)}, fields: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := hashpb.Stats{Messages: 100}
+			var bytes int64
+			opts := append([]hashpb.Option{hashpb.WithStats(&stats), hashpb.WithObserver(func(o hashpb.Observation) { bytes = o.Bytes })}, tc.opts...)
+			if _, err := hashpb.SumAuto(nil, msg, opts...); err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+
+			want := hashpb.Stats{MessageType: "cerbos.hashpb.test.NestedTestAllTypes", Bytes: bytes, Fields: tc.fields, Messages: 3, MaxDepth: 3}
+			if stats != want {
+				t.Errorf("Unexpected stats: want=%+v have=%+v", want, stats)
+			}
+
+			if bytes == 0 {
+				t.Error("Expected bytes to be written")
+			}
+		})
+	}
+}
+
+func TestWithStatsDigest(t *testing.T) {
+	msg := mkTestAllTypesMsg()
+
+	want, err := hashpb.SumAuto(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	var stats hashpb.Stats
+	have, err := hashpb.SumAuto(nil, msg, hashpb.WithStats(&stats))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if string(want) != string(have) {
+		t.Errorf("Collecting stats changed the digest: want=%x have=%x", want, have)
+	}
+
+	if stats.Messages < 2 || stats.MaxDepth < 2 || stats.Fields == 0 || stats.Bytes == 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"hash"
+	"io"
+)
+
+// WithTee writes the exact bytes fed to the hash function to w as well. Use it to capture the canonical stream when
+// diagnosing digest mismatches between environments: the streams captured in each environment differ exactly where
+// the inputs to the hash function differ. Hashing fails with the error returned by w if a write fails. If WithTee is used several times,
+// the bytes are written to all the writers.
+func WithTee(w io.Writer) Option {
+	return func(o *Options) {
+		if o.tee == nil {
+			o.tee = w
+			return
+		}
+
+		o.tee = io.MultiWriter(o.tee, w)
+	}
+}
+
+type teeHasher struct {
+	hash.Hash
+	w io.Writer
+}
+
+func (t *teeHasher) Write(p []byte) (int, error) {
+	if _, err := t.Hash.Write(p); err != nil {
+		return 0, err
+	}
+
+	return t.w.Write(p)
+}
@@ -0,0 +1,45 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+)
+
+func TestWithTee(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(2)
+
+	want, err := hashpb.Sum64(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	var first, second bytes.Buffer
+	have, err := hashpb.Sum64Auto(msg, hashpb.WithTee(&first), hashpb.WithTee(&second))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if have != want {
+		t.Errorf("Tee changed the digest: want=%x have=%x", want, have)
+	}
+
+	if teeSum := xxhash.Sum64(first.Bytes()); teeSum != want {
+		t.Errorf("Tee output does not match the hashed bytes: want=%x have=%x", want, teeSum)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Expected all tees to receive the same bytes")
+	}
+
+	errTee := errors.New("tee failed")
+	if _, err := hashpb.Sum64(msg, hashpb.WithTee(&failingHasher{Hash: xxhash.New(), err: errTee})); !errors.Is(err, errTee) {
+		t.Errorf("Expected tee error, got %v", err)
+	}
+}
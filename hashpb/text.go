@@ -0,0 +1,24 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// SumText unmarshals the protobuf text format (textproto) encoding of a message of the type described by md into a
+// dynamic message and returns its hash, which is handy for test fixtures and tooling that specify messages as
+// textproto files. Like SumJSON, it produces the same digest as the message unmarshaled into its generated type.
+func SumText(md protoreflect.MessageDescriptor, textBytes []byte, opts ...Option) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	if err := prototext.Unmarshal(textBytes, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s from text: %w", md.FullName(), err)
+	}
+
+	return Sum(nil, msg, opts...)
+}
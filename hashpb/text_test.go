@@ -0,0 +1,47 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+)
+
+func TestSumText(t *testing.T) {
+	msg := &pb.NestedTestAllTypes{
+		Payload: &pb.TestAllTypes{SingleInt64: 42, SingleString: "foo", MapInt32String: map[int32]string{1: "a", 2: "b"}},
+		Child:   &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}}},
+	}
+
+	text := `
+payload {
+  single_int64: 42
+  single_string: "foo"
+  map_int32_string { key: 2 value: "b" }
+  map_int32_string { key: 1 value: "a" }
+}
+child { payload { repeated_int32: [1, 2, 3] } }
+`
+
+	want, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	have, err := hashpb.SumText(msg.ProtoReflect().Descriptor(), []byte(text))
+	if err != nil {
+		t.Fatalf("Failed to hash text: %v", err)
+	}
+
+	if !bytes.Equal(want, have) {
+		t.Errorf("Hash mismatch: want=%x have=%x", want, have)
+	}
+
+	if _, err := hashpb.SumText(msg.ProtoReflect().Descriptor(), []byte(`payload: 1`)); err == nil {
+		t.Error("Expected error hashing invalid text")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrInvalidUTF8 is returned by hashing functions using WithStrictUTF8 when a string value is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("string value is not valid UTF-8")
+
+// WithStrictUTF8 makes hashing fail with ErrInvalidUTF8 if the value of a string field (including the elements of
+// repeated fields and the values of maps) of the message, or of any message nested in it, is not valid UTF-8. Such
+// values would fail serialization of proto3 messages anyway, so this makes sure that the hashed stream only contains
+// valid protobuf string content. Ignored fields are not checked.
+// The generated methods don't validate strings, so SumAuto and Sum64Auto use reflection when it is set.
+func WithStrictUTF8() Option {
+	return func(o *Options) {
+		o.strictUTF8 = true
+	}
+}
+
+// checkUTF8 returns an error if s, which is a value of the field, is not valid UTF-8.
+func checkUTF8(fd protoreflect.FieldDescriptor, s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("%w: %s", ErrInvalidUTF8, fd.FullName())
+	}
+
+	return nil
+}
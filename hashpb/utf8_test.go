@@ -0,0 +1,54 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithStrictUTF8(t *testing.T) {
+	invalid := string([]byte{0xff, 'a'})
+
+	testCases := []struct {
+		name    string
+		msg     proto.Message
+		wantErr bool
+	}{
+		{name: "valid", msg: &pb.TestAllTypes{SingleString: "héllo", RepeatedString: []string{"a"}}},
+		{name: "singular", msg: &pb.TestAllTypes{SingleString: invalid}, wantErr: true},
+		{name: "repeated", msg: &pb.TestAllTypes{RepeatedString: []string{"a", invalid}}, wantErr: true},
+		{name: "nested", msg: &pb.NestedTestAllTypes{Payload: &pb.TestAllTypes{SingleString: invalid}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+				_, err := hashpb.SumAuto(nil, tc.msg, hashpb.WithScheme(scheme), hashpb.WithStrictUTF8())
+				if tc.wantErr && !errors.Is(err, hashpb.ErrInvalidUTF8) {
+					t.Errorf("Expected ErrInvalidUTF8 with scheme %v, got %v", scheme, err)
+				} else if !tc.wantErr && err != nil {
+					t.Errorf("Unexpected error with scheme %v: %v", scheme, err)
+				}
+			}
+		})
+	}
+
+	t.Run("ignored", func(t *testing.T) {
+		msg := &pb.TestAllTypes{SingleString: invalid}
+		if _, err := hashpb.Sum(nil, msg, hashpb.WithStrictUTF8(), hashpb.WithIgnore("cerbos.hashpb.test.TestAllTypes.single_string")); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		if _, err := hashpb.Sum(nil, &pb.TestAllTypes{SingleString: invalid}); err != nil {
+			t.Errorf("Unexpected error without the option: %v", err)
+		}
+	})
+}
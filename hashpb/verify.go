@@ -0,0 +1,56 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// MismatchError is returned by Verify and Verify64 when the digest of the message doesn't match the expected digest.
+type MismatchError struct {
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *MismatchError) Error() string {
+	if len(e.Expected) != len(e.Actual) {
+		return fmt.Sprintf("digest length mismatch: expected %d bytes, got %d bytes (the digest may have been computed with a different hash function)", len(e.Expected), len(e.Actual))
+	}
+
+	return fmt.Sprintf("digest mismatch: expected %x, got %x", e.Expected, e.Actual)
+}
+
+// Verify calculates the hash of the message and compares it with the expected digest.
+// It returns a *MismatchError if they differ.
+func Verify(msg proto.Message, expected []byte, opts ...Option) error {
+	actual, err := Sum(nil, msg, opts...)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(actual, expected) {
+		return &MismatchError{Expected: expected, Actual: actual}
+	}
+
+	return nil
+}
+
+// Verify64 calculates the 64-bit hash of the message and compares it with the expected digest.
+// It returns a *MismatchError if they differ.
+func Verify64(msg proto.Message, expected uint64, opts ...Option) error {
+	actual, err := Sum64(msg, opts...)
+	if err != nil {
+		return err
+	}
+
+	if actual != expected {
+		return &MismatchError{Expected: binary.BigEndian.AppendUint64(nil, expected), Actual: binary.BigEndian.AppendUint64(nil, actual)}
+	}
+
+	return nil
+}
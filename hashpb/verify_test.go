@@ -0,0 +1,54 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+func TestVerify(t *testing.T) {
+	msg := mkNestedTestAllTypesMsg(2)
+
+	digest, err := hashpb.Sum(nil, msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	digest64, err := hashpb.Sum64(msg)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	if err := hashpb.Verify(msg, digest); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err := hashpb.Verify64(msg, digest64); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	msg.Payload.SingleString = "changed"
+
+	var mismatch *hashpb.MismatchError
+	if err := hashpb.Verify(msg, digest); !errors.As(err, &mismatch) || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("Expected mismatch error: %v", err)
+	}
+
+	if err := hashpb.Verify64(msg, digest64); !errors.As(err, &mismatch) || len(mismatch.Expected) != 8 {
+		t.Errorf("Expected mismatch error: %v", err)
+	}
+
+	if err := hashpb.Verify(msg, digest, hashpb.WithHash(sha256.New)); !errors.As(err, &mismatch) || !strings.Contains(err.Error(), "length mismatch") {
+		t.Errorf("Expected length mismatch error: %v", err)
+	}
+
+	if err := hashpb.Verify(nil, digest); err == nil || errors.As(err, &mismatch) {
+		t.Errorf("Expected hashing error: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+const (
+	// GenVersion is the version of the code generated by the protoc-gen-go-hashpb release that matches this package.
+	// It is incremented when the generated code changes in a way that requires support from this package or that
+	// changes the digests it produces.
+	GenVersion = 1
+	// MinGenVersion is the oldest version of generated code that this package supports.
+	MinGenVersion = 1
+)
+
+// EnforceVersion is used by generated code to check at compile time that it is compatible with this package.
+// Generated code declares constants of this type whose values underflow (and therefore fail to compile) if the code
+// was generated for a version outside the range [MinGenVersion, GenVersion].
+//
+// This type is not intended to be used directly.
+type EnforceVersion uint
@@ -0,0 +1,152 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import (
+	"errors"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protorange"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Walk calls fn with the path from the root message to each populated value of the message that is hashed, in the
+// order in which the values are hashed: fields in field number order (the member of a oneof that is set at the
+// position of the first member of the oneof), list elements in index order and map entries in key order. The message
+// itself is visited first, followed by each field and, for lists, maps and messages, the values they contain.
+// Elements of lists that are hashed unordered or sorted by a key are visited in index order.
+//
+// Values are visited as they are in the message, before any of the normalizations set using options are applied.
+// Only the options that exclude fields, such as WithIgnore, WithIgnoreMatcher and WithFieldFilter, affect which values
+// are visited. Extensions, unknown fields and the contents of google.protobuf.Any values are not hashed, so they are not
+// visited either.
+//
+// The traversal is built on protorange and fn can return protorange.Break to skip the values contained in the current
+// value or protorange.Terminate to stop the walk.
+func Walk(msg proto.Message, fn func(protopath.Values) error, opts ...Option) error {
+	if msg == nil {
+		return errNilMessage
+	}
+
+	m := msg.ProtoReflect()
+	o := newOptions(opts)
+	ignore, filter := o.ignoreFor(m.Descriptor()), o.fieldFilter
+
+	var visits []protopath.Values
+	// excluded is the length of the path of the excluded field whose values are being visited, or -1.
+	excluded := -1
+	// a nil resolver stops protorange from expanding google.protobuf.Any values.
+	rangeOpts := protorange.Options{Stable: true, Resolver: (*protoregistry.Types)(nil)}
+	err := rangeOpts.Range(m, func(p protopath.Values) error {
+		if excluded >= 0 {
+			if p.Len() > excluded {
+				return nil
+			}
+			excluded = -1
+		}
+
+		switch step := p.Index(-1).Step; step.Kind() {
+		case protopath.UnknownAccessStep:
+			return nil
+		case protopath.FieldAccessStep:
+			if walkExcluded(ignore, filter, step.FieldDescriptor()) {
+				excluded = p.Len()
+				return nil
+			}
+		}
+
+		visits = append(visits, protopath.Values{
+			Path:   append(protopath.Path(nil), p.Path...),
+			Values: append([]protoreflect.Value(nil), p.Values...),
+		})
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// protorange visits fields in field number order, but the member of a oneof that is set is hashed at the position of
+	// the first member of the oneof.
+	sort.SliceStable(visits, func(i, j int) bool { return pathLess(visits[i].Path, visits[j].Path) })
+
+	skip := -1
+	for _, v := range visits {
+		if skip >= 0 {
+			if len(v.Path) > skip {
+				continue
+			}
+			skip = -1
+		}
+
+		if err := fn(v); err != nil {
+			switch {
+			case errors.Is(err, protorange.Break):
+				skip = len(v.Path)
+			case errors.Is(err, protorange.Terminate):
+				return nil
+			default:
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkExcluded reports whether the field is left out of the hash. Members of a oneof are excluded by ignoring the oneof.
+func walkExcluded(ignore map[string]struct{}, filter func(protoreflect.FieldDescriptor) bool, fd protoreflect.FieldDescriptor) bool {
+	if fd.IsExtension() {
+		return true
+	}
+
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		_, ignored := ignore[string(od.FullName())]
+		return ignored || (filter != nil && !filter(fd))
+	}
+
+	return fieldExcluded(ignore, filter, fd)
+}
+
+// pathLess orders paths in traversal order, with every path preceding the paths that extend it.
+func pathLess(a, b protopath.Path) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		sa, sb := a[i], b[i]
+		switch sa.Kind() {
+		case protopath.FieldAccessStep:
+			if sa.FieldDescriptor() != sb.FieldDescriptor() {
+				return traversalPosition(sa.FieldDescriptor()) < traversalPosition(sb.FieldDescriptor())
+			}
+		case protopath.ListIndexStep:
+			if sa.ListIndex() != sb.ListIndex() {
+				return sa.ListIndex() < sb.ListIndex()
+			}
+		case protopath.MapIndexStep:
+			if ka, kb := sa.MapIndex(), sb.MapIndex(); ka.Interface() != kb.Interface() {
+				return mapKeyLess(a[i-1].FieldDescriptor().MapKey().Kind(), ka, kb)
+			}
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// traversalPosition returns the field number at whose position the field is hashed.
+func traversalPosition(fd protoreflect.FieldDescriptor) protoreflect.FieldNumber {
+	od := fd.ContainingOneof()
+	if od == nil || od.IsSynthetic() {
+		return fd.Number()
+	}
+
+	pos := fd.Number()
+	for i := 0; i < od.Fields().Len(); i++ {
+		if n := od.Fields().Get(i).Number(); n < pos {
+			pos = n
+		}
+	}
+
+	return pos
+}
@@ -0,0 +1,124 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protorange"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestWalk(t *testing.T) {
+	msg := &pb.TestAllTypes{
+		SingleString:   "foo",
+		SingleBool:     true,
+		StandaloneEnum: pb.TestAllTypes_BAR,
+		NestedType:     &pb.TestAllTypes_SingleNestedEnum{SingleNestedEnum: pb.TestAllTypes_BAZ},
+		SingleDuration: durationpb.New(1),
+		RepeatedInt32:  []int32{3, 1},
+		MapInt32String: map[int32]string{10: "ten", 2: "two", -1: "minus one"},
+	}
+
+	walk := func(t *testing.T, fn func(protopath.Values) error, opts ...hashpb.Option) []string {
+		t.Helper()
+		var paths []string
+		err := hashpb.Walk(msg, func(v protopath.Values) error {
+			paths = append(paths, v.Path.String())
+			return fn(v)
+		}, opts...)
+		if err != nil {
+			t.Fatalf("Failed to walk: %v", err)
+		}
+		return paths
+	}
+
+	noop := func(protopath.Values) error { return nil }
+
+	t.Run("traversal_order", func(t *testing.T) {
+		want := []string{
+			"(cerbos.hashpb.test.TestAllTypes)",
+			"(cerbos.hashpb.test.TestAllTypes).single_bool",
+			"(cerbos.hashpb.test.TestAllTypes).single_string",
+			"(cerbos.hashpb.test.TestAllTypes).single_nested_enum",
+			"(cerbos.hashpb.test.TestAllTypes).standalone_enum",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32[0]",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32[1]",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string[-1]",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string[2]",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string[10]",
+			"(cerbos.hashpb.test.TestAllTypes).single_duration",
+			"(cerbos.hashpb.test.TestAllTypes).single_duration.nanos",
+		}
+		if diff := cmp.Diff(want, walk(t, noop)); diff != "" {
+			t.Errorf("Path mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		want := []string{
+			"(cerbos.hashpb.test.TestAllTypes)",
+			"(cerbos.hashpb.test.TestAllTypes).single_bool",
+			"(cerbos.hashpb.test.TestAllTypes).standalone_enum",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32[0]",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32[1]",
+			"(cerbos.hashpb.test.TestAllTypes).single_duration",
+			"(cerbos.hashpb.test.TestAllTypes).single_duration.nanos",
+		}
+		have := walk(t, noop, hashpb.WithIgnore(
+			"cerbos.hashpb.test.TestAllTypes.single_string",
+			"cerbos.hashpb.test.TestAllTypes.nested_type",
+			"cerbos.hashpb.test.TestAllTypes.map_int32_string",
+		))
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("Path mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("break_and_terminate", func(t *testing.T) {
+		have := walk(t, func(v protopath.Values) error {
+			switch v.Path.String() {
+			case "(cerbos.hashpb.test.TestAllTypes).repeated_int32":
+				return protorange.Break
+			case "(cerbos.hashpb.test.TestAllTypes).map_int32_string[2]":
+				return protorange.Terminate
+			default:
+				return nil
+			}
+		})
+		want := []string{
+			"(cerbos.hashpb.test.TestAllTypes)",
+			"(cerbos.hashpb.test.TestAllTypes).single_bool",
+			"(cerbos.hashpb.test.TestAllTypes).single_string",
+			"(cerbos.hashpb.test.TestAllTypes).single_nested_enum",
+			"(cerbos.hashpb.test.TestAllTypes).standalone_enum",
+			"(cerbos.hashpb.test.TestAllTypes).repeated_int32",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string[-1]",
+			"(cerbos.hashpb.test.TestAllTypes).map_int32_string[2]",
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("Path mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		if err := hashpb.Walk(msg, func(protopath.Values) error { return errStop }); !errors.Is(err, errStop) {
+			t.Errorf("Expected the error returned by fn, got %v", err)
+		}
+
+		if err := hashpb.Walk(nil, func(protopath.Values) error { return nil }); !errors.Is(err, hashpb.ErrInvalidMessage) {
+			t.Errorf("Expected ErrInvalidMessage, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wellknown provides canonicalizers for common message types from the google.type package, to be used with
+// hashpb.WithCanonicalizer. Values with the same meaning but different representations, such as an amount of money
+// with more than a unit worth of nanos, are hashed in a single canonical form.
+//
+// The canonicalizers use protobuf reflection, so they work with the generated google.golang.org/genproto types as
+// well as with dynamic messages, and this package doesn't depend on genproto.
+package wellknown
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Full names of the message types supported by this package.
+const (
+	MoneyName     protoreflect.FullName = "google.type.Money"
+	DateName      protoreflect.FullName = "google.type.Date"
+	TimeOfDayName protoreflect.FullName = "google.type.TimeOfDay"
+	LatLngName    protoreflect.FullName = "google.type.LatLng"
+)
+
+const nanosPerUnit = 1_000_000_000
+
+// Options returns options that register all the canonicalizers of this package.
+func Options() []hashpb.Option {
+	return []hashpb.Option{
+		hashpb.WithCanonicalizer(MoneyName, Money),
+		hashpb.WithCanonicalizer(DateName, Date),
+		hashpb.WithCanonicalizer(TimeOfDayName, TimeOfDay),
+		hashpb.WithCanonicalizer(LatLngName, LatLng),
+	}
+}
+
+// Money canonicalizes a google.type.Money message. Whole units are carried from nanos to units so that nanos are
+// less than a unit and have the same sign as units, and the currency code is converted to upper case.
+func Money(m protoreflect.Message) protoreflect.Message {
+	c := clone(m)
+	fields := c.Descriptor().Fields()
+	code, unitsField, nanosField := fields.ByName("currency_code"), fields.ByName("units"), fields.ByName("nanos")
+
+	units, nanos := c.Get(unitsField).Int(), c.Get(nanosField).Int()
+	units += nanos / nanosPerUnit
+	nanos %= nanosPerUnit
+
+	switch {
+	case units > 0 && nanos < 0:
+		units--
+		nanos += nanosPerUnit
+	case units < 0 && nanos > 0:
+		units++
+		nanos -= nanosPerUnit
+	}
+
+	c.Set(code, protoreflect.ValueOfString(strings.ToUpper(strings.TrimSpace(c.Get(code).String()))))
+	c.Set(unitsField, protoreflect.ValueOfInt64(units))
+	c.Set(nanosField, protoreflect.ValueOfInt32(int32(nanos)))
+
+	return c
+}
+
+// Date canonicalizes a google.type.Date message. Full dates with days or months out of range are normalized to the
+// date they denote (for example, 31 April becomes 1 May). Partial dates, with a zero year, month or day, are left as
+// they are.
+func Date(m protoreflect.Message) protoreflect.Message {
+	fields := m.Descriptor().Fields()
+	yearField, monthField, dayField := fields.ByName("year"), fields.ByName("month"), fields.ByName("day")
+
+	year, month, day := m.Get(yearField).Int(), m.Get(monthField).Int(), m.Get(dayField).Int()
+	if year == 0 || month == 0 || day == 0 {
+		return m
+	}
+
+	t := time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+
+	c := clone(m)
+	c.Set(yearField, protoreflect.ValueOfInt32(int32(t.Year())))
+	c.Set(monthField, protoreflect.ValueOfInt32(int32(t.Month())))
+	c.Set(dayField, protoreflect.ValueOfInt32(int32(t.Day())))
+
+	return c
+}
+
+// TimeOfDay canonicalizes a google.type.TimeOfDay message. Nanos, seconds and minutes out of range are carried to the
+// next larger unit (for example, 10:59:60 becomes 11:00:00). Negative times are left as they are.
+func TimeOfDay(m protoreflect.Message) protoreflect.Message {
+	fields := m.Descriptor().Fields()
+	hoursField, minutesField := fields.ByName("hours"), fields.ByName("minutes")
+	secondsField, nanosField := fields.ByName("seconds"), fields.ByName("nanos")
+
+	total := time.Duration(m.Get(hoursField).Int())*time.Hour +
+		time.Duration(m.Get(minutesField).Int())*time.Minute +
+		time.Duration(m.Get(secondsField).Int())*time.Second +
+		time.Duration(m.Get(nanosField).Int())
+	if total < 0 {
+		return m
+	}
+
+	c := clone(m)
+	c.Set(hoursField, protoreflect.ValueOfInt32(int32(total/time.Hour)))
+	c.Set(minutesField, protoreflect.ValueOfInt32(int32(total%time.Hour/time.Minute)))
+	c.Set(secondsField, protoreflect.ValueOfInt32(int32(total%time.Minute/time.Second)))
+	c.Set(nanosField, protoreflect.ValueOfInt32(int32(total%time.Second)))
+
+	return c
+}
+
+// LatLng canonicalizes a google.type.LatLng message. Latitudes beyond the poles are folded back into [-90, 90] (moving
+// the longitude to the other side of the globe), longitudes are wrapped into [-180, 180), and the longitude of a pole
+// is set to zero. Negative zeros are replaced by zeros. Coordinates that are not finite are left as they are.
+func LatLng(m protoreflect.Message) protoreflect.Message {
+	fields := m.Descriptor().Fields()
+	latField, lngField := fields.ByName("latitude"), fields.ByName("longitude")
+
+	lat, lng := m.Get(latField).Float(), m.Get(lngField).Float()
+	if math.IsNaN(lat) || math.IsInf(lat, 0) || math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return m
+	}
+
+	lat = math.Mod(lat, 360)
+	switch {
+	case lat > 180:
+		lat -= 360
+	case lat < -180:
+		lat += 360
+	}
+
+	switch {
+	case lat > 90:
+		lat = 180 - lat
+		lng += 180
+	case lat < -90:
+		lat = -180 - lat
+		lng += 180
+	}
+
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	lng -= 180
+
+	if lat == 90 || lat == -90 {
+		lng = 0
+	}
+
+	c := clone(m)
+	// adding zero turns negative zeros into zeros.
+	c.Set(latField, protoreflect.ValueOfFloat64(lat+0))
+	c.Set(lngField, protoreflect.ValueOfFloat64(lng+0))
+
+	return c
+}
+
+func clone(m protoreflect.Message) protoreflect.Message {
+	return proto.Clone(m.Interface()).ProtoReflect()
+}
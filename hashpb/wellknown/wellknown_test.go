@@ -0,0 +1,175 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package wellknown_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestCanonicalizers(t *testing.T) {
+	types := googleTypes(t)
+
+	testCases := []struct {
+		name      string
+		msg       protoreflect.FullName
+		want      map[string]any
+		same      map[string]any
+		different map[string]any
+	}{
+		{
+			name:      "money",
+			msg:       wellknown.MoneyName,
+			want:      map[string]any{"currency_code": "USD", "units": int64(3), "nanos": int32(500_000_000)},
+			same:      map[string]any{"currency_code": "usd", "units": int64(2), "nanos": int32(1_500_000_000)},
+			different: map[string]any{"currency_code": "EUR", "units": int64(3), "nanos": int32(500_000_000)},
+		},
+		{
+			name:      "negative_money",
+			msg:       wellknown.MoneyName,
+			want:      map[string]any{"currency_code": "USD", "units": int64(-1), "nanos": int32(-750_000_000)},
+			same:      map[string]any{"currency_code": "USD", "units": int64(-2), "nanos": int32(250_000_000)},
+			different: map[string]any{"currency_code": "USD", "units": int64(-1), "nanos": int32(750_000_000)},
+		},
+		{
+			name:      "date",
+			msg:       wellknown.DateName,
+			want:      map[string]any{"year": int32(2024), "month": int32(5), "day": int32(1)},
+			same:      map[string]any{"year": int32(2024), "month": int32(4), "day": int32(31)},
+			different: map[string]any{"year": int32(0), "month": int32(5), "day": int32(1)},
+		},
+		{
+			name:      "time_of_day",
+			msg:       wellknown.TimeOfDayName,
+			want:      map[string]any{"hours": int32(11)},
+			same:      map[string]any{"hours": int32(10), "minutes": int32(59), "seconds": int32(59), "nanos": int32(1_000_000_000)},
+			different: map[string]any{"hours": int32(11), "nanos": int32(1)},
+		},
+		{
+			name:      "lat_lng",
+			msg:       wellknown.LatLngName,
+			want:      map[string]any{"latitude": 80.0, "longitude": -170.0},
+			same:      map[string]any{"latitude": 100.0, "longitude": 10.0},
+			different: map[string]any{"latitude": 80.0, "longitude": 10.0},
+		},
+		{
+			name:      "pole",
+			msg:       wellknown.LatLngName,
+			want:      map[string]any{"latitude": 90.0},
+			same:      map[string]any{"latitude": 90.0, "longitude": 123.0},
+			different: map[string]any{"latitude": -90.0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := types[tc.msg]
+			mkMsg := func(values map[string]any) proto.Message {
+				msg := dynamicpb.NewMessage(md)
+				for name, v := range values {
+					msg.Set(md.Fields().ByName(protoreflect.Name(name)), protoreflect.ValueOf(v))
+				}
+				return msg
+			}
+
+			for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+				sum := func(m proto.Message, opts ...hashpb.Option) []byte {
+					t.Helper()
+
+					digest, err := hashpb.Sum(nil, m, append(opts, hashpb.WithScheme(scheme))...)
+					if err != nil {
+						t.Fatalf("Failed to hash: %v", err)
+					}
+
+					return digest
+				}
+
+				opts := wellknown.Options()
+				want := sum(mkMsg(tc.want), opts...)
+				if have := sum(mkMsg(tc.same), opts...); !bytes.Equal(want, have) {
+					t.Errorf("Expected %v to hash like %v with scheme %v", tc.same, tc.want, scheme)
+				}
+
+				if have := sum(mkMsg(tc.different), opts...); bytes.Equal(want, have) {
+					t.Errorf("Expected %v to hash differently from %v with scheme %v", tc.different, tc.want, scheme)
+				}
+
+				if bytes.Equal(sum(mkMsg(tc.want)), sum(mkMsg(tc.same))) {
+					t.Errorf("Expected %v to hash differently from %v without canonicalization with scheme %v", tc.same, tc.want, scheme)
+				}
+			}
+		})
+	}
+}
+
+// googleTypes returns dynamic descriptors of the google.type messages supported by the package.
+func googleTypes(t *testing.T) map[protoreflect.FullName]protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+
+	int32Type, int64Type := descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("google/type/test_types.proto"),
+		Package: proto.String("google.type"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Money"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("currency_code", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("units", 2, int64Type),
+					field("nanos", 3, int32Type),
+				},
+			},
+			{
+				Name:  proto.String("Date"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("year", 1, int32Type), field("month", 2, int32Type), field("day", 3, int32Type)},
+			},
+			{
+				Name: proto.String("TimeOfDay"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("hours", 1, int32Type),
+					field("minutes", 2, int32Type),
+					field("seconds", 3, int32Type),
+					field("nanos", 4, int32Type),
+				},
+			},
+			{
+				Name: proto.String("LatLng"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("latitude", 1, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+					field("longitude", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	types := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	for i := 0; i < fd.Messages().Len(); i++ {
+		md := fd.Messages().Get(i)
+		types[md.FullName()] = md
+	}
+
+	return types
+}
@@ -0,0 +1,43 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+var wrapperTypes = map[protoreflect.FullName]struct{}{
+	"google.protobuf.DoubleValue": {},
+	"google.protobuf.FloatValue":  {},
+	"google.protobuf.Int64Value":  {},
+	"google.protobuf.UInt64Value": {},
+	"google.protobuf.Int32Value":  {},
+	"google.protobuf.UInt32Value": {},
+	"google.protobuf.BoolValue":   {},
+	"google.protobuf.StringValue": {},
+	"google.protobuf.BytesValue":  {},
+}
+
+// WithWrappersAsOptional hashes the well-known wrapper types (google.protobuf.Int64Value and friends) like the
+// equivalent proto3 optional scalar fields, so that migrating a field from a wrapper type to an optional scalar of the
+// same type (or the other way round) doesn't change the digests of existing messages. With the default scheme, unset
+// wrappers are hashed as the default value of the wrapped type instead of being left out, and with SchemeObjectHash,
+// wrappers are hashed as their value instead of as a message.
+// The generated methods hash wrappers as messages, so SumAuto and Sum64Auto use reflection when it is set.
+func WithWrappersAsOptional() Option {
+	return func(o *Options) {
+		o.wrappers = true
+	}
+}
+
+// wrappedField returns the value field of the message if it is a wrapper type, or nil otherwise.
+func wrappedField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	if md == nil {
+		return nil
+	}
+
+	if _, ok := wrapperTypes[md.FullName()]; !ok {
+		return nil
+	}
+
+	return md.Fields().ByName("value")
+}
@@ -0,0 +1,126 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package hashpb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWithWrappersAsOptional(t *testing.T) {
+	wrapped, optional := wrapperMigrationDescriptors(t)
+
+	testCases := []struct {
+		name string
+		x    *int64
+		s    *string
+	}{
+		{name: "set", x: proto.Int64(42), s: proto.String("wibble")},
+		{name: "zero", x: proto.Int64(0), s: proto.String("")},
+		{name: "unset"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := dynamicpb.NewMessage(wrapped)
+			after := dynamicpb.NewMessage(optional)
+			if tc.x != nil {
+				before.Set(wrapped.Fields().ByName("x"), protoreflect.ValueOfMessage(wrapperspb.Int64(*tc.x).ProtoReflect()))
+				after.Set(optional.Fields().ByName("x"), protoreflect.ValueOfInt64(*tc.x))
+			}
+
+			if tc.s != nil {
+				before.Set(wrapped.Fields().ByName("s"), protoreflect.ValueOfMessage(wrapperspb.String(*tc.s).ProtoReflect()))
+				after.Set(optional.Fields().ByName("s"), protoreflect.ValueOfString(*tc.s))
+			}
+
+			for _, scheme := range []hashpb.Scheme{hashpb.SchemeDefault, hashpb.SchemeObjectHash} {
+				want, err := hashpb.Sum(nil, after, hashpb.WithScheme(scheme))
+				if err != nil {
+					t.Fatalf("Failed to hash: %v", err)
+				}
+
+				have, err := hashpb.Sum(nil, before, hashpb.WithScheme(scheme), hashpb.WithWrappersAsOptional())
+				if err != nil {
+					t.Fatalf("Failed to hash: %v", err)
+				}
+
+				if !bytes.Equal(want, have) {
+					t.Errorf("Expected wrappers to hash like optional fields with scheme %v: want=%x have=%x", scheme, want, have)
+				}
+
+				// set wrappers already hash like optional scalars with the default scheme, and unset ones are left out
+				// with SchemeObjectHash.
+				if (scheme == hashpb.SchemeDefault) == (tc.x == nil) {
+					if plain, _ := hashpb.Sum(nil, before, hashpb.WithScheme(scheme)); bytes.Equal(want, plain) {
+						t.Errorf("Expected wrappers to hash like messages without the option with scheme %v", scheme)
+					}
+				}
+			}
+		})
+	}
+}
+
+// wrapperMigrationDescriptors returns the descriptors of a message with wrapper fields and of the same message after
+// migrating the fields to proto3 optional scalars.
+func wrapperMigrationDescriptors(t *testing.T) (protoreflect.MessageDescriptor, protoreflect.MessageDescriptor) {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		} else {
+			fd.Proto3Optional = proto.Bool(true)
+			fd.OneofIndex = proto.Int32(number - 1)
+		}
+
+		return fd
+	}
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("migration.proto"),
+		Package:    proto.String("migration"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Wrapped"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("x", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Int64Value"),
+					field("s", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				},
+			},
+			{
+				Name: proto.String("Optional"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("x", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+					field("s", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_x")}, {Name: proto.String("_s")}},
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+
+	return fd.Messages().Get(0), fd.Messages().Get(1)
+}
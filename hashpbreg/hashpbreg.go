@@ -0,0 +1,45 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hashpbreg is a registry of the hash functions generated by protoc-gen-go-hashpb.
+// Generated code registers its hash functions here when the plugin is invoked with the registry=true parameter.
+package hashpbreg
+
+import (
+	"fmt"
+	"hash"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// HashFunc hashes msg using generated code. It reports false if msg is not of the Go type the function was generated for
+// (for example, when msg is a dynamic message) and nothing was written to the hasher.
+type HashFunc func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool
+
+var (
+	mu    sync.RWMutex
+	funcs = make(map[string]HashFunc)
+)
+
+// Register registers the hash function for the message with the given fully-qualified name.
+// It panics if a function is already registered for that name.
+func Register(fullName string, fn HashFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := funcs[fullName]; ok {
+		panic(fmt.Errorf("hash function for %s is already registered", fullName))
+	}
+
+	funcs[fullName] = fn
+}
+
+// Lookup returns the hash function registered for the message with the given fully-qualified name.
+func Lookup(fullName string) (HashFunc, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	fn, ok := funcs[fullName]
+	return fn, ok
+}
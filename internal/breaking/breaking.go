@@ -0,0 +1,195 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package breaking detects schema changes that alter the digests of existing data.
+package breaking
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Kind is the category of a change.
+type Kind string
+
+const (
+	// FieldAdded is reported for new singular scalar fields. Their default values are hashed, so existing digests change.
+	FieldAdded Kind = "FIELD_ADDED"
+	// FieldRemoved is reported for removed fields.
+	FieldRemoved Kind = "FIELD_REMOVED"
+	// FieldRenumbered is reported when a field keeps its name but changes its number, which changes the traversal order.
+	FieldRenumbered Kind = "FIELD_RENUMBERED"
+	// FieldTypeChanged is reported when the kind, cardinality or message type of a field changes.
+	FieldTypeChanged Kind = "FIELD_TYPE_CHANGED"
+	// OneofChanged is reported when a field moves into, out of, or between oneofs or when a oneof is renamed.
+	OneofChanged Kind = "ONEOF_CHANGED"
+	// FieldRenamed is reported when a field is renamed. Digests are not affected but ignore sets referring to the field must be updated.
+	FieldRenamed Kind = "FIELD_RENAMED"
+)
+
+// Change describes a schema change.
+type Change struct {
+	Kind        Kind
+	Name        string
+	Description string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Kind, c.Name, c.Description)
+}
+
+// Breaking reports whether the change alters the digests of existing data.
+func (c Change) Breaking() bool {
+	return c.Kind != FieldRenamed
+}
+
+// Compare returns the changes to messages that exist in both previous and current.
+func Compare(previous, current *protoregistry.Files) []Change {
+	var changes []Change
+	previous.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		changes = compareMessages(changes, fd.Messages(), current)
+		return true
+	})
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+func compareMessages(changes []Change, mds protoreflect.MessageDescriptors, current *protoregistry.Files) []Change {
+	for i := 0; i < mds.Len(); i++ {
+		prev := mds.Get(i)
+		changes = compareMessages(changes, prev.Messages(), current)
+
+		if prev.IsMapEntry() {
+			continue
+		}
+
+		desc, err := current.FindDescriptorByName(prev.FullName())
+		if err != nil {
+			continue
+		}
+
+		if curr, ok := desc.(protoreflect.MessageDescriptor); ok {
+			changes = compareFields(changes, prev, curr)
+		}
+	}
+
+	return changes
+}
+
+func compareFields(changes []Change, prev, curr protoreflect.MessageDescriptor) []Change {
+	prevFields, currFields := prev.Fields(), curr.Fields()
+	matched := make(map[protoreflect.FieldNumber]struct{})
+
+	for i := 0; i < prevFields.Len(); i++ {
+		pf := prevFields.Get(i)
+		cf := currFields.ByNumber(pf.Number())
+		if cf == nil {
+			if renumbered := currFields.ByName(pf.Name()); renumbered != nil {
+				matched[renumbered.Number()] = struct{}{}
+				changes = append(changes, Change{
+					Kind:        FieldRenumbered,
+					Name:        string(pf.FullName()),
+					Description: fmt.Sprintf("field number changed from %d to %d", pf.Number(), renumbered.Number()),
+				})
+				changes = compareField(changes, pf, renumbered)
+				continue
+			}
+
+			changes = append(changes, Change{Kind: FieldRemoved, Name: string(pf.FullName()), Description: "field removed"})
+			continue
+		}
+
+		matched[cf.Number()] = struct{}{}
+		if pf.Name() != cf.Name() {
+			changes = append(changes, Change{
+				Kind:        FieldRenamed,
+				Name:        string(pf.FullName()),
+				Description: fmt.Sprintf("field renamed to %s", cf.Name()),
+			})
+		}
+
+		changes = compareField(changes, pf, cf)
+	}
+
+	for i := 0; i < currFields.Len(); i++ {
+		cf := currFields.Get(i)
+		if _, ok := matched[cf.Number()]; ok {
+			continue
+		}
+
+		if cf.Cardinality() != protoreflect.Repeated && cf.Message() == nil && realOneof(cf) == nil {
+			changes = append(changes, Change{
+				Kind:        FieldAdded,
+				Name:        string(cf.FullName()),
+				Description: "new singular field: its default value is included in the hash",
+			})
+		}
+	}
+
+	return changes
+}
+
+func compareField(changes []Change, pf, cf protoreflect.FieldDescriptor) []Change {
+	if pt, ct := fieldType(pf), fieldType(cf); pt != ct {
+		changes = append(changes, Change{
+			Kind:        FieldTypeChanged,
+			Name:        string(pf.FullName()),
+			Description: fmt.Sprintf("field type changed from %s to %s", pt, ct),
+		})
+	}
+
+	if po, co := oneofName(pf), oneofName(cf); po != co {
+		changes = append(changes, Change{
+			Kind:        OneofChanged,
+			Name:        string(pf.FullName()),
+			Description: fmt.Sprintf("containing oneof changed from %q to %q", po, co),
+		})
+	}
+
+	return changes
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", fieldType(fd.MapKey()), fieldType(fd.MapValue()))
+	case fd.IsList():
+		return "repeated " + singularType(fd)
+	default:
+		return singularType(fd)
+	}
+}
+
+func singularType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.Message() != nil:
+		return string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func realOneof(fd protoreflect.FieldDescriptor) protoreflect.OneofDescriptor {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return od
+	}
+
+	return nil
+}
+
+func oneofName(fd protoreflect.FieldDescriptor) string {
+	if od := realOneof(fd); od != nil {
+		return string(od.Name())
+	}
+
+	return ""
+}
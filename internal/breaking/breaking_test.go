@@ -0,0 +1,114 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package breaking_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/breaking"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCompare(t *testing.T) {
+	previous := mkFiles(t,
+		field("a", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		field("b", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		msgField("c", 3),
+		oneofField(field("d", 4, descriptorpb.FieldDescriptorProto_TYPE_INT32)),
+		oneofField(field("e", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING)),
+		field("f", 6, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		field("g", 7, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+		field("h", 8, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		field("i", 9, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+	)
+
+	current := mkFiles(t,
+		field("a", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		field("b", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		msgField("c", 3),
+		oneofField(field("d", 4, descriptorpb.FieldDescriptorProto_TYPE_INT32)),
+		field("e", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		field("f2", 6, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		field("g", 17, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+		field("h", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		field("j", 10, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+		msgField("k", 11),
+	)
+
+	want := []struct {
+		kind breaking.Kind
+		name string
+	}{
+		{kind: breaking.OneofChanged, name: "test.Msg.e"},
+		{kind: breaking.FieldRenamed, name: "test.Msg.f"},
+		{kind: breaking.FieldRenumbered, name: "test.Msg.g"},
+		{kind: breaking.FieldTypeChanged, name: "test.Msg.h"},
+		{kind: breaking.FieldRemoved, name: "test.Msg.i"},
+		{kind: breaking.FieldAdded, name: "test.Msg.j"},
+	}
+
+	have := breaking.Compare(previous, current)
+	if len(have) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(want), len(have), have)
+	}
+
+	for i, w := range want {
+		if have[i].Kind != w.kind || have[i].Name != w.name {
+			t.Errorf("Change %d: want=%s %s have=%s", i, w.kind, w.name, have[i])
+		}
+	}
+
+	if changes := breaking.Compare(previous, previous); len(changes) != 0 {
+		t.Fatalf("Expected no changes: %v", changes)
+	}
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+func msgField(name string, number int32) *descriptorpb.FieldDescriptorProto {
+	f := field(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	f.TypeName = proto.String(".test.Sub")
+	return f
+}
+
+func oneofField(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.OneofIndex = proto.Int32(0)
+	return f
+}
+
+func mkFiles(t *testing.T, fields ...*descriptorpb.FieldDescriptorProto) *protoregistry.Files {
+	t.Helper()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Sub")},
+			{
+				Name:      proto.String("Msg"),
+				Field:     fields,
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("o")}},
+			},
+		},
+	}
+
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}})
+	if err != nil {
+		t.Fatalf("Failed to create files: %v", err)
+	}
+
+	return files
+}
@@ -0,0 +1,138 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package descset loads serialized FileDescriptorSets such as the ones produced by `buf build` or `protoc --descriptor_set_out`.
+package descset
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Load reads the FileDescriptorSet at path.
+func Load(path string) (*descriptorpb.FileDescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	return fds, nil
+}
+
+// LoadFiles reads the FileDescriptorSet at path and builds a registry of the files it contains.
+func LoadFiles(path string) (*protoregistry.Files, error) {
+	fds, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors from %s: %w", path, err)
+	}
+
+	return files, nil
+}
+
+// Build creates a FileDescriptorSet containing the given files and their transitive dependencies.
+// Dependencies are listed before the files that import them.
+func Build(files ...protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	fds := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]struct{})
+
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if _, ok := seen[fd.Path()]; ok {
+			return
+		}
+		seen[fd.Path()] = struct{}{}
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, fd := range files {
+		add(fd)
+	}
+
+	return fds
+}
+
+// Request creates a plugin request for generating the given files from the descriptor set, or all the files in the
+// descriptor set if none are given. The parameter is passed to the plugin as is.
+func Request(fds *descriptorpb.FileDescriptorSet, filesToGenerate []string, parameter string) (*pluginpb.CodeGeneratorRequest, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto, len(fds.File))
+	for _, f := range fds.File {
+		files[f.GetName()] = f
+	}
+
+	if len(filesToGenerate) == 0 {
+		for _, f := range fds.File {
+			filesToGenerate = append(filesToGenerate, f.GetName())
+		}
+	}
+
+	for _, name := range filesToGenerate {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("file %s is not in the descriptor set", name)
+		}
+	}
+
+	// protogen requires dependencies to be listed before the files that import them.
+	var sorted []*descriptorpb.FileDescriptorProto
+	visited := make(map[string]struct{}, len(files))
+	var visit func(string) error
+	visit = func(name string) error {
+		if _, ok := visited[name]; ok {
+			return nil
+		}
+		visited[name] = struct{}{}
+
+		f, ok := files[name]
+		if !ok {
+			return fmt.Errorf("dependency %s is not in the descriptor set", name)
+		}
+
+		for _, dep := range f.Dependency {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		sorted = append(sorted, f)
+		return nil
+	}
+
+	for _, f := range fds.File {
+		if err := visit(f.GetName()); err != nil {
+			return nil, err
+		}
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: filesToGenerate,
+		ProtoFile:      sorted,
+	}
+
+	if parameter = strings.TrimSpace(parameter); parameter != "" {
+		req.Parameter = proto.String(parameter)
+	}
+
+	return req, nil
+}
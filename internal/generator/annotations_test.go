@@ -0,0 +1,139 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestStringAnnotations(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, &optionspb.FieldOptions{CaseInsensitive: true, TrimSpace: true})
+	field := fds.File[1].MessageType[0].Field[0]
+	field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	field.Options = opts
+
+	files := generateFrom(t, fds, nil, "paths=source_relative,split_proto_packages=true,objecthash=true,normalize_unicode=true")
+	have := files["b/hashpb_helpers_b.pb.go"]
+
+	for _, want := range []string{
+		"protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(norm.NFC.String(m.GetX()))))",
+		"objecthash.String(strings.ToLower(strings.TrimSpace(norm.NFC.String(m.X))))",
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+}
+
+func TestSampledListAnnotation(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, &optionspb.FieldOptions{SampleEvery: 100})
+	field := fds.File[1].MessageType[0].Field[0]
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	field.Options = opts
+
+	files := generateFrom(t, fds, nil, "paths=source_relative,split_proto_packages=true,objecthash=true")
+	have := files["b/hashpb_helpers_b.pb.go"]
+
+	for _, want := range []string{
+		"_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.X))))",
+		"for i := 0; i < len(m.X); i += 100 {",
+		"l.Add(objecthash.Int(int64(len(m.X))))",
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+}
+
+func TestSortByAnnotation(t *testing.T) {
+	sortedDescriptors := func(sortBy string) *descriptorpb.FileDescriptorSet {
+		fds := sharedPackageDescriptors()
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, optionspb.E_Field, &optionspb.FieldOptions{SortBy: sortBy})
+		field := fds.File[0].MessageType[0].Field[0]
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		field.Options = opts
+		return fds
+	}
+
+	files := generateFrom(t, sortedDescriptors("x"), nil, "paths=source_relative,split_proto_packages=true,objecthash=true")
+	have := files["a/hashpb_helpers_a.pb.go"]
+
+	for _, want := range []string{
+		"sorted := make([]*B, len(m.B))",
+		"copy(sorted, m.B)",
+		"sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetX() < sorted[j].GetX() })",
+		"for _, v := range sorted {",
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+
+	req, err := descset.Request(sortedDescriptors("y"), nil, "paths=source_relative")
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if !strings.Contains(resp.GetError(), "sort_by") {
+		t.Errorf("Expected an error for a sort key that doesn't exist, got %q", resp.GetError())
+	}
+}
+
+func TestUnorderedAnnotation(t *testing.T) {
+	unorderedDescriptors := func(fo *optionspb.FieldOptions) *descriptorpb.FileDescriptorSet {
+		fds := sharedPackageDescriptors()
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, optionspb.E_Field, fo)
+		field := fds.File[0].MessageType[0].Field[0]
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		field.Options = opts
+		return fds
+	}
+
+	files := generateFrom(t, unorderedDescriptors(&optionspb.FieldOptions{Unordered: true}), nil, "paths=source_relative,split_proto_packages=true,objecthash=true")
+	have := files["a/hashpb_helpers_a.pb.go"]
+
+	for _, want := range []string{
+		"hasher := sha256.New()",
+		"digests = append(digests, hasher.Sum(nil))",
+		"sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })",
+		"_, _ = hasher.Write(protowire.AppendBytes(nil, digest))",
+		"var s objecthash.Set",
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+
+	req, err := descset.Request(unorderedDescriptors(&optionspb.FieldOptions{Unordered: true, SortBy: "x"}), nil, "paths=source_relative")
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if !strings.Contains(resp.GetError(), "unordered") {
+		t.Errorf("Expected an error for unordered combined with sort_by, got %q", resp.GetError())
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// apiLevel is the Go API generated by protoc-gen-go for a message, as in the api_level feature of pb.go.
+type apiLevel int32
+
+const (
+	apiLevelUnspecified apiLevel = iota
+	apiOpen
+	apiHybrid
+	apiOpaque
+)
+
+var apiLevels = map[string]apiLevel{
+	"API_OPEN":   apiOpen,
+	"API_HYBRID": apiHybrid,
+	"API_OPAQUE": apiOpaque,
+}
+
+const (
+	// goFeaturesField is the number of the pb.go extension of google.protobuf.FeatureSet.
+	goFeaturesField = 1002
+	// apiLevelField is the number of the api_level field of pb.GoFeatures. It's read from the encoded features because
+	// the version of the protobuf module used by the plugin predates it.
+	apiLevelField = 2
+)
+
+func parseAPILevel(name string) (apiLevel, error) {
+	if name == "" {
+		return apiOpen, nil
+	}
+
+	level, ok := apiLevels[name]
+	if !ok {
+		return apiLevelUnspecified, fmt.Errorf("invalid default_api_level %q: must be API_OPEN, API_HYBRID or API_OPAQUE", name)
+	}
+
+	return level, nil
+}
+
+// opaque reports whether the message uses the opaque Go API, which hides the struct fields behind accessor methods.
+// The api_level feature of the message or the closest enclosing message or file that sets it takes precedence over
+// the default_api_level parameter. The hybrid API keeps the struct fields, so it's accessed like the open API.
+func (g *codegen) opaque(msg *protogen.Message) bool {
+	for d := protoreflect.Descriptor(msg.Desc); d != nil; d = d.Parent() {
+		var features *descriptorpb.FeatureSet
+		switch opts := d.Options().(type) {
+		case *descriptorpb.MessageOptions:
+			features = opts.GetFeatures()
+		case *descriptorpb.FileOptions:
+			features = opts.GetFeatures()
+		}
+
+		if level := featuresAPILevel(features); level != apiLevelUnspecified {
+			return level == apiOpaque
+		}
+	}
+
+	return g.apiLevel == apiOpaque
+}
+
+// featuresAPILevel returns the api_level set in the pb.go extension of the features, if any.
+func featuresAPILevel(features *descriptorpb.FeatureSet) apiLevel {
+	if features == nil {
+		return apiLevelUnspecified
+	}
+
+	b, err := proto.Marshal(features)
+	if err != nil {
+		return apiLevelUnspecified
+	}
+
+	level := apiLevelUnspecified
+	for _, ext := range fieldValues(b, goFeaturesField, protowire.BytesType) {
+		for _, v := range fieldValues(ext, apiLevelField, protowire.VarintType) {
+			if n, _ := protowire.ConsumeVarint(v); n != 0 {
+				level = apiLevel(n)
+			}
+		}
+	}
+
+	return level
+}
+
+// fieldValues returns the encoded values of the occurrences of a field with the given number and wire type.
+func fieldValues(b []byte, num protowire.Number, typ protowire.Type) [][]byte {
+	var values [][]byte
+	for len(b) > 0 {
+		n, t, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			return values
+		}
+
+		valueLen := protowire.ConsumeFieldValue(n, t, b[tagLen:])
+		if valueLen < 0 {
+			return values
+		}
+
+		if n == num && t == typ {
+			value := b[tagLen : tagLen+valueLen]
+			if typ == protowire.BytesType {
+				value, _ = protowire.ConsumeBytes(value)
+			}
+			values = append(values, value)
+		}
+
+		b = b[tagLen+valueLen:]
+	}
+
+	return values
+}
+
+// fieldExpr returns the expression that reads the value of a field of the receiver: the struct field, or the getter
+// for messages using the opaque API.
+func (g *codegen) fieldExpr(field *protogen.Field) string {
+	if g.opaque(field.Parent) {
+		return fieldAccess("Get" + field.GoName + "()")
+	}
+
+	return fieldAccess(field.GoName)
+}
+
+// oneofCase returns the constant returned by the Which method of a oneof of a message using the opaque API when the
+// field is the member that is set.
+func oneofCase(field *protogen.Field) protogen.GoIdent {
+	return field.Parent.GoIdent.GoImportPath.Ident(field.Parent.GoIdent.GoName + "_" + field.GoName + "_case")
+}
@@ -0,0 +1,158 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestOpaqueAPI(t *testing.T) {
+	files := generateFrom(t, opaqueDescriptors(), nil, "paths=source_relative,objecthash=true")
+	helpers := files["o/hashpb_helpers.pb.go"]
+
+	for _, want := range []string{
+		// lists and maps
+		"if len(m.GetList()) > 0 {",
+		"for _, v := range m.GetList() {",
+		"for k := range m.GetCounts() {",
+		"m.GetCounts()[k]",
+		// oneofs
+		"switch m.WhichChoice() {",
+		"case M_A_case:",
+		"protowire.AppendString(nil, m.GetA())",
+		"case M_B_case:",
+		"o_M_N_hashpb_sum(m.GetB(), hasher, ignore)",
+		// objecthash presence and values
+		"!ok && m.HasExplicit() {",
+		"objecthash.Int(int64(m.GetExplicit()))",
+		"!ok && len(m.GetList()) > 0 {",
+		"o_M_N_hashpb_objecthash(m.GetB(), ignore)",
+		// the nested message overrides the API level of the file
+		"if len(m.Tags) > 0 {",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, helpers)
+		}
+	}
+
+	for _, unwanted := range []string{"m.List", "m.Counts", "m.Choice", "m.Explicit"} {
+		if strings.Contains(helpers, unwanted) {
+			t.Errorf("Unexpected struct field access %q in opaque message:\n%s", unwanted, helpers)
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "o/hashpb_helpers.pb.go", helpers, parser.AllErrors); err != nil {
+		t.Errorf("Generated file is invalid: %v", err)
+	}
+}
+
+func TestDefaultAPILevel(t *testing.T) {
+	helpers := generate(t, "paths=source_relative,default_api_level=API_OPAQUE")["internal/pb/hashpb_helpers.pb.go"]
+	for _, want := range []string{
+		"switch m.WhichNestedType() {",
+		"case TestAllTypes_SingleNestedMessage_case:",
+		"for _, v := range m.GetRepeatedInt32() {",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("Expected generated code to contain %q", want)
+		}
+	}
+
+	for _, level := range []string{"API_OPEN", "API_HYBRID"} {
+		helpers := generate(t, "paths=source_relative,default_api_level="+level)["internal/pb/hashpb_helpers.pb.go"]
+		if !strings.Contains(helpers, "for _, v := range m.RepeatedInt32 {") {
+			t.Errorf("Expected struct field access with %s", level)
+		}
+	}
+
+	resp, err := generator.Run(request(t, "paths=source_relative,default_api_level=API_CLOSED"))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if !strings.Contains(resp.GetError(), "invalid default_api_level") {
+		t.Errorf("Expected an error for an invalid API level, got %q", resp.GetError())
+	}
+}
+
+// opaqueDescriptors returns the descriptor of an edition 2023 file using the opaque API, with a nested message that
+// uses the open API.
+func opaqueDescriptors() *descriptorpb.FileDescriptorSet {
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	counts := field("counts", 3, repeated, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	counts.TypeName = proto.String(".o.M.CountsEntry")
+
+	a := field("a", 4, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	a.OneofIndex = proto.Int32(0)
+
+	b := field("b", 5, optional, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	b.TypeName = proto.String(".o.M.N")
+	b.OneofIndex = proto.Int32(0)
+
+	return &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("o/o.proto"),
+			Package: proto.String("o"),
+			Syntax:  proto.String("editions"),
+			Edition: descriptorpb.Edition_EDITION_2023.Enum(),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/o;o"), Features: apiLevelFeatures(3)},
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("M"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("explicit", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					field("list", 2, repeated, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					counts,
+					a,
+					b,
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("choice")}},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("CountsEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+							field("value", 2, optional, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+					{
+						Name:    proto.String("N"),
+						Field:   []*descriptorpb.FieldDescriptorProto{field("tags", 1, repeated, descriptorpb.FieldDescriptorProto_TYPE_STRING)},
+						Options: &descriptorpb.MessageOptions{Features: apiLevelFeatures(1)},
+					},
+				},
+			}},
+		}},
+	}
+}
+
+// apiLevelFeatures returns features setting the api_level of the pb.go extension, which is encoded by hand because
+// the version of the protobuf module in use predates it.
+func apiLevelFeatures(level uint64) *descriptorpb.FeatureSet {
+	goFeatures := protowire.AppendVarint(protowire.AppendTag(nil, 2, protowire.VarintType), level)
+
+	features := &descriptorpb.FeatureSet{}
+	features.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 1002, protowire.BytesType), goFeatures))
+
+	return features
+}
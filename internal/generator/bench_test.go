@@ -0,0 +1,37 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenBenchmarks(t *testing.T) {
+	files := generate(t, "paths=source_relative,gen_benchmarks=true")
+
+	bench, ok := files["internal/pb/all_types_hashpb_bench_test.go"]
+	if !ok {
+		t.Fatal("Expected a benchmark file to be generated")
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "bench_test.go", bench, 0); err != nil {
+		t.Fatalf("Generated benchmark file is invalid: %v", err)
+	}
+
+	for _, want := range []string{
+		"func BenchmarkHashPB_TestAllTypes(b *testing.B) {",
+		"hashpbtest.BenchmarkHashPB(b, &TestAllTypes{})",
+	} {
+		if !strings.Contains(bench, want) {
+			t.Errorf("Expected benchmark file to contain %q", want)
+		}
+	}
+
+	if _, ok := generate(t, "paths=source_relative")["internal/pb/all_types_hashpb_bench_test.go"]; ok {
+		t.Error("Unexpected benchmark file generated without gen_benchmarks")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func TestBuildTags(t *testing.T) {
+	files := generate(t, "paths=source_relative,gen_tests=true,gen_vectors=true,build_tags=!js && !wasip1")
+	for name, content := range files {
+		if !strings.HasSuffix(name, ".go") {
+			if strings.Contains(content, "go:build") {
+				t.Errorf("Unexpected build constraint in %s", name)
+			}
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), name, content, parser.ParseComments|parser.PackageClauseOnly)
+		if err != nil {
+			t.Fatalf("Generated file %s is invalid: %v", name, err)
+		}
+
+		var found bool
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if constraint.IsGoBuild(c.Text) {
+					found = true
+					if c.Text != "//go:build !js && !wasip1" {
+						t.Errorf("Unexpected build constraint in %s: %s", name, c.Text)
+					}
+				}
+			}
+		}
+
+		if !found {
+			t.Errorf("Missing build constraint in %s", name)
+		}
+	}
+
+	resp, err := generator.Run(request(t, "build_tags=js &&"))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Error("Expected error for invalid build tags")
+	}
+}
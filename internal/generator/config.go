@@ -0,0 +1,282 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+const stringsImp = protogen.GoImportPath("strings")
+
+// Transform names accepted in the configuration file.
+const (
+	TransformLowerCase = "lower_case"
+	TransformTrimSpace = "trim_space"
+)
+
+var (
+	goIdentifier = regexp.MustCompile(`^[A-Z]\w*$`)
+
+	// reservedMethodNames are the names of the methods generated by protoc-gen-go and by this plugin, which can't be
+	// used as the name of the HashPB method.
+	reservedMethodNames = map[string]struct{}{
+		"Descriptor":         {},
+		"ProtoMessage":       {},
+		"ProtoReflect":       {},
+		"Reset":              {},
+		"String":             {},
+		"AppendHashPB":       {},
+		"HashPBErr":          {},
+		"HashPBFiltered":     {},
+		"HashPBMulti":        {},
+		"HashPBNoIgnore":     {},
+		"HashPBShallow":      {},
+		"HashPBWithMaxDepth": {},
+		"ObjectHashPB":       {},
+		"Sum64HashPB":        {},
+		"WriteHashPB":        {},
+	}
+
+	transformFns = map[string]protogen.GoIdent{
+		TransformLowerCase: stringsImp.Ident("ToLower"),
+		TransformTrimSpace: stringsImp.Ident("TrimSpace"),
+	}
+)
+
+// Config holds generation rules for messages that cannot be annotated in their .proto files.
+// It is read from the YAML or JSON file given by the config plugin parameter.
+//
+//	messages:
+//	  pkg.Msg:
+//	    skip: true            # do not generate methods for pkg.Msg
+//	    method_name: Digest   # generate Digest instead of HashPB
+//	    fields:
+//	      updated_at:
+//	        ignore: true      # always leave the field out of the hash
+//	      email:
+//	        transforms: [trim_space, lower_case]
+type Config struct {
+	Messages map[string]MessageConfig `yaml:"messages" json:"messages"`
+}
+
+// MessageConfig holds the rules for a message.
+type MessageConfig struct {
+	// Skip disables generating methods for the message. Helpers are still generated if other messages reference it.
+	Skip bool `yaml:"skip" json:"skip"`
+	// MethodName replaces the name of the generated HashPB method.
+	MethodName string `yaml:"method_name" json:"method_name"`
+	// Fields holds the rules for fields and oneofs, keyed by name.
+	Fields map[string]FieldConfig `yaml:"fields" json:"fields"`
+}
+
+// FieldConfig holds the rules for a field or oneof.
+type FieldConfig struct {
+	// Ignore leaves the field out of the generated code, as if it was always in the ignore set.
+	Ignore bool `yaml:"ignore" json:"ignore"`
+	// Transforms are applied in order to the values of string fields before they are hashed.
+	Transforms []string `yaml:"transforms" json:"transforms"`
+}
+
+// LoadConfig reads the configuration from the given YAML or JSON file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	conf := &Config{}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	for msgName, mc := range conf.Messages {
+		if mc.MethodName != "" && !goIdentifier.MatchString(mc.MethodName) {
+			return nil, fmt.Errorf("invalid method name %q for %s: must be an exported Go identifier", mc.MethodName, msgName)
+		}
+
+		if _, ok := reservedMethodNames[mc.MethodName]; ok {
+			return nil, fmt.Errorf("invalid method name %q for %s: conflicts with a generated method", mc.MethodName, msgName)
+		}
+
+		for fieldName, fc := range mc.Fields {
+			for _, t := range fc.Transforms {
+				if _, ok := transformFns[t]; !ok {
+					return nil, fmt.Errorf("unknown transform %q for %s.%s", t, msgName, fieldName)
+				}
+			}
+		}
+	}
+
+	return conf, nil
+}
+
+// withIgnored returns a copy of the configuration with rules ignoring the given fields and oneofs, which are identified
+// by their fully-qualified names and must be defined in the given files.
+func (c *Config) withIgnored(files []*protogen.File, names []string) (*Config, error) {
+	msgs := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	var walk func([]*protogen.Message)
+	walk = func(ms []*protogen.Message) {
+		for _, msg := range ms {
+			msgs[msg.Desc.FullName()] = msg.Desc
+			walk(msg.Messages)
+		}
+	}
+
+	for _, f := range files {
+		walk(f.Messages)
+	}
+
+	conf := &Config{Messages: make(map[string]MessageConfig)}
+	if c != nil {
+		for name, mc := range c.Messages {
+			fields := make(map[string]FieldConfig, len(mc.Fields))
+			for fieldName, fc := range mc.Fields {
+				fields[fieldName] = fc
+			}
+			mc.Fields = fields
+			conf.Messages[name] = mc
+		}
+	}
+
+	for _, name := range names {
+		fullName := protoreflect.FullName(name)
+		md, ok := msgs[fullName.Parent()]
+		if !ok || (md.Fields().ByName(fullName.Name()) == nil && md.Oneofs().ByName(fullName.Name()) == nil) {
+			return nil, fmt.Errorf("ignored field %s is not defined", name)
+		}
+
+		mc := conf.Messages[string(md.FullName())]
+		if mc.Fields == nil {
+			mc.Fields = make(map[string]FieldConfig)
+		}
+
+		fc := mc.Fields[string(fullName.Name())]
+		fc.Ignore = true
+		mc.Fields[string(fullName.Name())] = fc
+		conf.Messages[string(md.FullName())] = mc
+	}
+
+	return conf, nil
+}
+
+func (c *Config) message(md protoreflect.MessageDescriptor) MessageConfig {
+	if c == nil {
+		return MessageConfig{}
+	}
+
+	return c.Messages[string(md.FullName())]
+}
+
+func (c *Config) field(fd protoreflect.FieldDescriptor) FieldConfig {
+	return c.message(fd.ContainingMessage()).Fields[string(fd.Name())]
+}
+
+// ignored reports whether the field is ignored by the configuration.
+func (c *Config) ignored(fd protoreflect.FieldDescriptor) bool {
+	return c.field(fd).Ignore
+}
+
+// oneofIgnored reports whether the oneof as a whole is ignored by the configuration.
+func (c *Config) oneofIgnored(od protoreflect.OneofDescriptor) bool {
+	return c.message(od.Parent().(protoreflect.MessageDescriptor)).Fields[string(od.Name())].Ignore
+}
+
+// methodName returns the name of the generated HashPB method of the message.
+func (c *Config) methodName(md protoreflect.MessageDescriptor) string {
+	if name := c.message(md).MethodName; name != "" {
+		return name
+	}
+
+	return "HashPB"
+}
+
+// validate checks that the rules for the message can be applied to it.
+func (c *Config) validate(md protoreflect.MessageDescriptor) error {
+	for name, fc := range c.message(md).Fields {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			if od := md.Oneofs().ByName(protoreflect.Name(name)); od != nil && len(fc.Transforms) == 0 {
+				continue
+			}
+			return fmt.Errorf("config refers to unknown field %s.%s", md.FullName(), name)
+		}
+
+		if len(fc.Transforms) > 0 && (fd.Kind() != protoreflect.StringKind || fd.IsMap()) {
+			return fmt.Errorf("transforms can only be applied to string fields: %s", fd.FullName())
+		}
+	}
+
+	return nil
+}
+
+// validateMethodName checks that the method name configured for the message doesn't conflict with the getters of its
+// fields.
+func (c *Config) validateMethodName(msg *protogen.Message) error {
+	name := c.message(msg.Desc).MethodName
+	if name == "" {
+		return nil
+	}
+
+	for _, field := range msg.Fields {
+		if name == "Get"+field.GoName {
+			return fmt.Errorf("invalid method name %q for %s: conflicts with the getter of %s", name, msg.Desc.FullName(), field.Desc.FullName())
+		}
+	}
+
+	return nil
+}
+
+// transformed wraps the expression with the transforms configured for the field.
+func (c *Config) transformed(fd protoreflect.FieldDescriptor, expr []any) []any {
+	if fd.Kind() != protoreflect.StringKind {
+		return expr
+	}
+
+	for _, t := range c.field(fd).Transforms {
+		expr = append(append([]any{transformFns[t], "("}, expr...), ")")
+	}
+
+	return expr
+}
+
+// customised reports whether the digests of the message differ from the ones produced by the hashpb package because
+// of the rules applied to it or to the messages reachable from it.
+func (c *Config) customised(md protoreflect.MessageDescriptor) bool {
+	if c == nil {
+		return false
+	}
+
+	return c.customisedReachable(md, make(map[protoreflect.FullName]struct{}))
+}
+
+func (c *Config) customisedReachable(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]struct{}) bool {
+	if _, ok := seen[md.FullName()]; ok {
+		return false
+	}
+	seen[md.FullName()] = struct{}{}
+
+	if mc := c.message(md); mc.MethodName != "" || len(mc.Fields) > 0 {
+		return true
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+
+		if fd.Message() != nil && c.customisedReachable(fd.Message(), seen) {
+			return true
+		}
+	}
+
+	return false
+}
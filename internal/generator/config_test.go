@@ -0,0 +1,197 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const testConfig = `
+messages:
+  cerbos.hashpb.test.TestAllTypes:
+    method_name: Digest
+    fields:
+      single_timestamp:
+        ignore: true
+      nested_type:
+        ignore: true
+      single_string:
+        transforms: [trim_space, lower_case]
+  cerbos.hashpb.test.TestAllTypesOptional:
+    skip: true
+`
+
+func TestConfig(t *testing.T) {
+	files := generate(t, "paths=source_relative,gen_conformance_tests=true,registry=true,config="+writeConfig(t, testConfig))
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	for _, want := range []string{
+		"protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(m.GetSingleString())))",
+		`ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]`,
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("Expected helpers to contain %q", want)
+		}
+	}
+
+	for _, unwanted := range []string{
+		`ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]`,
+		`ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]`,
+	} {
+		if strings.Contains(helpers, unwanted) {
+			t.Errorf("Expected helpers not to contain %q", unwanted)
+		}
+	}
+
+	methods := files["internal/pb/all_types_hashpb.pb.go"]
+	if !strings.Contains(methods, "func (m *TestAllTypes) Digest(hasher hash.Hash") {
+		t.Error("Expected Digest method for TestAllTypes")
+	}
+
+	if strings.Contains(methods, "func (m *TestAllTypesOptional) HashPB(") {
+		t.Error("Expected TestAllTypesOptional to be skipped")
+	}
+
+	if !strings.Contains(methods, "func (m *TestAllTypesOptional_NestedMessage) HashPB(") {
+		t.Error("Expected nested messages of skipped messages to be generated")
+	}
+
+	for _, unwanted := range []string{`Register("cerbos.hashpb.test.TestAllTypes",`, `Register("cerbos.hashpb.test.NestedTestAllTypes",`} {
+		if strings.Contains(methods, unwanted) {
+			t.Errorf("Expected methods not to contain %q", unwanted)
+		}
+	}
+
+	if !strings.Contains(methods, `Register("cerbos.hashpb.test.TestAllTypes.NestedMessage",`) {
+		t.Error("Expected registration of unaffected message")
+	}
+
+	tests := files["internal/pb/all_types_hashpb_conformance_test.go"]
+	for _, unwanted := range []string{"TestHashPBConformance_TestAllTypes(", "TestHashPBConformance_NestedTestAllTypes("} {
+		if strings.Contains(tests, unwanted) {
+			t.Errorf("Expected conformance tests not to contain %q", unwanted)
+		}
+	}
+
+	if !strings.Contains(tests, "TestHashPBConformance_TestAllTypes_NestedMessage(") {
+		t.Error("Expected conformance test for unaffected message")
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	testCases := map[string]string{
+		"unknown transform": "messages: {cerbos.hashpb.test.TestAllTypes: {fields: {single_string: {transforms: [upper]}}}}",
+		"non-string field":  "messages: {cerbos.hashpb.test.TestAllTypes: {fields: {single_int32: {transforms: [trim_space]}}}}",
+		"unknown field":     "messages: {cerbos.hashpb.test.TestAllTypes: {fields: {missing: {ignore: true}}}}",
+		"method name":       "messages: {cerbos.hashpb.test.TestAllTypes: {method_name: hash}}",
+		"protobuf method":   "messages: {cerbos.hashpb.test.TestAllTypes: {method_name: ProtoReflect}}",
+		"hashpb method":     "messages: {cerbos.hashpb.test.TestAllTypes: {method_name: HashPBErr}}",
+		"getter":            "messages: {cerbos.hashpb.test.TestAllTypes: {method_name: GetSingleString}}",
+		"syntax":            "messages: [",
+	}
+
+	for name, conf := range testCases {
+		conf := conf
+		t.Run(name, func(t *testing.T) {
+			req := request(t, "config="+writeConfig(t, conf))
+			resp, err := generator.Run(req)
+			if err != nil {
+				t.Fatalf("Failed to run generator: %v", err)
+			}
+
+			if resp.Error == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		generate(t, "config="+writeConfig(t, `{"messages": {"cerbos.hashpb.test.TestAllTypes": {"skip": true}}}`))
+	})
+}
+
+func writeConfig(t *testing.T, conf string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "hashpb.yaml")
+	if err := os.WriteFile(path, []byte(conf), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	return path
+}
+
+func request(t *testing.T, opt string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+
+	req, err := descset.Request(descset.Build(pb.File_internal_pb_all_types_proto), []string{"internal/pb/all_types.proto"}, opt)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	return req
+}
+
+// generate runs the generator on all_types.proto and returns the contents of the generated files keyed by name.
+func generate(t *testing.T, opt string) map[string]string {
+	t.Helper()
+
+	resp, err := generator.Run(request(t, opt))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("Generator failed: %s", resp.GetError())
+	}
+
+	files := make(map[string]string, len(resp.File))
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	return files
+}
+
+func TestIgnoreParam(t *testing.T) {
+	files := generate(t, "paths=source_relative,gen_conformance_tests=true,ignore=cerbos.hashpb.test.TestAllTypes.single_int32:cerbos.hashpb.test.TestAllTypes.nested_type,config="+writeConfig(t, testConfig))
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	for _, unwanted := range []string{
+		`ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]`,
+		`ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]`,
+		`ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]`,
+	} {
+		if strings.Contains(helpers, unwanted) {
+			t.Errorf("Expected helpers not to contain %q", unwanted)
+		}
+	}
+
+	if !strings.Contains(helpers, "protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(m.GetSingleString())))") {
+		t.Error("Expected the rules in the configuration file to be applied as well")
+	}
+
+	if strings.Contains(files["internal/pb/all_types_hashpb_conformance_test.go"], "TestHashPBConformance_TestAllTypes(") {
+		t.Error("Expected conformance tests not to cover messages with ignored fields")
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		resp, err := generator.Run(request(t, "ignore=cerbos.hashpb.test.TestAllTypes.missing"))
+		if err != nil {
+			t.Fatalf("Failed to run generator: %v", err)
+		}
+
+		if resp.Error == nil {
+			t.Error("Expected error")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestEditions(t *testing.T) {
+	fds := editionsDescriptors()
+
+	req, err := descset.Request(fds, nil, "paths=source_relative,objecthash=true")
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("Generator failed: %s", resp.GetError())
+	}
+
+	if have := resp.GetMaximumEdition(); have < int32(descriptorpb.Edition_EDITION_2023) {
+		t.Errorf("Unexpected maximum edition: %d", have)
+	}
+
+	var have string
+	for _, f := range resp.File {
+		if f.GetName() == "e/hashpb_helpers.pb.go" {
+			have = f.GetContent()
+		}
+	}
+
+	for _, want := range []string{
+		// explicit presence (the default in edition 2023)
+		`!ok && m.Explicit != nil {`,
+		`objecthash.Int(int64(*m.Explicit))`,
+		// implicit presence
+		`!ok && m.Implicit != 0 {`,
+		// delimited message encoding
+		`e_M_G_hashpb_sum(m.GetG(), hasher, ignore)`,
+		`e_M_G_hashpb_objecthash(m.G, ignore)`,
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "e/hashpb_helpers.pb.go", have, parser.AllErrors); err != nil {
+		t.Errorf("Generated file is invalid: %v", err)
+	}
+}
+
+// editionsDescriptors returns the descriptor of an edition 2023 file with fields using explicit presence, implicit
+// presence and delimited message encoding.
+func editionsDescriptors() *descriptorpb.FileDescriptorSet {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, features *descriptorpb.FeatureSet) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+
+		if features != nil {
+			fd.Options = &descriptorpb.FieldOptions{Features: features}
+		}
+
+		return fd
+	}
+
+	delimited := field("g", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, &descriptorpb.FeatureSet{
+		MessageEncoding: descriptorpb.FeatureSet_DELIMITED.Enum(),
+	})
+	delimited.TypeName = proto.String(".e.M.G")
+
+	return &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("e/e.proto"),
+			Package: proto.String("e"),
+			Syntax:  proto.String("editions"),
+			Edition: descriptorpb.Edition_EDITION_2023.Enum(),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/e;e")},
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("M"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("explicit", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, nil),
+					field("implicit", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, &descriptorpb.FeatureSet{
+						FieldPresence: descriptorpb.FeatureSet_IMPLICIT.Enum(),
+					}),
+					delimited,
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:  proto.String("G"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("x", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil)},
+				}},
+			}},
+		}},
+	}
+}
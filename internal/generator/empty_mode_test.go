@@ -0,0 +1,82 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestEmptyAsSet(t *testing.T) {
+	files := generate(t, "paths=source_relative,gen_conformance_tests=true,gen_vectors=true,empty_as_set=true")
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	start := strings.Index(helpers, "func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(")
+	if start < 0 {
+		t.Fatal("Expected helper for TestAllTypesOptional to be generated")
+	}
+
+	if !strings.Contains(helpers[start:], "if m.SingleBytes != nil {") {
+		t.Error("Expected unset optional bytes fields to be left out")
+	}
+
+	// Repeated fields have no presence, so nil and empty slices hash identically in both modes.
+	for _, unwanted := range []string{"if m.RepeatedBytes != nil {", "if m.GetRepeatedBytes() != nil {"} {
+		if strings.Contains(helpers, unwanted) {
+			t.Errorf("Expected repeated fields not to be affected by empty_as_set, found %q", unwanted)
+		}
+	}
+
+	want := "hashpbtest.CheckConformance(t, &TestAllTypes{}, hashpb.WithEmptyMode(hashpb.EmptyAsSet))"
+	if !strings.Contains(files["internal/pb/all_types_hashpb_conformance_test.go"], want) {
+		t.Errorf("Expected conformance tests to contain %q", want)
+	}
+
+	var corpus hashpbtest.VectorCorpus
+	if err := json.Unmarshal([]byte(files["internal/pb/all_types_hashpb_vectors.json"]), &corpus); err != nil {
+		t.Fatalf("Failed to unmarshal test vectors: %v", err)
+	}
+
+	differs := false
+	for _, v := range corpus.Vectors {
+		if !v.EmptyAsSet || v.NormalizeUnicode {
+			t.Fatalf("Expected vector of %s to record empty_as_set only: %+v", v.Type, v)
+		}
+
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(v.Type))
+		if err != nil {
+			t.Fatalf("Failed to find message type: %v", err)
+		}
+
+		m := mt.New().Interface()
+		if err := proto.Unmarshal(v.Input, m); err != nil {
+			t.Fatalf("Failed to unmarshal input: %v", err)
+		}
+
+		digest, err := hashpb.Sum64(m, v.Options()...)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if have := fmt.Sprintf("%016x", digest); have != v.XXHash {
+			t.Errorf("xxhash digest mismatch for %s with seed %d: want=%s have=%s", v.Type, v.Seed, v.XXHash, have)
+		}
+
+		if plain, _ := hashpb.Sum64(m, hashpb.WithIgnore(v.Ignore...)); plain != digest {
+			differs = true
+		}
+	}
+
+	if !differs {
+		t.Error("Expected empty_as_set to change the digests of some vectors")
+	}
+}
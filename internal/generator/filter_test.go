@@ -0,0 +1,75 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func TestIncludeExclude(t *testing.T) {
+	testCases := []struct {
+		name   string
+		opt    string
+		want   []string
+		unwant []string
+	}{
+		{
+			name:   "include",
+			opt:    `include=^cerbos\.hashpb\.test\.TestAllTypesOptional$`,
+			want:   []string{"func (m *TestAllTypesOptional) HashPB("},
+			unwant: []string{"func (m *TestAllTypes) HashPB(", "func (m *TestAllTypesOptional_NestedMessage) HashPB("},
+		},
+		{
+			name:   "exclude",
+			opt:    `exclude=NestedMessage$`,
+			want:   []string{"func (m *TestAllTypes) HashPB(", "func (m *TestAllTypesOptional) HashPB("},
+			unwant: []string{"func (m *TestAllTypes_NestedMessage) HashPB(", "func (m *TestAllTypesOptional_NestedMessage) HashPB("},
+		},
+		{
+			name:   "include and exclude",
+			opt:    `include=Optional,exclude=Optional$`,
+			want:   []string{"func (m *TestAllTypesOptional_NestedMessage) HashPB("},
+			unwant: []string{"func (m *TestAllTypes) HashPB(", "func (m *TestAllTypesOptional) HashPB("},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			methods := generate(t, "paths=source_relative,"+tc.opt)["internal/pb/all_types_hashpb.pb.go"]
+			for _, want := range tc.want {
+				if !strings.Contains(methods, want) {
+					t.Errorf("Expected %q", want)
+				}
+			}
+
+			for _, unwant := range tc.unwant {
+				if strings.Contains(methods, unwant) {
+					t.Errorf("Unexpected %q", unwant)
+				}
+			}
+		})
+	}
+
+	t.Run("helpers of excluded messages", func(t *testing.T) {
+		helpers := generate(t, "paths=source_relative,exclude=NestedMessage$")["internal/pb/hashpb_helpers.pb.go"]
+		if !strings.Contains(helpers, "func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(") {
+			t.Error("Expected helper for excluded message reachable from included message")
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		resp, err := generator.Run(request(t, "include=("))
+		if err != nil {
+			t.Fatalf("Failed to run generator: %v", err)
+		}
+
+		if resp.Error == nil {
+			t.Error("Expected error")
+		}
+	})
+}
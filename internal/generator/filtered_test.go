@@ -0,0 +1,33 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFiltered(t *testing.T) {
+	files := generate(t, "paths=source_relative,filtered=true")
+
+	if !strings.Contains(files["internal/pb/all_types_hashpb.pb.go"], "func (m *TestAllTypes) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {") {
+		t.Error("Expected HashPBFiltered method to be generated")
+	}
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	start := strings.Index(helpers, "func cerbos_hashpb_test_TestAllTypes_hashpb_sum_filtered(")
+	if start < 0 {
+		t.Fatal("Expected filtered helper to be generated")
+	}
+
+	helper := helpers[start:]
+	helper = helper[:strings.Index(helper, "\n}\n")]
+	if strings.Contains(helper, "ignore[") || strings.Contains(helper, "Ignored(") {
+		t.Errorf("Expected filtered helper not to check an ignore set:\n%s", helper)
+	}
+
+	if !strings.Contains(helper, `filter("cerbos.hashpb.test.TestAllTypes.single_nested_enum")`) {
+		t.Errorf("Expected filtered helper to check oneof members:\n%s", helper)
+	}
+}
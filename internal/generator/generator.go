@@ -4,23 +4,54 @@
 package generator
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"go/build/constraint"
+	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime/debug"
 	"sort"
+	"strings"
+	"text/template"
 
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/spec"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/testgen"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
 const (
-	funcSuffix   = "_hashpb_sum"
-	hasherImp    = protogen.GoImportPath("hash")
-	mathImp      = protogen.GoImportPath("math")
-	protowireImp = protogen.GoImportPath("google.golang.org/protobuf/encoding/protowire")
-	sortImp      = protogen.GoImportPath("sort")
+	funcSuffix        = "_hashpb_sum"
+	limitedFuncSuffix = "_limited"
+	errFuncSuffix     = "_err"
+	noIgnoreSuffix    = "_noignore"
+	shallowSuffix     = "_shallow"
+	filteredSuffix    = "_filtered"
+	hasherImp         = protogen.GoImportPath("hash")
+	mathImp           = protogen.GoImportPath("math")
+	ioImp             = protogen.GoImportPath("io")
+	hashpbImp         = protogen.GoImportPath("github.com/cerbos/protoc-gen-go-hashpb/hashpb")
+	hashpbregImp      = protogen.GoImportPath("github.com/cerbos/protoc-gen-go-hashpb/hashpbreg")
+	hashpbtestImp     = protogen.GoImportPath("github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest")
+	testingImp        = protogen.GoImportPath("testing")
+	protoImp          = protogen.GoImportPath("google.golang.org/protobuf/proto")
+	protowireImp      = protogen.GoImportPath("google.golang.org/protobuf/encoding/protowire")
+	sortImp           = protogen.GoImportPath("sort")
+	bytesImp          = protogen.GoImportPath("bytes")
+	sha256Imp         = protogen.GoImportPath("crypto/sha256")
+	xxhashImp         = protogen.GoImportPath("github.com/cespare/xxhash/v2")
+	normImp           = protogen.GoImportPath("golang.org/x/text/unicode/norm")
 
 	boolKeyCmpFn      = "func(i, j int) bool{ return !keys[i] && keys[j] }"
 	primitiveKeyCmpFn = "func(i, j int) bool { return keys[i] < keys[j] }"
@@ -30,17 +61,45 @@ const (
 var (
 	Version = "dev"
 
-	appendBytesFn   = protowireImp.Ident("AppendBytes")
-	appendFixed32Fn = protowireImp.Ident("AppendFixed32")
-	appendFixed64Fn = protowireImp.Ident("AppendFixed64")
-	appendStringFn  = protowireImp.Ident("AppendString")
-	appendVarintFn  = protowireImp.Ident("AppendVarint")
-	encodeBoolFn    = protowireImp.Ident("EncodeBool")
-	encodeZigZagFn  = protowireImp.Ident("EncodeZigZag")
-	float32BitsFn   = mathImp.Ident("Float32bits")
-	float64BitsFn   = mathImp.Ident("Float64bits")
-	hashFn          = hasherImp.Ident("Hash")
-	sortSliceFn     = sortImp.Ident("Slice")
+	appendBytesFn     = protowireImp.Ident("AppendBytes")
+	appendFixed32Fn   = protowireImp.Ident("AppendFixed32")
+	appendFixed64Fn   = protowireImp.Ident("AppendFixed64")
+	appendStringFn    = protowireImp.Ident("AppendString")
+	appendVarintFn    = protowireImp.Ident("AppendVarint")
+	encodeBoolFn      = protowireImp.Ident("EncodeBool")
+	encodeZigZagFn    = protowireImp.Ident("EncodeZigZag")
+	errMaxDepth       = hashpbImp.Ident("ErrMaxDepth")
+	enforceVersion    = hashpbImp.Ident("EnforceVersion")
+	genVersion        = hashpbImp.Ident("GenVersion")
+	ignoredFn         = hashpbImp.Ident("Ignored")
+	minGenVersion     = hashpbImp.Ident("MinGenVersion")
+	xxhashNewFn       = xxhashImp.Ident("New")
+	multiHasherFn     = hashpbImp.Ident("NewMultiHasher")
+	normalizeUnicode  = hashpbImp.Ident("WithNormalizeUnicode")
+	withEmptyMode     = hashpbImp.Ident("WithEmptyMode")
+	emptyAsSet        = hashpbImp.Ident("EmptyAsSet")
+	nfcString         = normImp.Ident("NFC")
+	toLowerFn         = stringsImp.Ident("ToLower")
+	trimSpaceFn       = stringsImp.Ident("TrimSpace")
+	float32BitsFn     = mathImp.Ident("Float32bits")
+	float64BitsFn     = mathImp.Ident("Float64bits")
+	benchHashPBFn     = hashpbtestImp.Ident("BenchmarkHashPB")
+	checkConfFn       = hashpbtestImp.Ident("CheckConformance")
+	checkMapOrderFn   = hashpbtestImp.Ident("CheckMapOrder")
+	checkGoldenFn     = hashpbtestImp.Ident("CheckGolden")
+	fuzzConfFn        = hashpbtestImp.Ident("FuzzConformance")
+	goldenType        = hashpbtestImp.Ident("Golden")
+	hashFn            = hasherImp.Ident("Hash")
+	writerType        = ioImp.Ident("Writer")
+	protoMessage      = protoImp.Ident("Message")
+	registerFn        = hashpbregImp.Ident("Register")
+	sortSliceFn       = sortImp.Ident("Slice")
+	sortSliceStableFn = sortImp.Ident("SliceStable")
+	bytesCompareFn    = bytesImp.Ident("Compare")
+	sha256NewFn       = sha256Imp.Ident("New")
+	testingB          = testingImp.Ident("B")
+	testingF          = testingImp.Ident("F")
+	testingT          = testingImp.Ident("T")
 
 	nonIdentifierChars = regexp.MustCompile(`[^\w]+`)
 )
@@ -53,26 +112,341 @@ func init() {
 	}
 }
 
-func Generate(p *protogen.Plugin) error {
-	p.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+// Params holds the plugin parameters.
+type Params struct {
+	// Registry enables registering the generated hash functions with the hashpbreg package.
+	Registry bool
+	// GenConformanceTests enables generating tests that compare the generated code with the hashpb package.
+	GenConformanceTests bool
+	// GenTests enables generating golden tests with the expected digests of populated messages.
+	GenTests bool
+	// GenVectors enables generating a language-neutral JSON corpus of test vectors.
+	GenVectors bool
+	// GenFuzzTests enables generating fuzz tests that compare the generated code with the hashpb package.
+	GenFuzzTests bool
+	// GenMapOrderTests enables generating tests that check that digests don't depend on the insertion order of maps.
+	GenMapOrderTests bool
+	// GenBenchmarks enables generating benchmarks that hash populated messages.
+	GenBenchmarks bool
+	// SchemaFingerprint enables generating a schema fingerprint constant for each message.
+	SchemaFingerprint bool
+	// GenSpec enables generating a JSON description of how each message is hashed.
+	GenSpec bool
+	// DepthLimit enables generating HashPBWithMaxDepth methods that return an error instead of hashing deeply nested messages.
+	DepthLimit bool
+	// SplitProtoPackages enables generating separate helpers for each proto package in a Go package, which is done
+	// automatically when the generator can see that the Go package is shared by several proto packages.
+	SplitProtoPackages bool
+	// PerFileHelpers enables generating self-contained files with their own helpers for each .proto file.
+	PerFileHelpers bool
+	// SingleFile enables writing the helpers and methods of each package to a single file.
+	SingleFile bool
+	// Roots restricts generation to the listed messages and the messages reachable from them.
+	Roots stringList
+	// Include restricts generation to messages with fully-qualified names matching the regular expression.
+	Include string
+	// Exclude prevents generating methods for messages with fully-qualified names matching the regular expression.
+	Exclude string
+	// Config is the path of a YAML or JSON file with generation rules for messages and fields.
+	Config string
+	// Ignore lists the fully-qualified names of fields and oneofs to leave out of the generated code.
+	Ignore stringList
+	// PropagateErrors enables generating HashPBErr methods that return the errors returned by the hasher.
+	PropagateErrors bool
+	// ObjectHash enables generating ObjectHashPB methods that produce objecthash-compatible digests.
+	ObjectHash bool
+	// Templates is the path of a directory with templates overriding the default method and helper templates.
+	Templates string
+	// DefaultAPILevel is the Go API level of the messages that don't set the api_level feature, as in protoc-gen-go.
+	DefaultAPILevel string
+	// BuildTags is a build constraint expression added to the generated Go files.
+	BuildTags string
+	// Sum64 enables generating Sum64HashPB methods that return the 64-bit xxhash digest of the message.
+	Sum64 bool
+	// AppendHash enables generating AppendHashPB methods that append the xxhash digest of the message to a buffer.
+	AppendHash bool
+	// MultiHash enables generating HashPBMulti methods that write the message to several hashers in a single traversal.
+	MultiHash bool
+	// Writer enables generating WriteHashPB methods that write the canonical bytes of the message to an io.Writer.
+	Writer bool
+	// NormalizeUnicode enables normalizing the values of string fields to NFC before hashing them.
+	NormalizeUnicode bool
+	// NoIgnore enables generating HashPBNoIgnore methods that hash every field without checking an ignore set.
+	NoIgnore bool
+	// Shallow enables generating HashPBShallow methods that hash message fields only by presence and type.
+	Shallow bool
+	// Filtered enables generating HashPBFiltered methods that only hash the fields accepted by a predicate.
+	Filtered bool
+	// EmptyAsSet enables leaving bytes fields with explicit presence that are not set out of the hash, so that they are
+	// distinct from fields set to an empty value.
+	EmptyAsSet bool
+}
+
+// NewParams defines the plugin parameters on the given flag set.
+func NewParams(flags *flag.FlagSet) *Params {
+	params := &Params{}
+	flags.BoolVar(&params.Registry, "registry", false, "Register generated hash functions with hashpbreg")
+	flags.BoolVar(&params.GenConformanceTests, "gen_conformance_tests", false, "Generate tests comparing generated code with hashpb")
+	flags.BoolVar(&params.GenTests, "gen_tests", false, "Generate golden tests with expected digests")
+	flags.BoolVar(&params.GenVectors, "gen_vectors", false, "Generate a JSON corpus of test vectors")
+	flags.BoolVar(&params.GenFuzzTests, "gen_fuzz_tests", false, "Generate fuzz tests comparing generated code with hashpb")
+	flags.BoolVar(&params.GenMapOrderTests, "gen_map_order_tests", false, "Generate tests checking that digests don't depend on map insertion order")
+	flags.BoolVar(&params.GenBenchmarks, "gen_benchmarks", false, "Generate benchmarks hashing populated messages")
+	flags.BoolVar(&params.SchemaFingerprint, "schema_fingerprint", false, "Generate schema fingerprint constants")
+	flags.BoolVar(&params.GenSpec, "gen_spec", false, "Generate a JSON description of the hashing scheme for each message")
+	flags.BoolVar(&params.DepthLimit, "depth_limit", false, "Generate HashPBWithMaxDepth methods that limit the nesting depth")
+	flags.BoolVar(&params.SplitProtoPackages, "split_proto_packages", false, "Generate separate helpers for each proto package in a Go package")
+	flags.BoolVar(&params.PerFileHelpers, "per_file_helpers", false, "Generate self-contained files with their own helpers for each .proto file")
+	flags.BoolVar(&params.SingleFile, "single_file", false, "Write the helpers and methods of each package to a single hashpb.pb.go file")
+	flags.Var(&params.Roots, "roots", "Fully-qualified name of a message to generate code for, along with the messages reachable from it (repeatable)")
+	flags.StringVar(&params.Include, "include", "", "Only generate methods for messages with fully-qualified names matching this regular expression")
+	flags.StringVar(&params.Exclude, "exclude", "", "Don't generate methods for messages with fully-qualified names matching this regular expression")
+	flags.StringVar(&params.Config, "config", "", "Path to a YAML or JSON file with generation rules for messages and fields")
+	flags.Var(&params.Ignore, "ignore", "Fully-qualified name of a field or oneof to leave out of the generated code (repeatable)")
+	flags.BoolVar(&params.PropagateErrors, "propagate_errors", false, "Generate HashPBErr methods that return hasher write errors")
+	flags.BoolVar(&params.ObjectHash, "objecthash", false, "Generate ObjectHashPB methods producing objecthash-compatible digests")
+	flags.StringVar(&params.Templates, "templates", "", "Directory with templates overriding the default method and helper templates")
+	flags.BoolVar(&params.Sum64, "sum64", false, "Generate Sum64HashPB methods returning the 64-bit xxhash digest")
+	flags.BoolVar(&params.AppendHash, "append_hash", false, "Generate AppendHashPB methods appending the xxhash digest to a buffer")
+	flags.BoolVar(&params.MultiHash, "multi_hash", false, "Generate HashPBMulti methods hashing with several hashers in a single traversal")
+	flags.BoolVar(&params.Writer, "writer", false, "Generate WriteHashPB methods writing the canonical bytes to an io.Writer")
+	flags.BoolVar(&params.NoIgnore, "no_ignore", false, "Generate HashPBNoIgnore methods that don't check an ignore set")
+	flags.BoolVar(&params.Shallow, "shallow", false, "Generate HashPBShallow methods that hash message fields only by presence and type")
+	flags.BoolVar(&params.Filtered, "filtered", false, "Generate HashPBFiltered methods that only hash the fields accepted by a predicate")
+	flags.BoolVar(&params.EmptyAsSet, "empty_as_set", false, "Distinguish unset bytes fields with explicit presence from empty ones")
+	flags.BoolVar(&params.NormalizeUnicode, "normalize_unicode", false, "Normalize the values of string fields to NFC before hashing them")
+	flags.StringVar(&params.DefaultAPILevel, "default_api_level", "", "Go API level of messages that don't set the api_level feature: API_OPEN, API_HYBRID or API_OPAQUE")
+	flags.StringVar(&params.BuildTags, "build_tags", "", "Build constraint expression (as in //go:build lines) to add to generated Go files")
+	return params
+}
+
+// stringList is a flag that accumulates the values of repeated parameters.
+// Values can also be separated with colons because protoc splits plugin parameters on commas.
+type stringList []string
+
+func (sl *stringList) String() string {
+	return strings.Join(*sl, ":")
+}
+
+func (sl *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ":") {
+		if v != "" {
+			*sl = append(*sl, v)
+		}
+	}
+
+	return nil
+}
+
+// Run runs the generator on the given request, parsing the plugin parameters from the request.
+func Run(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	var flags flag.FlagSet
+	params := NewParams(&flags)
+
+	p, err := protogen.Options{ParamFunc: flags.Set}.New(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Generate(p, params); err != nil {
+		p.Error(err)
+	}
+
+	resp := p.Response()
+	if resp.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS) != 0 {
+		// protoc refuses to run plugins that support editions without declaring the range of supported editions.
+		resp.MinimumEdition = proto.Int32(int32(descriptorpb.Edition_EDITION_2023))
+		resp.MaximumEdition = proto.Int32(int32(protodesc.SupportedEditionsMaximum))
+	}
+
+	return resp, nil
+}
+
+// WriteFiles writes the files of the response to the directory, creating subdirectories as needed. It returns the
+// error reported by the generator, if any, without writing any files.
+func WriteFiles(resp *pluginpb.CodeGeneratorResponse, dir string) error {
+	if resp.Error != nil {
+		return errors.New(resp.GetError())
+	}
+
+	for _, f := range resp.File {
+		if f.GetInsertionPoint() != "" {
+			return fmt.Errorf("insertion point %s in %s is not supported", f.GetInsertionPoint(), f.GetName())
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+
+		if err := os.WriteFile(path, []byte(f.GetContent()), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func Generate(p *protogen.Plugin, params *Params) error {
+	p.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	if params.PerFileHelpers && params.SingleFile {
+		return errors.New("per_file_helpers and single_file cannot be used together")
+	}
+
 	// group files by import path because the helpers need to be generated at the package level.
-	pkgFiles := make(map[protogen.GoImportPath][]*protogen.File)
+	// If the Go package is shared by several proto packages, the helpers are generated for each proto package
+	// separately so that the output doesn't depend on whether the proto packages are generated together.
+	sharedPkgs := sharedGoPackages(p.Files)
+	pkgFiles := make(map[helperUnit][]*protogen.File)
 	for _, f := range p.Files {
 		if !f.Generate {
 			continue
 		}
 
-		if f.Desc.Syntax() != protoreflect.Proto3 {
-			return fmt.Errorf("file is not protobuf v3: %s", f.Desc.Path())
+		if f.Desc.Syntax() != protoreflect.Proto3 && f.Desc.Syntax() != protoreflect.Editions {
+			return fmt.Errorf("file is not protobuf v3 or editions: %s", f.Desc.Path())
+		}
+
+		unit := helperUnit{importPath: f.GoImportPath}
+		if params.PerFileHelpers {
+			unit.file = f.Desc.Path()
+		} else if _, ok := sharedPkgs[f.GoImportPath]; ok || params.SplitProtoPackages {
+			unit.protoPackage = f.Desc.Package()
+		}
+
+		pkgFiles[unit] = append(pkgFiles[unit], f)
+	}
+
+	g := &codegen{Plugin: p, params: params}
+	tmpls, err := loadTemplates(params.Templates)
+	if err != nil {
+		return err
+	}
+	g.templates = tmpls
+
+	if g.apiLevel, err = parseAPILevel(params.DefaultAPILevel); err != nil {
+		return err
+	}
+
+	if params.BuildTags != "" {
+		expr, err := constraint.Parse("//go:build " + params.BuildTags)
+		if err != nil {
+			return fmt.Errorf("invalid build tags %q: %w", params.BuildTags, err)
+		}
+		g.buildConstraint = expr.String()
+	}
+
+	if params.Config != "" {
+		if g.config, err = LoadConfig(params.Config); err != nil {
+			return err
+		}
+	}
+
+	if len(params.Ignore) > 0 {
+		if g.config, err = g.config.withIgnored(p.Files, params.Ignore); err != nil {
+			return err
+		}
+	}
+
+	if g.config != nil {
+		for _, files := range pkgFiles {
+			for _, f := range files {
+				if err := validateConfig(g.config, f.Messages); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(params.Roots) > 0 {
+		if err := g.findRoots(pkgFiles); err != nil {
+			return err
 		}
+	}
 
-		pkgFiles[f.GoImportPath] = append(pkgFiles[f.GoImportPath], f)
+	if err := g.compileFilters(); err != nil {
+		return err
+	}
+
+	for unit, files := range pkgFiles {
+		g.unit = unit
+		helpers, helpersFile, err := g.generateHelpers(files)
+		if err != nil {
+			return err
+		}
+
+		if err := g.generateMethods(files, helpers, helpersFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// helperUnit identifies a set of files that share a helpers file.
+type helperUnit struct {
+	importPath protogen.GoImportPath
+	// protoPackage is set if the helpers are generated separately for each proto package in the Go package.
+	protoPackage protoreflect.FullName
+	// file is set if the helpers are generated separately for each file.
+	file string
+}
+
+// suffix returns the suffix that makes the names of the helpers file and functions of the unit unique.
+func (u helperUnit) suffix() string {
+	switch {
+	case u.file != "":
+		return "_" + nonIdentifierChars.ReplaceAllLiteralString(strings.TrimSuffix(u.file, ".proto"), "_")
+	case u.protoPackage != "":
+		return "_" + nonIdentifierChars.ReplaceAllLiteralString(string(u.protoPackage), "_")
+	default:
+		return ""
+	}
+}
+
+// sharedGoPackages returns the Go import paths that are shared by several proto packages among the given files,
+// which include the dependencies of the files to generate.
+func sharedGoPackages(files []*protogen.File) map[protogen.GoImportPath]struct{} {
+	protoPkgs := make(map[protogen.GoImportPath]protoreflect.FullName)
+	shared := make(map[protogen.GoImportPath]struct{})
+	for _, f := range files {
+		if pkg, ok := protoPkgs[f.GoImportPath]; ok && pkg != f.Desc.Package() {
+			shared[f.GoImportPath] = struct{}{}
+		}
+		protoPkgs[f.GoImportPath] = f.Desc.Package()
+	}
+
+	return shared
+}
+
+// findRoots checks that all the root messages are defined in the files to generate.
+func (g *codegen) findRoots(pkgFiles map[helperUnit][]*protogen.File) error {
+	g.roots = make(map[protoreflect.FullName]struct{}, len(g.params.Roots))
+	for _, r := range g.params.Roots {
+		g.roots[protoreflect.FullName(r)] = struct{}{}
+	}
+
+	found := make(map[protoreflect.FullName]struct{}, len(g.roots))
+	var walk func([]*protogen.Message)
+	walk = func(msgs []*protogen.Message) {
+		for _, msg := range msgs {
+			if _, ok := g.roots[msg.Desc.FullName()]; ok {
+				found[msg.Desc.FullName()] = struct{}{}
+			}
+			walk(msg.Messages)
+		}
 	}
 
-	g := &codegen{Plugin: p}
 	for _, files := range pkgFiles {
-		g.generateHelpers(files)
-		g.generateMethods(files)
+		for _, f := range files {
+			walk(f.Messages)
+		}
+	}
+
+	for _, r := range g.params.Roots {
+		if _, ok := found[protoreflect.FullName(r)]; !ok {
+			return fmt.Errorf("root message %s is not defined in the files to generate", r)
+		}
 	}
 
 	return nil
@@ -80,34 +454,110 @@ func Generate(p *protogen.Plugin) error {
 
 type codegen struct {
 	*protogen.Plugin
+	params          *Params
+	config          *Config
+	roots           map[protoreflect.FullName]struct{}
+	include         *regexp.Regexp
+	exclude         *regexp.Regexp
+	unit            helperUnit
+	templates       map[string]*template.Template
+	buildConstraint string
+	apiLevel        apiLevel
 }
 
-// generateHelpers generates helper functions for calculating the hash for each message type.
+func (g *codegen) compileFilters() (err error) {
+	if g.params.Include != "" {
+		if g.include, err = regexp.Compile(g.params.Include); err != nil {
+			return fmt.Errorf("invalid include expression: %w", err)
+		}
+	}
+
+	if g.params.Exclude != "" {
+		if g.exclude, err = regexp.Compile(g.params.Exclude); err != nil {
+			return fmt.Errorf("invalid exclude expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// filtering reports whether the roots, include or exclude parameters restrict the messages to generate code for.
+func (g *codegen) filtering() bool {
+	return g.roots != nil || g.include != nil || g.exclude != nil
+}
+
+// selected reports whether methods should be generated for the message according to the include and exclude parameters.
+func (g *codegen) selected(md protoreflect.MessageDescriptor) bool {
+	name := string(md.FullName())
+	return (g.include == nil || g.include.MatchString(name)) && (g.exclude == nil || !g.exclude.MatchString(name))
+}
+
+func validateConfig(conf *Config, msgs []*protogen.Message) error {
+	for _, msg := range msgs {
+		if err := conf.validate(msg.Desc); err != nil {
+			return err
+		}
+
+		if err := conf.validateMethodName(msg); err != nil {
+			return err
+		}
+
+		if err := validateConfig(conf, msg.Messages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateHelpers generates helper functions for calculating the hash for each message type and returns the messages
+// that have helpers, keyed by helper name, along with the file containing the helpers.
 // Because messages can be recursive, we need to do this to avoid getting into an infinite loop.
-func (g *codegen) generateHelpers(files []*protogen.File) {
+func (g *codegen) generateHelpers(files []*protogen.File) (map[string]*protogen.Message, *protogen.GeneratedFile, error) {
 	if len(files) == 0 {
-		return
+		return nil, nil, nil
 	}
 
-	// find all messages referenced by the files.
+	// find all messages referenced by the files (or by the selected messages defined in them).
 	msgsToGen := make(map[string]*protogen.Message)
 	for _, f := range files {
-		for _, msg := range f.Messages {
-			collectMessages(msgsToGen, msg)
+		if g.filtering() {
+			g.collectSelected(msgsToGen, f.Messages)
+		} else {
+			for _, msg := range f.Messages {
+				collectMessages(msgsToGen, msg)
+			}
 		}
 	}
 
 	if len(msgsToGen) == 0 {
-		return
+		return nil, nil, nil
 	}
 
-	fileName := filepath.Join(filepath.Dir(files[0].Desc.Path()), "hashpb_helpers.pb.go")
-	gf := g.NewGeneratedFile(fileName, files[0].GoImportPath)
-	gf.P("// Code generated by protoc-gen-go-hashpb. Do not edit.")
-	gf.P("// protoc-gen-go-hashpb ", Version)
-	gf.P()
-	gf.P("package ", files[0].GoPackageName)
-	gf.P()
+	var gf *protogen.GeneratedFile
+	if g.unit.file != "" {
+		// per-file helpers are written to the file containing the methods.
+		gf = g.NewGeneratedFile(files[0].GeneratedFilenamePrefix+"_hashpb.pb.go", files[0].GoImportPath)
+		g.genFileHeader(gf, files[0])
+	} else {
+		baseName := "hashpb_helpers" + g.unit.suffix() + ".pb.go"
+		if g.params.SingleFile {
+			baseName = "hashpb" + g.unit.suffix() + ".pb.go"
+		}
+
+		// use the output path resolved by protogen so that the helpers are written next to the other generated files
+		// regardless of the paths and module parameters.
+		fileName := path.Join(path.Dir(files[0].GeneratedFilenamePrefix), baseName)
+		gf = g.NewGeneratedFile(fileName, files[0].GoImportPath)
+		gf.P("// Code generated by protoc-gen-go-hashpb. Do not edit.")
+		gf.P("// protoc-gen-go-hashpb ", Version)
+		gf.P()
+		g.genBuildConstraint(gf)
+		gf.P("package ", files[0].GoPackageName)
+		gf.P()
+	}
+
+	genVersionCheck(gf)
 
 	// sort message names to make the generated file predictable (no spurious diffs)
 	msgNames := make([]string, len(msgsToGen))
@@ -119,8 +569,67 @@ func (g *codegen) generateHelpers(files []*protogen.File) {
 	sort.Strings(msgNames)
 
 	for _, mn := range msgNames {
-		g.genHelperForMsg(gf, msgsToGen[mn])
+		if err := validateListAnnotations(msgsToGen[mn]); err != nil {
+			return nil, nil, err
+		}
+
+		if err := g.genHelperForMsg(gf, msgsToGen[mn], plainHelper); err != nil {
+			return nil, nil, err
+		}
 		gf.P()
+
+		if g.params.DepthLimit {
+			if err := g.genHelperForMsg(gf, msgsToGen[mn], depthLimitedHelper); err != nil {
+				return nil, nil, err
+			}
+			gf.P()
+		}
+
+		if g.params.PropagateErrors || g.params.Writer {
+			if err := g.genHelperForMsg(gf, msgsToGen[mn], errHelper); err != nil {
+				return nil, nil, err
+			}
+			gf.P()
+		}
+
+		if g.params.NoIgnore {
+			if err := g.genHelperForMsg(gf, msgsToGen[mn], noIgnoreHelper); err != nil {
+				return nil, nil, err
+			}
+			gf.P()
+		}
+
+		if g.params.Shallow {
+			if err := g.genHelperForMsg(gf, msgsToGen[mn], shallowHelper); err != nil {
+				return nil, nil, err
+			}
+			gf.P()
+		}
+
+		if g.params.Filtered {
+			if err := g.genHelperForMsg(gf, msgsToGen[mn], filteredHelper); err != nil {
+				return nil, nil, err
+			}
+			gf.P()
+		}
+
+		if g.params.ObjectHash {
+			g.genObjectHashHelperForMsg(gf, msgsToGen[mn])
+			gf.P()
+		}
+	}
+
+	return msgsToGen, gf, nil
+}
+
+// collectSelected collects the messages (including nested messages) that are roots and match the include and exclude
+// parameters, along with the messages reachable from them.
+func (g *codegen) collectSelected(col map[string]*protogen.Message, msgs []*protogen.Message) {
+	for _, msg := range msgs {
+		if _, ok := g.roots[msg.Desc.FullName()]; (ok || g.roots == nil) && !msg.Desc.IsMapEntry() && g.selected(msg.Desc) {
+			collectMessages(col, msg)
+		}
+		g.collectSelected(col, msg.Messages)
 	}
 }
 
@@ -153,7 +662,115 @@ func sumFuncName(md protoreflect.MessageDescriptor) string {
 	return fqn + funcSuffix
 }
 
-func (g *codegen) genHelperForMsg(gf *protogen.GeneratedFile, msg *protogen.Message) {
+// helperVariant identifies the flavour of the generated helper functions.
+type helperVariant int
+
+const (
+	// plainHelper ignores write errors.
+	plainHelper helperVariant = iota
+	// depthLimitedHelper takes the remaining nesting depth as an argument and returns hashpb.ErrMaxDepth when it is exhausted.
+	depthLimitedHelper
+	// errHelper returns the first error returned by the hasher.
+	errHelper
+	// noIgnoreHelper ignores write errors and doesn't take an ignore set.
+	noIgnoreHelper
+	// shallowHelper ignores write errors and writes the type names of message values instead of descending into them.
+	shallowHelper
+	// filteredHelper ignores write errors and takes a predicate over fully-qualified field names instead of an ignore set.
+	filteredHelper
+)
+
+// helperName returns the name of the helper function of the given variant for the message.
+func (g *codegen) helperName(variant helperVariant, md protoreflect.MessageDescriptor) string {
+	name := sumFuncName(md) + g.unit.suffix()
+	switch variant {
+	case depthLimitedHelper:
+		return name + limitedFuncSuffix
+	case errHelper:
+		return name + errFuncSuffix
+	case noIgnoreHelper:
+		return name + noIgnoreSuffix
+	case shallowHelper:
+		return name + shallowSuffix
+	case filteredHelper:
+		return name + filteredSuffix
+	default:
+		return name
+	}
+}
+
+func (v helperVariant) returnsError() bool {
+	return v == depthLimitedHelper || v == errHelper
+}
+
+// checksIgnore reports whether helpers of the variant skip the fields in the ignore set.
+func (v helperVariant) checksIgnore() bool {
+	return v != noIgnoreHelper && v != filteredHelper
+}
+
+// fieldCond returns the condition that holds when helpers of the variant write the field, or nil if they always do.
+func (v helperVariant) fieldCond(fd protoreflect.FieldDescriptor) []any {
+	switch {
+	case v == filteredHelper:
+		return []any{"filter(\"", fd.FullName(), "\")"}
+	case v.checksIgnore():
+		return ignoreCond(fd)
+	default:
+		return nil
+	}
+}
+
+// writeCall returns the code surrounding a call to hasher.Write in helpers of the variant.
+func (v helperVariant) writeCall() (string, string) {
+	if v == errHelper {
+		return "if _, err := hasher.Write(", "; err != nil {\nreturn err\n}"
+	}
+	return "_, _ = hasher.Write(", ""
+}
+
+// genHelperForMsg generates the helper function of the given variant for the message.
+// Plain helpers are rendered using the helper template.
+func (g *codegen) genHelperForMsg(gf *protogen.GeneratedFile, msg *protogen.Message, variant helperVariant) error {
+	if variant == plainHelper {
+		body := &bufferPrinter{gf: gf}
+		g.genHelperFields(body, msg, variant)
+
+		return g.execTemplate(gf, HelperTemplate, HelperData{
+			Name:     g.helperName(variant, msg.Desc),
+			FullName: string(msg.Desc.FullName()),
+			GoType:   gf.QualifiedGoIdent(msg.GoIdent),
+			Writer:   gf.QualifiedGoIdent(writerType),
+			Body:     body.buf.String(),
+			gf:       gf,
+		})
+	}
+
+	switch variant {
+	case depthLimitedHelper:
+		gf.P("func ", g.helperName(variant, msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", writerType, ", ignore map[string]struct{}, depth int) error {")
+		gf.P("if depth < 1 {")
+		gf.P("return ", errMaxDepth)
+		gf.P("}")
+	case errHelper:
+		gf.P("func ", g.helperName(variant, msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", writerType, ", ignore map[string]struct{}) error {")
+	case noIgnoreHelper:
+		gf.P("func ", g.helperName(variant, msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", writerType, ") {")
+	case shallowHelper:
+		gf.P("func ", g.helperName(variant, msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", writerType, ", ignore map[string]struct{}) {")
+	case filteredHelper:
+		gf.P("func ", g.helperName(variant, msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", writerType, ", filter func(string) bool) {")
+	}
+
+	g.genHelperFields(gf, msg, variant)
+	if variant.returnsError() {
+		gf.P("return nil")
+	}
+	gf.P("}")
+	return nil
+}
+
+// genHelperFields generates the code that writes the fields of the message to the hasher.
+func (g *codegen) genHelperFields(gf printer, msg *protogen.Message, variant helperVariant) {
 	fields := make([]*protogen.Field, len(msg.Fields))
 	copy(fields, msg.Fields)
 
@@ -161,65 +778,281 @@ func (g *codegen) genHelperForMsg(gf *protogen.GeneratedFile, msg *protogen.Mess
 		return fields[i].Desc.Number() < fields[j].Desc.Number()
 	})
 
-	gf.P("func ", sumFuncName(msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ",hasher ", hashFn, ", ignore map[string]struct{}) {")
-
 	oneOfs := make(map[string]struct{})
 
 	for _, field := range fields {
 		if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
-			if _, ok := oneOfs[field.Oneof.GoName]; !ok {
-				g.genOneOfField(gf, field)
+			if _, ok := oneOfs[field.Oneof.GoName]; !ok && !g.config.oneofIgnored(field.Oneof.Desc) {
+				g.genOneOfField(gf, field, variant)
 				oneOfs[field.Oneof.GoName] = struct{}{}
 			}
-		} else {
-			g.genField(gf, field)
+		} else if !g.config.ignored(field.Desc) {
+			g.genField(gf, field, variant)
 		}
 	}
-
-	gf.P("}")
 }
 
-func (g *codegen) genField(gf *protogen.GeneratedFile, field *protogen.Field) {
-	gf.P("if _, ok := ignore[\"", field.Desc.FullName(), "\"]; !ok {")
+func (g *codegen) genField(gf printer, field *protogen.Field, variant helperVariant) {
+	cond := variant.fieldCond(field.Desc)
+	if cond != nil {
+		gf.P(append(append([]any{"if "}, cond...), " {")...)
+	}
+
+	// with empty_as_set, unset bytes fields with explicit presence are left out so that they are distinct from empty ones.
+	presence := g.params.EmptyAsSet && hashpb.EmptyModeApplies(field.Desc)
+	if presence {
+		gf.P("if ", g.presenceExpr(field), " {")
+	}
 
 	switch {
 	case field.Desc.IsList():
-		g.genListField(gf, field)
+		g.genListField(gf, field, variant)
 	case field.Desc.IsMap():
-		g.genMapField(gf, field)
+		g.genMapField(gf, field, variant)
 	default:
-		g.genSingularField(gf, field.Desc, fieldAccess(fmt.Sprintf("Get%s()", field.GoName)))
+		g.genSingularField(gf, field.Desc, fieldAccess(fmt.Sprintf("Get%s()", field.GoName)), variant)
 	}
 
-	gf.P("}")
+	if presence {
+		gf.P("}")
+	}
+
+	if cond != nil {
+		gf.P("}")
+	}
+}
+
+// presenceExpr returns the expression that is true when the field, which has explicit presence, is set.
+func (g *codegen) presenceExpr(field *protogen.Field) string {
+	if g.opaque(field.Parent) {
+		return fieldAccess("Has" + field.GoName + "()")
+	}
+
+	return fieldAccess(field.GoName) + " != nil"
+}
+
+// stringValue returns the expression hashed for the value of a string field, which is normalized to NFC if the
+// normalize_unicode parameter is set, trimmed and converted to lower case if the field is annotated with
+// (hashpb.field).trim_space and (hashpb.field).case_insensitive, and then passed through the transforms configured
+// for the field.
+func (g *codegen) stringValue(fd protoreflect.FieldDescriptor, value string) []any {
+	expr := []any{value}
+	if g.params.NormalizeUnicode {
+		expr = []any{nfcString, ".String(", value, ")"}
+	}
+
+	fo := fieldOptions(fd)
+	if fo.GetTrimSpace() {
+		expr = append(append([]any{trimSpaceFn, "("}, expr...), ")")
+	}
+
+	if fo.GetCaseInsensitive() {
+		expr = append(append([]any{toLowerFn, "("}, expr...), ")")
+	}
+
+	return g.config.transformed(fd, expr)
+}
+
+// fieldOptions returns the (hashpb.field) options of the field, which are empty if the field isn't annotated.
+func fieldOptions(fd protoreflect.FieldDescriptor) *optionspb.FieldOptions {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+
+	return proto.GetExtension(opts, optionspb.E_Field).(*optionspb.FieldOptions)
+}
+
+// ignoreCond returns the condition that holds when the field is not in the ignore set. Fields with a stable name are
+// matched against both their fully-qualified name and their stable ignore key.
+func ignoreCond(fd protoreflect.FieldDescriptor) []any {
+	if key := hashpb.StableIgnoreKey(fd); key != "" {
+		return []any{"!", ignoredFn, "(ignore, \"", fd.FullName(), "\", \"", key, "\")"}
+	}
+
+	return []any{"_, ok := ignore[\"", fd.FullName(), "\"]; !ok"}
 }
 
-func (g *codegen) genOneOfField(gf *protogen.GeneratedFile, field *protogen.Field) {
+func (g *codegen) genOneOfField(gf printer, field *protogen.Field, variant helperVariant) {
+	if g.opaque(field.Parent) {
+		g.genOpaqueOneOfField(gf, field, variant)
+		return
+	}
+
 	fieldName := fieldAccess(field.Oneof.GoName)
 
 	gf.P("if ", fieldName, " != nil {")
-	gf.P("if _, ok := ignore[\"", field.Desc.ContainingOneof().FullName(), "\"]; !ok {")
+	closeCheck := g.genOneofIgnoreCheck(gf, field, variant)
 	gf.P("switch t := ", fieldName, ".(type) {")
 	for _, f := range field.Oneof.Fields {
+		if g.config.ignored(f.Desc) {
+			continue
+		}
+
 		gf.P("case *", f.GoIdent, ":")
-		g.genSingularField(gf, f.Desc, "t."+f.GoName)
+		g.genOneofMember(gf, f, "t."+f.GoName, variant)
 	}
 	gf.P("}")
+	closeCheck()
 	gf.P("}")
+}
+
+// genOpaqueOneOfField generates the code that writes the member of a oneof that is set, using the Which method and
+// the getters of a message using the opaque API.
+func (g *codegen) genOpaqueOneOfField(gf printer, field *protogen.Field, variant helperVariant) {
+	closeCheck := g.genOneofIgnoreCheck(gf, field, variant)
+	gf.P("switch ", fieldAccess("Which"+field.Oneof.GoName+"()"), " {")
+	for _, f := range field.Oneof.Fields {
+		if g.config.ignored(f.Desc) {
+			continue
+		}
+
+		gf.P("case ", oneofCase(f), ":")
+		g.genOneofMember(gf, f, g.fieldExpr(f), variant)
+	}
 	gf.P("}")
+	closeCheck()
 }
 
-func (g *codegen) genListField(gf *protogen.GeneratedFile, field *protogen.Field) {
-	fieldName := fieldAccess(field.GoName)
+// genOneofIgnoreCheck opens the block that writes the oneof if it is not in the ignore set and returns a function that
+// closes it. Nothing is generated for helpers that don't check the ignore set.
+func (g *codegen) genOneofIgnoreCheck(gf printer, field *protogen.Field, variant helperVariant) func() {
+	if !variant.checksIgnore() {
+		return func() {}
+	}
+
+	gf.P("if _, ok := ignore[\"", field.Desc.ContainingOneof().FullName(), "\"]; !ok {")
+	return func() { gf.P("}") }
+}
+
+// genOneofMember generates the code that writes the value of a oneof member that is set. Filtered helpers only write
+// it if the filter accepts the member.
+func (g *codegen) genOneofMember(gf printer, f *protogen.Field, value string, variant helperVariant) {
+	if variant != filteredHelper {
+		g.genSingularField(gf, f.Desc, value, variant)
+		return
+	}
+
+	gf.P(append(append([]any{"if "}, variant.fieldCond(f.Desc)...), " {")...)
+	g.genSingularField(gf, f.Desc, value, variant)
+	gf.P("}")
+}
+
+func (g *codegen) genListField(gf printer, field *protogen.Field, variant helperVariant) {
+	fieldName := g.fieldExpr(field)
 	gf.P("if len(", fieldName, ") > 0 {")
+	if hashpb.Unordered(field.Desc) {
+		g.genUnorderedList(gf, field, fieldName, variant)
+		gf.P("}")
+		return
+	}
+
+	fieldName = genSortedList(gf, field, fieldName)
+	if k := sampleEvery(field.Desc); k > 0 {
+		// Sampled lists hash their length followed by every k-th element.
+		writeFn, writeEnd := variant.writeCall()
+		gf.P(writeFn, appendVarintFn, "(nil, uint64(len(", fieldName, "))))", writeEnd)
+		gf.P("for i := 0; i < len(", fieldName, "); i += ", k, " {")
+		gf.P("v := ", fieldName, "[i]")
+	} else {
+		gf.P("for _, v := range ", fieldName, " {")
+	}
+	g.genSingularField(gf, field.Desc, "v", variant)
+	gf.P("}")
+	gf.P("}")
+}
+
+// sampleEvery returns the sampling interval set by the (hashpb.field).sample_every option of a repeated field, or
+// zero if every element is hashed.
+func sampleEvery(fd protoreflect.FieldDescriptor) int {
+	if k := fieldOptions(fd).GetSampleEvery(); fd.IsList() && k > 1 {
+		return int(k)
+	}
+	return 0
+}
+
+// genUnorderedList generates code that hashes each element of a repeated message field annotated with
+// (hashpb.field).unordered into its own SHA-256 digest and writes the sorted digests as length-prefixed byte strings.
+func (g *codegen) genUnorderedList(gf printer, field *protogen.Field, fieldName string, variant helperVariant) {
+	writeFn, writeEnd := variant.writeCall()
+	gf.P("digests := make([][]byte, 0, len(", fieldName, "))")
 	gf.P("for _, v := range ", fieldName, " {")
-	g.genSingularField(gf, field.Desc, "v")
+	// the element is written to a new hasher that shadows the hasher of the message.
+	gf.P("hasher := ", sha256NewFn, "()")
+	g.genSingularField(gf, field.Desc, "v", variant)
+	gf.P("digests = append(digests, hasher.Sum(nil))")
 	gf.P("}")
+	gf.P(sortSliceFn, "(digests, func(i, j int) bool { return ", bytesCompareFn, "(digests[i], digests[j]) < 0 })")
+	gf.P("for _, digest := range digests {")
+	gf.P(writeFn, appendBytesFn, "(nil, digest))", writeEnd)
 	gf.P("}")
 }
 
-func (g *codegen) genMapField(gf *protogen.GeneratedFile, field *protogen.Field) {
-	fieldName := fieldAccess(field.GoName)
+// genSortedList generates code that copies the elements of a repeated message field annotated with
+// (hashpb.field).sort_by to a slice sorted by the sort key, and returns the expression to iterate over.
+// Fields without a sort key are iterated over as they are.
+func genSortedList(gf printer, field *protogen.Field, fieldName string) string {
+	key := hashpb.SortBy(field.Desc)
+	if key == nil {
+		return fieldName
+	}
+
+	var getter string
+	for _, f := range field.Message.Fields {
+		if f.Desc == key {
+			getter = "Get" + f.GoName + "()"
+		}
+	}
+
+	a, b := "sorted[i]."+getter, "sorted[j]."+getter
+	less := []any{a, " < ", b}
+	switch key.Kind() {
+	case protoreflect.BoolKind:
+		less = []any{"!", a, " && ", b}
+	case protoreflect.BytesKind:
+		less = []any{bytesCompareFn, "(", a, ", ", b, ") < 0"}
+	}
+
+	gf.P("sorted := make([]*", field.Message.GoIdent, ", len(", fieldName, "))")
+	gf.P("copy(sorted, ", fieldName, ")")
+	gf.P(append(append([]any{sortSliceStableFn, "(sorted, func(i, j int) bool { return "}, less...), " })")...)
+	return "sorted"
+}
+
+// validateListAnnotations checks that the (hashpb.field).sort_by and (hashpb.field).unordered options of the fields of
+// the message are set on fields that support them.
+func validateListAnnotations(msg *protogen.Message) error {
+	for _, field := range msg.Fields {
+		fo := fieldOptions(field.Desc)
+		isMessageList := field.Desc.IsList() && field.Message != nil
+		if fo.GetUnordered() {
+			if !isMessageList {
+				return fmt.Errorf("(hashpb.field).unordered is only supported on repeated message fields: %s", field.Desc.FullName())
+			}
+
+			if fo.GetSortBy() != "" || fo.GetSampleEvery() > 1 {
+				return fmt.Errorf("(hashpb.field).unordered can't be combined with sort_by or sample_every: %s", field.Desc.FullName())
+			}
+		}
+
+		name := fo.GetSortBy()
+		if name == "" || hashpb.SortBy(field.Desc) != nil {
+			continue
+		}
+
+		if !isMessageList {
+			return fmt.Errorf("(hashpb.field).sort_by is only supported on repeated message fields: %s", field.Desc.FullName())
+		}
+
+		return fmt.Errorf("(hashpb.field).sort_by of %s must name a singular scalar field of %s that is not a float or double: %q",
+			field.Desc.FullName(), field.Message.Desc.FullName(), name)
+	}
+
+	return nil
+}
+
+func (g *codegen) genMapField(gf printer, field *protogen.Field, variant helperVariant) {
+	fieldName := g.fieldExpr(field)
 	gf.P("if len(", fieldName, ") > 0 {")
 	typeName, cmpFn := typeAndCompareFnForMapKey(field.Desc.MapKey())
 
@@ -235,7 +1068,7 @@ func (g *codegen) genMapField(gf *protogen.GeneratedFile, field *protogen.Field)
 	gf.P()
 
 	gf.P("for _, k := range keys {")
-	g.genSingularField(gf, field.Desc.MapValue(), fmt.Sprintf("%s[k]", fieldName))
+	g.genSingularField(gf, field.Desc.MapValue(), fmt.Sprintf("%s[k]", fieldName), variant)
 	gf.P("}")
 	gf.P("}")
 }
@@ -259,61 +1092,80 @@ func typeAndCompareFnForMapKey(mk protoreflect.FieldDescriptor) (string, string)
 	}
 }
 
-func (g *codegen) genSingularField(gf *protogen.GeneratedFile, fieldDesc protoreflect.FieldDescriptor, fieldName string) {
-	writeFn := "_, _ = hasher.Write("
+func (g *codegen) genSingularField(gf printer, fieldDesc protoreflect.FieldDescriptor, fieldName string, variant helperVariant) {
+	writeFn, writeEnd := variant.writeCall()
 
 	switch fieldDesc.Kind() {
 	case protoreflect.BoolKind:
 		// hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, ", encodeBoolFn, "(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, ", encodeBoolFn, "(", fieldName, ")))", writeEnd)
 	case protoreflect.EnumKind:
 		// hasher.Write(protowire.AppendVarint(nil, uint64(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))", writeEnd)
 	case protoreflect.Int32Kind:
 		// hasher.Write(protowire.AppendVarint(nil, uint64(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))", writeEnd)
 	case protoreflect.Sint32Kind:
 		// hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(...))))
-		gf.P(writeFn, appendVarintFn, "(nil, ", encodeZigZagFn, "(int64(", fieldName, "))))")
+		gf.P(writeFn, appendVarintFn, "(nil, ", encodeZigZagFn, "(int64(", fieldName, "))))", writeEnd)
 	case protoreflect.Uint32Kind:
 		// hasher.Write(protowire.AppendVarint(nil, uint64(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))", writeEnd)
 	case protoreflect.Int64Kind:
 		// hasher.Write(protowire.AppendVarint(nil, uint64(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, uint64(", fieldName, ")))", writeEnd)
 	case protoreflect.Sint64Kind:
 		// hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(...)))
-		gf.P(writeFn, appendVarintFn, "(nil, ", encodeZigZagFn, "(", fieldName, ")))")
+		gf.P(writeFn, appendVarintFn, "(nil, ", encodeZigZagFn, "(", fieldName, ")))", writeEnd)
 	case protoreflect.Uint64Kind:
 		// hasher.Write(protowire.AppendVarint(nil, ...))
-		gf.P(writeFn, appendVarintFn, "(nil, ", fieldName, "))")
+		gf.P(writeFn, appendVarintFn, "(nil, ", fieldName, "))", writeEnd)
 	case protoreflect.Sfixed32Kind:
 		// hasher.Write(protowire.AppendFixed32(nil, uint32(...)))
-		gf.P(writeFn, appendFixed32Fn, "(nil, uint32(", fieldName, ")))")
+		gf.P(writeFn, appendFixed32Fn, "(nil, uint32(", fieldName, ")))", writeEnd)
 	case protoreflect.Fixed32Kind:
 		// hasher.Write(protowire.AppendFixed32(nil, uint32(...)))
-		gf.P(writeFn, appendFixed32Fn, "(nil, uint32(", fieldName, ")))")
+		gf.P(writeFn, appendFixed32Fn, "(nil, uint32(", fieldName, ")))", writeEnd)
 	case protoreflect.FloatKind:
 		// hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(...)))
-		gf.P(writeFn, appendFixed32Fn, "(nil,", float32BitsFn, "(", fieldName, ")))")
+		gf.P(writeFn, appendFixed32Fn, "(nil,", float32BitsFn, "(", fieldName, ")))", writeEnd)
 	case protoreflect.Sfixed64Kind:
 		// hasher.Write(protowire.AppendFixed64(nil, uint64(...)))
-		gf.P(writeFn, appendFixed64Fn, "(nil, uint64(", fieldName, ")))")
+		gf.P(writeFn, appendFixed64Fn, "(nil, uint64(", fieldName, ")))", writeEnd)
 	case protoreflect.Fixed64Kind:
 		// hasher.Write(protowire.AppendFixed64(nil, ...))
-		gf.P(writeFn, appendFixed64Fn, "(nil, ", fieldName, "))")
+		gf.P(writeFn, appendFixed64Fn, "(nil, ", fieldName, "))", writeEnd)
 	case protoreflect.DoubleKind:
 		// hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(...)))
-		gf.P(writeFn, appendFixed64Fn, "(nil,", float64BitsFn, "(", fieldName, ")))")
+		gf.P(writeFn, appendFixed64Fn, "(nil,", float64BitsFn, "(", fieldName, ")))", writeEnd)
 	case protoreflect.StringKind:
 		// hasher.Write(protowire.AppendString(nil, ...))
-		gf.P(writeFn, appendStringFn, "(nil, ", fieldName, "))")
+		value := g.stringValue(fieldDesc, fieldName)
+		gf.P(append(append([]any{writeFn, appendStringFn, "(nil, "}, value...), "))", writeEnd)...)
 	case protoreflect.BytesKind:
 		// hasher.Write(protowire.AppendBytes(nil, ...))
-		gf.P(writeFn, appendBytesFn, "(nil, ", fieldName, "))")
-	case protoreflect.MessageKind:
+		gf.P(writeFn, appendBytesFn, "(nil, ", fieldName, "))", writeEnd)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// groups and fields with delimited message encoding (editions) are hashed like any other message field.
 		gf.P("if ", fieldName, " != nil {")
-		gf.P(sumFuncName(fieldDesc.Message()), "(", fieldName, ",hasher, ignore)")
+		switch variant {
+		case depthLimitedHelper:
+			gf.P("if err := ", g.helperName(variant, fieldDesc.Message()), "(", fieldName, ",hasher, ignore, depth-1); err != nil {")
+			gf.P("return err")
+			gf.P("}")
+		case errHelper:
+			gf.P("if err := ", g.helperName(variant, fieldDesc.Message()), "(", fieldName, ",hasher, ignore); err != nil {")
+			gf.P("return err")
+			gf.P("}")
+		case noIgnoreHelper:
+			gf.P(g.helperName(variant, fieldDesc.Message()), "(", fieldName, ",hasher)")
+		case shallowHelper:
+			gf.P(writeFn, appendStringFn, "(nil, \"", fieldDesc.Message().FullName(), "\"))", writeEnd)
+		case filteredHelper:
+			gf.P(g.helperName(variant, fieldDesc.Message()), "(", fieldName, ",hasher, filter)")
+		default:
+			gf.P(g.helperName(variant, fieldDesc.Message()), "(", fieldName, ",hasher, ignore)")
+		}
 		gf.P("}")
 	default:
 		panic(fmt.Errorf("unhandled field kind %s", fieldDesc.Kind().String()))
@@ -326,50 +1178,554 @@ func fieldAccess(name string) string {
 	return fmt.Sprintf("%s.%s", receiverIdent, name)
 }
 
-// generateMethods generates helper methods (HashPB) for the top level messages defined in each file.
-func (g *codegen) generateMethods(files []*protogen.File) {
+// generateMethods generates helper methods (HashPB) for the messages defined in each file that have helpers.
+// In single file and per-file helpers modes, the methods are written to the helpers file.
+func (g *codegen) generateMethods(files []*protogen.File, helpers map[string]*protogen.Message, helpersFile *protogen.GeneratedFile) error {
 	for _, f := range files {
-		gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb.pb.go", f.GoImportPath)
-		gf.P("// Code generated by protoc-gen-go-hashpb. Do not edit.")
-		gf.P("// protoc-gen-go-hashpb ", Version)
-		gf.P("// Source: ", f.Desc.Path())
-		gf.P()
-		gf.P("package ", files[0].GoPackageName)
-		gf.P()
+		var gf *protogen.GeneratedFile
+		switch {
+		case g.unit.file != "":
+			if helpersFile == nil {
+				continue
+			}
+
+			gf = helpersFile
+		case g.params.SingleFile:
+			if helpersFile == nil {
+				continue
+			}
+
+			gf = helpersFile
+			gf.P("// Source: ", f.Desc.Path())
+			gf.P()
+		default:
+			gf = g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb.pb.go", f.GoImportPath)
+			g.genFileHeader(gf, f)
+		}
+
+		genFuncs := make(map[string]*protogen.Message)
+
+		for _, msg := range fileMessages(f) {
+			if err := g.genMethodForMsg(gf, helpers, genFuncs, msg); err != nil {
+				return err
+			}
+		}
+
+		if g.params.SchemaFingerprint {
+			constName := f.GoDescriptorIdent.GoName + "_HashPBSchemaFingerprint"
+			gf.P("// ", constName, " is a fingerprint of ", f.Desc.Path(), " and the files it imports.")
+			gf.P("// It changes when any declaration in these files changes, regardless of source code info and declaration order.")
+			gf.P("const ", constName, " uint64 = ", fmt.Sprintf("0x%016x", hashpb.FileFingerprint(f.Desc)))
+			gf.P()
+		}
 
-		genFuncs := make(map[string]struct{})
+		if g.params.Registry {
+			g.genRegistration(gf, f, genFuncs)
+		}
 
-		for _, msg := range f.Messages {
-			g.genMethodForMsg(gf, genFuncs, msg)
+		if g.params.GenConformanceTests {
+			g.genConformanceTests(f, genFuncs)
+		}
+
+		if g.params.GenFuzzTests {
+			g.genFuzzTests(f, genFuncs)
+		}
+
+		if g.params.GenMapOrderTests {
+			g.genMapOrderTests(f, genFuncs)
+		}
+
+		if g.params.GenBenchmarks {
+			g.genBenchmarks(f, genFuncs)
+		}
+
+		if g.params.GenTests {
+			if err := g.genGoldenTests(f, genFuncs); err != nil {
+				return err
+			}
+		}
+
+		if g.params.GenVectors {
+			if err := g.genVectors(f, genFuncs); err != nil {
+				return err
+			}
+		}
+
+		if g.params.GenSpec {
+			if err := g.genSpec(f, genFuncs); err != nil {
+				return err
+			}
 		}
 	}
+
+	return nil
+}
+
+func (g *codegen) genFileHeader(gf *protogen.GeneratedFile, f *protogen.File) {
+	gf.P("// Code generated by protoc-gen-go-hashpb. Do not edit.")
+	gf.P("// protoc-gen-go-hashpb ", Version)
+	gf.P("// Source: ", f.Desc.Path())
+	gf.P()
+	g.genBuildConstraint(gf)
+	gf.P("package ", f.GoPackageName)
+	gf.P()
 }
 
-func (g *codegen) genMethodForMsg(gf *protogen.GeneratedFile, genFuncs map[string]struct{}, msg *protogen.Message) {
+// genVersionCheck generates constants that fail to compile if the hashpb package doesn't support the generated code.
+func genVersionCheck(gf *protogen.GeneratedFile) {
+	gf.P("// This is a compile-time assertion to ensure that this generated file")
+	gf.P("// is compatible with the version of the hashpb package in use.")
+	gf.P("const (")
+	gf.P("_ = ", enforceVersion, "(", hashpb.GenVersion, " - ", minGenVersion, ")")
+	gf.P("_ = ", enforceVersion, "(", genVersion, " - ", hashpb.GenVersion, ")")
+	gf.P(")")
+	gf.P()
+}
+
+// genBuildConstraint generates the //go:build line requested with the build_tags parameter, if any.
+func (g *codegen) genBuildConstraint(gf *protogen.GeneratedFile) {
+	if g.buildConstraint != "" {
+		gf.P("//go:build ", g.buildConstraint)
+		gf.P()
+	}
+}
+
+func (g *codegen) genMethodForMsg(gf *protogen.GeneratedFile, helpers, genFuncs map[string]*protogen.Message, msg *protogen.Message) error {
 	if msg.Desc.IsMapEntry() {
-		return
+		return nil
 	}
 
 	if len(msg.Fields) == 0 {
-		return
+		return nil
 	}
 
 	if _, ok := genFuncs[msg.GoIdent.GoName]; ok {
-		return
+		return nil
 	}
 
-	genFuncs[msg.GoIdent.GoName] = struct{}{}
+	if _, ok := helpers[sumFuncName(msg.Desc)]; !ok || !g.selected(msg.Desc) || g.config.message(msg.Desc).Skip {
+		return nil
+	}
 
-	gf.P("// HashPB computes a hash of the message using the given hash function")
-	gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
-	gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPB(hasher ", hashFn, ", ignore map[string]struct{}) {")
-	gf.P("if ", receiverIdent, " != nil {")
-	gf.P(sumFuncName(msg.Desc), "(", receiverIdent, ", hasher, ignore)")
-	gf.P("}")
+	genFuncs[msg.GoIdent.GoName] = msg
+
+	if err := g.execTemplate(gf, MethodTemplate, MethodData{
+		MethodName: g.config.methodName(msg.Desc),
+		FullName:   string(msg.Desc.FullName()),
+		GoType:     gf.QualifiedGoIdent(msg.GoIdent),
+		Hash:       gf.QualifiedGoIdent(hashFn),
+		Helper:     g.helperName(plainHelper, msg.Desc),
+		gf:         gf,
+	}); err != nil {
+		return err
+	}
+	gf.P()
+
+	if g.params.Sum64 {
+		gf.P("// Sum64HashPB computes the 64-bit xxhash digest of the message")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") Sum64HashPB(ignore map[string]struct{}) uint64 {")
+		gf.P("hasher := ", xxhashNewFn, "()")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P(g.helperName(plainHelper, msg.Desc), "(", receiverIdent, ", hasher, ignore)")
+		gf.P("}")
+		gf.P("return hasher.Sum64()")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.AppendHash {
+		gf.P("// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {")
+		gf.P("hasher := ", xxhashNewFn, "()")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P(g.helperName(plainHelper, msg.Desc), "(", receiverIdent, ", hasher, ignore)")
+		gf.P("}")
+		gf.P("return hasher.Sum(dst)")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.MultiHash {
+		gf.P("// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBMulti(ignore map[string]struct{}, hashers ...", hashFn, ") {")
+		gf.P("if ", receiverIdent, " != nil && len(hashers) > 0 {")
+		gf.P(g.helperName(plainHelper, msg.Desc), "(", receiverIdent, ", ", multiHasherFn, "(hashers...), ignore)")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.DepthLimit {
+		gf.P("// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth")
+		gf.P("// if messages are nested more than maxDepth levels deep (the message itself is the first level)")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBWithMaxDepth(hasher ", hashFn, ", ignore map[string]struct{}, maxDepth int) error {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P("return ", g.helperName(depthLimitedHelper, msg.Desc), "(", receiverIdent, ", hasher, ignore, maxDepth)")
+		gf.P("}")
+		gf.P("return nil")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.PropagateErrors {
+		gf.P("// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBErr(hasher ", hashFn, ", ignore map[string]struct{}) error {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P("return ", g.helperName(errHelper, msg.Desc), "(", receiverIdent, ", hasher, ignore)")
+		gf.P("}")
+		gf.P("return nil")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.Writer {
+		gf.P("// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") WriteHashPB(w ", writerType, ", ignore map[string]struct{}) error {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P("return ", g.helperName(errHelper, msg.Desc), "(", receiverIdent, ", w, ignore)")
+		gf.P("}")
+		gf.P("return nil")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.NoIgnore {
+		gf.P("// HashPBNoIgnore computes a hash of the message using the given hash function, including every field")
+		gf.P("// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBNoIgnore(hasher ", hashFn, ") {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P(g.helperName(noIgnoreHelper, msg.Desc), "(", receiverIdent, ", hasher)")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.Shallow {
+		gf.P("// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type")
+		gf.P("// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBShallow(hasher ", hashFn, ", ignore map[string]struct{}) {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P(g.helperName(shallowHelper, msg.Desc), "(", receiverIdent, ", hasher, ignore)")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.Filtered {
+		gf.P("// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter")
+		gf.P("// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set")
+		gf.P("// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") HashPBFiltered(hasher ", hashFn, ", filter func(fullName string) bool) {")
+		gf.P("if ", receiverIdent, " != nil {")
+		gf.P(g.helperName(filteredHelper, msg.Desc), "(", receiverIdent, ", hasher, filter)")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.ObjectHash {
+		gf.P("// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message")
+		gf.P("// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash")
+		gf.P("func (", receiverIdent, " *", msg.GoIdent, ") ObjectHashPB(ignore map[string]struct{}) [", objectHashSize, "]byte {")
+		gf.P("return ", g.objectHashFuncName(msg.Desc), "(", receiverIdent, ", ignore)")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.params.SchemaFingerprint {
+		constName := msg.GoIdent.GoName + "_HashPBSchemaFingerprint"
+		gf.P("// ", constName, " is a fingerprint of the schema of ", msg.GoIdent.GoName, " and the messages reachable from it.")
+		gf.P("// It changes when the schema changes in a way that could affect the digests produced by HashPB.")
+		gf.P("const ", constName, " uint64 = ", fmt.Sprintf("0x%016x", hashpb.SchemaFingerprint(msg.Desc)))
+		gf.P()
+	}
+
+	return nil
+}
+
+// genRegistration generates an init function that registers the HashPB methods of the file with hashpbreg. Messages
+// affected by the rules in the configuration file are not registered because the hashpb package would use their
+// methods to compute digests that don't match its own.
+func (g *codegen) genRegistration(gf *protogen.GeneratedFile, f *protogen.File, genFuncs map[string]*protogen.Message) {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return
+	}
+
+	gf.P("func init() {")
+	for _, msg := range msgs {
+		gf.P(registerFn, "(\"", msg.Desc.FullName(), "\", func(msg ", protoMessage, ", hasher ", hashFn, ", ignore map[string]struct{}) bool {")
+		gf.P("m, ok := msg.(*", msg.GoIdent, ")")
+		gf.P("if ok {")
+		gf.P("m.", g.config.methodName(msg.Desc), "(hasher, ignore)")
+		gf.P("}")
+		gf.P("return ok")
+		gf.P("})")
+	}
 	gf.P("}")
 	gf.P()
+}
+
+// collectFileMessages returns the messages from genFuncs sorted by full name.
+func collectFileMessages(f *protogen.File, genFuncs map[string]*protogen.Message) []*protogen.Message {
+	var msgs []*protogen.Message
+	for _, msg := range fileMessages(f) {
+		if m, ok := genFuncs[msg.GoIdent.GoName]; ok && m == msg {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs
+}
+
+// fileMessages returns the messages declared in the file, including nested messages, sorted by full name so that the
+// generated code doesn't change when the declarations are reordered or moved around within the file.
+func fileMessages(f *protogen.File) []*protogen.Message {
+	var msgs []*protogen.Message
+	var walk func([]*protogen.Message)
+	walk = func(ms []*protogen.Message) {
+		for _, msg := range ms {
+			msgs = append(msgs, msg)
+			walk(msg.Messages)
+		}
+	}
+	walk(f.Messages)
+
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Desc.FullName() < msgs[j].Desc.FullName() })
+	return msgs
+}
+
+// testableMessages returns the messages of the file whose digests can be checked against the hashpb package.
+// Messages affected by the rules in the configuration file are left out because the hashpb package doesn't know
+// about them.
+func (g *codegen) testableMessages(f *protogen.File, genFuncs map[string]*protogen.Message) []*protogen.Message {
+	msgs := collectFileMessages(f, genFuncs)
+	if g.config == nil {
+		return msgs
+	}
+
+	testable := msgs[:0]
+	for _, msg := range msgs {
+		if !g.config.customised(msg.Desc) {
+			testable = append(testable, msg)
+		}
+	}
+
+	return testable
+}
+
+// genConformanceTests generates a test file that checks the HashPB methods of the file against the hashpb package.
+func (g *codegen) genConformanceTests(f *protogen.File, genFuncs map[string]*protogen.Message) {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return
+	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_conformance_test.go", f.GoImportPath)
+	g.genFileHeader(gf, f)
+
+	for _, msg := range msgs {
+		gf.P("func TestHashPBConformance_", msg.GoIdent.GoName, "(t *", testingT, ") {")
+		gf.P(append([]any{checkConfFn, "(t, &", msg.GoIdent, "{}"}, append(g.conformanceOptions(), ")")...)...)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// conformanceOptions returns the arguments that make the hashpb package hash messages like the generated code when
+// comparing the two in generated tests.
+func (g *codegen) conformanceOptions() []any {
+	var opts []any
+	if g.params.NormalizeUnicode {
+		opts = append(opts, ", ", normalizeUnicode, "()")
+	}
+
+	if g.params.EmptyAsSet {
+		opts = append(opts, ", ", withEmptyMode, "(", emptyAsSet, ")")
+	}
+
+	return opts
+}
+
+// hashOptions returns the options that make the hashpb package hash messages like the generated code when computing
+// golden digests at generation time.
+func (g *codegen) hashOptions() []hashpb.Option {
+	var opts []hashpb.Option
+	if g.params.NormalizeUnicode {
+		opts = append(opts, hashpb.WithNormalizeUnicode())
+	}
+
+	if g.params.EmptyAsSet {
+		opts = append(opts, hashpb.WithEmptyMode(hashpb.EmptyAsSet))
+	}
+
+	return opts
+}
+
+// genFuzzTests generates a test file with fuzz targets that compare the HashPB methods of the file with the hashpb package.
+func (g *codegen) genFuzzTests(f *protogen.File, genFuncs map[string]*protogen.Message) {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return
+	}
 
-	for _, msg := range msg.Messages {
-		g.genMethodForMsg(gf, genFuncs, msg)
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_fuzz_test.go", f.GoImportPath)
+	g.genFileHeader(gf, f)
+
+	for _, msg := range msgs {
+		gf.P("func FuzzHashPB_", msg.GoIdent.GoName, "(f *", testingF, ") {")
+		gf.P(append([]any{fuzzConfFn, "(f, &", msg.GoIdent, "{}"}, append(g.conformanceOptions(), ")")...)...)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// genMapOrderTests generates a test file that checks that the digests of the messages of the file that contain maps
+// don't depend on the order in which the map entries were inserted.
+func (g *codegen) genMapOrderTests(f *protogen.File, genFuncs map[string]*protogen.Message) {
+	var msgs []*protogen.Message
+	for _, msg := range collectFileMessages(f, genFuncs) {
+		if reachesMap(msg.Desc, make(map[protoreflect.FullName]struct{})) {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return
+	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_map_order_test.go", f.GoImportPath)
+	g.genFileHeader(gf, f)
+
+	for _, msg := range msgs {
+		gf.P("func TestHashPBMapOrder_", msg.GoIdent.GoName, "(t *", testingT, ") {")
+		gf.P(append([]any{checkMapOrderFn, "(t, &", msg.GoIdent, "{}"}, append(g.conformanceOptions(), ")")...)...)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// reachesMap reports whether the message or any of the messages reachable from it has a map field.
+func reachesMap(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]struct{}) bool {
+	if _, ok := seen[md.FullName()]; ok {
+		return false
+	}
+	seen[md.FullName()] = struct{}{}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			return true
+		}
+
+		if fd.Message() != nil && reachesMap(fd.Message(), seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// genBenchmarks generates a test file with benchmarks that hash populated instances of the messages of the file.
+func (g *codegen) genBenchmarks(f *protogen.File, genFuncs map[string]*protogen.Message) {
+	msgs := collectFileMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return
 	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_bench_test.go", f.GoImportPath)
+	g.genFileHeader(gf, f)
+
+	for _, msg := range msgs {
+		gf.P("func BenchmarkHashPB_", msg.GoIdent.GoName, "(b *", testingB, ") {")
+		gf.P(append([]any{benchHashPBFn, "(b, &", msg.GoIdent, "{}"}, append(g.conformanceOptions(), ")")...)...)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// genGoldenTests generates a test file that checks the HashPB methods of the file against digests computed at generation time.
+func (g *codegen) genGoldenTests(f *protogen.File, genFuncs map[string]*protogen.Message) error {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_test.go", f.GoImportPath)
+	g.genFileHeader(gf, f)
+
+	for _, msg := range msgs {
+		golden, err := testgen.MakeGolden(dynamicpb.NewMessage(msg.Desc), g.hashOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to compute golden digests for %s: %w", msg.Desc.FullName(), err)
+		}
+
+		gf.P("func TestHashPBGolden_", msg.GoIdent.GoName, "(t *", testingT, ") {")
+		gf.P(checkGoldenFn, "(t, &", msg.GoIdent, "{}, []", goldenType, "{")
+		for _, gv := range golden {
+			gf.P("{Seed: ", gv.Seed, ", XXHash: ", fmt.Sprintf("0x%016x", gv.XXHash), ", SHA256: \"", gv.SHA256, "\"},")
+		}
+		gf.P("})")
+		gf.P("}")
+		gf.P()
+	}
+
+	return nil
+}
+
+// genVectors generates a JSON file containing test vectors for the messages of the file.
+// The vectors can be used to check other implementations of the hashing scheme.
+func (g *codegen) genVectors(f *protogen.File, genFuncs map[string]*protogen.Message) error {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	corpus := testgen.VectorCorpus{}
+	for _, msg := range msgs {
+		vectors, err := testgen.MakeVectors(dynamicpb.NewMessage(msg.Desc), g.hashOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to compute test vectors for %s: %w", msg.Desc.FullName(), err)
+		}
+		corpus.Vectors = append(corpus.Vectors, vectors...)
+	}
+
+	out, err := json.MarshalIndent(corpus, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal test vectors for %s: %w", f.Desc.Path(), err)
+	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_vectors.json", f.GoImportPath)
+	_, err = gf.Write(append(out, '\n'))
+	return err
+}
+
+// genSpec generates a JSON file describing how the messages of the file (and the messages they reference) are hashed.
+func (g *codegen) genSpec(f *protogen.File, genFuncs map[string]*protogen.Message) error {
+	msgs := g.testableMessages(f, genFuncs)
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	roots := make([]protoreflect.MessageDescriptor, len(msgs))
+	for i, msg := range msgs {
+		roots[i] = msg.Desc
+	}
+
+	out, err := json.MarshalIndent(spec.Build(roots...), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec for %s: %w", f.Desc.Path(), err)
+	}
+
+	gf := g.NewGeneratedFile(f.GeneratedFilenamePrefix+"_hashpb_spec.json", f.GoImportPath)
+	_, err = gf.Write(append(out, '\n'))
+	return err
 }
@@ -4,16 +4,26 @@
 package generator_test
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"hash"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
 	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
 	"github.com/cespare/xxhash/v2"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -21,14 +31,10 @@ import (
 
 var sink byte
 
-type Hashable interface {
-	HashPB(hash.Hash, map[string]struct{})
-}
-
 func TestHashPB(t *testing.T) {
 	testCases := []struct {
 		name  string
-		input Hashable
+		input hashpb.Hashable
 	}{
 		{
 			name:  "nil",
@@ -87,12 +93,12 @@ func TestHashPB(t *testing.T) {
 func TestIgnore(t *testing.T) {
 	testCases := []struct {
 		name   string
-		input  func() Hashable
+		input  func() hashpb.Hashable
 		ignore map[string]struct{}
 	}{
 		{
 			name: "oneOfField",
-			input: func() Hashable {
+			input: func() hashpb.Hashable {
 				m := mkTestAllTypesMsg()
 				m.NestedType = &pb.TestAllTypes_SingleNestedEnum{
 					SingleNestedEnum: pb.TestAllTypes_BAZ,
@@ -105,7 +111,7 @@ func TestIgnore(t *testing.T) {
 		},
 		{
 			name: "individualFields",
-			input: func() Hashable {
+			input: func() hashpb.Hashable {
 				m := mkTestAllTypesMsg()
 				m.SingleTimestamp = timestamppb.Now()
 				m.MapBoolString = map[bool]string{false: "foo"}
@@ -147,7 +153,106 @@ func TestIgnore(t *testing.T) {
 	}
 }
 
-func sum64(m Hashable, ignore map[string]struct{}) uint64 {
+func TestVectors(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "pb", "all_types_hashpb_vectors.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test vectors: %v", err)
+	}
+
+	var corpus hashpbtest.VectorCorpus
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		t.Fatalf("Failed to unmarshal test vectors: %v", err)
+	}
+
+	for i, v := range corpus.Vectors {
+		v := v
+		t.Run(fmt.Sprintf("%s_%d", v.Type, i), func(t *testing.T) {
+			mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(v.Type))
+			if err != nil {
+				t.Fatalf("Failed to find message type: %v", err)
+			}
+
+			m := mt.New().Interface()
+			if err := proto.Unmarshal(v.Input, m); err != nil {
+				t.Fatalf("Failed to unmarshal input: %v", err)
+			}
+
+			ignore := make(map[string]struct{}, len(v.Ignore))
+			for _, fn := range v.Ignore {
+				ignore[fn] = struct{}{}
+			}
+
+			if have := fmt.Sprintf("%016x", sum64(m.(hashpb.Hashable), ignore)); have != v.XXHash {
+				t.Errorf("xxhash digest mismatch: want=%s have=%s", v.XXHash, have)
+			}
+
+			sha := sha256.New()
+			m.(hashpb.Hashable).HashPB(sha, ignore)
+			if have := hex.EncodeToString(sha.Sum(nil)); have != v.SHA256 {
+				t.Errorf("sha256 digest mismatch: want=%s have=%s", v.SHA256, have)
+			}
+		})
+	}
+}
+
+func TestSum64HashPB(t *testing.T) {
+	ignore := map[string]struct{}{"cerbos.hashpb.test.TestAllTypes.single_string": {}}
+	for _, m := range []*pb.TestAllTypes{nil, {}, mkTestAllTypesMsg()} {
+		for _, ig := range []map[string]struct{}{nil, ignore} {
+			if want, have := sum64(m, ig), m.Sum64HashPB(ig); want != have {
+				t.Errorf("Digest mismatch: HashPB=%d Sum64HashPB=%d", want, have)
+			}
+		}
+	}
+}
+
+func TestAppendHashPB(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "key:"...)
+	for _, m := range []*pb.TestAllTypes{nil, {}, mkTestAllTypesMsg()} {
+		want, err := hashpb.Sum([]byte("key:"), m)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		have := m.AppendHashPB(buf, nil)
+		if !bytes.Equal(want, have) {
+			t.Errorf("Digest mismatch: Sum=%x AppendHashPB=%x", want, have)
+		}
+
+		if &have[0] != &buf[:1][0] {
+			t.Error("Expected digest to be appended to the existing buffer")
+		}
+	}
+}
+
+func TestWriteHashPB(t *testing.T) {
+	m := mkNestedTestAllTypesMsg(2)
+
+	var buf bytes.Buffer
+	if err := m.WriteHashPB(&buf, nil); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	if want, have := sum64(m, nil), xxhash.Sum64(buf.Bytes()); want != have {
+		t.Errorf("Digest mismatch: HashPB=%d WriteHashPB=%d", want, have)
+	}
+
+	errWrite := errors.New("write failed")
+	if err := m.WriteHashPB(failingWriter{err: errWrite}, nil); !errors.Is(err, errWrite) {
+		t.Errorf("Expected write error, got %v", err)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func sum64(m hashpb.Hashable, ignore map[string]struct{}) uint64 {
 	h := xxhash.New()
 	m.HashPB(h, ignore)
 	return h.Sum64()
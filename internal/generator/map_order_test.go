@@ -0,0 +1,35 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenMapOrderTests(t *testing.T) {
+	files := generate(t, "paths=source_relative,normalize_unicode=true,gen_map_order_tests=true")
+
+	tests, ok := files["internal/pb/all_types_hashpb_map_order_test.go"]
+	if !ok {
+		t.Fatal("Expected a map order test file to be generated")
+	}
+
+	for _, want := range []string{
+		"func TestHashPBMapOrder_TestAllTypes(t *testing.T) {",
+		"hashpbtest.CheckMapOrder(t, &TestAllTypes{}, hashpb.WithNormalizeUnicode())",
+		"hashpbtest.CheckMapOrder(t, &NestedTestAllTypes{}, hashpb.WithNormalizeUnicode())",
+	} {
+		if !strings.Contains(tests, want) {
+			t.Errorf("Expected map order tests to contain %q", want)
+		}
+	}
+
+	// messages that can't contain maps are left out.
+	for _, unwanted := range []string{"NoFields", "TestAllTypes_NestedMessage"} {
+		if strings.Contains(tests, "TestHashPBMapOrder_"+unwanted+"(") {
+			t.Errorf("Unexpected map order test for %s", unwanted)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNoIgnore(t *testing.T) {
+	files := generate(t, "paths=source_relative,no_ignore=true")
+
+	if !strings.Contains(files["internal/pb/all_types_hashpb.pb.go"], "func (m *TestAllTypes) HashPBNoIgnore(hasher hash.Hash) {") {
+		t.Error("Expected HashPBNoIgnore method to be generated")
+	}
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	start := strings.Index(helpers, "func cerbos_hashpb_test_TestAllTypes_hashpb_sum_noignore(")
+	if start < 0 {
+		t.Fatal("Expected no-ignore helper to be generated")
+	}
+
+	helper := helpers[start:]
+	helper = helper[:strings.Index(helper, "\n}\n")]
+	if strings.Contains(helper, "ignore[") || strings.Contains(helper, "Ignored(") {
+		t.Errorf("Expected no-ignore helper not to check the ignore set:\n%s", helper)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUnicode(t *testing.T) {
+	files := generate(t, "paths=source_relative,objecthash=true,gen_conformance_tests=true,gen_fuzz_tests=true,normalize_unicode=true")
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	for _, want := range []string{
+		"protowire.AppendString(nil, norm.NFC.String(m.GetSingleString()))",
+		"objecthash.String(norm.NFC.String(m.SingleString))",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("Expected helpers to contain %q", want)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"internal/pb/all_types_hashpb_conformance_test.go": "hashpbtest.CheckConformance(t, &TestAllTypes{}, hashpb.WithNormalizeUnicode())",
+		"internal/pb/all_types_hashpb_fuzz_test.go":        "hashpbtest.FuzzConformance(f, &TestAllTypes{}, hashpb.WithNormalizeUnicode())",
+	} {
+		if !strings.Contains(files[name], want) {
+			t.Errorf("Expected %s to contain %q", name, want)
+		}
+	}
+}
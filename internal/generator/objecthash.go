@@ -0,0 +1,209 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"sort"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	objectHashFuncSuffix = "_hashpb_objecthash"
+	objectHashImp        = protogen.GoImportPath("github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash")
+)
+
+var (
+	objectHashBool   = objectHashImp.Ident("Bool")
+	objectHashBytes  = objectHashImp.Ident("Bytes")
+	objectHashDict   = objectHashImp.Ident("Dict")
+	objectHashFloat  = objectHashImp.Ident("Float")
+	objectHashInt    = objectHashImp.Ident("Int")
+	objectHashList   = objectHashImp.Ident("List")
+	objectHashSet    = objectHashImp.Ident("Set")
+	objectHashSize   = objectHashImp.Ident("Size")
+	objectHashString = objectHashImp.Ident("String")
+	objectHashUint   = objectHashImp.Ident("Uint")
+)
+
+func (g *codegen) objectHashFuncName(md protoreflect.MessageDescriptor) string {
+	fqn := nonIdentifierChars.ReplaceAllLiteralString(string(md.FullName()), "_")
+	return fqn + objectHashFuncSuffix + g.unit.suffix()
+}
+
+// genObjectHashHelperForMsg generates a function that calculates the objecthash-compatible digest of the message.
+// Only populated fields are added to the dictionary, which is keyed by field number.
+func (g *codegen) genObjectHashHelperForMsg(gf *protogen.GeneratedFile, msg *protogen.Message) {
+	fields := make([]*protogen.Field, len(msg.Fields))
+	copy(fields, msg.Fields)
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Desc.Number() < fields[j].Desc.Number()
+	})
+
+	gf.P("func ", g.objectHashFuncName(msg.Desc), "(", receiverIdent, " *", msg.GoIdent, ", ignore map[string]struct{}) [", objectHashSize, "]byte {")
+	gf.P("var d ", objectHashDict)
+	if len(fields) == 0 {
+		gf.P("return d.Sum()")
+		gf.P("}")
+		return
+	}
+
+	gf.P("if ", receiverIdent, " != nil {")
+
+	oneOfs := make(map[string]struct{})
+
+	for _, field := range fields {
+		if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+			if _, ok := oneOfs[field.Oneof.GoName]; !ok && !g.config.oneofIgnored(field.Oneof.Desc) {
+				g.genObjectHashOneOfField(gf, field)
+				oneOfs[field.Oneof.GoName] = struct{}{}
+			}
+		} else if !g.config.ignored(field.Desc) {
+			g.genObjectHashField(gf, field)
+		}
+	}
+
+	gf.P("}")
+	gf.P("return d.Sum()")
+	gf.P("}")
+}
+
+func (g *codegen) genObjectHashField(gf *protogen.GeneratedFile, field *protogen.Field) {
+	fieldName := g.fieldExpr(field)
+	key := fmt.Sprintf("(%d)", field.Desc.Number())
+	opaque := g.opaque(field.Parent)
+
+	presence := objectHashPresence(field.Desc, fieldName)
+	if opaque && field.Desc.HasPresence() {
+		presence = []any{fieldAccess("Has" + field.GoName + "()")}
+	}
+
+	cond := append(append([]any{"if "}, ignoreCond(field.Desc)...), " && ")
+	gf.P(append(cond, append(presence, " {")...)...)
+
+	switch {
+	case field.Desc.IsList() && hashpb.Unordered(field.Desc):
+		gf.P("var s ", objectHashSet)
+		gf.P("for _, v := range ", fieldName, " {")
+		gf.P(append([]any{"s.Add("}, append(g.objectHashValue(field.Desc, "v"), ")")...)...)
+		gf.P("}")
+		gf.P("d.Add(", objectHashInt, key, ", s.Sum())")
+	case field.Desc.IsList():
+		gf.P("var l ", objectHashList)
+		fieldName := genSortedList(gf, field, fieldName)
+		if k := sampleEvery(field.Desc); k > 0 {
+			gf.P("l.Add(", objectHashInt, "(int64(len(", fieldName, "))))")
+			gf.P("for i := 0; i < len(", fieldName, "); i += ", k, " {")
+			gf.P("v := ", fieldName, "[i]")
+		} else {
+			gf.P("for _, v := range ", fieldName, " {")
+		}
+		gf.P(append([]any{"l.Add("}, append(g.objectHashValue(field.Desc, "v"), ")")...)...)
+		gf.P("}")
+		gf.P("d.Add(", objectHashInt, key, ", l.Sum())")
+	case field.Desc.IsMap():
+		gf.P("var md ", objectHashDict)
+		gf.P("for k, v := range ", fieldName, " {")
+		parts := []any{"md.Add("}
+		parts = append(parts, g.objectHashValue(field.Desc.MapKey(), "k")...)
+		parts = append(parts, ", ")
+		parts = append(parts, g.objectHashValue(field.Desc.MapValue(), "v")...)
+		gf.P(append(parts, ")")...)
+		gf.P("}")
+		gf.P("d.Add(", objectHashInt, key, ", md.Sum())")
+	default:
+		value := fieldName
+		if !opaque && field.Desc.HasPresence() && field.Desc.Message() == nil && field.Desc.Kind() != protoreflect.BytesKind {
+			value = "*" + fieldName
+		}
+		gf.P(append([]any{"d.Add(", objectHashInt, key, ", "}, append(g.objectHashValue(field.Desc, value), ")")...)...)
+	}
+
+	gf.P("}")
+}
+
+func (g *codegen) genObjectHashOneOfField(gf *protogen.GeneratedFile, field *protogen.Field) {
+	opaque := g.opaque(field.Parent)
+
+	gf.P("if _, ok := ignore[\"", field.Desc.ContainingOneof().FullName(), "\"]; !ok {")
+	if opaque {
+		gf.P("switch ", fieldAccess("Which"+field.Oneof.GoName+"()"), " {")
+	} else {
+		gf.P("switch t := ", fieldAccess(field.Oneof.GoName), ".(type) {")
+	}
+
+	for _, f := range field.Oneof.Fields {
+		if g.config.ignored(f.Desc) {
+			continue
+		}
+
+		value := "t." + f.GoName
+		if opaque {
+			gf.P("case ", oneofCase(f), ":")
+			value = g.fieldExpr(f)
+		} else {
+			gf.P("case *", f.GoIdent, ":")
+		}
+		gf.P(append([]any{"d.Add(", objectHashInt, fmt.Sprintf("(%d), ", f.Desc.Number())}, append(g.objectHashValue(f.Desc, value), ")")...)...)
+	}
+	gf.P("}")
+	gf.P("}")
+}
+
+// objectHashPresence returns the condition that is true when the field is populated, following the rules of
+// protoreflect.Message.Has.
+func objectHashPresence(fd protoreflect.FieldDescriptor, fieldName string) []any {
+	switch {
+	case fd.IsList(), fd.IsMap():
+		return []any{"len(", fieldName, ") > 0"}
+	case fd.HasPresence():
+		return []any{fieldName, " != nil"}
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return []any{fieldName}
+	case protoreflect.StringKind:
+		return []any{fieldName, ` != ""`}
+	case protoreflect.BytesKind:
+		return []any{"len(", fieldName, ") > 0"}
+	case protoreflect.FloatKind:
+		return []any{float32BitsFn, "(", fieldName, ") != 0"}
+	case protoreflect.DoubleKind:
+		return []any{float64BitsFn, "(", fieldName, ") != 0"}
+	default:
+		return []any{fieldName, " != 0"}
+	}
+}
+
+func (g *codegen) objectHashValue(fd protoreflect.FieldDescriptor, value string) []any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return []any{objectHashBool, "(", value, ")"}
+	case protoreflect.EnumKind, protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return []any{objectHashInt, "(int64(", value, "))"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return []any{objectHashInt, "(", value, ")"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return []any{objectHashUint, "(uint64(", value, "))"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return []any{objectHashUint, "(", value, ")"}
+	case protoreflect.FloatKind:
+		return []any{objectHashFloat, "(float64(", value, "))"}
+	case protoreflect.DoubleKind:
+		return []any{objectHashFloat, "(", value, ")"}
+	case protoreflect.StringKind:
+		return append(append([]any{objectHashString, "("}, g.stringValue(fd, value)...), ")")
+	case protoreflect.BytesKind:
+		return []any{objectHashBytes, "(", value, ")"}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return []any{g.objectHashFuncName(fd.Message()), "(", value, ", ignore)"}
+	default:
+		panic(fmt.Errorf("unhandled field kind %s", fd.Kind().String()))
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestMethodOrderIsIndependentOfDeclarationOrder(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	b := fds.File[1]
+	nested := proto.Clone(b.MessageType[0]).(*descriptorpb.DescriptorProto)
+	nested.Name = proto.String("D")
+	c := proto.Clone(b.MessageType[0]).(*descriptorpb.DescriptorProto)
+	c.Name = proto.String("C")
+	c.NestedType = []*descriptorpb.DescriptorProto{nested}
+	c.Field = append(c.Field, &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("d"),
+		JsonName: proto.String("d"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".b.C.D"),
+	})
+	b.MessageType = append(b.MessageType, c)
+
+	const opt = "paths=source_relative,split_proto_packages=true,registry=true"
+	want := generateFrom(t, fds, nil, opt)
+
+	b.MessageType[0], b.MessageType[1] = b.MessageType[1], b.MessageType[0]
+	have := generateFrom(t, fds, nil, opt)
+
+	for name, content := range want {
+		if have[name] != content {
+			t.Errorf("Output of %s depends on the declaration order:\nwant:\n%s\nhave:\n%s", name, content, have[name])
+		}
+	}
+
+	methods := want["b/b_hashpb.pb.go"]
+	if i, j, k := strings.Index(methods, "func (m *B) HashPB"), strings.Index(methods, "func (m *C) HashPB"), strings.Index(methods, "func (m *C_D) HashPB"); i < 0 || i > j || j > k {
+		t.Errorf("Expected methods to be sorted by full name:\n%s", methods)
+	}
+}
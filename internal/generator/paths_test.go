@@ -0,0 +1,71 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutputPaths(t *testing.T) {
+	testCases := []struct {
+		opt string
+		dir string
+	}{
+		{opt: "paths=source_relative", dir: "internal/pb"},
+		{opt: "paths=import", dir: "github.com/cerbos/protoc-gen-go-hashpb/internal/pb"},
+		{opt: "module=github.com/cerbos/protoc-gen-go-hashpb", dir: "internal/pb"},
+		{opt: "module=github.com/cerbos/protoc-gen-go-hashpb/internal", dir: "pb"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.opt, func(t *testing.T) {
+			files := generate(t, tc.opt)
+			for _, name := range []string{"hashpb_helpers.pb.go", "all_types_hashpb.pb.go"} {
+				if _, ok := files[tc.dir+"/"+name]; !ok {
+					t.Errorf("Expected %s/%s to be generated: %v", tc.dir, name, keys(files))
+				}
+			}
+		})
+	}
+}
+
+func TestModulePrefix(t *testing.T) {
+	const module = "module=github.com/cerbos/protoc-gen-go-hashpb"
+
+	for _, opt := range []string{
+		module + ",registry=true,gen_conformance_tests=true,gen_tests=true,gen_vectors=true,gen_fuzz_tests=true,gen_map_order_tests=true,gen_benchmarks=true,gen_spec=true",
+		module + ",single_file=true",
+		module + ",per_file_helpers=true",
+	} {
+		for name := range generate(t, opt) {
+			if !strings.HasPrefix(name, "internal/pb/") {
+				t.Errorf("Expected module prefix to be stripped from %s with %s", name, opt)
+			}
+		}
+	}
+
+	for _, opt := range []string{"module=example.com", "module=example.com,split_proto_packages=true", "module=example.com,single_file=true"} {
+		files := generateFrom(t, sharedPackageDescriptors(), []string{"a/a.proto", "b/b.proto"}, opt)
+		if len(files) == 0 {
+			t.Errorf("Expected files to be generated with %s", opt)
+		}
+
+		for name := range files {
+			if !strings.HasPrefix(name, "shared/") {
+				t.Errorf("Expected module prefix to be stripped from %s with %s", name, opt)
+			}
+		}
+	}
+}
+
+func keys(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	return names
+}
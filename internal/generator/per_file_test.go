@@ -0,0 +1,56 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func TestPerFileHelpers(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	opt := "paths=source_relative,per_file_helpers=true,registry=true"
+
+	both := generateFrom(t, fds, nil, opt)
+	if len(both) != 2 {
+		t.Errorf("Expected two files: %v", keys(both))
+	}
+
+	for name, want := range map[string][]string{
+		"a/a_hashpb.pb.go": {"func b_B_hashpb_sum_a_a(", "func a_A_hashpb_sum_a_a(", "func (m *A) HashPB("},
+		"b/b_hashpb.pb.go": {"func b_B_hashpb_sum_b_b(", "func (m *B) HashPB("},
+	} {
+		have := both[name]
+		for _, w := range want {
+			if !strings.Contains(have, w) {
+				t.Errorf("Expected %s to contain %q", name, w)
+			}
+		}
+
+		if _, err := parser.ParseFile(token.NewFileSet(), name, have, parser.AllErrors); err != nil {
+			t.Errorf("Generated file %s is invalid: %v", name, err)
+		}
+	}
+
+	for _, file := range []string{"a/a.proto", "b/b.proto"} {
+		for name, have := range generateFrom(t, fds, []string{file}, opt) {
+			if both[name] != have {
+				t.Errorf("%s differs when %s is generated on its own", name, file)
+			}
+		}
+	}
+
+	resp, err := generator.Run(request(t, "per_file_helpers=true,single_file=true"))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Error("Expected error when combined with single_file")
+	}
+}
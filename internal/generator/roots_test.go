@@ -0,0 +1,53 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func TestRoots(t *testing.T) {
+	files := generate(t, "paths=source_relative,roots=cerbos.hashpb.test.TestAllTypesOptional")
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	for _, want := range []string{
+		"func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(",
+		"func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(",
+		"func google_protobuf_Timestamp_hashpb_sum(",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("Expected helpers to contain %q", want)
+		}
+	}
+
+	if strings.Contains(helpers, "func cerbos_hashpb_test_TestAllTypes_hashpb_sum(") {
+		t.Error("Expected no helper for unreachable message")
+	}
+
+	methods := files["internal/pb/all_types_hashpb.pb.go"]
+	if !strings.Contains(methods, "func (m *TestAllTypesOptional_NestedMessage) HashPB(") {
+		t.Error("Expected method for reachable message")
+	}
+
+	if strings.Contains(methods, "func (m *TestAllTypes) HashPB(") {
+		t.Error("Expected no method for unreachable message")
+	}
+
+	multi := generate(t, "paths=source_relative,roots=cerbos.hashpb.test.TestAllTypesOptional,roots=cerbos.hashpb.test.TestAllTypes")
+	if !strings.Contains(multi["internal/pb/all_types_hashpb.pb.go"], "func (m *TestAllTypes) HashPB(") {
+		t.Error("Expected method for second root")
+	}
+
+	resp, err := generator.Run(request(t, "roots=cerbos.hashpb.test.Missing"))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Error("Expected error for unknown root")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSharedGoPackage(t *testing.T) {
+	fds := sharedPackageDescriptors()
+
+	both := generateFrom(t, fds, nil, "paths=source_relative")
+	onlyA := generateFrom(t, fds, []string{"a/a.proto"}, "paths=source_relative")
+	onlyB := generateFrom(t, fds, []string{"b/b.proto"}, "paths=source_relative")
+
+	if _, ok := both["a/hashpb_helpers.pb.go"]; ok {
+		t.Error("Unexpected unsuffixed helpers file")
+	}
+
+	for name, want := range map[string]string{
+		"a/hashpb_helpers_a.pb.go": "func b_B_hashpb_sum_a(",
+		"b/hashpb_helpers_b.pb.go": "func b_B_hashpb_sum_b(",
+	} {
+		have, ok := both[name]
+		if !ok {
+			t.Errorf("Expected %s to be generated: %v", name, keys(both))
+			continue
+		}
+
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected %s to contain %q", name, want)
+		}
+
+		if _, err := parser.ParseFile(token.NewFileSet(), name, have, parser.AllErrors); err != nil {
+			t.Errorf("Generated file %s is invalid: %v", name, err)
+		}
+	}
+
+	// the output must not depend on whether the packages are generated together, as long as the generator can see
+	// both packages in the request.
+	for name, have := range onlyA {
+		if both[name] != have {
+			t.Errorf("%s differs when a/a.proto is generated on its own", name)
+		}
+	}
+
+	for name, have := range onlyB {
+		if both[name] != have {
+			t.Errorf("%s differs when b/b.proto is generated on its own", name)
+		}
+	}
+}
+
+func TestSplitProtoPackages(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	fds.File = fds.File[1:] // only b/b.proto, so the generator can't see that the Go package is shared
+
+	files := generateFrom(t, fds, nil, "paths=source_relative,split_proto_packages=true")
+	if _, ok := files["b/hashpb_helpers_b.pb.go"]; !ok {
+		t.Errorf("Expected suffixed helpers file: %v", keys(files))
+	}
+
+	files = generateFrom(t, fds, nil, "paths=source_relative")
+	if _, ok := files["b/hashpb_helpers.pb.go"]; !ok {
+		t.Errorf("Expected unsuffixed helpers file: %v", keys(files))
+	}
+}
+
+// sharedPackageDescriptors returns the descriptors of two files from different proto packages sharing a Go package.
+// The message in package a references the message in package b.
+func sharedPackageDescriptors() *descriptorpb.FileDescriptorSet {
+	opts := &descriptorpb.FileOptions{GoPackage: proto.String("example.com/shared;shared")}
+	return &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			{
+				Name:       proto.String("a/a.proto"),
+				Package:    proto.String("a"),
+				Syntax:     proto.String("proto3"),
+				Dependency: []string{"b/b.proto"},
+				Options:    opts,
+				MessageType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("A"),
+					Field: []*descriptorpb.FieldDescriptorProto{{
+						Name:     proto.String("b"),
+						JsonName: proto.String("b"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".b.B"),
+					}},
+				}},
+			},
+			{
+				Name:    proto.String("b/b.proto"),
+				Package: proto.String("b"),
+				Syntax:  proto.String("proto3"),
+				Options: opts,
+				MessageType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("B"),
+					Field: []*descriptorpb.FieldDescriptorProto{{
+						Name:     proto.String("x"),
+						JsonName: proto.String("x"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func generateFrom(t *testing.T, fds *descriptorpb.FileDescriptorSet, filesToGenerate []string, opt string) map[string]string {
+	t.Helper()
+
+	req, err := descset.Request(fds, filesToGenerate, opt)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("Generator failed: %s", resp.GetError())
+	}
+
+	files := make(map[string]string, len(resp.File))
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	return files
+}
@@ -0,0 +1,45 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestSingleFile(t *testing.T) {
+	files := generate(t, "paths=source_relative,registry=true,single_file=true,gen_conformance_tests=true")
+
+	for _, name := range []string{"internal/pb/hashpb_helpers.pb.go", "internal/pb/all_types_hashpb.pb.go"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("Unexpected file %s", name)
+		}
+	}
+
+	if _, ok := files["internal/pb/all_types_hashpb_conformance_test.go"]; !ok {
+		t.Error("Expected conformance tests to be generated separately")
+	}
+
+	content, ok := files["internal/pb/hashpb.pb.go"]
+	if !ok {
+		t.Fatal("Expected internal/pb/hashpb.pb.go to be generated")
+	}
+
+	for _, want := range []string{
+		"func cerbos_hashpb_test_TestAllTypes_hashpb_sum(",
+		"func (m *TestAllTypes) HashPB(",
+		"// Source: internal/pb/all_types.proto",
+		"func init() {",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q", want)
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "hashpb.pb.go", content, parser.AllErrors); err != nil {
+		t.Errorf("Generated file is invalid: %v", err)
+	}
+}
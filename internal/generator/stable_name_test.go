@@ -0,0 +1,38 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestStableName(t *testing.T) {
+	fds := sharedPackageDescriptors()
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, optionspb.E_Field, &optionspb.FieldOptions{StableName: "original"})
+	fds.File[1].MessageType[0].Field[0].Options = opts
+
+	files := generateFrom(t, fds, nil, "paths=source_relative,split_proto_packages=true,objecthash=true")
+	have := files["b/hashpb_helpers_b.pb.go"]
+
+	for _, want := range []string{
+		`if !hashpb.Ignored(ignore, "b.B.x", "b.B.original") {`,
+		`if !hashpb.Ignored(ignore, "b.B.x", "b.B.original") && `,
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, have)
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "b/hashpb_helpers_b.pb.go", have, parser.AllErrors); err != nil {
+		t.Errorf("Generated file is invalid: %v", err)
+	}
+}
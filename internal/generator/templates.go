@@ -0,0 +1,144 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Names of the templates that can be overridden using the templates parameter.
+const (
+	HelperTemplate = "helper.tmpl"
+	MethodTemplate = "method.tmpl"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// HelperData is the data passed to the helper template.
+type HelperData struct {
+	// Name is the name of the helper function.
+	Name string
+	// FullName is the fully-qualified name of the message.
+	FullName string
+	// GoType is the (qualified) Go type of the message.
+	GoType string
+	// Writer is the (qualified) io.Writer type of the hasher parameter.
+	Writer string
+	// Body is the code that writes the fields of the message (bound to m) to the hasher.
+	Body string
+
+	gf *protogen.GeneratedFile
+}
+
+// Import imports the Go package with the given import path into the generated file and returns the name by which the
+// template can refer to it, as in {{ .Import "fmt" }}.Sprintf. The path can't be the import path of the generated file.
+func (d HelperData) Import(path string) (string, error) {
+	return importPackage(d.gf, path)
+}
+
+// MethodData is the data passed to the method template.
+type MethodData struct {
+	// MethodName is the name of the method, HashPB unless renamed in the configuration file.
+	MethodName string
+	// FullName is the fully-qualified name of the message.
+	FullName string
+	// GoType is the Go type of the message.
+	GoType string
+	// Hash is the (qualified) hash.Hash type.
+	Hash string
+	// Helper is the name of the helper function that hashes the message.
+	Helper string
+
+	gf *protogen.GeneratedFile
+}
+
+// Import imports the Go package with the given import path into the generated file and returns the name by which the
+// template can refer to it, as in {{ .Import "fmt" }}.Sprintf. The path can't be the import path of the generated file.
+func (d MethodData) Import(path string) (string, error) {
+	return importPackage(d.gf, path)
+}
+
+// importPackage imports the package into the generated file, using a placeholder identifier to find the package name
+// chosen by protogen.
+func importPackage(gf *protogen.GeneratedFile, path string) (string, error) {
+	const placeholder = "_"
+	qualified := gf.QualifiedGoIdent(protogen.GoIdent{GoName: placeholder, GoImportPath: protogen.GoImportPath(path)})
+	if !strings.HasSuffix(qualified, "."+placeholder) {
+		return "", fmt.Errorf("cannot import %q into a file of the same package", path)
+	}
+
+	return strings.TrimSuffix(qualified, "."+placeholder), nil
+}
+
+// loadTemplates loads the default templates, replacing them with the templates of the same name in dir if it is set.
+func loadTemplates(dir string) (map[string]*template.Template, error) {
+	tmpls := make(map[string]*template.Template, 2)
+	for _, name := range []string{HelperTemplate, MethodTemplate} {
+		text, err := fs.ReadFile(defaultTemplates, "templates/"+name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read default template %s: %w", name, err)
+		}
+
+		if dir != "" {
+			override, err := os.ReadFile(filepath.Join(dir, name))
+			switch {
+			case err == nil:
+				text = override
+			case !errors.Is(err, fs.ErrNotExist):
+				return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+			}
+		}
+
+		tmpl, err := template.New(name).Parse(string(text))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		tmpls[name] = tmpl
+	}
+
+	return tmpls, nil
+}
+
+func (g *codegen) execTemplate(gf *protogen.GeneratedFile, name string, data any) error {
+	var buf bytes.Buffer
+	if err := g.templates[name].Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+
+	gf.P(strings.TrimRight(buf.String(), "\n"))
+	return nil
+}
+
+// printer is implemented by protogen.GeneratedFile and bufferPrinter.
+type printer interface {
+	P(v ...any)
+}
+
+// bufferPrinter collects generated code in a buffer, qualifying identifiers using the file the code will be written to.
+type bufferPrinter struct {
+	gf  *protogen.GeneratedFile
+	buf bytes.Buffer
+}
+
+func (b *bufferPrinter) P(v ...any) {
+	for _, x := range v {
+		if ident, ok := x.(protogen.GoIdent); ok {
+			b.buf.WriteString(b.gf.QualifiedGoIdent(ident))
+		} else {
+			fmt.Fprint(&b.buf, x)
+		}
+	}
+	b.buf.WriteByte('\n')
+}
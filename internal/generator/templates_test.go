@@ -0,0 +1,77 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
+)
+
+func TestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	method := `// {{ .MethodName }} hashes {{ .FullName }}
+func (m *{{ .GoType }}) {{ .MethodName }}(hasher {{ .Hash }}, ignore map[string]struct{}) {
+	{{ .Helper }}(m, hasher, ignore)
+}
+
+// {{ .MethodName }}Hex returns the hex-encoded hash of the message
+func (m *{{ .GoType }}) {{ .MethodName }}Hex(hasher {{ .Hash }}) string {
+	{{ .Helper }}(m, hasher, nil)
+	return {{ .Import "encoding/hex" }}.EncodeToString(hasher.Sum(nil))
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, generator.MethodTemplate), []byte(method), 0o600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	files := generate(t, "paths=source_relative,templates="+dir)
+	methods := files["internal/pb/all_types_hashpb.pb.go"]
+	if !strings.Contains(methods, "// HashPB hashes cerbos.hashpb.test.TestAllTypes\n") {
+		t.Error("Expected methods to be rendered using the custom template")
+	}
+
+	for _, want := range []string{`hex "encoding/hex"`, "return hex.EncodeToString(hasher.Sum(nil))"} {
+		if !strings.Contains(methods, want) {
+			t.Errorf("Expected methods to contain %q", want)
+		}
+	}
+
+	helpers := files["internal/pb/hashpb_helpers.pb.go"]
+	if !strings.Contains(helpers, "func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher io.Writer, ignore map[string]struct{}) {") {
+		t.Error("Expected helpers to be rendered using the default template")
+	}
+
+	for name, content := range files {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, content, parser.AllErrors); err != nil {
+			t.Errorf("Generated file %s is invalid: %v", name, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, generator.MethodTemplate), []byte(`{{ .Import "github.com/cerbos/protoc-gen-go-hashpb/internal/pb" }}`), 0o600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	if resp, err := generator.Run(request(t, "paths=source_relative,templates="+dir)); err != nil || resp.Error == nil {
+		t.Errorf("Expected error for importing the package of the generated file: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, generator.HelperTemplate), []byte("{{ .Name "), 0o600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	resp, err := generator.Run(request(t, "templates="+dir))
+	if err != nil {
+		t.Fatalf("Failed to run generator: %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Error("Expected error for invalid template")
+	}
+}
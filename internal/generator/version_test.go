@@ -0,0 +1,32 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package generator_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+)
+
+func TestVersionCheck(t *testing.T) {
+	for _, opt := range []string{"paths=source_relative", "paths=source_relative,per_file_helpers=true"} {
+		for name, content := range generate(t, opt) {
+			// the check is written to the files containing the helpers.
+			if !strings.HasSuffix(name, ".pb.go") || !strings.Contains(content, "_hashpb_sum(m *") {
+				continue
+			}
+
+			for _, want := range []string{
+				fmt.Sprintf("_ = hashpb.EnforceVersion(%d - hashpb.MinGenVersion)", hashpb.GenVersion),
+				fmt.Sprintf("_ = hashpb.EnforceVersion(hashpb.GenVersion - %d)", hashpb.GenVersion),
+			} {
+				if !strings.Contains(content, want) {
+					t.Errorf("Expected %s generated with %s to contain %q", name, opt, want)
+				}
+			}
+		}
+	}
+}
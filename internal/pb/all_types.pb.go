@@ -9,6 +9,7 @@
 package pb
 
 import (
+	_ "github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
@@ -216,7 +217,6 @@ type TestAllTypes struct {
 	// Nested messages
 	//
 	// Types that are assignable to NestedType:
-	//
 	//	*TestAllTypes_SingleNestedMessage
 	//	*TestAllTypes_SingleNestedEnum
 	NestedType isTestAllTypes_NestedType `protobuf_oneof:"nested_type"`
@@ -1086,6 +1086,110 @@ func (x *TestAllTypesOptional) GetSingleNestedMessage() *TestAllTypesOptional_Ne
 	return nil
 }
 
+// This proto tests the field options of protoc-gen-go-hashpb.
+type TestFieldOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Renamed            string                   `protobuf:"bytes,1,opt,name=renamed,proto3" json:"renamed,omitempty"`
+	Email              string                   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	DisplayName        string                   `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Tags               []string                 `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Samples            []int64                  `protobuf:"varint,5,rep,packed,name=samples,proto3" json:"samples,omitempty"`
+	ItemsById          []*TestFieldOptions_Item `protobuf:"bytes,6,rep,name=items_by_id,json=itemsById,proto3" json:"items_by_id,omitempty"`
+	SampledItemsByRank []*TestFieldOptions_Item `protobuf:"bytes,7,rep,name=sampled_items_by_rank,json=sampledItemsByRank,proto3" json:"sampled_items_by_rank,omitempty"`
+	ItemSet            []*TestFieldOptions_Item `protobuf:"bytes,8,rep,name=item_set,json=itemSet,proto3" json:"item_set,omitempty"`
+}
+
+func (x *TestFieldOptions) Reset() {
+	*x = TestFieldOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_pb_all_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestFieldOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFieldOptions) ProtoMessage() {}
+
+func (x *TestFieldOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_all_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFieldOptions.ProtoReflect.Descriptor instead.
+func (*TestFieldOptions) Descriptor() ([]byte, []int) {
+	return file_internal_pb_all_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TestFieldOptions) GetRenamed() string {
+	if x != nil {
+		return x.Renamed
+	}
+	return ""
+}
+
+func (x *TestFieldOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TestFieldOptions) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *TestFieldOptions) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TestFieldOptions) GetSamples() []int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *TestFieldOptions) GetItemsById() []*TestFieldOptions_Item {
+	if x != nil {
+		return x.ItemsById
+	}
+	return nil
+}
+
+func (x *TestFieldOptions) GetSampledItemsByRank() []*TestFieldOptions_Item {
+	if x != nil {
+		return x.SampledItemsByRank
+	}
+	return nil
+}
+
+func (x *TestFieldOptions) GetItemSet() []*TestFieldOptions_Item {
+	if x != nil {
+		return x.ItemSet
+	}
+	return nil
+}
+
 type TestAllTypes_NestedMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1097,7 +1201,7 @@ type TestAllTypes_NestedMessage struct {
 func (x *TestAllTypes_NestedMessage) Reset() {
 	*x = TestAllTypes_NestedMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_pb_all_types_proto_msgTypes[4]
+		mi := &file_internal_pb_all_types_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1110,7 +1214,7 @@ func (x *TestAllTypes_NestedMessage) String() string {
 func (*TestAllTypes_NestedMessage) ProtoMessage() {}
 
 func (x *TestAllTypes_NestedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_all_types_proto_msgTypes[4]
+	mi := &file_internal_pb_all_types_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1144,7 +1248,7 @@ type NoFields_NestedMsg struct {
 func (x *NoFields_NestedMsg) Reset() {
 	*x = NoFields_NestedMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_pb_all_types_proto_msgTypes[10]
+		mi := &file_internal_pb_all_types_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1157,7 +1261,7 @@ func (x *NoFields_NestedMsg) String() string {
 func (*NoFields_NestedMsg) ProtoMessage() {}
 
 func (x *NoFields_NestedMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_all_types_proto_msgTypes[10]
+	mi := &file_internal_pb_all_types_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1191,7 +1295,7 @@ type TestAllTypesOptional_NestedMessage struct {
 func (x *TestAllTypesOptional_NestedMessage) Reset() {
 	*x = TestAllTypesOptional_NestedMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_pb_all_types_proto_msgTypes[12]
+		mi := &file_internal_pb_all_types_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1204,7 +1308,7 @@ func (x *TestAllTypesOptional_NestedMessage) String() string {
 func (*TestAllTypesOptional_NestedMessage) ProtoMessage() {}
 
 func (x *TestAllTypesOptional_NestedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_all_types_proto_msgTypes[12]
+	mi := &file_internal_pb_all_types_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,6 +1331,69 @@ func (x *TestAllTypesOptional_NestedMessage) GetBb() int32 {
 	return 0
 }
 
+type TestFieldOptions_Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rank  int64  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (x *TestFieldOptions_Item) Reset() {
+	*x = TestFieldOptions_Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_pb_all_types_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestFieldOptions_Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFieldOptions_Item) ProtoMessage() {}
+
+func (x *TestFieldOptions_Item) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_all_types_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFieldOptions_Item.ProtoReflect.Descriptor instead.
+func (*TestFieldOptions_Item) Descriptor() ([]byte, []int) {
+	return file_internal_pb_all_types_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *TestFieldOptions_Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TestFieldOptions_Item) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TestFieldOptions_Item) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 var File_internal_pb_all_types_proto protoreflect.FileDescriptor
 
 var file_internal_pb_all_types_proto_rawDesc = []byte{
@@ -1242,7 +1409,9 @@ var file_internal_pb_all_types_proto_rawDesc = []byte{
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
 	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61,
-	0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x1e, 0x0a, 0x0c,
+	0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x68, 0x61, 0x73,
+	0x68, 0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0x62, 0x2f, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x1e, 0x0a, 0x0c,
 	0x54, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
 	0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x05, 0x52, 0x0b, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x12,
@@ -1676,14 +1845,48 @@ var file_internal_pb_all_types_proto_rawDesc = []byte{
 	0x6c, 0x5f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x73, 0x69,
 	0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x77, 0x72, 0x61, 0x70, 0x70,
 	0x65, 0x72, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x6e, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x27, 0x0a, 0x0a,
-	0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x4f,
-	0x4f, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x41, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
-	0x47, 0x41, 0x5a, 0x10, 0x02, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9e, 0x04, 0x0a,
+	0x10, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x0e, 0xd2, 0x80, 0x19, 0x0a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xd2, 0x80, 0x19, 0x02,
+	0x10, 0x01, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x29, 0x0a, 0x0c, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x06, 0xd2, 0x80, 0x19, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x42, 0x08, 0xd2, 0x80, 0x19, 0x04, 0x10, 0x01, 0x18, 0x01, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x20, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x03, 0x42, 0x06, 0xd2, 0x80, 0x19, 0x02, 0x20, 0x02, 0x52, 0x07, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x73, 0x12, 0x53, 0x0a, 0x0b, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x5f, 0x62, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x65, 0x72, 0x62,
+	0x6f, 0x73, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x49, 0x74, 0x65, 0x6d, 0x42, 0x08, 0xd2, 0x80, 0x19, 0x04, 0x2a, 0x02, 0x69, 0x64, 0x52, 0x09,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x79, 0x49, 0x64, 0x12, 0x6a, 0x0a, 0x15, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x72, 0x61,
+	0x6e, 0x6b, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x65, 0x72, 0x62, 0x6f,
+	0x73, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x49,
+	0x74, 0x65, 0x6d, 0x42, 0x0c, 0xd2, 0x80, 0x19, 0x08, 0x20, 0x02, 0x2a, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x52, 0x12, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x42,
+	0x79, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x4c, 0x0a, 0x08, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x65, 0x72, 0x62, 0x6f, 0x73,
+	0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x74,
+	0x65, 0x6d, 0x42, 0x06, 0xd2, 0x80, 0x19, 0x02, 0x30, 0x01, 0x52, 0x07, 0x69, 0x74, 0x65, 0x6d,
+	0x53, 0x65, 0x74, 0x1a, 0x48, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12,
+	0x1c, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06,
+	0xd2, 0x80, 0x19, 0x02, 0x10, 0x01, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x2a, 0x27, 0x0a,
+	0x0a, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x07, 0x0a, 0x03, 0x47,
+	0x4f, 0x4f, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x41, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a,
+	0x03, 0x47, 0x41, 0x5a, 0x10, 0x02, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1699,7 +1902,7 @@ func file_internal_pb_all_types_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_pb_all_types_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_internal_pb_all_types_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_internal_pb_all_types_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_internal_pb_all_types_proto_goTypes = []interface{}{
 	(GlobalEnum)(0),                      // 0: cerbos.hashpb.test.GlobalEnum
 	(TestAllTypes_NestedEnum)(0),         // 1: cerbos.hashpb.test.TestAllTypes.NestedEnum
@@ -1708,82 +1911,87 @@ var file_internal_pb_all_types_proto_goTypes = []interface{}{
 	(*NestedTestAllTypes)(nil),           // 4: cerbos.hashpb.test.NestedTestAllTypes
 	(*NoFields)(nil),                     // 5: cerbos.hashpb.test.NoFields
 	(*TestAllTypesOptional)(nil),         // 6: cerbos.hashpb.test.TestAllTypesOptional
-	(*TestAllTypes_NestedMessage)(nil),   // 7: cerbos.hashpb.test.TestAllTypes.NestedMessage
-	nil,                                  // 8: cerbos.hashpb.test.TestAllTypes.MapStringStringEntry
-	nil,                                  // 9: cerbos.hashpb.test.TestAllTypes.MapUint64StringEntry
-	nil,                                  // 10: cerbos.hashpb.test.TestAllTypes.MapInt32StringEntry
-	nil,                                  // 11: cerbos.hashpb.test.TestAllTypes.MapBoolStringEntry
-	nil,                                  // 12: cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry
-	(*NoFields_NestedMsg)(nil),           // 13: cerbos.hashpb.test.NoFields.NestedMsg
-	nil,                                  // 14: cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry
-	(*TestAllTypesOptional_NestedMessage)(nil), // 15: cerbos.hashpb.test.TestAllTypesOptional.NestedMessage
-	(*anypb.Any)(nil),                          // 16: google.protobuf.Any
-	(*durationpb.Duration)(nil),                // 17: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),              // 18: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),                    // 19: google.protobuf.Struct
-	(*structpb.Value)(nil),                     // 20: google.protobuf.Value
-	(*wrapperspb.Int64Value)(nil),              // 21: google.protobuf.Int64Value
-	(*wrapperspb.Int32Value)(nil),              // 22: google.protobuf.Int32Value
-	(*wrapperspb.DoubleValue)(nil),             // 23: google.protobuf.DoubleValue
-	(*wrapperspb.FloatValue)(nil),              // 24: google.protobuf.FloatValue
-	(*wrapperspb.UInt64Value)(nil),             // 25: google.protobuf.UInt64Value
-	(*wrapperspb.UInt32Value)(nil),             // 26: google.protobuf.UInt32Value
-	(*wrapperspb.StringValue)(nil),             // 27: google.protobuf.StringValue
-	(*wrapperspb.BoolValue)(nil),               // 28: google.protobuf.BoolValue
-	(*wrapperspb.BytesValue)(nil),              // 29: google.protobuf.BytesValue
+	(*TestFieldOptions)(nil),             // 7: cerbos.hashpb.test.TestFieldOptions
+	(*TestAllTypes_NestedMessage)(nil),   // 8: cerbos.hashpb.test.TestAllTypes.NestedMessage
+	nil,                                  // 9: cerbos.hashpb.test.TestAllTypes.MapStringStringEntry
+	nil,                                  // 10: cerbos.hashpb.test.TestAllTypes.MapUint64StringEntry
+	nil,                                  // 11: cerbos.hashpb.test.TestAllTypes.MapInt32StringEntry
+	nil,                                  // 12: cerbos.hashpb.test.TestAllTypes.MapBoolStringEntry
+	nil,                                  // 13: cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry
+	(*NoFields_NestedMsg)(nil),           // 14: cerbos.hashpb.test.NoFields.NestedMsg
+	nil,                                  // 15: cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry
+	(*TestAllTypesOptional_NestedMessage)(nil), // 16: cerbos.hashpb.test.TestAllTypesOptional.NestedMessage
+	(*TestFieldOptions_Item)(nil),              // 17: cerbos.hashpb.test.TestFieldOptions.Item
+	(*anypb.Any)(nil),                          // 18: google.protobuf.Any
+	(*durationpb.Duration)(nil),                // 19: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),              // 20: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                    // 21: google.protobuf.Struct
+	(*structpb.Value)(nil),                     // 22: google.protobuf.Value
+	(*wrapperspb.Int64Value)(nil),              // 23: google.protobuf.Int64Value
+	(*wrapperspb.Int32Value)(nil),              // 24: google.protobuf.Int32Value
+	(*wrapperspb.DoubleValue)(nil),             // 25: google.protobuf.DoubleValue
+	(*wrapperspb.FloatValue)(nil),              // 26: google.protobuf.FloatValue
+	(*wrapperspb.UInt64Value)(nil),             // 27: google.protobuf.UInt64Value
+	(*wrapperspb.UInt32Value)(nil),             // 28: google.protobuf.UInt32Value
+	(*wrapperspb.StringValue)(nil),             // 29: google.protobuf.StringValue
+	(*wrapperspb.BoolValue)(nil),               // 30: google.protobuf.BoolValue
+	(*wrapperspb.BytesValue)(nil),              // 31: google.protobuf.BytesValue
 }
 var file_internal_pb_all_types_proto_depIdxs = []int32{
 	1,  // 0: cerbos.hashpb.test.TestAllTypes.standalone_enum:type_name -> cerbos.hashpb.test.TestAllTypes.NestedEnum
-	16, // 1: cerbos.hashpb.test.TestAllTypes.single_any:type_name -> google.protobuf.Any
-	17, // 2: cerbos.hashpb.test.TestAllTypes.single_duration:type_name -> google.protobuf.Duration
-	18, // 3: cerbos.hashpb.test.TestAllTypes.single_timestamp:type_name -> google.protobuf.Timestamp
-	19, // 4: cerbos.hashpb.test.TestAllTypes.single_struct:type_name -> google.protobuf.Struct
-	20, // 5: cerbos.hashpb.test.TestAllTypes.single_value:type_name -> google.protobuf.Value
-	21, // 6: cerbos.hashpb.test.TestAllTypes.single_int64_wrapper:type_name -> google.protobuf.Int64Value
-	22, // 7: cerbos.hashpb.test.TestAllTypes.single_int32_wrapper:type_name -> google.protobuf.Int32Value
-	23, // 8: cerbos.hashpb.test.TestAllTypes.single_double_wrapper:type_name -> google.protobuf.DoubleValue
-	24, // 9: cerbos.hashpb.test.TestAllTypes.single_float_wrapper:type_name -> google.protobuf.FloatValue
-	25, // 10: cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper:type_name -> google.protobuf.UInt64Value
-	26, // 11: cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper:type_name -> google.protobuf.UInt32Value
-	27, // 12: cerbos.hashpb.test.TestAllTypes.single_string_wrapper:type_name -> google.protobuf.StringValue
-	28, // 13: cerbos.hashpb.test.TestAllTypes.single_bool_wrapper:type_name -> google.protobuf.BoolValue
-	29, // 14: cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper:type_name -> google.protobuf.BytesValue
-	7,  // 15: cerbos.hashpb.test.TestAllTypes.single_nested_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
+	18, // 1: cerbos.hashpb.test.TestAllTypes.single_any:type_name -> google.protobuf.Any
+	19, // 2: cerbos.hashpb.test.TestAllTypes.single_duration:type_name -> google.protobuf.Duration
+	20, // 3: cerbos.hashpb.test.TestAllTypes.single_timestamp:type_name -> google.protobuf.Timestamp
+	21, // 4: cerbos.hashpb.test.TestAllTypes.single_struct:type_name -> google.protobuf.Struct
+	22, // 5: cerbos.hashpb.test.TestAllTypes.single_value:type_name -> google.protobuf.Value
+	23, // 6: cerbos.hashpb.test.TestAllTypes.single_int64_wrapper:type_name -> google.protobuf.Int64Value
+	24, // 7: cerbos.hashpb.test.TestAllTypes.single_int32_wrapper:type_name -> google.protobuf.Int32Value
+	25, // 8: cerbos.hashpb.test.TestAllTypes.single_double_wrapper:type_name -> google.protobuf.DoubleValue
+	26, // 9: cerbos.hashpb.test.TestAllTypes.single_float_wrapper:type_name -> google.protobuf.FloatValue
+	27, // 10: cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper:type_name -> google.protobuf.UInt64Value
+	28, // 11: cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper:type_name -> google.protobuf.UInt32Value
+	29, // 12: cerbos.hashpb.test.TestAllTypes.single_string_wrapper:type_name -> google.protobuf.StringValue
+	30, // 13: cerbos.hashpb.test.TestAllTypes.single_bool_wrapper:type_name -> google.protobuf.BoolValue
+	31, // 14: cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper:type_name -> google.protobuf.BytesValue
+	8,  // 15: cerbos.hashpb.test.TestAllTypes.single_nested_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
 	1,  // 16: cerbos.hashpb.test.TestAllTypes.single_nested_enum:type_name -> cerbos.hashpb.test.TestAllTypes.NestedEnum
-	7,  // 17: cerbos.hashpb.test.TestAllTypes.repeated_nested_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
+	8,  // 17: cerbos.hashpb.test.TestAllTypes.repeated_nested_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
 	1,  // 18: cerbos.hashpb.test.TestAllTypes.repeated_nested_enum:type_name -> cerbos.hashpb.test.TestAllTypes.NestedEnum
-	7,  // 19: cerbos.hashpb.test.TestAllTypes.repeated_lazy_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
-	8,  // 20: cerbos.hashpb.test.TestAllTypes.map_string_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapStringStringEntry
-	9,  // 21: cerbos.hashpb.test.TestAllTypes.map_uint64_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapUint64StringEntry
-	10, // 22: cerbos.hashpb.test.TestAllTypes.map_int32_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapInt32StringEntry
-	11, // 23: cerbos.hashpb.test.TestAllTypes.map_bool_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapBoolStringEntry
-	12, // 24: cerbos.hashpb.test.TestAllTypes.map_int64_nested_type:type_name -> cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry
+	8,  // 19: cerbos.hashpb.test.TestAllTypes.repeated_lazy_message:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
+	9,  // 20: cerbos.hashpb.test.TestAllTypes.map_string_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapStringStringEntry
+	10, // 21: cerbos.hashpb.test.TestAllTypes.map_uint64_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapUint64StringEntry
+	11, // 22: cerbos.hashpb.test.TestAllTypes.map_int32_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapInt32StringEntry
+	12, // 23: cerbos.hashpb.test.TestAllTypes.map_bool_string:type_name -> cerbos.hashpb.test.TestAllTypes.MapBoolStringEntry
+	13, // 24: cerbos.hashpb.test.TestAllTypes.map_int64_nested_type:type_name -> cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry
 	4,  // 25: cerbos.hashpb.test.NestedTestAllTypes.child:type_name -> cerbos.hashpb.test.NestedTestAllTypes
 	3,  // 26: cerbos.hashpb.test.NestedTestAllTypes.payload:type_name -> cerbos.hashpb.test.TestAllTypes
 	2,  // 27: cerbos.hashpb.test.TestAllTypesOptional.standalone_enum:type_name -> cerbos.hashpb.test.TestAllTypesOptional.NestedEnum
-	16, // 28: cerbos.hashpb.test.TestAllTypesOptional.single_any:type_name -> google.protobuf.Any
-	17, // 29: cerbos.hashpb.test.TestAllTypesOptional.single_duration:type_name -> google.protobuf.Duration
-	18, // 30: cerbos.hashpb.test.TestAllTypesOptional.single_timestamp:type_name -> google.protobuf.Timestamp
-	19, // 31: cerbos.hashpb.test.TestAllTypesOptional.single_struct:type_name -> google.protobuf.Struct
-	20, // 32: cerbos.hashpb.test.TestAllTypesOptional.single_value:type_name -> google.protobuf.Value
-	21, // 33: cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper:type_name -> google.protobuf.Int64Value
-	22, // 34: cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper:type_name -> google.protobuf.Int32Value
-	23, // 35: cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper:type_name -> google.protobuf.DoubleValue
-	24, // 36: cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper:type_name -> google.protobuf.FloatValue
-	25, // 37: cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper:type_name -> google.protobuf.UInt64Value
-	26, // 38: cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper:type_name -> google.protobuf.UInt32Value
-	27, // 39: cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper:type_name -> google.protobuf.StringValue
-	28, // 40: cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper:type_name -> google.protobuf.BoolValue
-	29, // 41: cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper:type_name -> google.protobuf.BytesValue
-	15, // 42: cerbos.hashpb.test.TestAllTypesOptional.single_nested_message:type_name -> cerbos.hashpb.test.TestAllTypesOptional.NestedMessage
-	7,  // 43: cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry.value:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
-	14, // 44: cerbos.hashpb.test.NoFields.NestedMsg.nested_messages:type_name -> cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry
-	4,  // 45: cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry.value:type_name -> cerbos.hashpb.test.NestedTestAllTypes
-	46, // [46:46] is the sub-list for method output_type
-	46, // [46:46] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	18, // 28: cerbos.hashpb.test.TestAllTypesOptional.single_any:type_name -> google.protobuf.Any
+	19, // 29: cerbos.hashpb.test.TestAllTypesOptional.single_duration:type_name -> google.protobuf.Duration
+	20, // 30: cerbos.hashpb.test.TestAllTypesOptional.single_timestamp:type_name -> google.protobuf.Timestamp
+	21, // 31: cerbos.hashpb.test.TestAllTypesOptional.single_struct:type_name -> google.protobuf.Struct
+	22, // 32: cerbos.hashpb.test.TestAllTypesOptional.single_value:type_name -> google.protobuf.Value
+	23, // 33: cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper:type_name -> google.protobuf.Int64Value
+	24, // 34: cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper:type_name -> google.protobuf.Int32Value
+	25, // 35: cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper:type_name -> google.protobuf.DoubleValue
+	26, // 36: cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper:type_name -> google.protobuf.FloatValue
+	27, // 37: cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper:type_name -> google.protobuf.UInt64Value
+	28, // 38: cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper:type_name -> google.protobuf.UInt32Value
+	29, // 39: cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper:type_name -> google.protobuf.StringValue
+	30, // 40: cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper:type_name -> google.protobuf.BoolValue
+	31, // 41: cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper:type_name -> google.protobuf.BytesValue
+	16, // 42: cerbos.hashpb.test.TestAllTypesOptional.single_nested_message:type_name -> cerbos.hashpb.test.TestAllTypesOptional.NestedMessage
+	17, // 43: cerbos.hashpb.test.TestFieldOptions.items_by_id:type_name -> cerbos.hashpb.test.TestFieldOptions.Item
+	17, // 44: cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank:type_name -> cerbos.hashpb.test.TestFieldOptions.Item
+	17, // 45: cerbos.hashpb.test.TestFieldOptions.item_set:type_name -> cerbos.hashpb.test.TestFieldOptions.Item
+	8,  // 46: cerbos.hashpb.test.TestAllTypes.MapInt64NestedTypeEntry.value:type_name -> cerbos.hashpb.test.TestAllTypes.NestedMessage
+	15, // 47: cerbos.hashpb.test.NoFields.NestedMsg.nested_messages:type_name -> cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry
+	4,  // 48: cerbos.hashpb.test.NoFields.NestedMsg.NestedMessagesEntry.value:type_name -> cerbos.hashpb.test.NestedTestAllTypes
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_internal_pb_all_types_proto_init() }
@@ -1841,6 +2049,18 @@ func file_internal_pb_all_types_proto_init() {
 			}
 		}
 		file_internal_pb_all_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestFieldOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_pb_all_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestAllTypes_NestedMessage); i {
 			case 0:
 				return &v.state
@@ -1852,7 +2072,7 @@ func file_internal_pb_all_types_proto_init() {
 				return nil
 			}
 		}
-		file_internal_pb_all_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_pb_all_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NoFields_NestedMsg); i {
 			case 0:
 				return &v.state
@@ -1864,7 +2084,7 @@ func file_internal_pb_all_types_proto_init() {
 				return nil
 			}
 		}
-		file_internal_pb_all_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_pb_all_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestAllTypesOptional_NestedMessage); i {
 			case 0:
 				return &v.state
@@ -1876,20 +2096,32 @@ func file_internal_pb_all_types_proto_init() {
 				return nil
 			}
 		}
+		file_internal_pb_all_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestFieldOptions_Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_internal_pb_all_types_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*TestAllTypes_SingleNestedMessage)(nil),
 		(*TestAllTypes_SingleNestedEnum)(nil),
 	}
 	file_internal_pb_all_types_proto_msgTypes[3].OneofWrappers = []interface{}{}
-	file_internal_pb_all_types_proto_msgTypes[12].OneofWrappers = []interface{}{}
+	file_internal_pb_all_types_proto_msgTypes[13].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_pb_all_types_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
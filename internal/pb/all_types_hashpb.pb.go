@@ -5,9 +5,115 @@
 package pb
 
 import (
+	hashpb "github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	objecthash "github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	hashpbreg "github.com/cerbos/protoc-gen-go-hashpb/hashpbreg"
+	v2 "github.com/cespare/xxhash/v2"
+	proto "google.golang.org/protobuf/proto"
 	hash "hash"
+	io "io"
 )
 
+// HashPB computes a hash of the message using the given hash function
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m, hasher, ignore)
+	}
+}
+
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *NestedTestAllTypes) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *NestedTestAllTypes) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *NestedTestAllTypes) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_NestedTestAllTypes_hashpb_objecthash(m, ignore)
+}
+
+// NestedTestAllTypes_HashPBSchemaFingerprint is a fingerprint of the schema of NestedTestAllTypes and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const NestedTestAllTypes_HashPBSchemaFingerprint uint64 = 0x3dc45bed30a87426
+
 // HashPB computes a hash of the message using the given hash function
 // The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
 func (m *TestAllTypes) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
@@ -16,6 +122,98 @@ func (m *TestAllTypes) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
 	}
 }
 
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestAllTypes) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestAllTypes) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestAllTypes_hashpb_objecthash(m, ignore)
+}
+
+// TestAllTypes_HashPBSchemaFingerprint is a fingerprint of the schema of TestAllTypes and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestAllTypes_HashPBSchemaFingerprint uint64 = 0x5b2893988ca1d5ec
+
 // HashPB computes a hash of the message using the given hash function
 // The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
 func (m *TestAllTypes_NestedMessage) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
@@ -24,14 +222,98 @@ func (m *TestAllTypes_NestedMessage) HashPB(hasher hash.Hash, ignore map[string]
 	}
 }
 
-// HashPB computes a hash of the message using the given hash function
+// Sum64HashPB computes the 64-bit xxhash digest of the message
 // The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
-func (m *NestedTestAllTypes) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
+func (m *TestAllTypes_NestedMessage) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
 	if m != nil {
-		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m, hasher, ignore)
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(m, w, ignore)
 	}
+	return nil
 }
 
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestAllTypes_NestedMessage) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestAllTypes_NestedMessage) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypes_NestedMessage) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(m, ignore)
+}
+
+// TestAllTypes_NestedMessage_HashPBSchemaFingerprint is a fingerprint of the schema of TestAllTypes_NestedMessage and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestAllTypes_NestedMessage_HashPBSchemaFingerprint uint64 = 0x6878fd7cf7ca22c6
+
 // HashPB computes a hash of the message using the given hash function
 // The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
 func (m *TestAllTypesOptional) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
@@ -40,6 +322,98 @@ func (m *TestAllTypesOptional) HashPB(hasher hash.Hash, ignore map[string]struct
 	}
 }
 
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestAllTypesOptional) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestAllTypesOptional) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestAllTypesOptional_hashpb_objecthash(m, ignore)
+}
+
+// TestAllTypesOptional_HashPBSchemaFingerprint is a fingerprint of the schema of TestAllTypesOptional and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestAllTypesOptional_HashPBSchemaFingerprint uint64 = 0x2efa87b7f2d78640
+
 // HashPB computes a hash of the message using the given hash function
 // The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
 func (m *TestAllTypesOptional_NestedMessage) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
@@ -47,3 +421,351 @@ func (m *TestAllTypesOptional_NestedMessage) HashPB(hasher hash.Hash, ignore map
 		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m, hasher, ignore)
 	}
 }
+
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestAllTypesOptional_NestedMessage) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestAllTypesOptional_NestedMessage) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestAllTypesOptional_NestedMessage) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_objecthash(m, ignore)
+}
+
+// TestAllTypesOptional_NestedMessage_HashPBSchemaFingerprint is a fingerprint of the schema of TestAllTypesOptional_NestedMessage and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestAllTypesOptional_NestedMessage_HashPBSchemaFingerprint uint64 = 0xaa99d9d9ce137cb6
+
+// HashPB computes a hash of the message using the given hash function
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum(m, hasher, ignore)
+	}
+}
+
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestFieldOptions) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestFieldOptions) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestFieldOptions_hashpb_objecthash(m, ignore)
+}
+
+// TestFieldOptions_HashPBSchemaFingerprint is a fingerprint of the schema of TestFieldOptions and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestFieldOptions_HashPBSchemaFingerprint uint64 = 0x5bf7e1149c9918e3
+
+// HashPB computes a hash of the message using the given hash function
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(m, hasher, ignore)
+	}
+}
+
+// Sum64HashPB computes the 64-bit xxhash digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) Sum64HashPB(ignore map[string]struct{}) uint64 {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum64()
+}
+
+// AppendHashPB appends the xxhash digest of the message to dst and returns the resulting slice
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) AppendHashPB(dst []byte, ignore map[string]struct{}) []byte {
+	hasher := v2.New()
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(m, hasher, ignore)
+	}
+	return hasher.Sum(dst)
+}
+
+// HashPBMulti computes hashes of the message using all the given hash functions in a single traversal
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) HashPBMulti(ignore map[string]struct{}, hashers ...hash.Hash) {
+	if m != nil && len(hashers) > 0 {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(m, hashpb.NewMultiHasher(hashers...), ignore)
+	}
+}
+
+// HashPBWithMaxDepth computes a hash of the message using the given hash function, returning hashpb.ErrMaxDepth
+// if messages are nested more than maxDepth levels deep (the message itself is the first level)
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) HashPBWithMaxDepth(hasher hash.Hash, ignore map[string]struct{}, maxDepth int) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_limited(m, hasher, ignore, maxDepth)
+	}
+	return nil
+}
+
+// HashPBErr computes a hash of the message using the given hash function, returning the first error returned by the hasher
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) HashPBErr(hasher hash.Hash, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(m, hasher, ignore)
+	}
+	return nil
+}
+
+// WriteHashPB writes the bytes that HashPB feeds to the hash function to w, returning the first error returned by w
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) WriteHashPB(w io.Writer, ignore map[string]struct{}) error {
+	if m != nil {
+		return cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(m, w, ignore)
+	}
+	return nil
+}
+
+// HashPBNoIgnore computes a hash of the message using the given hash function, including every field
+// It produces the same digest as HashPB with an empty ignore set without checking the ignore set for each field
+func (m *TestFieldOptions_Item) HashPBNoIgnore(hasher hash.Hash) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_noignore(m, hasher)
+	}
+}
+
+// HashPBShallow computes a hash of the message using the given hash function, hashing message fields only by presence and type
+// It produces the same digest as hashpb.Sum with the hashpb.WithShallow option
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) HashPBShallow(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_shallow(m, hasher, ignore)
+	}
+}
+
+// HashPBFiltered computes a hash of the message using the given hash function, including only the fields accepted by the filter
+// The filter is called with the fully-qualified names (pkg.msg.field) of the fields and of the oneof members that are set
+// It produces the same digest as hashpb.Sum with a hashpb.WithFieldFilter predicate calling the filter with the full name of the field
+func (m *TestFieldOptions_Item) HashPBFiltered(hasher hash.Hash, filter func(fullName string) bool) {
+	if m != nil {
+		cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_filtered(m, hasher, filter)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestFieldOptions_Item) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_TestFieldOptions_Item_hashpb_objecthash(m, ignore)
+}
+
+// TestFieldOptions_Item_HashPBSchemaFingerprint is a fingerprint of the schema of TestFieldOptions_Item and the messages reachable from it.
+// It changes when the schema changes in a way that could affect the digests produced by HashPB.
+const TestFieldOptions_Item_HashPBSchemaFingerprint uint64 = 0x2b026b51283c794d
+
+// File_internal_pb_all_types_proto_HashPBSchemaFingerprint is a fingerprint of internal/pb/all_types.proto and the files it imports.
+// It changes when any declaration in these files changes, regardless of source code info and declaration order.
+const File_internal_pb_all_types_proto_HashPBSchemaFingerprint uint64 = 0x9daf286f85fb16a6
+
+func init() {
+	hashpbreg.Register("cerbos.hashpb.test.NestedTestAllTypes", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*NestedTestAllTypes)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestAllTypes", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestAllTypes)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestAllTypes.NestedMessage", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestAllTypes_NestedMessage)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestAllTypesOptional", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestAllTypesOptional)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestAllTypesOptional.NestedMessage", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestAllTypesOptional_NestedMessage)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestFieldOptions", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestFieldOptions)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+	hashpbreg.Register("cerbos.hashpb.test.TestFieldOptions.Item", func(msg proto.Message, hasher hash.Hash, ignore map[string]struct{}) bool {
+		m, ok := msg.(*TestFieldOptions_Item)
+		if ok {
+			m.HashPB(hasher, ignore)
+		}
+		return ok
+	})
+}
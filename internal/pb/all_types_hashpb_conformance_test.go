@@ -0,0 +1,38 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/all_types.proto
+
+package pb
+
+import (
+	hashpbtest "github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	testing "testing"
+)
+
+func TestHashPBConformance_NestedTestAllTypes(t *testing.T) {
+	hashpbtest.CheckConformance(t, &NestedTestAllTypes{})
+}
+
+func TestHashPBConformance_TestAllTypes(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestAllTypes{})
+}
+
+func TestHashPBConformance_TestAllTypes_NestedMessage(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestAllTypes_NestedMessage{})
+}
+
+func TestHashPBConformance_TestAllTypesOptional(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestAllTypesOptional{})
+}
+
+func TestHashPBConformance_TestAllTypesOptional_NestedMessage(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestAllTypesOptional_NestedMessage{})
+}
+
+func TestHashPBConformance_TestFieldOptions(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestFieldOptions{})
+}
+
+func TestHashPBConformance_TestFieldOptions_Item(t *testing.T) {
+	hashpbtest.CheckConformance(t, &TestFieldOptions_Item{})
+}
@@ -0,0 +1,38 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/all_types.proto
+
+package pb
+
+import (
+	hashpbtest "github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	testing "testing"
+)
+
+func FuzzHashPB_NestedTestAllTypes(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &NestedTestAllTypes{})
+}
+
+func FuzzHashPB_TestAllTypes(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestAllTypes{})
+}
+
+func FuzzHashPB_TestAllTypes_NestedMessage(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestAllTypes_NestedMessage{})
+}
+
+func FuzzHashPB_TestAllTypesOptional(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestAllTypesOptional{})
+}
+
+func FuzzHashPB_TestAllTypesOptional_NestedMessage(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestAllTypesOptional_NestedMessage{})
+}
+
+func FuzzHashPB_TestFieldOptions(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestFieldOptions{})
+}
+
+func FuzzHashPB_TestFieldOptions_Item(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestFieldOptions_Item{})
+}
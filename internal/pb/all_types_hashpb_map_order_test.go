@@ -0,0 +1,22 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/all_types.proto
+
+package pb
+
+import (
+	hashpbtest "github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	testing "testing"
+)
+
+func TestHashPBMapOrder_NestedTestAllTypes(t *testing.T) {
+	hashpbtest.CheckMapOrder(t, &NestedTestAllTypes{})
+}
+
+func TestHashPBMapOrder_TestAllTypes(t *testing.T) {
+	hashpbtest.CheckMapOrder(t, &TestAllTypes{})
+}
+
+func TestHashPBMapOrder_TestAllTypesOptional(t *testing.T) {
+	hashpbtest.CheckMapOrder(t, &TestAllTypesOptional{})
+}
@@ -0,0 +1,73 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/all_types.proto
+
+package pb
+
+import (
+	hashpbtest "github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	testing "testing"
+)
+
+func TestHashPBGolden_NestedTestAllTypes(t *testing.T) {
+	hashpbtest.CheckGolden(t, &NestedTestAllTypes{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0xef46db3751d8e999, SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{Seed: 1, XXHash: 0x3a127a2c40dc12a7, SHA256: "f7c3867e0841fb367dbc602b67e9270be6085df4dc110fdca6145ff213128a49"},
+		{Seed: 2, XXHash: 0x2a3c6f436a2026d7, SHA256: "dcd3c1777ae6ea9398192d045ca06a367d9631d8f6ed4fbf923c24c1461ba7a7"},
+		{Seed: 3, XXHash: 0x824333c48974346c, SHA256: "031ef0babe16bb94815bbdb7a6a7e792dff2fecb0e873ff6eabdf6171b5f86a3"},
+	})
+}
+
+func TestHashPBGolden_TestAllTypes(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestAllTypes{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0x2c3a906e14ca47ed, SHA256: "878f32f76b159494f5a39f9321616c6068cdb82e88df89bcc739bbc1ea78e1f9"},
+		{Seed: 1, XXHash: 0xf7523557235f4f7f, SHA256: "8caf1a2054f77b8cbb997f2edc011f16ccc1c404073360895747534c28acd98e"},
+		{Seed: 2, XXHash: 0xd77ff357a3417809, SHA256: "10e51bd21a86ada9fb4da5293d926c4c775f1740e948d0623c2c1b12fe57b9e6"},
+		{Seed: 3, XXHash: 0x703495cb19afd8fc, SHA256: "54734b76862dd6ee5fe02aa16840c8259182ab64d15c97dbbb0f18a33aa847b7"},
+	})
+}
+
+func TestHashPBGolden_TestAllTypes_NestedMessage(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestAllTypes_NestedMessage{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0xe934a84adb052768, SHA256: "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d"},
+		{Seed: 1, XXHash: 0x8c7b8b98e2542ed7, SHA256: "05bbab69b49c6a60a7aec8bd515950011ce0585149b9cf76a5ca2c5544aa85fb"},
+		{Seed: 2, XXHash: 0xf733927cc990b037, SHA256: "9f09a3f9b21c79650219b3e6e9732f2f1481394a986e9530b46bcd3f1a9e9e31"},
+		{Seed: 3, XXHash: 0x9d5aaa5c6b6294e0, SHA256: "487c72c78aa7a99be87bfebea6de61e04645093fecd8f63dd86a99bdfa04ec53"},
+	})
+}
+
+func TestHashPBGolden_TestAllTypesOptional(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestAllTypesOptional{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0x2c3a906e14ca47ed, SHA256: "878f32f76b159494f5a39f9321616c6068cdb82e88df89bcc739bbc1ea78e1f9"},
+		{Seed: 1, XXHash: 0xd09c76ebc4d32ccc, SHA256: "917a99e1c8e88bb899830f5de92f9e67846530b2e3b900a2628a0e6516b9c4a8"},
+		{Seed: 2, XXHash: 0x33e64e7881307b07, SHA256: "aa6c4c573fb18dbd8bf0d4750a021c97fd83e77d15a1dfb51aa8928eb8fb900f"},
+		{Seed: 3, XXHash: 0xbd8f0bf24e5494cb, SHA256: "90d1025eb11bb1b46fd95b402b92ac720797e28d20f5824b964974e4ff3d1d98"},
+	})
+}
+
+func TestHashPBGolden_TestAllTypesOptional_NestedMessage(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestAllTypesOptional_NestedMessage{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0xe934a84adb052768, SHA256: "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d"},
+		{Seed: 1, XXHash: 0x8c7b8b98e2542ed7, SHA256: "05bbab69b49c6a60a7aec8bd515950011ce0585149b9cf76a5ca2c5544aa85fb"},
+		{Seed: 2, XXHash: 0xf733927cc990b037, SHA256: "9f09a3f9b21c79650219b3e6e9732f2f1481394a986e9530b46bcd3f1a9e9e31"},
+		{Seed: 3, XXHash: 0x9d5aaa5c6b6294e0, SHA256: "487c72c78aa7a99be87bfebea6de61e04645093fecd8f63dd86a99bdfa04ec53"},
+	})
+}
+
+func TestHashPBGolden_TestFieldOptions(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestFieldOptions{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0x31886f2e7daf8ca4, SHA256: "709e80c88487a2411e1ee4dfb9f22a861492d20c4765150c0c794abd70f8147c"},
+		{Seed: 1, XXHash: 0xb9bf64201755c085, SHA256: "289921dd5615bbbabb585c81998235b23f42f9e40d714bfd910d30ac66f28e93"},
+		{Seed: 2, XXHash: 0x496ccc995974cb6e, SHA256: "1224254d769e9fed72bc4e9d14f830849590f8c8809b6a0655bc575a280a3843"},
+		{Seed: 3, XXHash: 0xa29d1670280e8639, SHA256: "d8dbb9fbb8a4fe8547b34b297ba6ccc67012335e4fa24a07f89ad4d0e068eb6a"},
+	})
+}
+
+func TestHashPBGolden_TestFieldOptions_Item(t *testing.T) {
+	hashpbtest.CheckGolden(t, &TestFieldOptions_Item{}, []hashpbtest.Golden{
+		{Seed: 0, XXHash: 0x31886f2e7daf8ca4, SHA256: "709e80c88487a2411e1ee4dfb9f22a861492d20c4765150c0c794abd70f8147c"},
+		{Seed: 1, XXHash: 0x3cc96ddc97151196, SHA256: "869627ee77a1e396d2d84a73e32d4243de53f8b6a6b5e4abbf3c040337c23fdb"},
+		{Seed: 2, XXHash: 0x346ff34dee4132fe, SHA256: "dd7feea5510728afea13bb1c77b41035ac3c0808952a8e5effa754862e61e7c6"},
+		{Seed: 3, XXHash: 0xcab639e86955d086, SHA256: "49400a180805bdc8fed11891be5e819df6d78d63e3fffdee781454faed3e5952"},
+	})
+}
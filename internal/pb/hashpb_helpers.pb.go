@@ -4,18 +4,30 @@
 package pb
 
 import (
+	bytes "bytes"
+	sha256 "crypto/sha256"
+	hashpb "github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	objecthash "github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
 	protowire "google.golang.org/protobuf/encoding/protowire"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
-	hash "hash"
+	io "io"
 	math "math"
 	sort "sort"
+	strings "strings"
 )
 
-func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m *NestedTestAllTypes, hasher hash.Hash, ignore map[string]struct{}) {
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the version of the hashpb package in use.
+const (
+	_ = hashpb.EnforceVersion(1 - hashpb.MinGenVersion)
+	_ = hashpb.EnforceVersion(hashpb.GenVersion - 1)
+)
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m *NestedTestAllTypes, hasher io.Writer, ignore map[string]struct{}) {
 	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.child"]; !ok {
 		if m.GetChild() != nil {
 			cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m.GetChild(), hasher, ignore)
@@ -30,17 +42,189 @@ func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum(m *NestedTestAllTypes, has
 	}
 }
 
-func cerbos_hashpb_test_NoFields_hashpb_sum(m *NoFields, hasher hash.Hash, ignore map[string]struct{}) {
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_limited(m *NestedTestAllTypes, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.child"]; !ok {
+		if m.GetChild() != nil {
+			if err := cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_limited(m.GetChild(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.payload"]; !ok {
+		if m.GetPayload() != nil {
+			if err := cerbos_hashpb_test_TestAllTypes_hashpb_sum_limited(m.GetPayload(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_err(m *NestedTestAllTypes, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.child"]; !ok {
+		if m.GetChild() != nil {
+			if err := cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_err(m.GetChild(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.payload"]; !ok {
+		if m.GetPayload() != nil {
+			if err := cerbos_hashpb_test_TestAllTypes_hashpb_sum_err(m.GetPayload(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_noignore(m *NestedTestAllTypes, hasher io.Writer) {
+	if m.GetChild() != nil {
+		cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_noignore(m.GetChild(), hasher)
+	}
+
+	if m.GetPayload() != nil {
+		cerbos_hashpb_test_TestAllTypes_hashpb_sum_noignore(m.GetPayload(), hasher)
+	}
+
+}
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_shallow(m *NestedTestAllTypes, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.child"]; !ok {
+		if m.GetChild() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.NestedTestAllTypes"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.payload"]; !ok {
+		if m.GetPayload() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypes"))
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_filtered(m *NestedTestAllTypes, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.NestedTestAllTypes.child") {
+		if m.GetChild() != nil {
+			cerbos_hashpb_test_NestedTestAllTypes_hashpb_sum_filtered(m.GetChild(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.NestedTestAllTypes.payload") {
+		if m.GetPayload() != nil {
+			cerbos_hashpb_test_TestAllTypes_hashpb_sum_filtered(m.GetPayload(), hasher, filter)
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_NestedTestAllTypes_hashpb_objecthash(m *NestedTestAllTypes, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.child"]; !ok && m.Child != nil {
+			d.Add(objecthash.Int(1), cerbos_hashpb_test_NestedTestAllTypes_hashpb_objecthash(m.Child, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.NestedTestAllTypes.payload"]; !ok && m.Payload != nil {
+			d.Add(objecthash.Int(2), cerbos_hashpb_test_TestAllTypes_hashpb_objecthash(m.Payload, ignore))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum(m *NoFields, hasher io.Writer, ignore map[string]struct{}) {
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum_limited(m *NoFields, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum_err(m *NoFields, hasher io.Writer, ignore map[string]struct{}) error {
+	return nil
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum_noignore(m *NoFields, hasher io.Writer) {
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum_shallow(m *NoFields, hasher io.Writer, ignore map[string]struct{}) {
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_sum_filtered(m *NoFields, hasher io.Writer, filter func(string) bool) {
+}
+
+func cerbos_hashpb_test_NoFields_hashpb_objecthash(m *NoFields, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m *TestAllTypesOptional_NestedMessage, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_limited(m *TestAllTypesOptional_NestedMessage, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_err(m *TestAllTypesOptional_NestedMessage, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_noignore(m *TestAllTypesOptional_NestedMessage, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
 }
 
-func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum(m *TestAllTypesOptional_NestedMessage, hasher hash.Hash, ignore map[string]struct{}) {
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_shallow(m *TestAllTypesOptional_NestedMessage, hasher io.Writer, ignore map[string]struct{}) {
 	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
 
 	}
 }
 
-func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m *TestAllTypesOptional, hasher hash.Hash, ignore map[string]struct{}) {
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_filtered(m *TestAllTypesOptional_NestedMessage, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_objecthash(m *TestAllTypesOptional_NestedMessage, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.NestedMessage.bb"]; !ok && m.Bb != nil {
+			d.Add(objecthash.Int(1), objecthash.Int(int64(*m.Bb)))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m *TestAllTypesOptional, hasher io.Writer, ignore map[string]struct{}) {
 	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
 
@@ -197,261 +381,5133 @@ func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum(m *TestAllTypesOptional,
 	}
 }
 
-func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m *TestAllTypes_NestedMessage, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
-
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_limited(m *TestAllTypesOptional, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
 	}
-}
-
-func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint64"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed64"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed32"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed64"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double"]; !ok {
 		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string"]; !ok {
 		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes"]; !ok {
 		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
 
 	}
-	if m.NestedType != nil {
-		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
-			switch t := m.NestedType.(type) {
-			case *TestAllTypes_SingleNestedMessage:
-				if t.SingleNestedMessage != nil {
-					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(t.SingleNestedMessage, hasher, ignore)
-				}
-
-			case *TestAllTypes_SingleNestedEnum:
-				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
-
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_nested_message"]; !ok {
+		if m.GetSingleNestedMessage() != nil {
+			if err := cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_limited(m.GetSingleNestedMessage(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
+
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.standalone_enum"]; !ok {
 		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok {
-		if len(m.RepeatedInt32) > 0 {
-			for _, v := range m.RepeatedInt32 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
-
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			if err := google_protobuf_Any_hashpb_sum_limited(m.GetSingleAny(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok {
-		if len(m.RepeatedInt64) > 0 {
-			for _, v := range m.RepeatedInt64 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			if err := google_protobuf_Duration_hashpb_sum_limited(m.GetSingleDuration(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok {
-		if len(m.RepeatedUint32) > 0 {
-			for _, v := range m.RepeatedUint32 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			if err := google_protobuf_Timestamp_hashpb_sum_limited(m.GetSingleTimestamp(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok {
-		if len(m.RepeatedUint64) > 0 {
-			for _, v := range m.RepeatedUint64 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, v))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			if err := google_protobuf_Struct_hashpb_sum_limited(m.GetSingleStruct(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok {
-		if len(m.RepeatedSint32) > 0 {
-			for _, v := range m.RepeatedSint32 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			if err := google_protobuf_Value_hashpb_sum_limited(m.GetSingleValue(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok {
-		if len(m.RepeatedSint64) > 0 {
-			for _, v := range m.RepeatedSint64 {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			if err := google_protobuf_Int64Value_hashpb_sum_limited(m.GetSingleInt64Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok {
-		if len(m.RepeatedFixed32) > 0 {
-			for _, v := range m.RepeatedFixed32 {
-				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			if err := google_protobuf_Int32Value_hashpb_sum_limited(m.GetSingleInt32Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok {
-		if len(m.RepeatedFixed64) > 0 {
-			for _, v := range m.RepeatedFixed64 {
-				_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			if err := google_protobuf_DoubleValue_hashpb_sum_limited(m.GetSingleDoubleWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok {
-		if len(m.RepeatedSfixed32) > 0 {
-			for _, v := range m.RepeatedSfixed32 {
-				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			if err := google_protobuf_FloatValue_hashpb_sum_limited(m.GetSingleFloatWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok {
-		if len(m.RepeatedSfixed64) > 0 {
-			for _, v := range m.RepeatedSfixed64 {
-				_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			if err := google_protobuf_UInt64Value_hashpb_sum_limited(m.GetSingleUint64Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok {
-		if len(m.RepeatedFloat) > 0 {
-			for _, v := range m.RepeatedFloat {
-				_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			if err := google_protobuf_UInt32Value_hashpb_sum_limited(m.GetSingleUint32Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
-	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok {
-		if len(m.RepeatedDouble) > 0 {
-			for _, v := range m.RepeatedDouble {
-				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
 
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			if err := google_protobuf_StringValue_hashpb_sum_limited(m.GetSingleStringWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
 			}
 		}
+
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			if err := google_protobuf_BoolValue_hashpb_sum_limited(m.GetSingleBoolWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			if err := google_protobuf_BytesValue_hashpb_sum_limited(m.GetSingleBytesWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_err(m *TestAllTypesOptional, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32())))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetSingleString())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes"]; !ok {
+		if _, err := hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_nested_message"]; !ok {
+		if m.GetSingleNestedMessage() != nil {
+			if err := cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_err(m.GetSingleNestedMessage(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.standalone_enum"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			if err := google_protobuf_Any_hashpb_sum_err(m.GetSingleAny(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			if err := google_protobuf_Duration_hashpb_sum_err(m.GetSingleDuration(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			if err := google_protobuf_Timestamp_hashpb_sum_err(m.GetSingleTimestamp(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			if err := google_protobuf_Struct_hashpb_sum_err(m.GetSingleStruct(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			if err := google_protobuf_Value_hashpb_sum_err(m.GetSingleValue(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			if err := google_protobuf_Int64Value_hashpb_sum_err(m.GetSingleInt64Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			if err := google_protobuf_Int32Value_hashpb_sum_err(m.GetSingleInt32Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			if err := google_protobuf_DoubleValue_hashpb_sum_err(m.GetSingleDoubleWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			if err := google_protobuf_FloatValue_hashpb_sum_err(m.GetSingleFloatWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			if err := google_protobuf_UInt64Value_hashpb_sum_err(m.GetSingleUint64Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			if err := google_protobuf_UInt32Value_hashpb_sum_err(m.GetSingleUint32Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			if err := google_protobuf_StringValue_hashpb_sum_err(m.GetSingleStringWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			if err := google_protobuf_BoolValue_hashpb_sum_err(m.GetSingleBoolWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			if err := google_protobuf_BytesValue_hashpb_sum_err(m.GetSingleBytesWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_noignore(m *TestAllTypesOptional, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	if m.GetSingleNestedMessage() != nil {
+		cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_noignore(m.GetSingleNestedMessage(), hasher)
+	}
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	if m.GetSingleAny() != nil {
+		google_protobuf_Any_hashpb_sum_noignore(m.GetSingleAny(), hasher)
+	}
+
+	if m.GetSingleDuration() != nil {
+		google_protobuf_Duration_hashpb_sum_noignore(m.GetSingleDuration(), hasher)
+	}
+
+	if m.GetSingleTimestamp() != nil {
+		google_protobuf_Timestamp_hashpb_sum_noignore(m.GetSingleTimestamp(), hasher)
+	}
+
+	if m.GetSingleStruct() != nil {
+		google_protobuf_Struct_hashpb_sum_noignore(m.GetSingleStruct(), hasher)
+	}
+
+	if m.GetSingleValue() != nil {
+		google_protobuf_Value_hashpb_sum_noignore(m.GetSingleValue(), hasher)
+	}
+
+	if m.GetSingleInt64Wrapper() != nil {
+		google_protobuf_Int64Value_hashpb_sum_noignore(m.GetSingleInt64Wrapper(), hasher)
+	}
+
+	if m.GetSingleInt32Wrapper() != nil {
+		google_protobuf_Int32Value_hashpb_sum_noignore(m.GetSingleInt32Wrapper(), hasher)
+	}
+
+	if m.GetSingleDoubleWrapper() != nil {
+		google_protobuf_DoubleValue_hashpb_sum_noignore(m.GetSingleDoubleWrapper(), hasher)
+	}
+
+	if m.GetSingleFloatWrapper() != nil {
+		google_protobuf_FloatValue_hashpb_sum_noignore(m.GetSingleFloatWrapper(), hasher)
+	}
+
+	if m.GetSingleUint64Wrapper() != nil {
+		google_protobuf_UInt64Value_hashpb_sum_noignore(m.GetSingleUint64Wrapper(), hasher)
+	}
+
+	if m.GetSingleUint32Wrapper() != nil {
+		google_protobuf_UInt32Value_hashpb_sum_noignore(m.GetSingleUint32Wrapper(), hasher)
+	}
+
+	if m.GetSingleStringWrapper() != nil {
+		google_protobuf_StringValue_hashpb_sum_noignore(m.GetSingleStringWrapper(), hasher)
+	}
+
+	if m.GetSingleBoolWrapper() != nil {
+		google_protobuf_BoolValue_hashpb_sum_noignore(m.GetSingleBoolWrapper(), hasher)
+	}
+
+	if m.GetSingleBytesWrapper() != nil {
+		google_protobuf_BytesValue_hashpb_sum_noignore(m.GetSingleBytesWrapper(), hasher)
+	}
+
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_shallow(m *TestAllTypesOptional, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_nested_message"]; !ok {
+		if m.GetSingleNestedMessage() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypesOptional.NestedMessage"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.standalone_enum"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Any"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Duration"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Timestamp"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Struct"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Int64Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Int32Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.DoubleValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.FloatValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.UInt64Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.UInt32Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.StringValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.BoolValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.BytesValue"))
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_sum_filtered(m *TestAllTypesOptional, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_int32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_int64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_uint32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_uint64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_sint32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_sint64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_fixed32") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_fixed64") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_sfixed32") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_sfixed64") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_float") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_double") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_bool") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_string") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_bytes") {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_nested_message") {
+		if m.GetSingleNestedMessage() != nil {
+			cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_sum_filtered(m.GetSingleNestedMessage(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.standalone_enum") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_any") {
+		if m.GetSingleAny() != nil {
+			google_protobuf_Any_hashpb_sum_filtered(m.GetSingleAny(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_duration") {
+		if m.GetSingleDuration() != nil {
+			google_protobuf_Duration_hashpb_sum_filtered(m.GetSingleDuration(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_timestamp") {
+		if m.GetSingleTimestamp() != nil {
+			google_protobuf_Timestamp_hashpb_sum_filtered(m.GetSingleTimestamp(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_struct") {
+		if m.GetSingleStruct() != nil {
+			google_protobuf_Struct_hashpb_sum_filtered(m.GetSingleStruct(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_value") {
+		if m.GetSingleValue() != nil {
+			google_protobuf_Value_hashpb_sum_filtered(m.GetSingleValue(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper") {
+		if m.GetSingleInt64Wrapper() != nil {
+			google_protobuf_Int64Value_hashpb_sum_filtered(m.GetSingleInt64Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper") {
+		if m.GetSingleInt32Wrapper() != nil {
+			google_protobuf_Int32Value_hashpb_sum_filtered(m.GetSingleInt32Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper") {
+		if m.GetSingleDoubleWrapper() != nil {
+			google_protobuf_DoubleValue_hashpb_sum_filtered(m.GetSingleDoubleWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper") {
+		if m.GetSingleFloatWrapper() != nil {
+			google_protobuf_FloatValue_hashpb_sum_filtered(m.GetSingleFloatWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper") {
+		if m.GetSingleUint64Wrapper() != nil {
+			google_protobuf_UInt64Value_hashpb_sum_filtered(m.GetSingleUint64Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper") {
+		if m.GetSingleUint32Wrapper() != nil {
+			google_protobuf_UInt32Value_hashpb_sum_filtered(m.GetSingleUint32Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper") {
+		if m.GetSingleStringWrapper() != nil {
+			google_protobuf_StringValue_hashpb_sum_filtered(m.GetSingleStringWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper") {
+		if m.GetSingleBoolWrapper() != nil {
+			google_protobuf_BoolValue_hashpb_sum_filtered(m.GetSingleBoolWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper") {
+		if m.GetSingleBytesWrapper() != nil {
+			google_protobuf_BytesValue_hashpb_sum_filtered(m.GetSingleBytesWrapper(), hasher, filter)
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypesOptional_hashpb_objecthash(m *TestAllTypesOptional, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32"]; !ok && m.SingleInt32 != nil {
+			d.Add(objecthash.Int(1), objecthash.Int(int64(*m.SingleInt32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64"]; !ok && m.SingleInt64 != nil {
+			d.Add(objecthash.Int(2), objecthash.Int(*m.SingleInt64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32"]; !ok && m.SingleUint32 != nil {
+			d.Add(objecthash.Int(3), objecthash.Uint(uint64(*m.SingleUint32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64"]; !ok && m.SingleUint64 != nil {
+			d.Add(objecthash.Int(4), objecthash.Uint(*m.SingleUint64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint32"]; !ok && m.SingleSint32 != nil {
+			d.Add(objecthash.Int(5), objecthash.Int(int64(*m.SingleSint32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sint64"]; !ok && m.SingleSint64 != nil {
+			d.Add(objecthash.Int(6), objecthash.Int(*m.SingleSint64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed32"]; !ok && m.SingleFixed32 != nil {
+			d.Add(objecthash.Int(7), objecthash.Uint(uint64(*m.SingleFixed32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_fixed64"]; !ok && m.SingleFixed64 != nil {
+			d.Add(objecthash.Int(8), objecthash.Uint(*m.SingleFixed64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed32"]; !ok && m.SingleSfixed32 != nil {
+			d.Add(objecthash.Int(9), objecthash.Int(int64(*m.SingleSfixed32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_sfixed64"]; !ok && m.SingleSfixed64 != nil {
+			d.Add(objecthash.Int(10), objecthash.Int(*m.SingleSfixed64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float"]; !ok && m.SingleFloat != nil {
+			d.Add(objecthash.Int(11), objecthash.Float(float64(*m.SingleFloat)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double"]; !ok && m.SingleDouble != nil {
+			d.Add(objecthash.Int(12), objecthash.Float(*m.SingleDouble))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool"]; !ok && m.SingleBool != nil {
+			d.Add(objecthash.Int(13), objecthash.Bool(*m.SingleBool))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string"]; !ok && m.SingleString != nil {
+			d.Add(objecthash.Int(14), objecthash.String(*m.SingleString))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes"]; !ok && m.SingleBytes != nil {
+			d.Add(objecthash.Int(15), objecthash.Bytes(m.SingleBytes))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_nested_message"]; !ok && m.SingleNestedMessage != nil {
+			d.Add(objecthash.Int(18), cerbos_hashpb_test_TestAllTypesOptional_NestedMessage_hashpb_objecthash(m.SingleNestedMessage, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.standalone_enum"]; !ok && m.StandaloneEnum != nil {
+			d.Add(objecthash.Int(22), objecthash.Int(int64(*m.StandaloneEnum)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_any"]; !ok && m.SingleAny != nil {
+			d.Add(objecthash.Int(100), google_protobuf_Any_hashpb_objecthash(m.SingleAny, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_duration"]; !ok && m.SingleDuration != nil {
+			d.Add(objecthash.Int(101), google_protobuf_Duration_hashpb_objecthash(m.SingleDuration, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_timestamp"]; !ok && m.SingleTimestamp != nil {
+			d.Add(objecthash.Int(102), google_protobuf_Timestamp_hashpb_objecthash(m.SingleTimestamp, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_struct"]; !ok && m.SingleStruct != nil {
+			d.Add(objecthash.Int(103), google_protobuf_Struct_hashpb_objecthash(m.SingleStruct, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_value"]; !ok && m.SingleValue != nil {
+			d.Add(objecthash.Int(104), google_protobuf_Value_hashpb_objecthash(m.SingleValue, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int64_wrapper"]; !ok && m.SingleInt64Wrapper != nil {
+			d.Add(objecthash.Int(105), google_protobuf_Int64Value_hashpb_objecthash(m.SingleInt64Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_int32_wrapper"]; !ok && m.SingleInt32Wrapper != nil {
+			d.Add(objecthash.Int(106), google_protobuf_Int32Value_hashpb_objecthash(m.SingleInt32Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_double_wrapper"]; !ok && m.SingleDoubleWrapper != nil {
+			d.Add(objecthash.Int(107), google_protobuf_DoubleValue_hashpb_objecthash(m.SingleDoubleWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_float_wrapper"]; !ok && m.SingleFloatWrapper != nil {
+			d.Add(objecthash.Int(108), google_protobuf_FloatValue_hashpb_objecthash(m.SingleFloatWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint64_wrapper"]; !ok && m.SingleUint64Wrapper != nil {
+			d.Add(objecthash.Int(109), google_protobuf_UInt64Value_hashpb_objecthash(m.SingleUint64Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_uint32_wrapper"]; !ok && m.SingleUint32Wrapper != nil {
+			d.Add(objecthash.Int(110), google_protobuf_UInt32Value_hashpb_objecthash(m.SingleUint32Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_string_wrapper"]; !ok && m.SingleStringWrapper != nil {
+			d.Add(objecthash.Int(111), google_protobuf_StringValue_hashpb_objecthash(m.SingleStringWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bool_wrapper"]; !ok && m.SingleBoolWrapper != nil {
+			d.Add(objecthash.Int(112), google_protobuf_BoolValue_hashpb_objecthash(m.SingleBoolWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypesOptional.single_bytes_wrapper"]; !ok && m.SingleBytesWrapper != nil {
+			d.Add(objecthash.Int(113), google_protobuf_BytesValue_hashpb_objecthash(m.SingleBytesWrapper, ignore))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m *TestAllTypes_NestedMessage, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(m *TestAllTypes_NestedMessage, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(m *TestAllTypes_NestedMessage, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(m *TestAllTypes_NestedMessage, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_shallow(m *TestAllTypes_NestedMessage, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(m *TestAllTypes_NestedMessage, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestAllTypes.NestedMessage.bb") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetBb())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(m *TestAllTypes_NestedMessage, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.NestedMessage.bb"]; !ok && m.Bb != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(int64(m.Bb)))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if m.NestedType != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
+			switch t := m.NestedType.(type) {
+			case *TestAllTypes_SingleNestedMessage:
+				if t.SingleNestedMessage != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(t.SingleNestedMessage, hasher, ignore)
+				}
+
+			case *TestAllTypes_SingleNestedEnum:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok {
+		if len(m.RepeatedInt32) > 0 {
+			for _, v := range m.RepeatedInt32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok {
+		if len(m.RepeatedInt64) > 0 {
+			for _, v := range m.RepeatedInt64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok {
+		if len(m.RepeatedUint32) > 0 {
+			for _, v := range m.RepeatedUint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok {
+		if len(m.RepeatedUint64) > 0 {
+			for _, v := range m.RepeatedUint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok {
+		if len(m.RepeatedSint32) > 0 {
+			for _, v := range m.RepeatedSint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok {
+		if len(m.RepeatedSint64) > 0 {
+			for _, v := range m.RepeatedSint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok {
+		if len(m.RepeatedFixed32) > 0 {
+			for _, v := range m.RepeatedFixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok {
+		if len(m.RepeatedFixed64) > 0 {
+			for _, v := range m.RepeatedFixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok {
+		if len(m.RepeatedSfixed32) > 0 {
+			for _, v := range m.RepeatedSfixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok {
+		if len(m.RepeatedSfixed64) > 0 {
+			for _, v := range m.RepeatedSfixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok {
+		if len(m.RepeatedFloat) > 0 {
+			for _, v := range m.RepeatedFloat {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok {
+		if len(m.RepeatedDouble) > 0 {
+			for _, v := range m.RepeatedDouble {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok {
+		if len(m.RepeatedBool) > 0 {
+			for _, v := range m.RepeatedBool {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok {
+		if len(m.RepeatedBytes) > 0 {
+			for _, v := range m.RepeatedBytes {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok {
+		if len(m.RepeatedNestedMessage) > 0 {
+			for _, v := range m.RepeatedNestedMessage {
+				if v != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(v, hasher, ignore)
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok {
+		if len(m.RepeatedNestedEnum) > 0 {
+			for _, v := range m.RepeatedNestedEnum {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok {
+		if len(m.RepeatedStringPiece) > 0 {
+			for _, v := range m.RepeatedStringPiece {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok {
+		if len(m.RepeatedCord) > 0 {
+			for _, v := range m.RepeatedCord {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok {
+		if len(m.RepeatedLazyMessage) > 0 {
+			for _, v := range m.RepeatedLazyMessage {
+				if v != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(v, hasher, ignore)
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok {
+		if len(m.MapUint64String) > 0 {
+			keys := make([]uint64, len(m.MapUint64String))
+			i := 0
+			for k := range m.MapUint64String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok {
+		if len(m.MapInt32String) > 0 {
+			keys := make([]int32, len(m.MapInt32String))
+			i := 0
+			for k := range m.MapInt32String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok {
+		if len(m.MapBoolString) > 0 {
+			keys := make([]bool, len(m.MapBoolString))
+			i := 0
+			for k := range m.MapBoolString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok {
+		if len(m.MapInt64NestedType) > 0 {
+			keys := make([]int64, len(m.MapInt64NestedType))
+			i := 0
+			for k := range m.MapInt64NestedType {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if m.MapInt64NestedType[k] != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m.MapInt64NestedType[k], hasher, ignore)
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			google_protobuf_Any_hashpb_sum(m.GetSingleAny(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			google_protobuf_Duration_hashpb_sum(m.GetSingleDuration(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			google_protobuf_Timestamp_hashpb_sum(m.GetSingleTimestamp(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			google_protobuf_Struct_hashpb_sum(m.GetSingleStruct(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			google_protobuf_Value_hashpb_sum(m.GetSingleValue(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			google_protobuf_Int64Value_hashpb_sum(m.GetSingleInt64Wrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			google_protobuf_Int32Value_hashpb_sum(m.GetSingleInt32Wrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			google_protobuf_DoubleValue_hashpb_sum(m.GetSingleDoubleWrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			google_protobuf_FloatValue_hashpb_sum(m.GetSingleFloatWrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			google_protobuf_UInt64Value_hashpb_sum(m.GetSingleUint64Wrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			google_protobuf_UInt32Value_hashpb_sum(m.GetSingleUint32Wrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			google_protobuf_StringValue_hashpb_sum(m.GetSingleStringWrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			google_protobuf_BoolValue_hashpb_sum(m.GetSingleBoolWrapper(), hasher, ignore)
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			google_protobuf_BytesValue_hashpb_sum(m.GetSingleBytesWrapper(), hasher, ignore)
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum_limited(m *TestAllTypes, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if m.NestedType != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
+			switch t := m.NestedType.(type) {
+			case *TestAllTypes_SingleNestedMessage:
+				if t.SingleNestedMessage != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(t.SingleNestedMessage, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			case *TestAllTypes_SingleNestedEnum:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok {
+		if len(m.RepeatedInt32) > 0 {
+			for _, v := range m.RepeatedInt32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok {
+		if len(m.RepeatedInt64) > 0 {
+			for _, v := range m.RepeatedInt64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok {
+		if len(m.RepeatedUint32) > 0 {
+			for _, v := range m.RepeatedUint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok {
+		if len(m.RepeatedUint64) > 0 {
+			for _, v := range m.RepeatedUint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok {
+		if len(m.RepeatedSint32) > 0 {
+			for _, v := range m.RepeatedSint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok {
+		if len(m.RepeatedSint64) > 0 {
+			for _, v := range m.RepeatedSint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok {
+		if len(m.RepeatedFixed32) > 0 {
+			for _, v := range m.RepeatedFixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok {
+		if len(m.RepeatedFixed64) > 0 {
+			for _, v := range m.RepeatedFixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok {
+		if len(m.RepeatedSfixed32) > 0 {
+			for _, v := range m.RepeatedSfixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok {
+		if len(m.RepeatedSfixed64) > 0 {
+			for _, v := range m.RepeatedSfixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok {
+		if len(m.RepeatedFloat) > 0 {
+			for _, v := range m.RepeatedFloat {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok {
+		if len(m.RepeatedDouble) > 0 {
+			for _, v := range m.RepeatedDouble {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok {
+		if len(m.RepeatedBool) > 0 {
+			for _, v := range m.RepeatedBool {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok {
+		if len(m.RepeatedBytes) > 0 {
+			for _, v := range m.RepeatedBytes {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok {
+		if len(m.RepeatedNestedMessage) > 0 {
+			for _, v := range m.RepeatedNestedMessage {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok {
+		if len(m.RepeatedNestedEnum) > 0 {
+			for _, v := range m.RepeatedNestedEnum {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok {
+		if len(m.RepeatedStringPiece) > 0 {
+			for _, v := range m.RepeatedStringPiece {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok {
+		if len(m.RepeatedCord) > 0 {
+			for _, v := range m.RepeatedCord {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok {
+		if len(m.RepeatedLazyMessage) > 0 {
+			for _, v := range m.RepeatedLazyMessage {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok {
+		if len(m.MapUint64String) > 0 {
+			keys := make([]uint64, len(m.MapUint64String))
+			i := 0
+			for k := range m.MapUint64String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok {
+		if len(m.MapInt32String) > 0 {
+			keys := make([]int32, len(m.MapInt32String))
+			i := 0
+			for k := range m.MapInt32String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok {
+		if len(m.MapBoolString) > 0 {
+			keys := make([]bool, len(m.MapBoolString))
+			i := 0
+			for k := range m.MapBoolString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok {
+		if len(m.MapInt64NestedType) > 0 {
+			keys := make([]int64, len(m.MapInt64NestedType))
+			i := 0
+			for k := range m.MapInt64NestedType {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if m.MapInt64NestedType[k] != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_limited(m.MapInt64NestedType[k], hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			if err := google_protobuf_Any_hashpb_sum_limited(m.GetSingleAny(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			if err := google_protobuf_Duration_hashpb_sum_limited(m.GetSingleDuration(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			if err := google_protobuf_Timestamp_hashpb_sum_limited(m.GetSingleTimestamp(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			if err := google_protobuf_Struct_hashpb_sum_limited(m.GetSingleStruct(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			if err := google_protobuf_Value_hashpb_sum_limited(m.GetSingleValue(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			if err := google_protobuf_Int64Value_hashpb_sum_limited(m.GetSingleInt64Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			if err := google_protobuf_Int32Value_hashpb_sum_limited(m.GetSingleInt32Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			if err := google_protobuf_DoubleValue_hashpb_sum_limited(m.GetSingleDoubleWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			if err := google_protobuf_FloatValue_hashpb_sum_limited(m.GetSingleFloatWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			if err := google_protobuf_UInt64Value_hashpb_sum_limited(m.GetSingleUint64Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			if err := google_protobuf_UInt32Value_hashpb_sum_limited(m.GetSingleUint32Wrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			if err := google_protobuf_StringValue_hashpb_sum_limited(m.GetSingleStringWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			if err := google_protobuf_BoolValue_hashpb_sum_limited(m.GetSingleBoolWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			if err := google_protobuf_BytesValue_hashpb_sum_limited(m.GetSingleBytesWrapper(), hasher, ignore, depth-1); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum_err(m *TestAllTypes, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32())))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetSingleString())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok {
+		if _, err := hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes())); err != nil {
+			return err
+		}
+
+	}
+	if m.NestedType != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
+			switch t := m.NestedType.(type) {
+			case *TestAllTypes_SingleNestedMessage:
+				if t.SingleNestedMessage != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(t.SingleNestedMessage, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			case *TestAllTypes_SingleNestedEnum:
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok {
+		if len(m.RepeatedInt32) > 0 {
+			for _, v := range m.RepeatedInt32 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok {
+		if len(m.RepeatedInt64) > 0 {
+			for _, v := range m.RepeatedInt64 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok {
+		if len(m.RepeatedUint32) > 0 {
+			for _, v := range m.RepeatedUint32 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok {
+		if len(m.RepeatedUint64) > 0 {
+			for _, v := range m.RepeatedUint64 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok {
+		if len(m.RepeatedSint32) > 0 {
+			for _, v := range m.RepeatedSint32 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v)))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok {
+		if len(m.RepeatedSint64) > 0 {
+			for _, v := range m.RepeatedSint64 {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok {
+		if len(m.RepeatedFixed32) > 0 {
+			for _, v := range m.RepeatedFixed32 {
+				if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok {
+		if len(m.RepeatedFixed64) > 0 {
+			for _, v := range m.RepeatedFixed64 {
+				if _, err := hasher.Write(protowire.AppendFixed64(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok {
+		if len(m.RepeatedSfixed32) > 0 {
+			for _, v := range m.RepeatedSfixed32 {
+				if _, err := hasher.Write(protowire.AppendFixed32(nil, uint32(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok {
+		if len(m.RepeatedSfixed64) > 0 {
+			for _, v := range m.RepeatedSfixed64 {
+				if _, err := hasher.Write(protowire.AppendFixed64(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok {
+		if len(m.RepeatedFloat) > 0 {
+			for _, v := range m.RepeatedFloat {
+				if _, err := hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok {
+		if len(m.RepeatedDouble) > 0 {
+			for _, v := range m.RepeatedDouble {
+				if _, err := hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok {
+		if len(m.RepeatedBool) > 0 {
+			for _, v := range m.RepeatedBool {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				if _, err := hasher.Write(protowire.AppendString(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok {
+		if len(m.RepeatedBytes) > 0 {
+			for _, v := range m.RepeatedBytes {
+				if _, err := hasher.Write(protowire.AppendBytes(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok {
+		if len(m.RepeatedNestedMessage) > 0 {
+			for _, v := range m.RepeatedNestedMessage {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok {
+		if len(m.RepeatedNestedEnum) > 0 {
+			for _, v := range m.RepeatedNestedEnum {
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok {
+		if len(m.RepeatedStringPiece) > 0 {
+			for _, v := range m.RepeatedStringPiece {
+				if _, err := hasher.Write(protowire.AppendString(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok {
+		if len(m.RepeatedCord) > 0 {
+			for _, v := range m.RepeatedCord {
+				if _, err := hasher.Write(protowire.AppendString(nil, v)); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok {
+		if len(m.RepeatedLazyMessage) > 0 {
+			for _, v := range m.RepeatedLazyMessage {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if _, err := hasher.Write(protowire.AppendString(nil, m.MapStringString[k])); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok {
+		if len(m.MapUint64String) > 0 {
+			keys := make([]uint64, len(m.MapUint64String))
+			i := 0
+			for k := range m.MapUint64String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if _, err := hasher.Write(protowire.AppendString(nil, m.MapUint64String[k])); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok {
+		if len(m.MapInt32String) > 0 {
+			keys := make([]int32, len(m.MapInt32String))
+			i := 0
+			for k := range m.MapInt32String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if _, err := hasher.Write(protowire.AppendString(nil, m.MapInt32String[k])); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok {
+		if len(m.MapBoolString) > 0 {
+			keys := make([]bool, len(m.MapBoolString))
+			i := 0
+			for k := range m.MapBoolString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+			for _, k := range keys {
+				if _, err := hasher.Write(protowire.AppendString(nil, m.MapBoolString[k])); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok {
+		if len(m.MapInt64NestedType) > 0 {
+			keys := make([]int64, len(m.MapInt64NestedType))
+			i := 0
+			for k := range m.MapInt64NestedType {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if m.MapInt64NestedType[k] != nil {
+					if err := cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_err(m.MapInt64NestedType[k], hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			if err := google_protobuf_Any_hashpb_sum_err(m.GetSingleAny(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			if err := google_protobuf_Duration_hashpb_sum_err(m.GetSingleDuration(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			if err := google_protobuf_Timestamp_hashpb_sum_err(m.GetSingleTimestamp(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			if err := google_protobuf_Struct_hashpb_sum_err(m.GetSingleStruct(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			if err := google_protobuf_Value_hashpb_sum_err(m.GetSingleValue(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			if err := google_protobuf_Int64Value_hashpb_sum_err(m.GetSingleInt64Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			if err := google_protobuf_Int32Value_hashpb_sum_err(m.GetSingleInt32Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			if err := google_protobuf_DoubleValue_hashpb_sum_err(m.GetSingleDoubleWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			if err := google_protobuf_FloatValue_hashpb_sum_err(m.GetSingleFloatWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			if err := google_protobuf_UInt64Value_hashpb_sum_err(m.GetSingleUint64Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			if err := google_protobuf_UInt32Value_hashpb_sum_err(m.GetSingleUint32Wrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			if err := google_protobuf_StringValue_hashpb_sum_err(m.GetSingleStringWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			if err := google_protobuf_BoolValue_hashpb_sum_err(m.GetSingleBoolWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			if err := google_protobuf_BytesValue_hashpb_sum_err(m.GetSingleBytesWrapper(), hasher, ignore); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum_noignore(m *TestAllTypes, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	if m.NestedType != nil {
+		switch t := m.NestedType.(type) {
+		case *TestAllTypes_SingleNestedMessage:
+			if t.SingleNestedMessage != nil {
+				cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(t.SingleNestedMessage, hasher)
+			}
+
+		case *TestAllTypes_SingleNestedEnum:
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
+
+		}
+	}
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	if len(m.RepeatedInt32) > 0 {
+		for _, v := range m.RepeatedInt32 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+		}
+	}
+	if len(m.RepeatedInt64) > 0 {
+		for _, v := range m.RepeatedInt64 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+		}
+	}
+	if len(m.RepeatedUint32) > 0 {
+		for _, v := range m.RepeatedUint32 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+		}
+	}
+	if len(m.RepeatedUint64) > 0 {
+		for _, v := range m.RepeatedUint64 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, v))
+
+		}
+	}
+	if len(m.RepeatedSint32) > 0 {
+		for _, v := range m.RepeatedSint32 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
+
+		}
+	}
+	if len(m.RepeatedSint64) > 0 {
+		for _, v := range m.RepeatedSint64 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
+
+		}
+	}
+	if len(m.RepeatedFixed32) > 0 {
+		for _, v := range m.RepeatedFixed32 {
+			_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+		}
+	}
+	if len(m.RepeatedFixed64) > 0 {
+		for _, v := range m.RepeatedFixed64 {
+			_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
+
+		}
+	}
+	if len(m.RepeatedSfixed32) > 0 {
+		for _, v := range m.RepeatedSfixed32 {
+			_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+		}
+	}
+	if len(m.RepeatedSfixed64) > 0 {
+		for _, v := range m.RepeatedSfixed64 {
+			_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
+
+		}
+	}
+	if len(m.RepeatedFloat) > 0 {
+		for _, v := range m.RepeatedFloat {
+			_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
+
+		}
+	}
+	if len(m.RepeatedDouble) > 0 {
+		for _, v := range m.RepeatedDouble {
+			_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
+
+		}
+	}
+	if len(m.RepeatedBool) > 0 {
+		for _, v := range m.RepeatedBool {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+
+		}
+	}
+	if len(m.RepeatedString) > 0 {
+		for _, v := range m.RepeatedString {
+			_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+		}
+	}
+	if len(m.RepeatedBytes) > 0 {
+		for _, v := range m.RepeatedBytes {
+			_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+
+		}
+	}
+	if len(m.RepeatedNestedMessage) > 0 {
+		for _, v := range m.RepeatedNestedMessage {
+			if v != nil {
+				cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(v, hasher)
+			}
+
+		}
+	}
+	if len(m.RepeatedNestedEnum) > 0 {
+		for _, v := range m.RepeatedNestedEnum {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+		}
+	}
+	if len(m.RepeatedStringPiece) > 0 {
+		for _, v := range m.RepeatedStringPiece {
+			_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+		}
+	}
+	if len(m.RepeatedCord) > 0 {
+		for _, v := range m.RepeatedCord {
+			_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+		}
+	}
+	if len(m.RepeatedLazyMessage) > 0 {
+		for _, v := range m.RepeatedLazyMessage {
+			if v != nil {
+				cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(v, hasher)
+			}
+
+		}
+	}
+	if len(m.MapStringString) > 0 {
+		keys := make([]string, len(m.MapStringString))
+		i := 0
+		for k := range m.MapStringString {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		for _, k := range keys {
+			_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+
+		}
+	}
+	if len(m.MapUint64String) > 0 {
+		keys := make([]uint64, len(m.MapUint64String))
+		i := 0
+		for k := range m.MapUint64String {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		for _, k := range keys {
+			_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+
+		}
+	}
+	if len(m.MapInt32String) > 0 {
+		keys := make([]int32, len(m.MapInt32String))
+		i := 0
+		for k := range m.MapInt32String {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		for _, k := range keys {
+			_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+
+		}
+	}
+	if len(m.MapBoolString) > 0 {
+		keys := make([]bool, len(m.MapBoolString))
+		i := 0
+		for k := range m.MapBoolString {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+		for _, k := range keys {
+			_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+
+		}
+	}
+	if len(m.MapInt64NestedType) > 0 {
+		keys := make([]int64, len(m.MapInt64NestedType))
+		i := 0
+		for k := range m.MapInt64NestedType {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		for _, k := range keys {
+			if m.MapInt64NestedType[k] != nil {
+				cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_noignore(m.MapInt64NestedType[k], hasher)
+			}
+
+		}
+	}
+	if m.GetSingleAny() != nil {
+		google_protobuf_Any_hashpb_sum_noignore(m.GetSingleAny(), hasher)
+	}
+
+	if m.GetSingleDuration() != nil {
+		google_protobuf_Duration_hashpb_sum_noignore(m.GetSingleDuration(), hasher)
+	}
+
+	if m.GetSingleTimestamp() != nil {
+		google_protobuf_Timestamp_hashpb_sum_noignore(m.GetSingleTimestamp(), hasher)
+	}
+
+	if m.GetSingleStruct() != nil {
+		google_protobuf_Struct_hashpb_sum_noignore(m.GetSingleStruct(), hasher)
+	}
+
+	if m.GetSingleValue() != nil {
+		google_protobuf_Value_hashpb_sum_noignore(m.GetSingleValue(), hasher)
+	}
+
+	if m.GetSingleInt64Wrapper() != nil {
+		google_protobuf_Int64Value_hashpb_sum_noignore(m.GetSingleInt64Wrapper(), hasher)
+	}
+
+	if m.GetSingleInt32Wrapper() != nil {
+		google_protobuf_Int32Value_hashpb_sum_noignore(m.GetSingleInt32Wrapper(), hasher)
+	}
+
+	if m.GetSingleDoubleWrapper() != nil {
+		google_protobuf_DoubleValue_hashpb_sum_noignore(m.GetSingleDoubleWrapper(), hasher)
+	}
+
+	if m.GetSingleFloatWrapper() != nil {
+		google_protobuf_FloatValue_hashpb_sum_noignore(m.GetSingleFloatWrapper(), hasher)
+	}
+
+	if m.GetSingleUint64Wrapper() != nil {
+		google_protobuf_UInt64Value_hashpb_sum_noignore(m.GetSingleUint64Wrapper(), hasher)
+	}
+
+	if m.GetSingleUint32Wrapper() != nil {
+		google_protobuf_UInt32Value_hashpb_sum_noignore(m.GetSingleUint32Wrapper(), hasher)
+	}
+
+	if m.GetSingleStringWrapper() != nil {
+		google_protobuf_StringValue_hashpb_sum_noignore(m.GetSingleStringWrapper(), hasher)
+	}
+
+	if m.GetSingleBoolWrapper() != nil {
+		google_protobuf_BoolValue_hashpb_sum_noignore(m.GetSingleBoolWrapper(), hasher)
+	}
+
+	if m.GetSingleBytesWrapper() != nil {
+		google_protobuf_BytesValue_hashpb_sum_noignore(m.GetSingleBytesWrapper(), hasher)
+	}
+
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum_shallow(m *TestAllTypes, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if m.NestedType != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
+			switch t := m.NestedType.(type) {
+			case *TestAllTypes_SingleNestedMessage:
+				if t.SingleNestedMessage != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypes.NestedMessage"))
+				}
+
+			case *TestAllTypes_SingleNestedEnum:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok {
+		if len(m.RepeatedInt32) > 0 {
+			for _, v := range m.RepeatedInt32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok {
+		if len(m.RepeatedInt64) > 0 {
+			for _, v := range m.RepeatedInt64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok {
+		if len(m.RepeatedUint32) > 0 {
+			for _, v := range m.RepeatedUint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok {
+		if len(m.RepeatedUint64) > 0 {
+			for _, v := range m.RepeatedUint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok {
+		if len(m.RepeatedSint32) > 0 {
+			for _, v := range m.RepeatedSint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok {
+		if len(m.RepeatedSint64) > 0 {
+			for _, v := range m.RepeatedSint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok {
+		if len(m.RepeatedFixed32) > 0 {
+			for _, v := range m.RepeatedFixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok {
+		if len(m.RepeatedFixed64) > 0 {
+			for _, v := range m.RepeatedFixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok {
+		if len(m.RepeatedSfixed32) > 0 {
+			for _, v := range m.RepeatedSfixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok {
+		if len(m.RepeatedSfixed64) > 0 {
+			for _, v := range m.RepeatedSfixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok {
+		if len(m.RepeatedFloat) > 0 {
+			for _, v := range m.RepeatedFloat {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok {
+		if len(m.RepeatedDouble) > 0 {
+			for _, v := range m.RepeatedDouble {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok {
+		if len(m.RepeatedBool) > 0 {
+			for _, v := range m.RepeatedBool {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok {
+		if len(m.RepeatedBytes) > 0 {
+			for _, v := range m.RepeatedBytes {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok {
+		if len(m.RepeatedNestedMessage) > 0 {
+			for _, v := range m.RepeatedNestedMessage {
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypes.NestedMessage"))
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok {
+		if len(m.RepeatedNestedEnum) > 0 {
+			for _, v := range m.RepeatedNestedEnum {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok {
+		if len(m.RepeatedStringPiece) > 0 {
+			for _, v := range m.RepeatedStringPiece {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok {
+		if len(m.RepeatedCord) > 0 {
+			for _, v := range m.RepeatedCord {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok {
+		if len(m.RepeatedLazyMessage) > 0 {
+			for _, v := range m.RepeatedLazyMessage {
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypes.NestedMessage"))
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok {
+		if len(m.MapUint64String) > 0 {
+			keys := make([]uint64, len(m.MapUint64String))
+			i := 0
+			for k := range m.MapUint64String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok {
+		if len(m.MapInt32String) > 0 {
+			keys := make([]int32, len(m.MapInt32String))
+			i := 0
+			for k := range m.MapInt32String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok {
+		if len(m.MapBoolString) > 0 {
+			keys := make([]bool, len(m.MapBoolString))
+			i := 0
+			for k := range m.MapBoolString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok {
+		if len(m.MapInt64NestedType) > 0 {
+			keys := make([]int64, len(m.MapInt64NestedType))
+			i := 0
+			for k := range m.MapInt64NestedType {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if m.MapInt64NestedType[k] != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestAllTypes.NestedMessage"))
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok {
+		if m.GetSingleAny() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Any"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok {
+		if m.GetSingleDuration() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Duration"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok {
+		if m.GetSingleTimestamp() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Timestamp"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok {
+		if m.GetSingleStruct() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Struct"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok {
+		if m.GetSingleValue() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok {
+		if m.GetSingleInt64Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Int64Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok {
+		if m.GetSingleInt32Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Int32Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok {
+		if m.GetSingleDoubleWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.DoubleValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok {
+		if m.GetSingleFloatWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.FloatValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok {
+		if m.GetSingleUint64Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.UInt64Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok {
+		if m.GetSingleUint32Wrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.UInt32Value"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.StringValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok {
+		if m.GetSingleBoolWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.BoolValue"))
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok {
+		if m.GetSingleBytesWrapper() != nil {
+			_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.BytesValue"))
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_sum_filtered(m *TestAllTypes, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestAllTypes.single_int32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_int64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleInt64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_uint32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSingleUint32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_uint64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetSingleUint64()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_sint32") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(m.GetSingleSint32()))))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_sint64") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(m.GetSingleSint64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_fixed32") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleFixed32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_fixed64") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, m.GetSingleFixed64()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_sfixed32") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(m.GetSingleSfixed32())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_sfixed64") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(m.GetSingleSfixed64())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_float") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetSingleFloat())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_double") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetSingleDouble())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_bool") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetSingleBool())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_string") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetSingleString()))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_bytes") {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetSingleBytes()))
+
+	}
+	if m.NestedType != nil {
+		switch t := m.NestedType.(type) {
+		case *TestAllTypes_SingleNestedMessage:
+			if filter("cerbos.hashpb.test.TestAllTypes.single_nested_message") {
+				if t.SingleNestedMessage != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(t.SingleNestedMessage, hasher, filter)
+				}
+
+			}
+		case *TestAllTypes_SingleNestedEnum:
+			if filter("cerbos.hashpb.test.TestAllTypes.single_nested_enum") {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.SingleNestedEnum)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.standalone_enum") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetStandaloneEnum())))
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_int32") {
+		if len(m.RepeatedInt32) > 0 {
+			for _, v := range m.RepeatedInt32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_int64") {
+		if len(m.RepeatedInt64) > 0 {
+			for _, v := range m.RepeatedInt64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_uint32") {
+		if len(m.RepeatedUint32) > 0 {
+			for _, v := range m.RepeatedUint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_uint64") {
+		if len(m.RepeatedUint64) > 0 {
+			for _, v := range m.RepeatedUint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_sint32") {
+		if len(m.RepeatedSint32) > 0 {
+			for _, v := range m.RepeatedSint32 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(int64(v))))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_sint64") {
+		if len(m.RepeatedSint64) > 0 {
+			for _, v := range m.RepeatedSint64 {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeZigZag(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_fixed32") {
+		if len(m.RepeatedFixed32) > 0 {
+			for _, v := range m.RepeatedFixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_fixed64") {
+		if len(m.RepeatedFixed64) > 0 {
+			for _, v := range m.RepeatedFixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_sfixed32") {
+		if len(m.RepeatedSfixed32) > 0 {
+			for _, v := range m.RepeatedSfixed32 {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, uint32(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_sfixed64") {
+		if len(m.RepeatedSfixed64) > 0 {
+			for _, v := range m.RepeatedSfixed64 {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_float") {
+		if len(m.RepeatedFloat) > 0 {
+			for _, v := range m.RepeatedFloat {
+				_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_double") {
+		if len(m.RepeatedDouble) > 0 {
+			for _, v := range m.RepeatedDouble {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_bool") {
 		if len(m.RepeatedBool) > 0 {
 			for _, v := range m.RepeatedBool {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_string") {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_bytes") {
+		if len(m.RepeatedBytes) > 0 {
+			for _, v := range m.RepeatedBytes {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_nested_message") {
+		if len(m.RepeatedNestedMessage) > 0 {
+			for _, v := range m.RepeatedNestedMessage {
+				if v != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(v, hasher, filter)
+				}
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_nested_enum") {
+		if len(m.RepeatedNestedEnum) > 0 {
+			for _, v := range m.RepeatedNestedEnum {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_string_piece") {
+		if len(m.RepeatedStringPiece) > 0 {
+			for _, v := range m.RepeatedStringPiece {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_cord") {
+		if len(m.RepeatedCord) > 0 {
+			for _, v := range m.RepeatedCord {
+				_, _ = hasher.Write(protowire.AppendString(nil, v))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.repeated_lazy_message") {
+		if len(m.RepeatedLazyMessage) > 0 {
+			for _, v := range m.RepeatedLazyMessage {
+				if v != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(v, hasher, filter)
+				}
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.map_string_string") {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.map_uint64_string") {
+		if len(m.MapUint64String) > 0 {
+			keys := make([]uint64, len(m.MapUint64String))
+			i := 0
+			for k := range m.MapUint64String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.map_int32_string") {
+		if len(m.MapInt32String) > 0 {
+			keys := make([]int32, len(m.MapInt32String))
+			i := 0
+			for k := range m.MapInt32String {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.map_bool_string") {
+		if len(m.MapBoolString) > 0 {
+			keys := make([]bool, len(m.MapBoolString))
+			i := 0
+			for k := range m.MapBoolString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.map_int64_nested_type") {
+		if len(m.MapInt64NestedType) > 0 {
+			keys := make([]int64, len(m.MapInt64NestedType))
+			i := 0
+			for k := range m.MapInt64NestedType {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				if m.MapInt64NestedType[k] != nil {
+					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum_filtered(m.MapInt64NestedType[k], hasher, filter)
+				}
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_any") {
+		if m.GetSingleAny() != nil {
+			google_protobuf_Any_hashpb_sum_filtered(m.GetSingleAny(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_duration") {
+		if m.GetSingleDuration() != nil {
+			google_protobuf_Duration_hashpb_sum_filtered(m.GetSingleDuration(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_timestamp") {
+		if m.GetSingleTimestamp() != nil {
+			google_protobuf_Timestamp_hashpb_sum_filtered(m.GetSingleTimestamp(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_struct") {
+		if m.GetSingleStruct() != nil {
+			google_protobuf_Struct_hashpb_sum_filtered(m.GetSingleStruct(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_value") {
+		if m.GetSingleValue() != nil {
+			google_protobuf_Value_hashpb_sum_filtered(m.GetSingleValue(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_int64_wrapper") {
+		if m.GetSingleInt64Wrapper() != nil {
+			google_protobuf_Int64Value_hashpb_sum_filtered(m.GetSingleInt64Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_int32_wrapper") {
+		if m.GetSingleInt32Wrapper() != nil {
+			google_protobuf_Int32Value_hashpb_sum_filtered(m.GetSingleInt32Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_double_wrapper") {
+		if m.GetSingleDoubleWrapper() != nil {
+			google_protobuf_DoubleValue_hashpb_sum_filtered(m.GetSingleDoubleWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_float_wrapper") {
+		if m.GetSingleFloatWrapper() != nil {
+			google_protobuf_FloatValue_hashpb_sum_filtered(m.GetSingleFloatWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper") {
+		if m.GetSingleUint64Wrapper() != nil {
+			google_protobuf_UInt64Value_hashpb_sum_filtered(m.GetSingleUint64Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper") {
+		if m.GetSingleUint32Wrapper() != nil {
+			google_protobuf_UInt32Value_hashpb_sum_filtered(m.GetSingleUint32Wrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_string_wrapper") {
+		if m.GetSingleStringWrapper() != nil {
+			google_protobuf_StringValue_hashpb_sum_filtered(m.GetSingleStringWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_bool_wrapper") {
+		if m.GetSingleBoolWrapper() != nil {
+			google_protobuf_BoolValue_hashpb_sum_filtered(m.GetSingleBoolWrapper(), hasher, filter)
+		}
+
+	}
+	if filter("cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper") {
+		if m.GetSingleBytesWrapper() != nil {
+			google_protobuf_BytesValue_hashpb_sum_filtered(m.GetSingleBytesWrapper(), hasher, filter)
+		}
+
+	}
+}
+
+func cerbos_hashpb_test_TestAllTypes_hashpb_objecthash(m *TestAllTypes, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32"]; !ok && m.SingleInt32 != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(int64(m.SingleInt32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64"]; !ok && m.SingleInt64 != 0 {
+			d.Add(objecthash.Int(2), objecthash.Int(m.SingleInt64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32"]; !ok && m.SingleUint32 != 0 {
+			d.Add(objecthash.Int(3), objecthash.Uint(uint64(m.SingleUint32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64"]; !ok && m.SingleUint64 != 0 {
+			d.Add(objecthash.Int(4), objecthash.Uint(m.SingleUint64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint32"]; !ok && m.SingleSint32 != 0 {
+			d.Add(objecthash.Int(5), objecthash.Int(int64(m.SingleSint32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sint64"]; !ok && m.SingleSint64 != 0 {
+			d.Add(objecthash.Int(6), objecthash.Int(m.SingleSint64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed32"]; !ok && m.SingleFixed32 != 0 {
+			d.Add(objecthash.Int(7), objecthash.Uint(uint64(m.SingleFixed32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_fixed64"]; !ok && m.SingleFixed64 != 0 {
+			d.Add(objecthash.Int(8), objecthash.Uint(m.SingleFixed64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed32"]; !ok && m.SingleSfixed32 != 0 {
+			d.Add(objecthash.Int(9), objecthash.Int(int64(m.SingleSfixed32)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_sfixed64"]; !ok && m.SingleSfixed64 != 0 {
+			d.Add(objecthash.Int(10), objecthash.Int(m.SingleSfixed64))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float"]; !ok && math.Float32bits(m.SingleFloat) != 0 {
+			d.Add(objecthash.Int(11), objecthash.Float(float64(m.SingleFloat)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double"]; !ok && math.Float64bits(m.SingleDouble) != 0 {
+			d.Add(objecthash.Int(12), objecthash.Float(m.SingleDouble))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool"]; !ok && m.SingleBool {
+			d.Add(objecthash.Int(13), objecthash.Bool(m.SingleBool))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string"]; !ok && m.SingleString != "" {
+			d.Add(objecthash.Int(14), objecthash.String(m.SingleString))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes"]; !ok && len(m.SingleBytes) > 0 {
+			d.Add(objecthash.Int(15), objecthash.Bytes(m.SingleBytes))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.nested_type"]; !ok {
+			switch t := m.NestedType.(type) {
+			case *TestAllTypes_SingleNestedMessage:
+				d.Add(objecthash.Int(18), cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(t.SingleNestedMessage, ignore))
+			case *TestAllTypes_SingleNestedEnum:
+				d.Add(objecthash.Int(21), objecthash.Int(int64(t.SingleNestedEnum)))
+			}
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.standalone_enum"]; !ok && m.StandaloneEnum != 0 {
+			d.Add(objecthash.Int(22), objecthash.Int(int64(m.StandaloneEnum)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int32"]; !ok && len(m.RepeatedInt32) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedInt32 {
+				l.Add(objecthash.Int(int64(v)))
+			}
+			d.Add(objecthash.Int(31), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_int64"]; !ok && len(m.RepeatedInt64) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedInt64 {
+				l.Add(objecthash.Int(v))
+			}
+			d.Add(objecthash.Int(32), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint32"]; !ok && len(m.RepeatedUint32) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedUint32 {
+				l.Add(objecthash.Uint(uint64(v)))
+			}
+			d.Add(objecthash.Int(33), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_uint64"]; !ok && len(m.RepeatedUint64) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedUint64 {
+				l.Add(objecthash.Uint(v))
+			}
+			d.Add(objecthash.Int(34), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint32"]; !ok && len(m.RepeatedSint32) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedSint32 {
+				l.Add(objecthash.Int(int64(v)))
+			}
+			d.Add(objecthash.Int(35), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sint64"]; !ok && len(m.RepeatedSint64) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedSint64 {
+				l.Add(objecthash.Int(v))
+			}
+			d.Add(objecthash.Int(36), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed32"]; !ok && len(m.RepeatedFixed32) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedFixed32 {
+				l.Add(objecthash.Uint(uint64(v)))
+			}
+			d.Add(objecthash.Int(37), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_fixed64"]; !ok && len(m.RepeatedFixed64) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedFixed64 {
+				l.Add(objecthash.Uint(v))
+			}
+			d.Add(objecthash.Int(38), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed32"]; !ok && len(m.RepeatedSfixed32) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedSfixed32 {
+				l.Add(objecthash.Int(int64(v)))
+			}
+			d.Add(objecthash.Int(39), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_sfixed64"]; !ok && len(m.RepeatedSfixed64) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedSfixed64 {
+				l.Add(objecthash.Int(v))
+			}
+			d.Add(objecthash.Int(40), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_float"]; !ok && len(m.RepeatedFloat) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedFloat {
+				l.Add(objecthash.Float(float64(v)))
+			}
+			d.Add(objecthash.Int(41), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_double"]; !ok && len(m.RepeatedDouble) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedDouble {
+				l.Add(objecthash.Float(v))
+			}
+			d.Add(objecthash.Int(42), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bool"]; !ok && len(m.RepeatedBool) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedBool {
+				l.Add(objecthash.Bool(v))
+			}
+			d.Add(objecthash.Int(43), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok && len(m.RepeatedString) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedString {
+				l.Add(objecthash.String(v))
+			}
+			d.Add(objecthash.Int(44), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok && len(m.RepeatedBytes) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedBytes {
+				l.Add(objecthash.Bytes(v))
+			}
+			d.Add(objecthash.Int(45), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok && len(m.RepeatedNestedMessage) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedNestedMessage {
+				l.Add(cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(48), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok && len(m.RepeatedNestedEnum) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedNestedEnum {
+				l.Add(objecthash.Int(int64(v)))
+			}
+			d.Add(objecthash.Int(51), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok && len(m.RepeatedStringPiece) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedStringPiece {
+				l.Add(objecthash.String(v))
+			}
+			d.Add(objecthash.Int(54), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok && len(m.RepeatedCord) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedCord {
+				l.Add(objecthash.String(v))
+			}
+			d.Add(objecthash.Int(55), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok && len(m.RepeatedLazyMessage) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedLazyMessage {
+				l.Add(cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(57), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok && len(m.MapStringString) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapStringString {
+				md.Add(objecthash.String(k), objecthash.String(v))
+			}
+			d.Add(objecthash.Int(58), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok && len(m.MapUint64String) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapUint64String {
+				md.Add(objecthash.Uint(k), objecthash.String(v))
+			}
+			d.Add(objecthash.Int(59), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok && len(m.MapInt32String) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapInt32String {
+				md.Add(objecthash.Int(int64(k)), objecthash.String(v))
+			}
+			d.Add(objecthash.Int(60), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok && len(m.MapBoolString) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapBoolString {
+				md.Add(objecthash.Bool(k), objecthash.String(v))
+			}
+			d.Add(objecthash.Int(61), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok && len(m.MapInt64NestedType) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapInt64NestedType {
+				md.Add(objecthash.Int(k), cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(62), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok && m.SingleAny != nil {
+			d.Add(objecthash.Int(100), google_protobuf_Any_hashpb_objecthash(m.SingleAny, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok && m.SingleDuration != nil {
+			d.Add(objecthash.Int(101), google_protobuf_Duration_hashpb_objecthash(m.SingleDuration, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok && m.SingleTimestamp != nil {
+			d.Add(objecthash.Int(102), google_protobuf_Timestamp_hashpb_objecthash(m.SingleTimestamp, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok && m.SingleStruct != nil {
+			d.Add(objecthash.Int(103), google_protobuf_Struct_hashpb_objecthash(m.SingleStruct, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok && m.SingleValue != nil {
+			d.Add(objecthash.Int(104), google_protobuf_Value_hashpb_objecthash(m.SingleValue, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok && m.SingleInt64Wrapper != nil {
+			d.Add(objecthash.Int(105), google_protobuf_Int64Value_hashpb_objecthash(m.SingleInt64Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok && m.SingleInt32Wrapper != nil {
+			d.Add(objecthash.Int(106), google_protobuf_Int32Value_hashpb_objecthash(m.SingleInt32Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok && m.SingleDoubleWrapper != nil {
+			d.Add(objecthash.Int(107), google_protobuf_DoubleValue_hashpb_objecthash(m.SingleDoubleWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok && m.SingleFloatWrapper != nil {
+			d.Add(objecthash.Int(108), google_protobuf_FloatValue_hashpb_objecthash(m.SingleFloatWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok && m.SingleUint64Wrapper != nil {
+			d.Add(objecthash.Int(109), google_protobuf_UInt64Value_hashpb_objecthash(m.SingleUint64Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok && m.SingleUint32Wrapper != nil {
+			d.Add(objecthash.Int(110), google_protobuf_UInt32Value_hashpb_objecthash(m.SingleUint32Wrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok && m.SingleStringWrapper != nil {
+			d.Add(objecthash.Int(111), google_protobuf_StringValue_hashpb_objecthash(m.SingleStringWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok && m.SingleBoolWrapper != nil {
+			d.Add(objecthash.Int(112), google_protobuf_BoolValue_hashpb_objecthash(m.SingleBoolWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok && m.SingleBytesWrapper != nil {
+			d.Add(objecthash.Int(113), google_protobuf_BytesValue_hashpb_objecthash(m.SingleBytesWrapper, ignore))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(m *TestFieldOptions_Item, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.id"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetId()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.rank"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.label"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_limited(m *TestFieldOptions_Item, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.id"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetId()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.rank"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.label"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel())))
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(m *TestFieldOptions_Item, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.id"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetId())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.rank"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.label"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_noignore(m *TestFieldOptions_Item, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetId()))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank())))
+
+	_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel())))
+
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_shallow(m *TestFieldOptions_Item, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.id"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetId()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.rank"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.label"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_filtered(m *TestFieldOptions_Item, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestFieldOptions.Item.id") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetId()))
+
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.Item.rank") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetRank())))
+
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.Item.label") {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetLabel())))
+
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_Item_hashpb_objecthash(m *TestFieldOptions_Item, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.id"]; !ok && m.Id != "" {
+			d.Add(objecthash.Int(1), objecthash.String(m.Id))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.rank"]; !ok && m.Rank != 0 {
+			d.Add(objecthash.Int(2), objecthash.Int(m.Rank))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.Item.label"]; !ok && m.Label != "" {
+			d.Add(objecthash.Int(3), objecthash.String(strings.ToLower(m.Label)))
+		}
+	}
+	return d.Sum()
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum(m *TestFieldOptions, hasher io.Writer, ignore map[string]struct{}) {
+	if !hashpb.Ignored(ignore, "cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetRenamed()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.email"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.display_name"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.tags"]; !ok {
+		if len(m.Tags) > 0 {
+			for _, v := range m.Tags {
+				_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.samples"]; !ok {
+		if len(m.Samples) > 0 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples))))
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.items_by_id"]; !ok {
+		if len(m.ItemsById) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(v, hasher, ignore)
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank"]; !ok {
+		if len(m.SampledItemsByRank) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted))))
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(v, hasher, ignore)
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.item_set"]; !ok {
+		if len(m.ItemSet) > 0 {
+			digests := make([][]byte, 0, len(m.ItemSet))
+			for _, v := range m.ItemSet {
+				hasher := sha256.New()
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum(v, hasher, ignore)
+				}
+
+				digests = append(digests, hasher.Sum(nil))
+			}
+			sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+			for _, digest := range digests {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, digest))
+			}
+		}
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum_limited(m *TestFieldOptions, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if !hashpb.Ignored(ignore, "cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetRenamed()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.email"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.display_name"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.tags"]; !ok {
+		if len(m.Tags) > 0 {
+			for _, v := range m.Tags {
+				_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.samples"]; !ok {
+		if len(m.Samples) > 0 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples))))
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.items_by_id"]; !ok {
+		if len(m.ItemsById) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank"]; !ok {
+		if len(m.SampledItemsByRank) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted))))
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.item_set"]; !ok {
+		if len(m.ItemSet) > 0 {
+			digests := make([][]byte, 0, len(m.ItemSet))
+			for _, v := range m.ItemSet {
+				hasher := sha256.New()
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+				digests = append(digests, hasher.Sum(nil))
+			}
+			sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+			for _, digest := range digests {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, digest))
+			}
+		}
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum_err(m *TestFieldOptions, hasher io.Writer, ignore map[string]struct{}) error {
+	if !hashpb.Ignored(ignore, "cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original") {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetRenamed())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.email"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.display_name"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.tags"]; !ok {
+		if len(m.Tags) > 0 {
+			for _, v := range m.Tags {
+				if _, err := hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v)))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.samples"]; !ok {
+		if len(m.Samples) > 0 {
+			if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples)))); err != nil {
+				return err
+			}
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(v))); err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.items_by_id"]; !ok {
+		if len(m.ItemsById) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank"]; !ok {
+		if len(m.SampledItemsByRank) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted)))); err != nil {
+				return err
+			}
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.item_set"]; !ok {
+		if len(m.ItemSet) > 0 {
+			digests := make([][]byte, 0, len(m.ItemSet))
+			for _, v := range m.ItemSet {
+				hasher := sha256.New()
+				if v != nil {
+					if err := cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+				digests = append(digests, hasher.Sum(nil))
+			}
+			sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+			for _, digest := range digests {
+				if _, err := hasher.Write(protowire.AppendBytes(nil, digest)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum_noignore(m *TestFieldOptions, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetRenamed()))
+
+	_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail())))
+
+	_, _ = hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName())))
+
+	if len(m.Tags) > 0 {
+		for _, v := range m.Tags {
+			_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v))))
+
+		}
+	}
+	if len(m.Samples) > 0 {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples))))
+		for i := 0; i < len(m.Samples); i += 2 {
+			v := m.Samples[i]
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+		}
+	}
+	if len(m.ItemsById) > 0 {
+		sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+		copy(sorted, m.ItemsById)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+		for _, v := range sorted {
+			if v != nil {
+				cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_noignore(v, hasher)
+			}
+
+		}
+	}
+	if len(m.SampledItemsByRank) > 0 {
+		sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+		copy(sorted, m.SampledItemsByRank)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted))))
+		for i := 0; i < len(sorted); i += 2 {
+			v := sorted[i]
+			if v != nil {
+				cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_noignore(v, hasher)
+			}
+
+		}
+	}
+	if len(m.ItemSet) > 0 {
+		digests := make([][]byte, 0, len(m.ItemSet))
+		for _, v := range m.ItemSet {
+			hasher := sha256.New()
+			if v != nil {
+				cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_noignore(v, hasher)
+			}
+
+			digests = append(digests, hasher.Sum(nil))
+		}
+		sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+		for _, digest := range digests {
+			_, _ = hasher.Write(protowire.AppendBytes(nil, digest))
+		}
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum_shallow(m *TestFieldOptions, hasher io.Writer, ignore map[string]struct{}) {
+	if !hashpb.Ignored(ignore, "cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetRenamed()))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.email"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.display_name"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.tags"]; !ok {
+		if len(m.Tags) > 0 {
+			for _, v := range m.Tags {
+				_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v))))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.samples"]; !ok {
+		if len(m.Samples) > 0 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples))))
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.items_by_id"]; !ok {
+		if len(m.ItemsById) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestFieldOptions.Item"))
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank"]; !ok {
+		if len(m.SampledItemsByRank) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted))))
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestFieldOptions.Item"))
+				}
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.item_set"]; !ok {
+		if len(m.ItemSet) > 0 {
+			digests := make([][]byte, 0, len(m.ItemSet))
+			for _, v := range m.ItemSet {
+				hasher := sha256.New()
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "cerbos.hashpb.test.TestFieldOptions.Item"))
+				}
+
+				digests = append(digests, hasher.Sum(nil))
+			}
+			sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+			for _, digest := range digests {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, digest))
+			}
+		}
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_sum_filtered(m *TestFieldOptions, hasher io.Writer, filter func(string) bool) {
+	if filter("cerbos.hashpb.test.TestFieldOptions.renamed") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetRenamed()))
+
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.email") {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(m.GetEmail())))
+
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.display_name") {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.TrimSpace(m.GetDisplayName())))
+
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.tags") {
+		if len(m.Tags) > 0 {
+			for _, v := range m.Tags {
+				_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(v))))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.samples") {
+		if len(m.Samples) > 0 {
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(m.Samples))))
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.items_by_id") {
+		if len(m.ItemsById) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_filtered(v, hasher, filter)
+				}
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank") {
+		if len(m.SampledItemsByRank) > 0 {
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(len(sorted))))
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_filtered(v, hasher, filter)
+				}
+
+			}
+		}
+	}
+	if filter("cerbos.hashpb.test.TestFieldOptions.item_set") {
+		if len(m.ItemSet) > 0 {
+			digests := make([][]byte, 0, len(m.ItemSet))
+			for _, v := range m.ItemSet {
+				hasher := sha256.New()
+				if v != nil {
+					cerbos_hashpb_test_TestFieldOptions_Item_hashpb_sum_filtered(v, hasher, filter)
+				}
+
+				digests = append(digests, hasher.Sum(nil))
+			}
+			sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+			for _, digest := range digests {
+				_, _ = hasher.Write(protowire.AppendBytes(nil, digest))
+			}
+		}
+	}
+}
+
+func cerbos_hashpb_test_TestFieldOptions_hashpb_objecthash(m *TestFieldOptions, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if !hashpb.Ignored(ignore, "cerbos.hashpb.test.TestFieldOptions.renamed", "cerbos.hashpb.test.TestFieldOptions.original") && m.Renamed != "" {
+			d.Add(objecthash.Int(1), objecthash.String(m.Renamed))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.email"]; !ok && m.Email != "" {
+			d.Add(objecthash.Int(2), objecthash.String(strings.ToLower(m.Email)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.display_name"]; !ok && m.DisplayName != "" {
+			d.Add(objecthash.Int(3), objecthash.String(strings.TrimSpace(m.DisplayName)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.tags"]; !ok && len(m.Tags) > 0 {
+			var l objecthash.List
+			for _, v := range m.Tags {
+				l.Add(objecthash.String(strings.ToLower(strings.TrimSpace(v))))
+			}
+			d.Add(objecthash.Int(4), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.samples"]; !ok && len(m.Samples) > 0 {
+			var l objecthash.List
+			l.Add(objecthash.Int(int64(len(m.Samples))))
+			for i := 0; i < len(m.Samples); i += 2 {
+				v := m.Samples[i]
+				l.Add(objecthash.Int(v))
+			}
+			d.Add(objecthash.Int(5), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.items_by_id"]; !ok && len(m.ItemsById) > 0 {
+			var l objecthash.List
+			sorted := make([]*TestFieldOptions_Item, len(m.ItemsById))
+			copy(sorted, m.ItemsById)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+			for _, v := range sorted {
+				l.Add(cerbos_hashpb_test_TestFieldOptions_Item_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(6), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.sampled_items_by_rank"]; !ok && len(m.SampledItemsByRank) > 0 {
+			var l objecthash.List
+			sorted := make([]*TestFieldOptions_Item, len(m.SampledItemsByRank))
+			copy(sorted, m.SampledItemsByRank)
+			sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetRank() < sorted[j].GetRank() })
+			l.Add(objecthash.Int(int64(len(sorted))))
+			for i := 0; i < len(sorted); i += 2 {
+				v := sorted[i]
+				l.Add(cerbos_hashpb_test_TestFieldOptions_Item_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(7), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.TestFieldOptions.item_set"]; !ok && len(m.ItemSet) > 0 {
+			var s objecthash.Set
+			for _, v := range m.ItemSet {
+				s.Add(cerbos_hashpb_test_TestFieldOptions_Item_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(8), s.Sum())
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Any_hashpb_sum(m *anypb.Any, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Any.type_url"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+
+	}
+	if _, ok := ignore["google.protobuf.Any.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_Any_hashpb_sum_limited(m *anypb.Any, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.Any.type_url"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+
+	}
+	if _, ok := ignore["google.protobuf.Any.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+	return nil
+}
+
+func google_protobuf_Any_hashpb_sum_err(m *anypb.Any, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Any.type_url"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetTypeUrl())); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["google.protobuf.Any.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendBytes(nil, m.GetValue())); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_Any_hashpb_sum_noignore(m *anypb.Any, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+
+	_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+}
+
+func google_protobuf_Any_hashpb_sum_shallow(m *anypb.Any, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Any.type_url"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+
+	}
+	if _, ok := ignore["google.protobuf.Any.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_Any_hashpb_sum_filtered(m *anypb.Any, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Any.type_url") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+
+	}
+	if filter("google.protobuf.Any.value") {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_Any_hashpb_objecthash(m *anypb.Any, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Any.type_url"]; !ok && m.TypeUrl != "" {
+			d.Add(objecthash.Int(1), objecthash.String(m.TypeUrl))
+		}
+		if _, ok := ignore["google.protobuf.Any.value"]; !ok && len(m.Value) > 0 {
+			d.Add(objecthash.Int(2), objecthash.Bytes(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_BoolValue_hashpb_sum(m *wrapperspb.BoolValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_BoolValue_hashpb_sum_limited(m *wrapperspb.BoolValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+
+	}
+	return nil
+}
+
+func google_protobuf_BoolValue_hashpb_sum_err(m *wrapperspb.BoolValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_BoolValue_hashpb_sum_noignore(m *wrapperspb.BoolValue, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+
+}
+
+func google_protobuf_BoolValue_hashpb_sum_shallow(m *wrapperspb.BoolValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_BoolValue_hashpb_sum_filtered(m *wrapperspb.BoolValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.BoolValue.value") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_BoolValue_hashpb_objecthash(m *wrapperspb.BoolValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok && m.Value {
+			d.Add(objecthash.Int(1), objecthash.Bool(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_BytesValue_hashpb_sum(m *wrapperspb.BytesValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_BytesValue_hashpb_sum_limited(m *wrapperspb.BytesValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+	return nil
+}
+
+func google_protobuf_BytesValue_hashpb_sum_err(m *wrapperspb.BytesValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendBytes(nil, m.GetValue())); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_BytesValue_hashpb_sum_noignore(m *wrapperspb.BytesValue, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+}
+
+func google_protobuf_BytesValue_hashpb_sum_shallow(m *wrapperspb.BytesValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_BytesValue_hashpb_sum_filtered(m *wrapperspb.BytesValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.BytesValue.value") {
+		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_BytesValue_hashpb_objecthash(m *wrapperspb.BytesValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok && len(m.Value) > 0 {
+			d.Add(objecthash.Int(1), objecthash.Bytes(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_DoubleValue_hashpb_sum(m *wrapperspb.DoubleValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_DoubleValue_hashpb_sum_limited(m *wrapperspb.DoubleValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+
+	}
+	return nil
+}
+
+func google_protobuf_DoubleValue_hashpb_sum_err(m *wrapperspb.DoubleValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_DoubleValue_hashpb_sum_noignore(m *wrapperspb.DoubleValue, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+
+}
+
+func google_protobuf_DoubleValue_hashpb_sum_shallow(m *wrapperspb.DoubleValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_DoubleValue_hashpb_sum_filtered(m *wrapperspb.DoubleValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.DoubleValue.value") {
+		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_DoubleValue_hashpb_objecthash(m *wrapperspb.DoubleValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok && math.Float64bits(m.Value) != 0 {
+			d.Add(objecthash.Int(1), objecthash.Float(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Duration_hashpb_sum(m *durationpb.Duration, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	}
+	if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+	}
+}
+
+func google_protobuf_Duration_hashpb_sum_limited(m *durationpb.Duration, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	}
+	if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+	}
+	return nil
+}
+
+func google_protobuf_Duration_hashpb_sum_err(m *durationpb.Duration, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds()))); err != nil {
+			return err
+		}
+
+	}
+	if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_Duration_hashpb_sum_noignore(m *durationpb.Duration, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+}
+
+func google_protobuf_Duration_hashpb_sum_shallow(m *durationpb.Duration, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	}
+	if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+	}
+}
+
+func google_protobuf_Duration_hashpb_sum_filtered(m *durationpb.Duration, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Duration.seconds") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	}
+	if filter("google.protobuf.Duration.nanos") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+	}
+}
+
+func google_protobuf_Duration_hashpb_objecthash(m *durationpb.Duration, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok && m.Seconds != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(m.Seconds))
+		}
+		if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok && m.Nanos != 0 {
+			d.Add(objecthash.Int(2), objecthash.Int(int64(m.Nanos)))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_FloatValue_hashpb_sum(m *wrapperspb.FloatValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_FloatValue_hashpb_sum_limited(m *wrapperspb.FloatValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+
+	}
+	return nil
+}
+
+func google_protobuf_FloatValue_hashpb_sum_err(m *wrapperspb.FloatValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_FloatValue_hashpb_sum_noignore(m *wrapperspb.FloatValue, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+
+}
+
+func google_protobuf_FloatValue_hashpb_sum_shallow(m *wrapperspb.FloatValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_FloatValue_hashpb_sum_filtered(m *wrapperspb.FloatValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.FloatValue.value") {
+		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_FloatValue_hashpb_objecthash(m *wrapperspb.FloatValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok && math.Float32bits(m.Value) != 0 {
+			d.Add(objecthash.Int(1), objecthash.Float(float64(m.Value)))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Int32Value_hashpb_sum(m *wrapperspb.Int32Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_Int32Value_hashpb_sum_limited(m *wrapperspb.Int32Value, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+	return nil
+}
+
+func google_protobuf_Int32Value_hashpb_sum_err(m *wrapperspb.Int32Value, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue()))); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_Int32Value_hashpb_sum_noignore(m *wrapperspb.Int32Value, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+}
+
+func google_protobuf_Int32Value_hashpb_sum_shallow(m *wrapperspb.Int32Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
 
-			}
+func google_protobuf_Int32Value_hashpb_sum_filtered(m *wrapperspb.Int32Value, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Int32Value.value") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_Int32Value_hashpb_objecthash(m *wrapperspb.Int32Value, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok && m.Value != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(int64(m.Value)))
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string"]; !ok {
-		if len(m.RepeatedString) > 0 {
-			for _, v := range m.RepeatedString {
-				_, _ = hasher.Write(protowire.AppendString(nil, v))
+	return d.Sum()
+}
 
-			}
+func google_protobuf_Int64Value_hashpb_sum(m *wrapperspb.Int64Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_Int64Value_hashpb_sum_limited(m *wrapperspb.Int64Value, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+	return nil
+}
+
+func google_protobuf_Int64Value_hashpb_sum_err(m *wrapperspb.Int64Value, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue()))); err != nil {
+			return err
 		}
+
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_bytes"]; !ok {
-		if len(m.RepeatedBytes) > 0 {
-			for _, v := range m.RepeatedBytes {
-				_, _ = hasher.Write(protowire.AppendBytes(nil, v))
+	return nil
+}
+
+func google_protobuf_Int64Value_hashpb_sum_noignore(m *wrapperspb.Int64Value, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+}
+
+func google_protobuf_Int64Value_hashpb_sum_shallow(m *wrapperspb.Int64Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_Int64Value_hashpb_sum_filtered(m *wrapperspb.Int64Value, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Int64Value.value") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_Int64Value_hashpb_objecthash(m *wrapperspb.Int64Value, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok && m.Value != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_ListValue_hashpb_sum(m *structpb.ListValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.ListValue.values"]; !ok {
+		if len(m.Values) > 0 {
+			for _, v := range m.Values {
+				if v != nil {
+					google_protobuf_Value_hashpb_sum(v, hasher, ignore)
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_message"]; !ok {
-		if len(m.RepeatedNestedMessage) > 0 {
-			for _, v := range m.RepeatedNestedMessage {
+}
+
+func google_protobuf_ListValue_hashpb_sum_limited(m *structpb.ListValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.ListValue.values"]; !ok {
+		if len(m.Values) > 0 {
+			for _, v := range m.Values {
 				if v != nil {
-					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(v, hasher, ignore)
+					if err := google_protobuf_Value_hashpb_sum_limited(v, hasher, ignore, depth-1); err != nil {
+						return err
+					}
 				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_nested_enum"]; !ok {
-		if len(m.RepeatedNestedEnum) > 0 {
-			for _, v := range m.RepeatedNestedEnum {
-				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(v)))
+	return nil
+}
+
+func google_protobuf_ListValue_hashpb_sum_err(m *structpb.ListValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.ListValue.values"]; !ok {
+		if len(m.Values) > 0 {
+			for _, v := range m.Values {
+				if v != nil {
+					if err := google_protobuf_Value_hashpb_sum_err(v, hasher, ignore); err != nil {
+						return err
+					}
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_string_piece"]; !ok {
-		if len(m.RepeatedStringPiece) > 0 {
-			for _, v := range m.RepeatedStringPiece {
-				_, _ = hasher.Write(protowire.AppendString(nil, v))
+	return nil
+}
 
+func google_protobuf_ListValue_hashpb_sum_noignore(m *structpb.ListValue, hasher io.Writer) {
+	if len(m.Values) > 0 {
+		for _, v := range m.Values {
+			if v != nil {
+				google_protobuf_Value_hashpb_sum_noignore(v, hasher)
 			}
+
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_cord"]; !ok {
-		if len(m.RepeatedCord) > 0 {
-			for _, v := range m.RepeatedCord {
-				_, _ = hasher.Write(protowire.AppendString(nil, v))
+}
+
+func google_protobuf_ListValue_hashpb_sum_shallow(m *structpb.ListValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.ListValue.values"]; !ok {
+		if len(m.Values) > 0 {
+			for _, v := range m.Values {
+				if v != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Value"))
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.repeated_lazy_message"]; !ok {
-		if len(m.RepeatedLazyMessage) > 0 {
-			for _, v := range m.RepeatedLazyMessage {
+}
+
+func google_protobuf_ListValue_hashpb_sum_filtered(m *structpb.ListValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.ListValue.values") {
+		if len(m.Values) > 0 {
+			for _, v := range m.Values {
 				if v != nil {
-					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(v, hasher, ignore)
+					google_protobuf_Value_hashpb_sum_filtered(v, hasher, filter)
 				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_string_string"]; !ok {
-		if len(m.MapStringString) > 0 {
-			keys := make([]string, len(m.MapStringString))
+}
+
+func google_protobuf_ListValue_hashpb_objecthash(m *structpb.ListValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.ListValue.values"]; !ok && len(m.Values) > 0 {
+			var l objecthash.List
+			for _, v := range m.Values {
+				l.Add(google_protobuf_Value_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(1), l.Sum())
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_StringValue_hashpb_sum(m *wrapperspb.StringValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_StringValue_hashpb_sum_limited(m *wrapperspb.StringValue, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
+
+	}
+	return nil
+}
+
+func google_protobuf_StringValue_hashpb_sum_err(m *wrapperspb.StringValue, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendString(nil, m.GetValue())); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func google_protobuf_StringValue_hashpb_sum_noignore(m *wrapperspb.StringValue, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
+
+}
+
+func google_protobuf_StringValue_hashpb_sum_shallow(m *wrapperspb.StringValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_StringValue_hashpb_sum_filtered(m *wrapperspb.StringValue, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.StringValue.value") {
+		_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
+
+	}
+}
+
+func google_protobuf_StringValue_hashpb_objecthash(m *wrapperspb.StringValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.StringValue.value"]; !ok && m.Value != "" {
+			d.Add(objecthash.Int(1), objecthash.String(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Struct_hashpb_sum(m *structpb.Struct, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Struct.fields"]; !ok {
+		if len(m.Fields) > 0 {
+			keys := make([]string, len(m.Fields))
 			i := 0
-			for k := range m.MapStringString {
+			for k := range m.Fields {
 				keys[i] = k
 				i++
 			}
@@ -459,16 +5515,24 @@ func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher hash.Has
 			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
 			for _, k := range keys {
-				_, _ = hasher.Write(protowire.AppendString(nil, m.MapStringString[k]))
+				if m.Fields[k] != nil {
+					google_protobuf_Value_hashpb_sum(m.Fields[k], hasher, ignore)
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_uint64_string"]; !ok {
-		if len(m.MapUint64String) > 0 {
-			keys := make([]uint64, len(m.MapUint64String))
+}
+
+func google_protobuf_Struct_hashpb_sum_limited(m *structpb.Struct, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
+	}
+	if _, ok := ignore["google.protobuf.Struct.fields"]; !ok {
+		if len(m.Fields) > 0 {
+			keys := make([]string, len(m.Fields))
 			i := 0
-			for k := range m.MapUint64String {
+			for k := range m.Fields {
 				keys[i] = k
 				i++
 			}
@@ -476,16 +5540,24 @@ func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher hash.Has
 			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
 			for _, k := range keys {
-				_, _ = hasher.Write(protowire.AppendString(nil, m.MapUint64String[k]))
+				if m.Fields[k] != nil {
+					if err := google_protobuf_Value_hashpb_sum_limited(m.Fields[k], hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int32_string"]; !ok {
-		if len(m.MapInt32String) > 0 {
-			keys := make([]int32, len(m.MapInt32String))
+	return nil
+}
+
+func google_protobuf_Struct_hashpb_sum_err(m *structpb.Struct, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Struct.fields"]; !ok {
+		if len(m.Fields) > 0 {
+			keys := make([]string, len(m.Fields))
 			i := 0
-			for k := range m.MapInt32String {
+			for k := range m.Fields {
 				keys[i] = k
 				i++
 			}
@@ -493,33 +5565,66 @@ func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher hash.Has
 			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
 			for _, k := range keys {
-				_, _ = hasher.Write(protowire.AppendString(nil, m.MapInt32String[k]))
+				if m.Fields[k] != nil {
+					if err := google_protobuf_Value_hashpb_sum_err(m.Fields[k], hasher, ignore); err != nil {
+						return err
+					}
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_bool_string"]; !ok {
-		if len(m.MapBoolString) > 0 {
-			keys := make([]bool, len(m.MapBoolString))
+	return nil
+}
+
+func google_protobuf_Struct_hashpb_sum_noignore(m *structpb.Struct, hasher io.Writer) {
+	if len(m.Fields) > 0 {
+		keys := make([]string, len(m.Fields))
+		i := 0
+		for k := range m.Fields {
+			keys[i] = k
+			i++
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		for _, k := range keys {
+			if m.Fields[k] != nil {
+				google_protobuf_Value_hashpb_sum_noignore(m.Fields[k], hasher)
+			}
+
+		}
+	}
+}
+
+func google_protobuf_Struct_hashpb_sum_shallow(m *structpb.Struct, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Struct.fields"]; !ok {
+		if len(m.Fields) > 0 {
+			keys := make([]string, len(m.Fields))
 			i := 0
-			for k := range m.MapBoolString {
+			for k := range m.Fields {
 				keys[i] = k
 				i++
 			}
 
-			sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
 			for _, k := range keys {
-				_, _ = hasher.Write(protowire.AppendString(nil, m.MapBoolString[k]))
+				if m.Fields[k] != nil {
+					_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Value"))
+				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.map_int64_nested_type"]; !ok {
-		if len(m.MapInt64NestedType) > 0 {
-			keys := make([]int64, len(m.MapInt64NestedType))
+}
+
+func google_protobuf_Struct_hashpb_sum_filtered(m *structpb.Struct, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Struct.fields") {
+		if len(m.Fields) > 0 {
+			keys := make([]string, len(m.Fields))
 			i := 0
-			for k := range m.MapInt64NestedType {
+			for k := range m.Fields {
 				keys[i] = k
 				i++
 			}
@@ -527,169 +5632,251 @@ func cerbos_hashpb_test_TestAllTypes_hashpb_sum(m *TestAllTypes, hasher hash.Has
 			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
 			for _, k := range keys {
-				if m.MapInt64NestedType[k] != nil {
-					cerbos_hashpb_test_TestAllTypes_NestedMessage_hashpb_sum(m.MapInt64NestedType[k], hasher, ignore)
+				if m.Fields[k] != nil {
+					google_protobuf_Value_hashpb_sum_filtered(m.Fields[k], hasher, filter)
 				}
 
 			}
 		}
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_any"]; !ok {
-		if m.GetSingleAny() != nil {
-			google_protobuf_Any_hashpb_sum(m.GetSingleAny(), hasher, ignore)
+}
+
+func google_protobuf_Struct_hashpb_objecthash(m *structpb.Struct, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Struct.fields"]; !ok && len(m.Fields) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.Fields {
+				md.Add(objecthash.String(k), google_protobuf_Value_hashpb_objecthash(v, ignore))
+			}
+			d.Add(objecthash.Int(1), md.Sum())
 		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Timestamp_hashpb_sum(m *timestamppb.Timestamp, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_duration"]; !ok {
-		if m.GetSingleDuration() != nil {
-			google_protobuf_Duration_hashpb_sum(m.GetSingleDuration(), hasher, ignore)
-		}
+	if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_timestamp"]; !ok {
-		if m.GetSingleTimestamp() != nil {
-			google_protobuf_Timestamp_hashpb_sum(m.GetSingleTimestamp(), hasher, ignore)
-		}
+}
 
+func google_protobuf_Timestamp_hashpb_sum_limited(m *timestamppb.Timestamp, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_struct"]; !ok {
-		if m.GetSingleStruct() != nil {
-			google_protobuf_Struct_hashpb_sum(m.GetSingleStruct(), hasher, ignore)
-		}
+	if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	}
+	if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_value"]; !ok {
-		if m.GetSingleValue() != nil {
-			google_protobuf_Value_hashpb_sum(m.GetSingleValue(), hasher, ignore)
+	return nil
+}
+
+func google_protobuf_Timestamp_hashpb_sum_err(m *timestamppb.Timestamp, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds()))); err != nil {
+			return err
 		}
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int64_wrapper"]; !ok {
-		if m.GetSingleInt64Wrapper() != nil {
-			google_protobuf_Int64Value_hashpb_sum(m.GetSingleInt64Wrapper(), hasher, ignore)
+	if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos()))); err != nil {
+			return err
 		}
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_int32_wrapper"]; !ok {
-		if m.GetSingleInt32Wrapper() != nil {
-			google_protobuf_Int32Value_hashpb_sum(m.GetSingleInt32Wrapper(), hasher, ignore)
-		}
+	return nil
+}
+
+func google_protobuf_Timestamp_hashpb_sum_noignore(m *timestamppb.Timestamp, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+
+}
+
+func google_protobuf_Timestamp_hashpb_sum_shallow(m *timestamppb.Timestamp, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_double_wrapper"]; !ok {
-		if m.GetSingleDoubleWrapper() != nil {
-			google_protobuf_DoubleValue_hashpb_sum(m.GetSingleDoubleWrapper(), hasher, ignore)
-		}
+	if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_float_wrapper"]; !ok {
-		if m.GetSingleFloatWrapper() != nil {
-			google_protobuf_FloatValue_hashpb_sum(m.GetSingleFloatWrapper(), hasher, ignore)
-		}
+}
+
+func google_protobuf_Timestamp_hashpb_sum_filtered(m *timestamppb.Timestamp, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.Timestamp.seconds") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint64_wrapper"]; !ok {
-		if m.GetSingleUint64Wrapper() != nil {
-			google_protobuf_UInt64Value_hashpb_sum(m.GetSingleUint64Wrapper(), hasher, ignore)
-		}
+	if filter("google.protobuf.Timestamp.nanos") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_uint32_wrapper"]; !ok {
-		if m.GetSingleUint32Wrapper() != nil {
-			google_protobuf_UInt32Value_hashpb_sum(m.GetSingleUint32Wrapper(), hasher, ignore)
+}
+
+func google_protobuf_Timestamp_hashpb_objecthash(m *timestamppb.Timestamp, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok && m.Seconds != 0 {
+			d.Add(objecthash.Int(1), objecthash.Int(m.Seconds))
+		}
+		if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok && m.Nanos != 0 {
+			d.Add(objecthash.Int(2), objecthash.Int(int64(m.Nanos)))
 		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_UInt32Value_hashpb_sum(m *wrapperspb.UInt32Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_string_wrapper"]; !ok {
-		if m.GetSingleStringWrapper() != nil {
-			google_protobuf_StringValue_hashpb_sum(m.GetSingleStringWrapper(), hasher, ignore)
-		}
+}
 
+func google_protobuf_UInt32Value_hashpb_sum_limited(m *wrapperspb.UInt32Value, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bool_wrapper"]; !ok {
-		if m.GetSingleBoolWrapper() != nil {
-			google_protobuf_BoolValue_hashpb_sum(m.GetSingleBoolWrapper(), hasher, ignore)
-		}
+	if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
 
 	}
-	if _, ok := ignore["cerbos.hashpb.test.TestAllTypes.single_bytes_wrapper"]; !ok {
-		if m.GetSingleBytesWrapper() != nil {
-			google_protobuf_BytesValue_hashpb_sum(m.GetSingleBytesWrapper(), hasher, ignore)
+	return nil
+}
+
+func google_protobuf_UInt32Value_hashpb_sum_err(m *wrapperspb.UInt32Value, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue()))); err != nil {
+			return err
 		}
 
 	}
+	return nil
 }
 
-func google_protobuf_Any_hashpb_sum(m *anypb.Any, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Any.type_url"]; !ok {
-		_, _ = hasher.Write(protowire.AppendString(nil, m.GetTypeUrl()))
+func google_protobuf_UInt32Value_hashpb_sum_noignore(m *wrapperspb.UInt32Value, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
 
-	}
-	if _, ok := ignore["google.protobuf.Any.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
+}
+
+func google_protobuf_UInt32Value_hashpb_sum_shallow(m *wrapperspb.UInt32Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
 
 	}
 }
 
-func google_protobuf_BoolValue_hashpb_sum(m *wrapperspb.BoolValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.BoolValue.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(m.GetValue())))
+func google_protobuf_UInt32Value_hashpb_sum_filtered(m *wrapperspb.UInt32Value, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.UInt32Value.value") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
 
 	}
 }
 
-func google_protobuf_BytesValue_hashpb_sum(m *wrapperspb.BytesValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.BytesValue.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendBytes(nil, m.GetValue()))
-
+func google_protobuf_UInt32Value_hashpb_objecthash(m *wrapperspb.UInt32Value, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok && m.Value != 0 {
+			d.Add(objecthash.Int(1), objecthash.Uint(uint64(m.Value)))
+		}
 	}
+	return d.Sum()
 }
 
-func google_protobuf_DoubleValue_hashpb_sum(m *wrapperspb.DoubleValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.DoubleValue.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(m.GetValue())))
+func google_protobuf_UInt64Value_hashpb_sum(m *wrapperspb.UInt64Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
 
 	}
 }
 
-func google_protobuf_Duration_hashpb_sum(m *durationpb.Duration, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Duration.seconds"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
-
+func google_protobuf_UInt64Value_hashpb_sum_limited(m *wrapperspb.UInt64Value, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
 	}
-	if _, ok := ignore["google.protobuf.Duration.nanos"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+	if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
 
 	}
+	return nil
 }
 
-func google_protobuf_FloatValue_hashpb_sum(m *wrapperspb.FloatValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.FloatValue.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendFixed32(nil, math.Float32bits(m.GetValue())))
+func google_protobuf_UInt64Value_hashpb_sum_err(m *wrapperspb.UInt64Value, hasher io.Writer, ignore map[string]struct{}) error {
+	if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok {
+		if _, err := hasher.Write(protowire.AppendVarint(nil, m.GetValue())); err != nil {
+			return err
+		}
 
 	}
+	return nil
 }
 
-func google_protobuf_Int32Value_hashpb_sum(m *wrapperspb.Int32Value, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Int32Value.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+func google_protobuf_UInt64Value_hashpb_sum_noignore(m *wrapperspb.UInt64Value, hasher io.Writer) {
+	_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
+
+}
+
+func google_protobuf_UInt64Value_hashpb_sum_shallow(m *wrapperspb.UInt64Value, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
 
 	}
 }
 
-func google_protobuf_Int64Value_hashpb_sum(m *wrapperspb.Int64Value, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Int64Value.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+func google_protobuf_UInt64Value_hashpb_sum_filtered(m *wrapperspb.UInt64Value, hasher io.Writer, filter func(string) bool) {
+	if filter("google.protobuf.UInt64Value.value") {
+		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
 
 	}
 }
 
-func google_protobuf_ListValue_hashpb_sum(m *structpb.ListValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.ListValue.values"]; !ok {
-		if len(m.Values) > 0 {
-			for _, v := range m.Values {
-				if v != nil {
-					google_protobuf_Value_hashpb_sum(v, hasher, ignore)
+func google_protobuf_UInt64Value_hashpb_objecthash(m *wrapperspb.UInt64Value, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok && m.Value != 0 {
+			d.Add(objecthash.Int(1), objecthash.Uint(m.Value))
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_Value_hashpb_sum(m *structpb.Value, hasher io.Writer, ignore map[string]struct{}) {
+	if m.Kind != nil {
+		if _, ok := ignore["google.protobuf.Value.kind"]; !ok {
+			switch t := m.Kind.(type) {
+			case *structpb.Value_NullValue:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.NullValue)))
+
+			case *structpb.Value_NumberValue:
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(t.NumberValue)))
+
+			case *structpb.Value_StringValue:
+				_, _ = hasher.Write(protowire.AppendString(nil, t.StringValue))
+
+			case *structpb.Value_BoolValue:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(t.BoolValue)))
+
+			case *structpb.Value_StructValue:
+				if t.StructValue != nil {
+					google_protobuf_Struct_hashpb_sum(t.StructValue, hasher, ignore)
+				}
+
+			case *structpb.Value_ListValue:
+				if t.ListValue != nil {
+					google_protobuf_ListValue_hashpb_sum(t.ListValue, hasher, ignore)
 				}
 
 			}
@@ -697,61 +5884,119 @@ func google_protobuf_ListValue_hashpb_sum(m *structpb.ListValue, hasher hash.Has
 	}
 }
 
-func google_protobuf_StringValue_hashpb_sum(m *wrapperspb.StringValue, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendString(nil, m.GetValue()))
-
+func google_protobuf_Value_hashpb_sum_limited(m *structpb.Value, hasher io.Writer, ignore map[string]struct{}, depth int) error {
+	if depth < 1 {
+		return hashpb.ErrMaxDepth
 	}
-}
+	if m.Kind != nil {
+		if _, ok := ignore["google.protobuf.Value.kind"]; !ok {
+			switch t := m.Kind.(type) {
+			case *structpb.Value_NullValue:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.NullValue)))
 
-func google_protobuf_Struct_hashpb_sum(m *structpb.Struct, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Struct.fields"]; !ok {
-		if len(m.Fields) > 0 {
-			keys := make([]string, len(m.Fields))
-			i := 0
-			for k := range m.Fields {
-				keys[i] = k
-				i++
-			}
+			case *structpb.Value_NumberValue:
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(t.NumberValue)))
 
-			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			case *structpb.Value_StringValue:
+				_, _ = hasher.Write(protowire.AppendString(nil, t.StringValue))
 
-			for _, k := range keys {
-				if m.Fields[k] != nil {
-					google_protobuf_Value_hashpb_sum(m.Fields[k], hasher, ignore)
+			case *structpb.Value_BoolValue:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(t.BoolValue)))
+
+			case *structpb.Value_StructValue:
+				if t.StructValue != nil {
+					if err := google_protobuf_Struct_hashpb_sum_limited(t.StructValue, hasher, ignore, depth-1); err != nil {
+						return err
+					}
+				}
+
+			case *structpb.Value_ListValue:
+				if t.ListValue != nil {
+					if err := google_protobuf_ListValue_hashpb_sum_limited(t.ListValue, hasher, ignore, depth-1); err != nil {
+						return err
+					}
 				}
 
 			}
 		}
 	}
+	return nil
 }
 
-func google_protobuf_Timestamp_hashpb_sum(m *timestamppb.Timestamp, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.Timestamp.seconds"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetSeconds())))
+func google_protobuf_Value_hashpb_sum_err(m *structpb.Value, hasher io.Writer, ignore map[string]struct{}) error {
+	if m.Kind != nil {
+		if _, ok := ignore["google.protobuf.Value.kind"]; !ok {
+			switch t := m.Kind.(type) {
+			case *structpb.Value_NullValue:
+				if _, err := hasher.Write(protowire.AppendVarint(nil, uint64(t.NullValue))); err != nil {
+					return err
+				}
 
-	}
-	if _, ok := ignore["google.protobuf.Timestamp.nanos"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetNanos())))
+			case *structpb.Value_NumberValue:
+				if _, err := hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(t.NumberValue))); err != nil {
+					return err
+				}
 
-	}
-}
+			case *structpb.Value_StringValue:
+				if _, err := hasher.Write(protowire.AppendString(nil, t.StringValue)); err != nil {
+					return err
+				}
 
-func google_protobuf_UInt32Value_hashpb_sum(m *wrapperspb.UInt32Value, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.UInt32Value.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(m.GetValue())))
+			case *structpb.Value_BoolValue:
+				if _, err := hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(t.BoolValue))); err != nil {
+					return err
+				}
+
+			case *structpb.Value_StructValue:
+				if t.StructValue != nil {
+					if err := google_protobuf_Struct_hashpb_sum_err(t.StructValue, hasher, ignore); err != nil {
+						return err
+					}
+				}
+
+			case *structpb.Value_ListValue:
+				if t.ListValue != nil {
+					if err := google_protobuf_ListValue_hashpb_sum_err(t.ListValue, hasher, ignore); err != nil {
+						return err
+					}
+				}
 
+			}
+		}
 	}
+	return nil
 }
 
-func google_protobuf_UInt64Value_hashpb_sum(m *wrapperspb.UInt64Value, hasher hash.Hash, ignore map[string]struct{}) {
-	if _, ok := ignore["google.protobuf.UInt64Value.value"]; !ok {
-		_, _ = hasher.Write(protowire.AppendVarint(nil, m.GetValue()))
+func google_protobuf_Value_hashpb_sum_noignore(m *structpb.Value, hasher io.Writer) {
+	if m.Kind != nil {
+		switch t := m.Kind.(type) {
+		case *structpb.Value_NullValue:
+			_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.NullValue)))
 
+		case *structpb.Value_NumberValue:
+			_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(t.NumberValue)))
+
+		case *structpb.Value_StringValue:
+			_, _ = hasher.Write(protowire.AppendString(nil, t.StringValue))
+
+		case *structpb.Value_BoolValue:
+			_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(t.BoolValue)))
+
+		case *structpb.Value_StructValue:
+			if t.StructValue != nil {
+				google_protobuf_Struct_hashpb_sum_noignore(t.StructValue, hasher)
+			}
+
+		case *structpb.Value_ListValue:
+			if t.ListValue != nil {
+				google_protobuf_ListValue_hashpb_sum_noignore(t.ListValue, hasher)
+			}
+
+		}
 	}
 }
 
-func google_protobuf_Value_hashpb_sum(m *structpb.Value, hasher hash.Hash, ignore map[string]struct{}) {
+func google_protobuf_Value_hashpb_sum_shallow(m *structpb.Value, hasher io.Writer, ignore map[string]struct{}) {
 	if m.Kind != nil {
 		if _, ok := ignore["google.protobuf.Value.kind"]; !ok {
 			switch t := m.Kind.(type) {
@@ -769,15 +6014,79 @@ func google_protobuf_Value_hashpb_sum(m *structpb.Value, hasher hash.Hash, ignor
 
 			case *structpb.Value_StructValue:
 				if t.StructValue != nil {
-					google_protobuf_Struct_hashpb_sum(t.StructValue, hasher, ignore)
+					_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.Struct"))
 				}
 
 			case *structpb.Value_ListValue:
 				if t.ListValue != nil {
-					google_protobuf_ListValue_hashpb_sum(t.ListValue, hasher, ignore)
+					_, _ = hasher.Write(protowire.AppendString(nil, "google.protobuf.ListValue"))
+				}
+
+			}
+		}
+	}
+}
+
+func google_protobuf_Value_hashpb_sum_filtered(m *structpb.Value, hasher io.Writer, filter func(string) bool) {
+	if m.Kind != nil {
+		switch t := m.Kind.(type) {
+		case *structpb.Value_NullValue:
+			if filter("google.protobuf.Value.null_value") {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.NullValue)))
+
+			}
+		case *structpb.Value_NumberValue:
+			if filter("google.protobuf.Value.number_value") {
+				_, _ = hasher.Write(protowire.AppendFixed64(nil, math.Float64bits(t.NumberValue)))
+
+			}
+		case *structpb.Value_StringValue:
+			if filter("google.protobuf.Value.string_value") {
+				_, _ = hasher.Write(protowire.AppendString(nil, t.StringValue))
+
+			}
+		case *structpb.Value_BoolValue:
+			if filter("google.protobuf.Value.bool_value") {
+				_, _ = hasher.Write(protowire.AppendVarint(nil, protowire.EncodeBool(t.BoolValue)))
+
+			}
+		case *structpb.Value_StructValue:
+			if filter("google.protobuf.Value.struct_value") {
+				if t.StructValue != nil {
+					google_protobuf_Struct_hashpb_sum_filtered(t.StructValue, hasher, filter)
+				}
+
+			}
+		case *structpb.Value_ListValue:
+			if filter("google.protobuf.Value.list_value") {
+				if t.ListValue != nil {
+					google_protobuf_ListValue_hashpb_sum_filtered(t.ListValue, hasher, filter)
 				}
 
 			}
 		}
 	}
 }
+
+func google_protobuf_Value_hashpb_objecthash(m *structpb.Value, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.Value.kind"]; !ok {
+			switch t := m.Kind.(type) {
+			case *structpb.Value_NullValue:
+				d.Add(objecthash.Int(1), objecthash.Int(int64(t.NullValue)))
+			case *structpb.Value_NumberValue:
+				d.Add(objecthash.Int(2), objecthash.Float(t.NumberValue))
+			case *structpb.Value_StringValue:
+				d.Add(objecthash.Int(3), objecthash.String(t.StringValue))
+			case *structpb.Value_BoolValue:
+				d.Add(objecthash.Int(4), objecthash.Bool(t.BoolValue))
+			case *structpb.Value_StructValue:
+				d.Add(objecthash.Int(5), google_protobuf_Struct_hashpb_objecthash(t.StructValue, ignore))
+			case *structpb.Value_ListValue:
+				d.Add(objecthash.Int(6), google_protobuf_ListValue_hashpb_objecthash(t.ListValue, ignore))
+			}
+		}
+	}
+	return d.Sum()
+}
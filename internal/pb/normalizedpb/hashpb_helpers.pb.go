@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+
+package normalizedpb
+
+import (
+	hashpb "github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	objecthash "github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	norm "golang.org/x/text/unicode/norm"
+	protowire "google.golang.org/protobuf/encoding/protowire"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	io "io"
+	sort "sort"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the version of the hashpb package in use.
+const (
+	_ = hashpb.EnforceVersion(1 - hashpb.MinGenVersion)
+	_ = hashpb.EnforceVersion(hashpb.GenVersion - 1)
+)
+
+func cerbos_hashpb_test_normalized_TestNormalizedStrings_hashpb_sum(m *TestNormalizedStrings, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.single_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(m.GetSingleString())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.optional_string"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(m.GetOptionalString())))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.repeated_string"]; !ok {
+		if len(m.RepeatedString) > 0 {
+			for _, v := range m.RepeatedString {
+				_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(v)))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.map_string_string"]; !ok {
+		if len(m.MapStringString) > 0 {
+			keys := make([]string, len(m.MapStringString))
+			i := 0
+			for k := range m.MapStringString {
+				keys[i] = k
+				i++
+			}
+
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+			for _, k := range keys {
+				_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(m.MapStringString[k])))
+
+			}
+		}
+	}
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.email"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, strings.ToLower(strings.TrimSpace(norm.NFC.String(m.GetEmail())))))
+
+	}
+	if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.single_string_wrapper"]; !ok {
+		if m.GetSingleStringWrapper() != nil {
+			google_protobuf_StringValue_hashpb_sum(m.GetSingleStringWrapper(), hasher, ignore)
+		}
+
+	}
+	if m.Choice != nil {
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.choice"]; !ok {
+			switch t := m.Choice.(type) {
+			case *TestNormalizedStrings_OneofString:
+				_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(t.OneofString)))
+
+			case *TestNormalizedStrings_OneofInt32:
+				_, _ = hasher.Write(protowire.AppendVarint(nil, uint64(t.OneofInt32)))
+
+			}
+		}
+	}
+}
+
+func cerbos_hashpb_test_normalized_TestNormalizedStrings_hashpb_objecthash(m *TestNormalizedStrings, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.single_string"]; !ok && m.SingleString != "" {
+			d.Add(objecthash.Int(1), objecthash.String(norm.NFC.String(m.SingleString)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.optional_string"]; !ok && m.OptionalString != nil {
+			d.Add(objecthash.Int(2), objecthash.String(norm.NFC.String(*m.OptionalString)))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.repeated_string"]; !ok && len(m.RepeatedString) > 0 {
+			var l objecthash.List
+			for _, v := range m.RepeatedString {
+				l.Add(objecthash.String(norm.NFC.String(v)))
+			}
+			d.Add(objecthash.Int(3), l.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.map_string_string"]; !ok && len(m.MapStringString) > 0 {
+			var md objecthash.Dict
+			for k, v := range m.MapStringString {
+				md.Add(objecthash.String(norm.NFC.String(k)), objecthash.String(norm.NFC.String(v)))
+			}
+			d.Add(objecthash.Int(4), md.Sum())
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.email"]; !ok && m.Email != "" {
+			d.Add(objecthash.Int(5), objecthash.String(strings.ToLower(strings.TrimSpace(norm.NFC.String(m.Email)))))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.single_string_wrapper"]; !ok && m.SingleStringWrapper != nil {
+			d.Add(objecthash.Int(6), google_protobuf_StringValue_hashpb_objecthash(m.SingleStringWrapper, ignore))
+		}
+		if _, ok := ignore["cerbos.hashpb.test.normalized.TestNormalizedStrings.choice"]; !ok {
+			switch t := m.Choice.(type) {
+			case *TestNormalizedStrings_OneofString:
+				d.Add(objecthash.Int(7), objecthash.String(norm.NFC.String(t.OneofString)))
+			case *TestNormalizedStrings_OneofInt32:
+				d.Add(objecthash.Int(8), objecthash.Int(int64(t.OneofInt32)))
+			}
+		}
+	}
+	return d.Sum()
+}
+
+func google_protobuf_StringValue_hashpb_sum(m *wrapperspb.StringValue, hasher io.Writer, ignore map[string]struct{}) {
+	if _, ok := ignore["google.protobuf.StringValue.value"]; !ok {
+		_, _ = hasher.Write(protowire.AppendString(nil, norm.NFC.String(m.GetValue())))
+
+	}
+}
+
+func google_protobuf_StringValue_hashpb_objecthash(m *wrapperspb.StringValue, ignore map[string]struct{}) [objecthash.Size]byte {
+	var d objecthash.Dict
+	if m != nil {
+		if _, ok := ignore["google.protobuf.StringValue.value"]; !ok && m.Value != "" {
+			d.Add(objecthash.Int(1), objecthash.String(norm.NFC.String(m.Value)))
+		}
+	}
+	return d.Sum()
+}
@@ -0,0 +1,279 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: internal/pb/normalizedpb/normalized.proto
+
+package normalizedpb
+
+import (
+	_ "github.com/cerbos/protoc-gen-go-hashpb/hashpb/optionspb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This proto tests code generated with the normalize_unicode parameter, which is only used for this file.
+type TestNormalizedStrings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SingleString        string                  `protobuf:"bytes,1,opt,name=single_string,json=singleString,proto3" json:"single_string,omitempty"`
+	OptionalString      *string                 `protobuf:"bytes,2,opt,name=optional_string,json=optionalString,proto3,oneof" json:"optional_string,omitempty"`
+	RepeatedString      []string                `protobuf:"bytes,3,rep,name=repeated_string,json=repeatedString,proto3" json:"repeated_string,omitempty"`
+	MapStringString     map[string]string       `protobuf:"bytes,4,rep,name=map_string_string,json=mapStringString,proto3" json:"map_string_string,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Email               string                  `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	SingleStringWrapper *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=single_string_wrapper,json=singleStringWrapper,proto3" json:"single_string_wrapper,omitempty"`
+	// Types that are assignable to Choice:
+	//	*TestNormalizedStrings_OneofString
+	//	*TestNormalizedStrings_OneofInt32
+	Choice isTestNormalizedStrings_Choice `protobuf_oneof:"choice"`
+}
+
+func (x *TestNormalizedStrings) Reset() {
+	*x = TestNormalizedStrings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_pb_normalizedpb_normalized_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestNormalizedStrings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNormalizedStrings) ProtoMessage() {}
+
+func (x *TestNormalizedStrings) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_normalizedpb_normalized_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNormalizedStrings.ProtoReflect.Descriptor instead.
+func (*TestNormalizedStrings) Descriptor() ([]byte, []int) {
+	return file_internal_pb_normalizedpb_normalized_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TestNormalizedStrings) GetSingleString() string {
+	if x != nil {
+		return x.SingleString
+	}
+	return ""
+}
+
+func (x *TestNormalizedStrings) GetOptionalString() string {
+	if x != nil && x.OptionalString != nil {
+		return *x.OptionalString
+	}
+	return ""
+}
+
+func (x *TestNormalizedStrings) GetRepeatedString() []string {
+	if x != nil {
+		return x.RepeatedString
+	}
+	return nil
+}
+
+func (x *TestNormalizedStrings) GetMapStringString() map[string]string {
+	if x != nil {
+		return x.MapStringString
+	}
+	return nil
+}
+
+func (x *TestNormalizedStrings) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TestNormalizedStrings) GetSingleStringWrapper() *wrapperspb.StringValue {
+	if x != nil {
+		return x.SingleStringWrapper
+	}
+	return nil
+}
+
+func (m *TestNormalizedStrings) GetChoice() isTestNormalizedStrings_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (x *TestNormalizedStrings) GetOneofString() string {
+	if x, ok := x.GetChoice().(*TestNormalizedStrings_OneofString); ok {
+		return x.OneofString
+	}
+	return ""
+}
+
+func (x *TestNormalizedStrings) GetOneofInt32() int32 {
+	if x, ok := x.GetChoice().(*TestNormalizedStrings_OneofInt32); ok {
+		return x.OneofInt32
+	}
+	return 0
+}
+
+type isTestNormalizedStrings_Choice interface {
+	isTestNormalizedStrings_Choice()
+}
+
+type TestNormalizedStrings_OneofString struct {
+	OneofString string `protobuf:"bytes,7,opt,name=oneof_string,json=oneofString,proto3,oneof"`
+}
+
+type TestNormalizedStrings_OneofInt32 struct {
+	OneofInt32 int32 `protobuf:"varint,8,opt,name=oneof_int32,json=oneofInt32,proto3,oneof"`
+}
+
+func (*TestNormalizedStrings_OneofString) isTestNormalizedStrings_Choice() {}
+
+func (*TestNormalizedStrings_OneofInt32) isTestNormalizedStrings_Choice() {}
+
+var File_internal_pb_normalizedpb_normalized_proto protoreflect.FileDescriptor
+
+var file_internal_pb_normalizedpb_normalized_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x6e, 0x6f,
+	0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x70, 0x62, 0x2f, 0x6e, 0x6f, 0x72, 0x6d, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x63, 0x65, 0x72,
+	0x62, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x68, 0x61, 0x73, 0x68,
+	0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa6, 0x04, 0x0a, 0x15, 0x54,
+	0x65, 0x73, 0x74, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6e,
+	0x67, 0x6c, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x0f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x12, 0x75, 0x0a, 0x11, 0x6d, 0x61, 0x70, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x49, 0x2e, 0x63, 0x65,
+	0x72, 0x62, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x73, 0x2e, 0x4d, 0x61, 0x70, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x6d, 0x61, 0x70, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xd2, 0x80, 0x19, 0x04, 0x10, 0x01, 0x18, 0x01,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x50, 0x0a, 0x15, 0x73, 0x69, 0x6e, 0x67, 0x6c,
+	0x65, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x13, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0c, 0x6f, 0x6e, 0x65,
+	0x6f, 0x66, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x0b, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x21,
+	0x0a, 0x0b, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x49, 0x6e, 0x74, 0x33,
+	0x32, 0x1a, 0x42, 0x0a, 0x14, 0x4d, 0x61, 0x70, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x42,
+	0x12, 0x0a, 0x10, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d,
+	0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_pb_normalizedpb_normalized_proto_rawDescOnce sync.Once
+	file_internal_pb_normalizedpb_normalized_proto_rawDescData = file_internal_pb_normalizedpb_normalized_proto_rawDesc
+)
+
+func file_internal_pb_normalizedpb_normalized_proto_rawDescGZIP() []byte {
+	file_internal_pb_normalizedpb_normalized_proto_rawDescOnce.Do(func() {
+		file_internal_pb_normalizedpb_normalized_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_pb_normalizedpb_normalized_proto_rawDescData)
+	})
+	return file_internal_pb_normalizedpb_normalized_proto_rawDescData
+}
+
+var file_internal_pb_normalizedpb_normalized_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_pb_normalizedpb_normalized_proto_goTypes = []interface{}{
+	(*TestNormalizedStrings)(nil),  // 0: cerbos.hashpb.test.normalized.TestNormalizedStrings
+	nil,                            // 1: cerbos.hashpb.test.normalized.TestNormalizedStrings.MapStringStringEntry
+	(*wrapperspb.StringValue)(nil), // 2: google.protobuf.StringValue
+}
+var file_internal_pb_normalizedpb_normalized_proto_depIdxs = []int32{
+	1, // 0: cerbos.hashpb.test.normalized.TestNormalizedStrings.map_string_string:type_name -> cerbos.hashpb.test.normalized.TestNormalizedStrings.MapStringStringEntry
+	2, // 1: cerbos.hashpb.test.normalized.TestNormalizedStrings.single_string_wrapper:type_name -> google.protobuf.StringValue
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_internal_pb_normalizedpb_normalized_proto_init() }
+func file_internal_pb_normalizedpb_normalized_proto_init() {
+	if File_internal_pb_normalizedpb_normalized_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_pb_normalizedpb_normalized_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestNormalizedStrings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_internal_pb_normalizedpb_normalized_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*TestNormalizedStrings_OneofString)(nil),
+		(*TestNormalizedStrings_OneofInt32)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_pb_normalizedpb_normalized_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_pb_normalizedpb_normalized_proto_goTypes,
+		DependencyIndexes: file_internal_pb_normalizedpb_normalized_proto_depIdxs,
+		MessageInfos:      file_internal_pb_normalizedpb_normalized_proto_msgTypes,
+	}.Build()
+	File_internal_pb_normalizedpb_normalized_proto = out.File
+	file_internal_pb_normalizedpb_normalized_proto_rawDesc = nil
+	file_internal_pb_normalizedpb_normalized_proto_goTypes = nil
+	file_internal_pb_normalizedpb_normalized_proto_depIdxs = nil
+}
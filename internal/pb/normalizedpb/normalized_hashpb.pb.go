@@ -0,0 +1,24 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/normalizedpb/normalized.proto
+
+package normalizedpb
+
+import (
+	objecthash "github.com/cerbos/protoc-gen-go-hashpb/hashpb/objecthash"
+	hash "hash"
+)
+
+// HashPB computes a hash of the message using the given hash function
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestNormalizedStrings) HashPB(hasher hash.Hash, ignore map[string]struct{}) {
+	if m != nil {
+		cerbos_hashpb_test_normalized_TestNormalizedStrings_hashpb_sum(m, hasher, ignore)
+	}
+}
+
+// ObjectHashPB computes an objecthash-compatible SHA-256 digest of the message
+// The ignore set must contain fully-qualified field names (pkg.msg.field) that should be ignored from the hash
+func (m *TestNormalizedStrings) ObjectHashPB(ignore map[string]struct{}) [objecthash.Size]byte {
+	return cerbos_hashpb_test_normalized_TestNormalizedStrings_hashpb_objecthash(m, ignore)
+}
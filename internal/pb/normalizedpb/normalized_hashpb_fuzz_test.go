@@ -0,0 +1,15 @@
+// Code generated by protoc-gen-go-hashpb. Do not edit.
+// protoc-gen-go-hashpb (devel)
+// Source: internal/pb/normalizedpb/normalized.proto
+
+package normalizedpb
+
+import (
+	hashpb "github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	hashpbtest "github.com/cerbos/protoc-gen-go-hashpb/hashpb/hashpbtest"
+	testing "testing"
+)
+
+func FuzzHashPB_TestNormalizedStrings(f *testing.F) {
+	hashpbtest.FuzzConformance(f, &TestNormalizedStrings{}, hashpb.WithNormalizeUnicode())
+}
@@ -0,0 +1,73 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package normalizedpb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The messages created by hashpbtest.Populate only contain ASCII strings, which are unchanged by normalization, so the
+// generated conformance tests don't tell whether the generated code normalizes strings.
+func TestNormalizeUnicode(t *testing.T) {
+	mkMsg := func(e string) *TestNormalizedStrings {
+		return &TestNormalizedStrings{
+			SingleString:        "caf" + e,
+			OptionalString:      proto.String(e),
+			RepeatedString:      []string{"a", e},
+			MapStringString:     map[string]string{e: e, "k": e},
+			Email:               " " + e + "@Example.com",
+			SingleStringWrapper: wrapperspb.String(e),
+			Choice:              &TestNormalizedStrings_OneofString{OneofString: e},
+		}
+	}
+
+	composed := mkMsg("\u00e9")
+	decomposed := mkMsg("e\u0301")
+
+	generated := func(m *TestNormalizedStrings) uint64 {
+		d := xxhash.New()
+		m.HashPB(d, nil)
+		return d.Sum64()
+	}
+
+	if a, b := mustSum64(t, composed), mustSum64(t, decomposed); a == b {
+		t.Fatal("Expected different digests for composed and decomposed strings without normalization")
+	}
+
+	if want, have := generated(composed), generated(decomposed); want != have {
+		t.Errorf("Expected identical digests for composed and decomposed strings: %d != %d", want, have)
+	}
+
+	for _, m := range []*TestNormalizedStrings{composed, decomposed} {
+		if want, have := mustSum64(t, m, hashpb.WithNormalizeUnicode()), generated(m); want != have {
+			t.Errorf("Digest mismatch: generated=%d reflection=%d", have, want)
+		}
+
+		wantOH, err := hashpb.Sum(nil, m, hashpb.WithScheme(hashpb.SchemeObjectHash), hashpb.WithNormalizeUnicode())
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+
+		if haveOH := m.ObjectHashPB(nil); !bytes.Equal(wantOH, haveOH[:]) {
+			t.Errorf("Objecthash digest mismatch: generated=%x reflection=%x", haveOH, wantOH)
+		}
+	}
+}
+
+func mustSum64(t *testing.T, m proto.Message, opts ...hashpb.Option) uint64 {
+	t.Helper()
+
+	digest, err := hashpb.Sum64(m, opts...)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	return digest
+}
@@ -0,0 +1,70 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spec describes how messages are hashed in a machine-readable form.
+// It describes the default options and is a thin wrapper around hashpb.DescribeScheme.
+package spec
+
+import (
+	"sort"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// Scheme identifies the hashing scheme described by the spec.
+	Scheme = "hashpb"
+	// Framing describes how the encoded values are combined.
+	Framing = hashpb.DefaultFraming
+	// MapKeyOrder describes the order of map entries.
+	MapKeyOrder = hashpb.MapKeyOrder
+)
+
+type (
+	// Spec describes how a set of messages is hashed.
+	Spec = hashpb.SchemeDescription
+	// Message describes the traversal of a message.
+	Message = hashpb.MessageDescription
+	// Field describes how a field (or a oneof) is hashed. Fields are listed in traversal order.
+	Field = hashpb.FieldDescription
+)
+
+// Build describes the given messages and all the messages reachable from them, sorted by name.
+func Build(roots ...protoreflect.MessageDescriptor) *Spec {
+	msgs := make(map[string]Message)
+	for _, md := range roots {
+		for _, msg := range hashpb.DescribeScheme(md).Messages {
+			msgs[msg.Name] = msg
+		}
+	}
+
+	names := make([]string, 0, len(msgs))
+	for name := range msgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	spec := &Spec{Scheme: Scheme, Framing: Framing, Messages: make([]Message, len(names))}
+	for i, name := range names {
+		spec.Messages[i] = msgs[name]
+	}
+
+	return spec
+}
+
+// DescribeMessage describes the traversal of a single message.
+func DescribeMessage(md protoreflect.MessageDescriptor) Message {
+	for _, msg := range hashpb.DescribeScheme(md).Messages {
+		if msg.Name == string(md.FullName()) {
+			return msg
+		}
+	}
+
+	return Message{Name: string(md.FullName())}
+}
+
+// Encoding describes how values of the given kind are encoded.
+func Encoding(kind protoreflect.Kind) string {
+	return hashpb.Encoding(kind)
+}
@@ -0,0 +1,59 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/spec"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDescribeMessage(t *testing.T) {
+	msg := spec.DescribeMessage((&pb.TestAllTypes{}).ProtoReflect().Descriptor())
+
+	// the oneof is hashed at the position of its lowest-numbered member (18)
+	for i, f := range msg.Fields {
+		if f.Name == "nested_type" && (msg.Fields[i-1].Name != "single_bytes" || msg.Fields[i+1].Name != "standalone_enum") {
+			t.Fatalf("Unexpected position of oneof: %s, %s, %s", msg.Fields[i-1].Name, f.Name, msg.Fields[i+1].Name)
+		}
+	}
+
+	for _, f := range msg.Fields {
+		switch f.Name {
+		case "nested_type":
+			if f.Kind != "oneof" || len(f.Members) != 2 || f.IgnoreKey != "cerbos.hashpb.test.TestAllTypes.nested_type" {
+				t.Errorf("Unexpected oneof description: %+v", f)
+			}
+		case "map_int64_nested_type":
+			if f.Kind != "map" || f.KeyKind != "int64" || f.Value == nil || f.Value.Type != "cerbos.hashpb.test.TestAllTypes.NestedMessage" {
+				t.Errorf("Unexpected map description: %+v", f)
+			}
+		case "repeated_sint32":
+			if f.Kind != "list" || f.Value == nil || f.Value.Encoding != spec.Encoding(protoreflect.Sint32Kind) {
+				t.Errorf("Unexpected list description: %+v", f)
+			}
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	s := spec.Build((&pb.NestedTestAllTypes{}).ProtoReflect().Descriptor())
+
+	names := make(map[string]struct{}, len(s.Messages))
+	for _, m := range s.Messages {
+		names[m.Name] = struct{}{}
+	}
+
+	for _, name := range []string{"cerbos.hashpb.test.NestedTestAllTypes", "cerbos.hashpb.test.TestAllTypes", "google.protobuf.Struct"} {
+		if _, ok := names[name]; !ok {
+			t.Errorf("Expected %s in spec", name)
+		}
+	}
+
+	if _, ok := names["cerbos.hashpb.test.TestAllTypes.MapStringStringEntry"]; ok {
+		t.Error("Map entries should not be described as messages")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package testgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// GoldenSeeds is the number of golden vectors generated for each message.
+const GoldenSeeds = 4
+
+// Golden is the expected digest of a message populated using the given seed.
+// Seed 0 denotes an empty message.
+type Golden struct {
+	Seed   int64
+	XXHash uint64
+	SHA256 string
+}
+
+// MakeGolden computes the golden vectors for the message type of msg using the hashpb package with the given options,
+// which must make the hashpb package hash messages like the generated code.
+func MakeGolden(msg proto.Message, opts ...hashpb.Option) ([]Golden, error) {
+	golden := make([]Golden, GoldenSeeds)
+	for seed := int64(0); seed < GoldenSeeds; seed++ {
+		m := NewPopulated(msg, seed)
+
+		xxh, err := hashpb.Sum64(m, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute xxhash digest with seed %d: %w", seed, err)
+		}
+
+		sha, err := hashpb.Sum(nil, m, append(opts, hashpb.WithHash(sha256.New))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute sha256 digest with seed %d: %w", seed, err)
+		}
+
+		golden[seed] = Golden{Seed: seed, XXHash: xxh, SHA256: hex.EncodeToString(sha)}
+	}
+
+	return golden, nil
+}
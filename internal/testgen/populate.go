@@ -0,0 +1,145 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testgen populates messages deterministically and computes the golden digests and test vectors of the
+// populated messages. It is shared by the plugin and the hashpbtest package and must not import the testing package,
+// so that it isn't linked into the plugin and the command-line tools.
+package testgen
+
+import (
+	"math/rand"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MaxDepth is the maximum nesting depth of messages created by Populate.
+const MaxDepth = 3
+
+const maxRepeated = 3
+
+// Populate sets the fields of msg to pseudo-random values derived from seed.
+// The same seed always produces the same message, which makes it suitable for golden tests.
+func Populate(msg proto.Message, seed int64) {
+	p := &populator{rnd: rand.New(rand.NewSource(seed))}
+	p.message(msg.ProtoReflect(), 0)
+}
+
+type populator struct {
+	rnd *rand.Rand
+}
+
+func (p *populator) message(m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			continue
+		}
+
+		p.field(m, fd, depth)
+	}
+
+	oneOfs := m.Descriptor().Oneofs()
+	for i := 0; i < oneOfs.Len(); i++ {
+		od := oneOfs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+
+		p.field(m, od.Fields().Get(p.rnd.Intn(od.Fields().Len())), depth)
+	}
+}
+
+func (p *populator) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, depth int) {
+	if IsMessage(fd) && depth >= MaxDepth {
+		return
+	}
+
+	switch {
+	case fd.IsList():
+		list := m.Mutable(fd).List()
+		for n := 1 + p.rnd.Intn(maxRepeated); n > 0; n-- {
+			if IsMessage(fd) {
+				elem := list.NewElement()
+				p.message(elem.Message(), depth+1)
+				list.Append(elem)
+			} else {
+				list.Append(p.scalar(fd))
+			}
+		}
+	case fd.IsMap():
+		mapVal := m.Mutable(fd).Map()
+		for n := 1 + p.rnd.Intn(maxRepeated); n > 0; n-- {
+			key := p.scalar(fd.MapKey()).MapKey()
+			if IsMessage(fd.MapValue()) {
+				val := mapVal.NewValue()
+				p.message(val.Message(), depth+1)
+				mapVal.Set(key, val)
+			} else {
+				mapVal.Set(key, p.scalar(fd.MapValue()))
+			}
+		}
+	case IsMessage(fd):
+		p.message(m.Mutable(fd).Message(), depth+1)
+	default:
+		m.Set(fd, p.scalar(fd))
+	}
+}
+
+func (p *populator) scalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(p.rnd.Intn(2) == 1)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(p.rnd.Intn(values.Len())).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(p.rnd.Int31() - p.rnd.Int31())
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(p.rnd.Int63() - p.rnd.Int63())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(p.rnd.Uint32())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(p.rnd.Uint64())
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(p.rnd.NormFloat64() * 1000))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(p.rnd.NormFloat64() * 1000)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(string(p.bytes("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 ")))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(p.bytes(""))
+	default:
+		panic("unexpected scalar kind " + fd.Kind().String())
+	}
+}
+
+func (p *populator) bytes(alphabet string) []byte {
+	b := make([]byte, p.rnd.Intn(16))
+	for i := range b {
+		if alphabet == "" {
+			b[i] = byte(p.rnd.Intn(256))
+		} else {
+			b[i] = alphabet[p.rnd.Intn(len(alphabet))]
+		}
+	}
+
+	return b
+}
+
+// NewPopulated returns a new instance of the message type of msg populated using the given seed.
+// Seed 0 returns an empty message.
+func NewPopulated(msg proto.Message, seed int64) proto.Message {
+	m := msg.ProtoReflect().New().Interface()
+	if seed > 0 {
+		Populate(m, seed)
+	}
+
+	return m
+}
+
+// IsMessage reports whether the values of the field are messages.
+func IsMessage(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+}
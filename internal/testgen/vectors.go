@@ -0,0 +1,123 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package testgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/hashpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Vector is a language-neutral test vector for the hashing scheme.
+// Input is the deterministic binary protobuf encoding of the message. NormalizeUnicode and EmptyAsSet record the
+// options that were used to compute the digests (see hashpb.WithNormalizeUnicode and hashpb.EmptyAsSet).
+type Vector struct {
+	Type             string   `json:"type"`
+	Seed             int64    `json:"seed"`
+	Input            []byte   `json:"input"`
+	Ignore           []string `json:"ignore,omitempty"`
+	NormalizeUnicode bool     `json:"normalize_unicode,omitempty"`
+	EmptyAsSet       bool     `json:"empty_as_set,omitempty"`
+	XXHash           string   `json:"xxhash64"`
+	SHA256           string   `json:"sha256"`
+}
+
+// Options returns the options that reproduce the digests of the vector.
+func (v Vector) Options() []hashpb.Option {
+	opts := []hashpb.Option{hashpb.WithIgnore(v.Ignore...)}
+	if v.NormalizeUnicode {
+		opts = append(opts, hashpb.WithNormalizeUnicode())
+	}
+
+	if v.EmptyAsSet {
+		opts = append(opts, hashpb.WithEmptyMode(hashpb.EmptyAsSet))
+	}
+
+	return opts
+}
+
+// VectorCorpus is a collection of test vectors.
+type VectorCorpus struct {
+	Vectors []Vector `json:"vectors"`
+}
+
+// MakeVectors computes test vectors for the message type of msg using the hashpb package with the given options, which
+// must make the hashpb package hash messages like the generated code. Only WithNormalizeUnicode and WithEmptyMode can be
+// recorded in the vectors. In addition to the golden seeds, it produces a vector that exercises the ignore option.
+func MakeVectors(msg proto.Message, opts ...hashpb.Option) ([]Vector, error) {
+	o := hashpb.NewOptions(opts...)
+	template := Vector{NormalizeUnicode: o.NormalizeUnicode(), EmptyAsSet: o.EmptyMode() == hashpb.EmptyAsSet}
+
+	vectors := make([]Vector, 0, GoldenSeeds+1)
+	for seed := int64(0); seed < GoldenSeeds; seed++ {
+		v, err := makeVector(NewPopulated(msg, seed), seed, template)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v)
+	}
+
+	if fields := msg.ProtoReflect().Descriptor().Fields(); fields.Len() > 0 {
+		template.Ignore = []string{ignoreName(lowestNumberedField(fields))}
+		v, err := makeVector(NewPopulated(msg, 1), 1, template)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v)
+	}
+
+	return vectors, nil
+}
+
+// makeVector computes the digests of the message with the options recorded in the template.
+func makeVector(m proto.Message, seed int64, template Vector) (Vector, error) {
+	fullName := m.ProtoReflect().Descriptor().FullName()
+	opts := template.Options()
+
+	input, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return Vector{}, fmt.Errorf("failed to marshal %s with seed %d: %w", fullName, seed, err)
+	}
+
+	xxh, err := hashpb.Sum64(m, opts...)
+	if err != nil {
+		return Vector{}, fmt.Errorf("failed to compute xxhash digest of %s with seed %d: %w", fullName, seed, err)
+	}
+
+	sha, err := hashpb.Sum(nil, m, append(opts, hashpb.WithHash(sha256.New))...)
+	if err != nil {
+		return Vector{}, fmt.Errorf("failed to compute sha256 digest of %s with seed %d: %w", fullName, seed, err)
+	}
+
+	v := template
+	v.Type = string(fullName)
+	v.Seed = seed
+	v.Input = input
+	v.XXHash = fmt.Sprintf("%016x", xxh)
+	v.SHA256 = hex.EncodeToString(sha)
+	return v, nil
+}
+
+func lowestNumberedField(fields protoreflect.FieldDescriptors) protoreflect.FieldDescriptor {
+	lowest := fields.Get(0)
+	for i := 1; i < fields.Len(); i++ {
+		if fd := fields.Get(i); fd.Number() < lowest.Number() {
+			lowest = fd
+		}
+	}
+
+	return lowest
+}
+
+func ignoreName(fd protoreflect.FieldDescriptor) string {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return string(od.FullName())
+	}
+
+	return string(fd.FullName())
+}
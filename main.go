@@ -4,10 +4,69 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/cerbos/protoc-gen-go-hashpb/internal/generator"
-	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 func main() {
-	protogen.Options{}.Run(generator.Generate)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Reads a CodeGeneratorRequest from stdin and writes a CodeGeneratorResponse to stdout, as protoc and buf expect.")
+		fmt.Fprintln(flag.CommandLine.Output(), "The flags are meant for debugging the generator with a request captured from protoc or buf.")
+		flag.PrintDefaults()
+	}
+	request := flag.String("request", "", "Read the serialized CodeGeneratorRequest from this file instead of stdin")
+	out := flag.String("out", "", "Write the generated files to this directory instead of writing a CodeGeneratorResponse to stdout")
+	opt := flag.String("opt", "", "Comma-separated plugin parameters that replace the parameters of the request")
+	flag.Parse()
+
+	if err := run(os.Stdin, os.Stdout, *request, *out, *opt); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+}
+
+func run(stdin io.Reader, stdout io.Writer, request, out, opt string) error {
+	var in []byte
+	var err error
+	if request != "" {
+		in, err = os.ReadFile(request)
+	} else {
+		in, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return err
+	}
+
+	if opt != "" {
+		req.Parameter = proto.String(opt)
+	}
+
+	resp, err := generator.Run(req)
+	if err != nil {
+		return err
+	}
+
+	if out != "" {
+		return generator.WriteFiles(resp, out)
+	}
+
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(data)
+	return err
 }
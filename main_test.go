@@ -0,0 +1,99 @@
+// Copyright 2021-2022 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/descset"
+	"github.com/cerbos/protoc-gen-go-hashpb/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const params = "paths=source_relative,registry=true,schema_fingerprint=true,objecthash=true,depth_limit=true,propagate_errors=true,sum64=true,append_hash=true,multi_hash=true,writer=true,no_ignore=true,shallow=true,filtered=true"
+
+func TestRunFromRequestFile(t *testing.T) {
+	req, err := descset.Request(descset.Build(pb.File_internal_pb_all_types_proto), []string{"internal/pb/all_types.proto"}, "invalid=true")
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	dir := t.TempDir()
+	request := filepath.Join(dir, "request.binpb")
+	if err := os.WriteFile(request, data, 0o600); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+
+	if err := run(nil, nil, request, filepath.Join(dir, "invalid"), ""); err == nil {
+		t.Error("Expected error for invalid parameters of the request")
+	}
+
+	out := filepath.Join(dir, "out")
+	if err := run(nil, nil, request, out, params); err != nil {
+		t.Fatalf("Failed to run: %v", err)
+	}
+
+	names := []string{"all_types_hashpb.pb.go", "hashpb_helpers.pb.go"}
+	for _, name := range names {
+		want := readGenerated(t, filepath.Join("internal", "pb", name))
+		have := readGenerated(t, filepath.Join(out, "internal", "pb", name))
+		if want != have {
+			t.Errorf("Generated %s differs from the committed version", name)
+		}
+	}
+
+	var stdout bytes.Buffer
+	if err := run(bytes.NewReader(data), &stdout, "", "", params); err != nil {
+		t.Fatalf("Failed to run: %v", err)
+	}
+
+	resp := &pluginpb.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(stdout.Bytes(), resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(resp.File) != len(names) {
+		t.Fatalf("Expected %d files in the response, got %d", len(names), len(resp.File))
+	}
+
+	for _, f := range resp.File {
+		if want := readGenerated(t, filepath.Join(out, filepath.FromSlash(f.GetName()))); dropVersion(f.GetContent()) != want {
+			t.Errorf("Response content of %s differs from the file written to the output directory", f.GetName())
+		}
+	}
+}
+
+// readGenerated reads a generated file, dropping the line with the plugin version.
+func readGenerated(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	return dropVersion(string(data))
+}
+
+func dropVersion(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if !strings.HasPrefix(l, "// protoc-gen-go-hashpb ") {
+			kept = append(kept, l)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
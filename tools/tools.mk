@@ -20,14 +20,48 @@ define BUF_GEN_TEMPLATE
     },\
     {\
       "name": "hashpb",\
+      "opt": "paths=source_relative,registry=true,gen_conformance_tests=true,gen_tests=true,gen_vectors=true,gen_fuzz_tests=true,gen_map_order_tests=true,schema_fingerprint=true,gen_spec=true,objecthash=true,depth_limit=true,propagate_errors=true,sum64=true,append_hash=true,multi_hash=true,writer=true,no_ignore=true,shallow=true,filtered=true",\
+      "out": ".",\
+      "path": "$(PROTOC_GEN_GO_HASHPB)"\
+    },\
+  ]\
+}
+endef
+
+define BUF_GEN_NORMALIZED_TEMPLATE
+{\
+  "version": "v1",\
+  "plugins": [\
+    {\
+      "name": "go",\
       "opt": "paths=source_relative",\
       "out": ".",\
+      "path": "$(PROTOC_GEN_GO)"\
+    },\
+    {\
+      "name": "hashpb",\
+      "opt": "paths=source_relative,gen_conformance_tests=true,gen_fuzz_tests=true,objecthash=true,normalize_unicode=true",\
+      "out": ".",\
       "path": "$(PROTOC_GEN_GO_HASHPB)"\
     },\
   ]\
 }
 endef
 
+define BUF_GEN_GO_TEMPLATE
+{\
+  "version": "v1",\
+  "plugins": [\
+    {\
+      "name": "go",\
+      "opt": "paths=source_relative",\
+      "out": ".",\
+      "path": "$(PROTOC_GEN_GO)"\
+    },\
+  ]\
+}
+endef
+
 $(TOOLS_BIN_DIR):
 	@ mkdir -p $(TOOLS_BIN_DIR)
 